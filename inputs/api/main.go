@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"log"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -13,6 +14,9 @@ import (
 	httpSwagger "github.com/swaggo/http-swagger"
 
 	_ "github.com/andre-felipe-wonsik-alves/docs"
+	env "github.com/andre-felipe-wonsik-alves/internal"
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/notify"
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/reminder"
 	"github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
 	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
 	"github.com/andre-felipe-wonsik-alves/internal/database"
@@ -42,10 +46,33 @@ func Execute(ctx context.Context, service *taskApi.Service) error {
 
 	log.Println("Conexão com o banco estabelecida!")
 
-	log.Println("Iniciando migrations...")
-	database.AutoMigrate(db)
+	log.Println("Verificando migrations...")
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		return err
+	}
+	if err := migrator.EnsureUpToDate(ctx); err != nil {
+		return err
+	}
+
+	log.Println("Schema do banco atualizado!")
+
+	interval, err := time.ParseDuration(env.GetEnv("REMINDER_INTERVAL", reminder.DefaultInterval.String()))
+	if err != nil {
+		return fmt.Errorf("REMINDER_INTERVAL inválido: %w", err)
+	}
 
-	log.Println("Migration concluída com sucesso!")
+	workerCtx, stopWorker := context.WithCancel(ctx)
+	var worker sync.WaitGroup
+	worker.Add(1)
+	go func() {
+		defer worker.Done()
+		reminder.NewScheduler(service, notify.NewRegistryFromEnv(), interval).Run(workerCtx)
+	}()
+	defer func() {
+		stopWorker()
+		worker.Wait()
+	}()
 
 	taskHandler := api.NewTaskHandler(service)
 
@@ -54,6 +81,7 @@ func Execute(ctx context.Context, service *taskApi.Service) error {
 	r.Use(middleware.Recoverer)
 	r.Use(middleware.Timeout(60 * time.Second))
 	r.Use(middleware.RequestID)
+	r.Use(api.AuditSource)
 
 	r.Get("/swagger/*", httpSwagger.Handler(
 		httpSwagger.URL("http://localhost:8080/swagger/doc.json"),
@@ -63,13 +91,63 @@ func Execute(ctx context.Context, service *taskApi.Service) error {
 		r.Route("/tasks", func(r chi.Router) {
 			r.Get("/", taskHandler.ListTasks)
 			r.Post("/", taskHandler.CreateTask)
+			r.Get("/due", taskHandler.GetDueTasks)
+			r.Get("/search", taskHandler.SearchTasks)
+			r.Get("/ready", taskHandler.ListReadyTasks)
 			r.Get("/{id}", taskHandler.GetTask)
 			r.Patch("/{id}", taskHandler.PatchTask)
 			r.Delete("/{id}", taskHandler.DeleteTask)
 			r.Patch("/{id}/complete", taskHandler.CompleteTask)
+			r.Patch("/{id}/status", taskHandler.SetTaskStatus)
+			r.Patch("/{id}/reopen", taskHandler.ReopenTask)
+			r.Post("/{id}/snooze", taskHandler.SnoozeTask)
+			r.Post("/{id}/restore", taskHandler.RestoreTask)
 			r.Get("/{id}/subtasks", taskHandler.ListSubtasks)
+			r.Get("/{id}/tree", taskHandler.GetTaskTree)
+			r.Post("/{id}/move", taskHandler.MoveTask)
+			r.Get("/{id}/history", taskHandler.TaskHistory)
+			r.Post("/{id}/tags", taskHandler.TagTask)
+			r.Delete("/{id}/tags/{tag}", taskHandler.UntagTask)
+			r.Get("/{id}/dependencies", taskHandler.ListDependencies)
+			r.Post("/{id}/dependencies", taskHandler.AddDependency)
+			r.Delete("/{id}/dependencies/{dependsOnID}", taskHandler.RemoveDependency)
+			r.Post("/{id}/time/start", taskHandler.StartTimer)
+			r.Route("/{id}/reminders", func(r chi.Router) {
+				r.Get("/", taskHandler.ListReminders)
+				r.Post("/", taskHandler.CreateReminder)
+				r.Patch("/{reminderID}", taskHandler.PatchReminder)
+				r.Delete("/{reminderID}", taskHandler.DeleteReminder)
+			})
 
 		})
+		r.Route("/tags", func(r chi.Router) {
+			r.Get("/", taskHandler.ListTags)
+			r.Post("/", taskHandler.CreateTag)
+			r.Get("/{tagID}", taskHandler.GetTag)
+			r.Patch("/{tagID}", taskHandler.PatchTag)
+			r.Delete("/{tagID}", taskHandler.DeleteTag)
+		})
+		r.Route("/projects", func(r chi.Router) {
+			r.Get("/", taskHandler.ListProjects)
+			r.Post("/", taskHandler.CreateProject)
+			r.Get("/{projectID}", taskHandler.GetProject)
+			r.Patch("/{projectID}", taskHandler.PatchProject)
+			r.Delete("/{projectID}", taskHandler.DeleteProject)
+			r.Get("/{projectID}/tasks", taskHandler.ListProjectTasks)
+		})
+		r.Get("/advice", taskHandler.GetAdvice)
+		r.Get("/plan", taskHandler.GetPlan)
+		r.Post("/plan/accept", taskHandler.AcceptPlan)
+		r.Route("/time", func(r chi.Router) {
+			r.Post("/stop", taskHandler.StopTimer)
+			r.Get("/running", taskHandler.GetRunningTimer)
+			r.Get("/entries", taskHandler.ListTimeEntries)
+			r.Get("/timesheet", taskHandler.GetTimesheet)
+		})
+		r.Route("/trash", func(r chi.Router) {
+			r.Get("/", taskHandler.ListTrash)
+			r.Delete("/{id}", taskHandler.PurgeTask)
+		})
 	})
 
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
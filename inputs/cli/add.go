@@ -7,51 +7,151 @@ import (
 	"strings"
 	"time"
 
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/notify"
 	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
 	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
 	"github.com/spf13/cobra"
 )
 
 func NewAddCli(service *taskApi.Service) *cobra.Command {
-	return &cobra.Command{
-		Use:   "add",
-		Short: "Adiciona uma nova tarefa",
+	var channelNames []string
+	var recurrence string
+	var remindAt []string
+	var remindDue []string
+	var due string
+	var estimate string
+	var tagNames []string
+
+	cmd := &cobra.Command{
+		Use:     "add",
+		Short:   "Adiciona uma nova tarefa",
+		Example: "  advisor-go add\n  advisor-go add --tag homelab   (ou escreva #homelab no título)\n  advisor-go add --project homelab\n  advisor-go add --due 31/12/2025 --remind-due -1d\n  advisor-go add --estimate 1h30m",
 		RunE: func(cli *cobra.Command, args []string) error {
 			ctx := cli.Context()
 			reader := bufio.NewReader(os.Stdin)
 
+			channels, err := notify.ParseChannels(channelNames)
+			if err != nil {
+				return err
+			}
+			if recurrence != "" {
+				if _, err := task.ParseRecurrence(recurrence); err != nil {
+					return err
+				}
+			}
+			reminders, err := parseReminderFlags(remindAt)
+			if err != nil {
+				return err
+			}
+			var dueAt *time.Time
+			var dueAllDay bool
+			if due != "" {
+				parsed, allDay, err := task.ParseDue(due, time.Local)
+				if err != nil {
+					return fmt.Errorf("--due inválido %q (use DD/MM/AAAA ou DD/MM/AAAA HH:MM)", due)
+				}
+				dueAt, dueAllDay = &parsed, allDay
+			}
+			if len(remindDue) > 0 && dueAt == nil {
+				return fmt.Errorf("--remind-due precisa de --due")
+			}
+			dueReminders, err := parseDueReminderFlags(remindDue)
+			if err != nil {
+				return err
+			}
+			reminders = append(reminders, dueReminders...)
+			var estimateMinutes int
+			if estimate != "" {
+				if estimateMinutes, err = task.ParseEstimate(estimate); err != nil {
+					return fmt.Errorf("--estimate inválido %q (use 45m, 1h30m ou os minutos)", estimate)
+				}
+			}
+			project, err := selectedProject(cli, service)
+			if err != nil {
+				return err
+			}
+			var projectID *string
+			var defaultPriority models.Priority
+			var defaultReminder string
+			if project != nil {
+				projectID = &project.ID
+				defaultPriority = project.DefaultPriority
+				defaultReminder = project.DefaultReminderOffset
+			}
+
 			title, err := promptNonEmpty(reader, "Título da tarefa (obrigatório): ")
 			if err != nil {
 				return err
 			}
+			// "#tag" no título vira tag da tarefa e sai do título.
+			title, titleTags := task.ExtractTags(title)
+			if title == "" {
+				return fmt.Errorf("o título não pode ter só tags")
+			}
+			var tags []models.Tag
+			for _, name := range append(tagNames, titleTags...) {
+				tags = append(tags, models.Tag{Name: name})
+			}
 
 			description, err := prompt(reader, "Descrição (opcional): ")
 			if err != nil {
 				return err
 			}
 
-			priorityStr, err := prompt(reader, "Prioridade da tarefa (média, alta ou baixa): ")
+			// Em branco, a prioridade e o lembrete ficam com os padrões do projeto.
+			priorityLabel := "Prioridade da tarefa (média, alta ou baixa): "
+			if defaultPriority != "" {
+				priorityLabel = fmt.Sprintf("Prioridade da tarefa (média, alta ou baixa; vazio usa %s): ", defaultPriority)
+			}
+			priorityStr, err := prompt(reader, priorityLabel)
 			if err != nil {
 				return err
 			}
-			priority, err := task.ParsePriority(priorityStr)
-			if err != nil {
-				return fmt.Errorf("prioridade inválida: %w", err)
+			var priority models.Priority
+			if priorityStr != "" || defaultPriority == "" {
+				if priority, err = task.ParsePriority(priorityStr); err != nil {
+					return fmt.Errorf("prioridade inválida: %w", err)
+				}
 			}
 
 			fmt.Println("\nInforme a data/hora do lembrete no formato:")
 			fmt.Println("  02/01/2006 15:04")
-			reminderStr, err := promptNonEmpty(reader, "Lembrar em: ")
+			// Com prazo, o lembrete principal é opcional.
+			var reminderStr string
+			switch {
+			case defaultReminder != "":
+				reminderStr, err = prompt(reader, fmt.Sprintf("Lembrar em (vazio: daqui a %s): ", defaultReminder))
+			case dueAt != nil:
+				reminderStr, err = prompt(reader, "Lembrar em (opcional): ")
+			default:
+				reminderStr, err = promptNonEmpty(reader, "Lembrar em: ")
+			}
 			if err != nil {
 				return err
 			}
 
-			reminderAt, err := parseReminder(reminderStr)
-			if err != nil {
-				return err
+			var reminderAt time.Time
+			if reminderStr != "" {
+				if reminderAt, err = parseReminder(reminderStr); err != nil {
+					return err
+				}
 			}
 
-			newTask, err := service.Create(ctx, title, description, priority, reminderAt)
+			newTask, err := service.CreateTask(ctx, models.Task{
+				Title:           title,
+				Description:     description,
+				Priority:        priority,
+				ReminderAt:      reminderAt,
+				DueAt:           dueAt,
+				DueAllDay:       dueAllDay,
+				EstimateMinutes: estimateMinutes,
+				Channels:        channels,
+				Recurrence:      recurrence,
+				Reminders:       reminders,
+				Tags:            tags,
+				ProjectID:       projectID,
+			})
 
 			if err != nil {
 				return err
@@ -60,11 +160,41 @@ func NewAddCli(service *taskApi.Service) *cobra.Command {
 			fmt.Println("\nTarefa adicionada com sucesso!")
 			fmt.Printf("ID: %s\n", newTask.ID)
 			fmt.Printf("Título: %s\n", newTask.Title)
-			fmt.Printf("Lembrar em: %s\n", newTask.ReminderAt.Format("02/01/2006 15:04"))
+			if project != nil {
+				fmt.Printf("Projeto: %s\n", project.Name)
+			}
+			if newTask.DueAt != nil {
+				fmt.Printf("Prazo: %s\n", formatDue(*newTask))
+			}
+			if newTask.EstimateMinutes > 0 {
+				fmt.Printf("Estimativa: %s\n", formatEstimate(newTask.EstimateMinutes))
+			}
+			if !newTask.ReminderAt.IsZero() {
+				fmt.Printf("Lembrar em: %s\n", newTask.ReminderAt.Format("02/01/2006 15:04"))
+			}
+			for _, r := range newTask.Reminders {
+				fmt.Printf("| > Lembrete: %s\n", r.FireAt.Format("02/01/2006 15:04"))
+			}
+			if newTask.Recurrence != "" {
+				fmt.Printf("Repete: %s\n", newTask.Recurrence)
+			}
+			if len(newTask.Tags) > 0 {
+				fmt.Printf("Tags: %s\n", formatTags(newTask.Tags))
+			}
 
 			return nil
 		},
 	}
+
+	cmd.Flags().StringVarP(&recurrence, "repeat", "r", "", "Recorrência (daily, weekly, monthly, RRULE como FREQ=WEEKLY;BYDAY=MO ou cron)")
+	cmd.Flags().StringArrayVarP(&remindAt, "remind", "R", nil, "Lembrete extra, relativo ao horário (-1d, -1h, 0) ou absoluto (02/01/2006 15:04); pode repetir")
+	cmd.Flags().StringVar(&due, "due", "", "Prazo da tarefa (DD/MM/AAAA para o dia inteiro ou DD/MM/AAAA HH:MM)")
+	cmd.Flags().StringArrayVar(&remindDue, "remind-due", nil, "Lembrete relativo ao prazo (-1d, -2h, 0); pode repetir")
+	cmd.Flags().StringVarP(&estimate, "estimate", "e", "", "Quanto a tarefa deve levar (45m, 1h30m); usada por advisor-go plan")
+	cmd.Flags().StringArrayVarP(&tagNames, "tag", "t", nil, "Tag da tarefa; pode repetir")
+	cmd.Flags().StringSliceVarP(&channelNames, "channels", "c", nil, "Canais de notificação (terminal, webhook, email, ntfy, gotify, command)")
+
+	return cmd
 }
 
 func prompt(reader *bufio.Reader, label string) (string, error) {
@@ -93,3 +223,31 @@ func parseReminder(input string) (time.Time, error) {
 	layout := "02/01/2006 15:04"
 	return time.ParseInLocation(layout, input, time.Local)
 }
+
+func parseReminderFlags(inputs []string) ([]models.Reminder, error) {
+	var reminders []models.Reminder
+	for _, input := range inputs {
+		if at, err := parseReminder(input); err == nil {
+			reminders = append(reminders, models.Reminder{At: &at})
+			continue
+		}
+		if _, err := task.ParseOffset(input); err != nil {
+			return nil, fmt.Errorf("lembrete inválido %q: %w", input, err)
+		}
+		reminders = append(reminders, models.Reminder{Offset: input})
+	}
+	return reminders, nil
+}
+
+// parseDueReminderFlags lê os deslocamentos de --remind-due, contados a partir
+// do prazo da tarefa.
+func parseDueReminderFlags(inputs []string) ([]models.Reminder, error) {
+	var reminders []models.Reminder
+	for _, input := range inputs {
+		if _, err := task.ParseOffset(input); err != nil {
+			return nil, fmt.Errorf("lembrete inválido %q: %w", input, err)
+		}
+		reminders = append(reminders, models.Reminder{Offset: input, Anchor: models.AnchorDue})
+	}
+	return reminders, nil
+}
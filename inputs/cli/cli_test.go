@@ -8,53 +8,13 @@ import (
 	"testing"
 	"time"
 
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
 	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task/repository"
 	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"github.com/spf13/cobra"
 )
 
-type fakeStore struct {
-	createTask  *models.Task
-	createErr   error
-	listTasks   []models.Task
-	listErr     error
-	patchID     string
-	patchChanges map[string]any
-	patchResult *models.Task
-	patchErr    error
-}
-
-func (f *fakeStore) Create(_ context.Context, task *models.Task) error {
-	if task.ID == "" {
-		task.ID = "task-123"
-	}
-	f.createTask = task
-	return f.createErr
-}
-
-func (f *fakeStore) GetByID(_ context.Context, _ string) (*models.Task, error) {
-	return nil, nil
-}
-
-func (f *fakeStore) List(_ context.Context) ([]models.Task, error) {
-	return f.listTasks, f.listErr
-}
-
-func (f *fakeStore) Patch(_ context.Context, id string, changes map[string]any) (*models.Task, error) {
-	f.patchID = id
-	f.patchChanges = changes
-	if f.patchErr != nil {
-		return nil, f.patchErr
-	}
-	if f.patchResult != nil {
-		return f.patchResult, nil
-	}
-	return &models.Task{ID: id, Done: true}, nil
-}
-
-func (f *fakeStore) Delete(_ context.Context, _ string) error {
-	return nil
-}
-
 func withStdin(input string, fn func()) {
 	original := os.Stdin
 	reader, writer, _ := os.Pipe()
@@ -81,8 +41,7 @@ func captureStdout(fn func()) string {
 }
 
 func TestNewAddCli_RunE_Success(t *testing.T) {
-	fakeRepo := &fakeStore{}
-	service := taskApi.NewService(fakeRepo)
+	service := taskApi.NewService(repository.NewMemoryStore())
 	cmd := NewAddCli(service)
 	cmd.SetContext(context.Background())
 
@@ -105,49 +64,49 @@ func TestNewAddCli_RunE_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if fakeRepo.createTask == nil {
-		t.Fatalf("expected Create to be called")
+	tasks, err := service.List(context.Background())
+	if err != nil || len(tasks) != 1 {
+		t.Fatalf("expected one stored task, got %+v, %v", tasks, err)
 	}
-	if fakeRepo.createTask.Title != "Tarefa A" {
-		t.Fatalf("unexpected title: %s", fakeRepo.createTask.Title)
+	created := tasks[0]
+	if created.Title != "Tarefa A" {
+		t.Fatalf("unexpected title: %s", created.Title)
 	}
-	if fakeRepo.createTask.Description != "Descricao A" {
-		t.Fatalf("unexpected description: %s", fakeRepo.createTask.Description)
+	if created.Description != "Descricao A" {
+		t.Fatalf("unexpected description: %s", created.Description)
 	}
-	if fakeRepo.createTask.Priority != models.PriorityMedium {
-		t.Fatalf("unexpected priority: %s", fakeRepo.createTask.Priority)
+	if created.Priority != models.PriorityMedium {
+		t.Fatalf("unexpected priority: %s", created.Priority)
 	}
 
 	expectedReminder := time.Date(2006, 1, 2, 15, 4, 0, 0, time.Local)
-	if !fakeRepo.createTask.ReminderAt.Equal(expectedReminder) {
-		t.Fatalf("unexpected reminder: %v", fakeRepo.createTask.ReminderAt)
+	if !created.ReminderAt.Equal(expectedReminder) {
+		t.Fatalf("unexpected reminder: %v", created.ReminderAt)
 	}
 
 	if !strings.Contains(output, "Tarefa adicionada com sucesso!") {
 		t.Fatalf("expected success message, got %q", output)
 	}
-	if !strings.Contains(output, "ID: task-123") {
+	if !strings.Contains(output, "ID: "+created.ID) {
 		t.Fatalf("expected task id in output, got %q", output)
 	}
 }
 
 func TestNewListCli_RunE_Success(t *testing.T) {
-	fakeRepo := &fakeStore{
-		listTasks: []models.Task{
-			{
-				ID:          "task-1",
-				Title:       "Tarefa 1",
-				Description: "Descricao 1",
-				Priority:    models.PriorityLow,
-				ReminderAt:  time.Date(2025, 1, 5, 10, 30, 0, 0, time.Local),
-			},
-		},
-	}
-	service := taskApi.NewService(fakeRepo)
+	ctx := context.Background()
+	service := taskApi.NewService(repository.NewMemoryStore())
+	created, err := service.CreateTask(ctx, models.Task{
+		Title:       "Tarefa 1",
+		Description: "Descricao 1",
+		Priority:    models.PriorityLow,
+		ReminderAt:  time.Date(2025, 1, 5, 10, 30, 0, 0, time.Local),
+	})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
 	cmd := NewListCli(service)
-	cmd.SetContext(context.Background())
+	cmd.SetContext(ctx)
 
-	var err error
 	var output string
 	output = captureStdout(func() {
 		err = cmd.Execute()
@@ -156,7 +115,7 @@ func TestNewListCli_RunE_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if !strings.Contains(output, "ID: task-1") {
+	if !strings.Contains(output, "ID: "+created.ID) {
 		t.Fatalf("expected task id in output, got %q", output)
 	}
 	if !strings.Contains(output, "Título: Tarefa 1") {
@@ -165,18 +124,15 @@ func TestNewListCli_RunE_Success(t *testing.T) {
 }
 
 func TestNewCompleteCli_RunE_Success(t *testing.T) {
-	fakeRepo := &fakeStore{
-		listTasks: []models.Task{
-			{ID: "task-1", Title: "Tarefa 1"},
-			{ID: "task-2", Title: "Tarefa 2"},
-		},
-	}
-	service := taskApi.NewService(fakeRepo)
+	ctx := context.Background()
+	service := taskApi.NewService(repository.NewMemoryStore())
+	first, _ := service.CreateTask(ctx, models.Task{Title: "Tarefa 1", Priority: models.PriorityLow})
+	second, _ := service.CreateTask(ctx, models.Task{Title: "Tarefa 2", Priority: models.PriorityLow})
 	cmd := NewCompleteCli(service)
-	cmd.SetContext(context.Background())
+	cmd.SetContext(ctx)
 
 	input := strings.Join([]string{
-		"task-2",
+		second.ID,
 		"",
 	}, "\n")
 
@@ -191,13 +147,978 @@ func TestNewCompleteCli_RunE_Success(t *testing.T) {
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
 	}
-	if fakeRepo.patchID != "task-2" {
-		t.Fatalf("expected patch id task-2, got %s", fakeRepo.patchID)
+	if done, err := service.GetByID(ctx, second.ID); err != nil || !done.Done {
+		t.Fatalf("expected %s to be done, got %+v, %v", second.ID, done, err)
 	}
-	if done, ok := fakeRepo.patchChanges["done"].(bool); !ok || !done {
-		t.Fatalf("expected done=true in patch changes, got %v", fakeRepo.patchChanges)
+	if open, err := service.GetByID(ctx, first.ID); err != nil || open.Done {
+		t.Fatalf("expected %s to stay open, got %+v, %v", first.ID, open, err)
 	}
-	if !strings.Contains(output, "ID: task-1") || !strings.Contains(output, "ID: task-2") {
+	if !strings.Contains(output, "ID: "+first.ID) || !strings.Contains(output, "ID: "+second.ID) {
 		t.Fatalf("expected tasks to be listed, got %q", output)
 	}
 }
+
+func TestNewCompleteCli_InvalidTransition(t *testing.T) {
+	ctx := context.Background()
+	service := taskApi.NewService(repository.NewMemoryStore())
+	created, _ := service.CreateTask(ctx, models.Task{Title: "Ligar", Priority: models.PriorityLow})
+	if _, err := service.SetStatus(ctx, created.ID, 0, models.StatusCancelled); err != nil {
+		t.Fatalf("cancel: %v", err)
+	}
+
+	cmd := NewCompleteCli(service)
+	cmd.SetContext(ctx)
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	var err error
+	withStdin(created.ID+"\n", func() {
+		captureStdout(func() {
+			err = cmd.Execute()
+		})
+	})
+	if err == nil || !strings.Contains(err.Error(), "está cancelada; reabra antes") {
+		t.Fatalf("expected the cancelled status in the error, got %v", err)
+	}
+}
+
+func TestNewSnoozeCli_RunE_Success(t *testing.T) {
+	ctx := context.Background()
+	store := repository.NewMemoryStore()
+	service := taskApi.NewService(store)
+	created, _ := service.CreateTask(ctx, models.Task{Title: "Tarefa 1", Priority: models.PriorityLow})
+	if _, err := store.Patch(ctx, created.ID, map[string]any{"snooze_count": 1}); err != nil {
+		t.Fatalf("patch: %v", err)
+	}
+	cmd := NewSnoozeCli(service)
+	cmd.SetContext(ctx)
+	cmd.SetArgs([]string{created.ID, "amanhã", "9:00"})
+
+	var err error
+	output := captureStdout(func() {
+		err = cmd.Execute()
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	snoozed, err := service.GetByID(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	expected := time.Date(tomorrow.Year(), tomorrow.Month(), tomorrow.Day(), 9, 0, 0, 0, time.Local)
+	if !snoozed.ReminderAt.Equal(expected) {
+		t.Fatalf("expected reminder_at %v, got %v", expected, snoozed.ReminderAt)
+	}
+	if snoozed.SnoozeCount != 2 {
+		t.Fatalf("expected snooze_count 2, got %v", snoozed.SnoozeCount)
+	}
+	if !strings.Contains(output, "Adiamentos: 2") {
+		t.Fatalf("expected snooze count in output, got %q", output)
+	}
+}
+
+func TestNewRestoreCli_OffersChildren(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name         string
+		answer       string
+		wantChildren int
+	}{
+		{name: "accept", answer: "s\n", wantChildren: 1},
+		{name: "decline", answer: "n\n", wantChildren: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := taskApi.NewService(repository.NewMemoryStore())
+			parent, _ := service.CreateTask(ctx, models.Task{Title: "parent", Priority: models.PriorityLow})
+			child, _ := service.CreateTask(ctx, models.Task{Title: "child", Priority: models.PriorityLow, ParentID: &parent.ID})
+			_ = service.Delete(ctx, child.ID)
+			_ = service.Delete(ctx, parent.ID)
+
+			cmd := NewRestoreCli(service)
+			cmd.SetContext(ctx)
+			cmd.SetArgs([]string{parent.ID})
+
+			var err error
+			var output string
+			withStdin(tt.answer, func() {
+				output = captureStdout(func() {
+					err = cmd.Execute()
+				})
+			})
+
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !strings.Contains(output, "1 subtarefa(s) na lixeira") {
+				t.Fatalf("expected prompt about children, got %q", output)
+			}
+			restored, _ := service.GetByID(ctx, parent.ID)
+			if restored == nil || len(restored.Children) != tt.wantChildren {
+				t.Fatalf("expected %d restored children, got %+v", tt.wantChildren, restored)
+			}
+		})
+	}
+}
+
+func TestNewPurgeCli_OlderThan(t *testing.T) {
+	ctx := context.Background()
+	service := taskApi.NewService(repository.NewMemoryStore())
+	created, _ := service.CreateTask(ctx, models.Task{Title: "old", Priority: models.PriorityLow})
+	_ = service.Delete(ctx, created.ID)
+
+	t.Run("requires id or flag", func(t *testing.T) {
+		cmd := NewPurgeCli(service)
+		cmd.SetContext(ctx)
+		cmd.SetArgs([]string{})
+		cmd.SilenceUsage = true
+
+		var err error
+		captureStdout(func() { err = cmd.Execute() })
+		if err == nil {
+			t.Fatalf("expected error without id or --older-than")
+		}
+	})
+
+	t.Run("keeps recent tasks", func(t *testing.T) {
+		cmd := NewPurgeCli(service)
+		cmd.SetContext(ctx)
+		cmd.SetArgs([]string{"--older-than", "30d"})
+
+		var err error
+		output := captureStdout(func() { err = cmd.Execute() })
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !strings.Contains(output, "0 tarefa(s) apagada(s)") {
+			t.Fatalf("unexpected output %q", output)
+		}
+		if trash, _ := service.Trash(ctx); len(trash) != 1 {
+			t.Fatalf("trash = %d, want 1", len(trash))
+		}
+	})
+}
+
+// racingStore altera a tarefa logo antes da primeira escrita condicional,
+// simulando outra operação concorrente.
+type racingStore struct {
+	taskApi.Store
+	raced bool
+}
+
+// Atomic não usa a transação do MemoryStore: a escrita concorrente vem de
+// fora dela e não pode ser desfeita junto com o conflito.
+func (s *racingStore) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(ctx)
+}
+
+func (s *racingStore) PatchIfVersion(ctx context.Context, id string, version int64, changes map[string]any) (*models.Task, error) {
+	if !s.raced {
+		s.raced = true
+		if _, err := s.Store.Patch(ctx, id, map[string]any{"title": "Editada em outro lugar"}); err != nil {
+			return nil, err
+		}
+	}
+	return s.Store.PatchIfVersion(ctx, id, version, changes)
+}
+
+func TestNewCompleteCli_VersionConflict(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		answer   string
+		wantErr  bool
+		wantDone bool
+	}{
+		{name: "retry", answer: "s\n", wantDone: true},
+		{name: "give up", answer: "n\n", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := taskApi.NewService(&racingStore{Store: repository.NewMemoryStore()})
+			created, err := service.CreateTask(ctx, models.Task{Title: "Tarefa", Priority: models.PriorityLow})
+			if err != nil {
+				t.Fatalf("create: %v", err)
+			}
+
+			cmd := NewCompleteCli(service)
+			cmd.SetContext(ctx)
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			var output string
+			withStdin(created.ID+"\n"+tt.answer, func() {
+				output = captureStdout(func() {
+					err = cmd.Execute()
+				})
+			})
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %t", err, tt.wantErr)
+			}
+			if !strings.Contains(output, "Editada em outro lugar") || !strings.Contains(output, "Tentar de novo") {
+				t.Fatalf("expected current version and retry prompt, got %q", output)
+			}
+			got, _ := service.GetByID(ctx, created.ID)
+			if got == nil || got.Done != tt.wantDone {
+				t.Fatalf("done = %v, want %t", got, tt.wantDone)
+			}
+		})
+	}
+}
+
+func TestNewHistoryCli(t *testing.T) {
+	ctx := task.WithSource(context.Background(), task.SourceCLI)
+	service := taskApi.NewService(repository.NewMemoryStore())
+	created, err := service.CreateTask(ctx, models.Task{Title: "Tarefa", Priority: models.PriorityLow})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := service.Patch(ctx, created.ID, map[string]any{"priority": models.PriorityHigh}); err != nil {
+		t.Fatalf("patch: %v", err)
+	}
+
+	cmd := NewHistoryCli(service)
+	cmd.SetContext(ctx)
+	cmd.SetArgs([]string{created.ID})
+
+	output := captureStdout(func() {
+		err = cmd.Execute()
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	for _, want := range []string{"criada (cli)", "alterada (cli)", `priority: "low" -> "high"`, `title: (vazio) -> "Tarefa"`} {
+		if !strings.Contains(output, want) {
+			t.Fatalf("expected %q in output, got %q", want, output)
+		}
+	}
+}
+
+func TestNewSearchCli(t *testing.T) {
+	ctx := context.Background()
+	service := taskApi.NewService(repository.NewMemoryStore())
+	for _, title := range []string{"Comprar café", "Lavar o carro"} {
+		if _, err := service.CreateTask(ctx, models.Task{Title: title, Priority: models.PriorityLow}); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	cmd := NewSearchCli(service)
+	cmd.SetContext(ctx)
+	cmd.SetArgs([]string{"cafe"})
+
+	var err error
+	output := captureStdout(func() {
+		err = cmd.Execute()
+	})
+
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(output, "Título: Comprar café") || strings.Contains(output, "Lavar o carro") {
+		t.Fatalf("expected only the matching task, got %q", output)
+	}
+	if !strings.Contains(output, "\033[1;33mcafé\033[0m") {
+		t.Fatalf("expected highlighted term, got %q", output)
+	}
+}
+
+func TestNewListCli_Flags(t *testing.T) {
+	ctx := context.Background()
+	service := taskApi.NewService(repository.NewMemoryStore())
+	for _, p := range []models.Priority{models.PriorityLow, models.PriorityHigh, models.PriorityMedium} {
+		if _, err := service.CreateTask(ctx, models.Task{Title: "Tarefa " + string(p), Priority: p}); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	run := func(t *testing.T, args ...string) (string, error) {
+		t.Helper()
+		cmd := NewListCli(service)
+		cmd.SetContext(ctx)
+		cmd.SetArgs(args)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		var err error
+		output := captureStdout(func() {
+			err = cmd.Execute()
+		})
+		return output, err
+	}
+
+	output, err := run(t, "--pending", "--sort", "-priority", "--limit", "2")
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	high, medium := strings.Index(output, "Tarefa high"), strings.Index(output, "Tarefa medium")
+	if high < 0 || medium < high || strings.Contains(output, "Tarefa low") {
+		t.Fatalf("expected high then medium on first page, got %q", output)
+	}
+	if !strings.Contains(output, "--cursor ") {
+		t.Fatalf("expected next page hint, got %q", output)
+	}
+
+	for _, args := range [][]string{
+		{"--done", "--pending"},
+		{"--sort", "title"},
+		{"--created-from", "amanhã"},
+	} {
+		if _, err := run(t, args...); err == nil {
+			t.Fatalf("expected error for %v", args)
+		}
+	}
+}
+
+func TestNewMoveCli(t *testing.T) {
+	ctx := context.Background()
+	service := taskApi.NewService(repository.NewMemoryStore())
+	project, _ := service.CreateTask(ctx, models.Task{Title: "Projeto", Priority: models.PriorityLow})
+	step, _ := service.CreateTask(ctx, models.Task{Title: "Etapa", Priority: models.PriorityLow})
+	if _, err := service.CreateTask(ctx, models.Task{Title: "Detalhe", Priority: models.PriorityLow, ParentID: &step.ID}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantErr    string
+		wantOutput string
+		wantParent *string
+	}{
+		{name: "under another task", args: []string{step.ID, project.ID}, wantOutput: "Caminho: Projeto > Etapa", wantParent: &project.ID},
+		{name: "to root", args: []string{step.ID, "--root"}, wantOutput: "Caminho: Etapa"},
+		{name: "missing destination", args: []string{step.ID}, wantErr: "--root"},
+		{name: "both destinations", args: []string{step.ID, project.ID, "--root"}, wantErr: "não os dois"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewMoveCli(service)
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tt.args)
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			var err error
+			output := captureStdout(func() {
+				err = cmd.Execute()
+			})
+
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if !strings.Contains(output, tt.wantOutput) || !strings.Contains(output, "Subtarefas levadas junto: 1") {
+				t.Fatalf("unexpected output %q", output)
+			}
+
+			moved, _ := service.GetByID(ctx, step.ID)
+			if (tt.wantParent == nil) != (moved.ParentID == nil) || (tt.wantParent != nil && *moved.ParentID != *tt.wantParent) {
+				t.Fatalf("parent_id = %v, want %v", moved.ParentID, tt.wantParent)
+			}
+		})
+	}
+}
+
+func TestNewAddCli_Tags(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name      string
+		title     string
+		args      []string
+		wantTitle string
+		wantTags  string
+		wantErr   bool
+	}{
+		{name: "hashtags in title", title: "Trocar #HD do #homelab", wantTitle: "Trocar do", wantTags: "hd,homelab"},
+		{name: "flag and hashtag", title: "Backup #homelab", args: []string{"--tag", "urgente"}, wantTitle: "Backup", wantTags: "homelab,urgente"},
+		{name: "lone hash stays", title: "Item # 2", wantTitle: "Item # 2"},
+		{name: "only tags", title: "#homelab", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := taskApi.NewService(repository.NewMemoryStore())
+			cmd := NewAddCli(service)
+			cmd.SetContext(ctx)
+			cmd.SetArgs(tt.args)
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			var err error
+			withStdin(strings.Join([]string{tt.title, "", "baixa", "02/01/2006 15:04", ""}, "\n"), func() {
+				captureStdout(func() {
+					err = cmd.Execute()
+				})
+			})
+
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			tasks, _ := service.List(ctx)
+			if len(tasks) != 1 || tasks[0].Title != tt.wantTitle {
+				t.Fatalf("tasks = %+v, want title %q", tasks, tt.wantTitle)
+			}
+			full, _ := service.GetByID(ctx, tasks[0].ID)
+			var names []string
+			for _, tag := range full.Tags {
+				names = append(names, tag.Name)
+			}
+			if got := strings.Join(names, ","); got != tt.wantTags {
+				t.Fatalf("tags = %q, want %q", got, tt.wantTags)
+			}
+		})
+	}
+}
+
+func TestNewTagCli(t *testing.T) {
+	ctx := context.Background()
+	service := taskApi.NewService(repository.NewMemoryStore())
+	backup, _ := service.CreateTask(ctx, models.Task{Title: "Backup", Priority: models.PriorityLow})
+
+	steps := []struct {
+		args       []string
+		wantOutput string
+		wantErr    string
+	}{
+		{args: []string{"create", "homelab", "--color", "cyan"}, wantOutput: "\033[36m#homelab\033[0m"},
+		{args: []string{"create", "homelab"}, wantErr: "já existe"},
+		{args: []string{"add", backup.ID, "homelab", "#urgente"}, wantOutput: "#urgente"},
+		{args: []string{"color", "urgente", "red"}, wantOutput: "\033[31m#urgente\033[0m"},
+		{args: []string{"color", "urgente", "rosa"}, wantErr: "cor"},
+		{args: []string{"list"}, wantOutput: "\033[36m#homelab\033[0m"},
+		{args: []string{"remove", backup.ID, "urgente"}, wantOutput: "Tarefa Backup: \033[36m#homelab\033[0m"},
+		{args: []string{"delete", "homelab"}, wantOutput: "Tag #homelab apagada."},
+		{args: []string{"remove", backup.ID, "homelab"}, wantErr: "não encontrada"},
+	}
+
+	for _, step := range steps {
+		cmd := NewTagCli(service)
+		cmd.SetContext(ctx)
+		cmd.SetArgs(step.args)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		var err error
+		output := captureStdout(func() {
+			err = cmd.Execute()
+		})
+
+		if step.wantErr != "" {
+			if err == nil || !strings.Contains(strings.ToLower(err.Error()), step.wantErr) {
+				t.Fatalf("tag %v: expected error containing %q, got %v", step.args, step.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("tag %v: expected no error, got %v", step.args, err)
+		}
+		if !strings.Contains(output, step.wantOutput) {
+			t.Fatalf("tag %v: expected %q in output, got %q", step.args, step.wantOutput, output)
+		}
+	}
+}
+
+func TestNewProjectCli(t *testing.T) {
+	ctx := context.Background()
+	service := taskApi.NewService(repository.NewMemoryStore())
+
+	steps := []struct {
+		args       []string
+		wantOutput string
+		wantErr    string
+	}{
+		{args: []string{"create", "homelab", "--priority", "alta", "--remind", "1d"}, wantOutput: "Prioridade padrão: high"},
+		{args: []string{"create", "homelab"}, wantErr: "já existe"},
+		{args: []string{"create", "x", "--priority", "urgente"}, wantErr: "projeto inválido"},
+		{args: []string{"edit", "homelab", "--name", "servidores", "--remind", ""}, wantOutput: "Nome: servidores"},
+		{args: []string{"edit", "servidores"}, wantErr: "nada para alterar"},
+		{args: []string{"show", "servidores"}, wantOutput: "0 abertas, 0 concluídas"},
+		{args: []string{"archive", "servidores"}, wantOutput: "Projeto servidores arquivado."},
+		{args: []string{"list"}, wantOutput: "Nenhum projeto criado."},
+		{args: []string{"list", "--archived"}, wantOutput: "| > Arquivado"},
+		{args: []string{"unarchive", "servidores"}, wantOutput: "desarquivado"},
+		{args: []string{"delete", "servidores"}, wantOutput: "Projeto servidores apagado."},
+		{args: []string{"show", "servidores"}, wantErr: "projeto não encontrado"},
+	}
+
+	for _, step := range steps {
+		cmd := NewProjectCli(service)
+		cmd.SetContext(ctx)
+		cmd.SetArgs(step.args)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		var err error
+		output := captureStdout(func() {
+			err = cmd.Execute()
+		})
+
+		if step.wantErr != "" {
+			if err == nil || !strings.Contains(strings.ToLower(err.Error()), step.wantErr) {
+				t.Fatalf("project %v: expected error containing %q, got %v", step.args, step.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("project %v: expected no error, got %v", step.args, err)
+		}
+		if !strings.Contains(output, step.wantOutput) {
+			t.Fatalf("project %v: expected %q in output, got %q", step.args, step.wantOutput, output)
+		}
+	}
+}
+
+func TestNewAddCli_Project(t *testing.T) {
+	ctx := context.Background()
+	service := taskApi.NewService(repository.NewMemoryStore())
+	if _, err := service.CreateProject(ctx, models.Project{Name: "homelab", DefaultPriority: models.PriorityHigh, DefaultReminderOffset: "1d"}); err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+
+	cmd := NewAddCli(service)
+	addProjectFlag(cmd)
+	cmd.SetContext(ctx)
+	cmd.SetArgs([]string{"--project", "homelab"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	var err error
+	var output string
+	withStdin(strings.Join([]string{"Backup", "", "", "", ""}, "\n"), func() {
+		output = captureStdout(func() {
+			err = cmd.Execute()
+		})
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(output, "vazio usa high") || !strings.Contains(output, "Projeto: homelab") {
+		t.Fatalf("expected project defaults in output, got %q", output)
+	}
+
+	tasks, _ := service.List(ctx)
+	if len(tasks) != 1 || tasks[0].Priority != models.PriorityHigh || tasks[0].ProjectID == nil {
+		t.Fatalf("expected task with project defaults, got %+v", tasks)
+	}
+	if wait := time.Until(tasks[0].ReminderAt); wait < 23*time.Hour || wait > 24*time.Hour {
+		t.Fatalf("reminder in %v, want one day", wait)
+	}
+
+	list := NewListCli(service)
+	addProjectFlag(list)
+	list.SetContext(ctx)
+	list.SetArgs([]string{"--project", "homelab"})
+	output = captureStdout(func() {
+		err = list.Execute()
+	})
+	if err != nil || !strings.Contains(output, "Projeto homelab: 1 abertas, 0 concluídas") {
+		t.Fatalf("list --project = %q, %v", output, err)
+	}
+
+	cmd = NewAddCli(service)
+	addProjectFlag(cmd)
+	cmd.SetContext(ctx)
+	cmd.SetArgs([]string{"--project", "missing"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "projeto") {
+		t.Fatalf("expected unknown project error, got %v", err)
+	}
+}
+
+func TestNewDependencyCli(t *testing.T) {
+	ctx := context.Background()
+	service := taskApi.NewService(repository.NewMemoryStore())
+	design, _ := service.CreateTask(ctx, models.Task{Title: "Design", Priority: models.PriorityLow})
+	build, _ := service.CreateTask(ctx, models.Task{Title: "Build", Priority: models.PriorityLow})
+
+	steps := []struct {
+		args       []string
+		wantOutput string
+		wantErr    string
+	}{
+		{args: []string{"add", build.ID, design.ID}, wantOutput: "Bloqueada por 1 tarefa(s) em aberto."},
+		{args: []string{"add", design.ID, build.ID}, wantErr: "ciclo"},
+		{args: []string{"list", design.ID}, wantOutput: build.ID + "  Build (em aberto)"},
+		{args: []string{"remove", build.ID, design.ID}, wantOutput: "Pronta para começar."},
+		{args: []string{"remove", build.ID, design.ID}, wantErr: "dependência não encontrada"},
+	}
+
+	for _, step := range steps {
+		cmd := NewDependencyCli(service)
+		cmd.SetContext(ctx)
+		cmd.SetArgs(step.args)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		var err error
+		output := captureStdout(func() {
+			err = cmd.Execute()
+		})
+
+		if step.wantErr != "" {
+			if err == nil || !strings.Contains(strings.ToLower(err.Error()), step.wantErr) {
+				t.Fatalf("deps %v: expected error containing %q, got %v", step.args, step.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("deps %v: expected no error, got %v", step.args, err)
+		}
+		if !strings.Contains(output, step.wantOutput) {
+			t.Fatalf("deps %v: expected %q in output, got %q", step.args, step.wantOutput, output)
+		}
+	}
+}
+
+func TestNewListCli_Ready(t *testing.T) {
+	ctx := context.Background()
+	service := taskApi.NewService(repository.NewMemoryStore())
+	design, _ := service.CreateTask(ctx, models.Task{Title: "Design", Priority: models.PriorityLow})
+	build, _ := service.CreateTask(ctx, models.Task{Title: "Build", Priority: models.PriorityLow})
+	if _, err := service.AddDependency(ctx, build.ID, design.ID); err != nil {
+		t.Fatalf("add dependency: %v", err)
+	}
+
+	list := NewListCli(service)
+	list.SetContext(ctx)
+	list.SetArgs([]string{})
+	output := captureStdout(func() {
+		if err := list.Execute(); err != nil {
+			t.Fatalf("list: %v", err)
+		}
+	})
+	if !strings.Contains(output, "| > Bloqueada por: "+design.ID) {
+		t.Fatalf("expected blocked task in list, got %q", output)
+	}
+
+	ready := NewListCli(service)
+	ready.SetContext(ctx)
+	ready.SetArgs([]string{"--ready"})
+	output = captureStdout(func() {
+		if err := ready.Execute(); err != nil {
+			t.Fatalf("list --ready: %v", err)
+		}
+	})
+	if !strings.Contains(output, "Design") || strings.Contains(output, "Build") {
+		t.Fatalf("expected only Design in ready list, got %q", output)
+	}
+}
+
+func TestNewAddCli_Due(t *testing.T) {
+	ctx := context.Background()
+	service := taskApi.NewService(repository.NewMemoryStore())
+	due := time.Now().AddDate(0, 0, 5)
+
+	cmd := NewAddCli(service)
+	cmd.SetContext(ctx)
+	cmd.SetArgs([]string{"--due", due.Format("02/01/2006"), "--remind-due", "-1d"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+
+	var err error
+	var output string
+	// Com --due, o lembrete principal pode ficar em branco.
+	withStdin(strings.Join([]string{"Renovar domínio", "", "alta", "", ""}, "\n"), func() {
+		output = captureStdout(func() {
+			err = cmd.Execute()
+		})
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(output, "Prazo: "+due.Format("02/01/2006")) || strings.Contains(output, "Lembrar em:") {
+		t.Fatalf("expected due date and no reminder_at in output, got %q", output)
+	}
+
+	tasks, _ := service.List(ctx)
+	if len(tasks) != 1 || tasks[0].DueAt == nil || !tasks[0].DueAllDay {
+		t.Fatalf("expected all-day due task, got %+v", tasks)
+	}
+	y, m, d := due.Date()
+	wantFire := time.Date(y, m, d-1, 0, 0, 0, 0, time.Local)
+	created, _ := service.GetByID(ctx, tasks[0].ID)
+	if len(created.Reminders) != 1 || created.Reminders[0].Anchor != models.AnchorDue || !created.Reminders[0].FireAt.Equal(wantFire) {
+		t.Fatalf("expected one reminder a day before the due date, got %+v", created.Reminders)
+	}
+
+	cmd = NewAddCli(service)
+	cmd.SetContext(ctx)
+	cmd.SetArgs([]string{"--remind-due", "-1d"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "--due") {
+		t.Fatalf("expected --remind-due without --due to fail, got %v", err)
+	}
+}
+
+func TestNewListCli_Due(t *testing.T) {
+	ctx := context.Background()
+	service := taskApi.NewService(repository.NewMemoryStore())
+	late := time.Now().AddDate(0, 0, -1)
+	later := time.Now().AddDate(0, 0, 10)
+	for _, tk := range []models.Task{
+		{Title: "sem prazo", Priority: models.PriorityLow},
+		{Title: "depois", Priority: models.PriorityLow, DueAt: &later},
+		{Title: "atrasada", Priority: models.PriorityLow, DueAt: &late},
+	} {
+		if _, err := service.CreateTask(ctx, tk); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	cmd := NewListCli(service)
+	cmd.SetContext(ctx)
+	cmd.SetArgs([]string{"--sort", "due_at"})
+	var err error
+	output := captureStdout(func() {
+		err = cmd.Execute()
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !strings.Contains(output, "Prazo: "+late.Format("02/01/2006 15:04")+" (atrasada)") {
+		t.Fatalf("expected overdue label, got %q", output)
+	}
+	first, second, third := strings.Index(output, "atrasada"), strings.Index(output, "depois"), strings.Index(output, "sem prazo")
+	if first < 0 || second < first || third < second {
+		t.Fatalf("expected tasks ordered by due date, got %q", output)
+	}
+}
+
+func TestNewStatusCli(t *testing.T) {
+	ctx := context.Background()
+	service := taskApi.NewService(repository.NewMemoryStore())
+	doc, _ := service.CreateTask(ctx, models.Task{Title: "Doc", Priority: models.PriorityLow})
+	call, _ := service.CreateTask(ctx, models.Task{Title: "Ligar", Priority: models.PriorityLow})
+
+	steps := []struct {
+		cmd        func(*taskApi.Service) *cobra.Command
+		args       []string
+		wantOutput string
+		wantErr    string
+	}{
+		{cmd: NewStatusCli, args: []string{doc.ID, "em-andamento"}, wantOutput: "em andamento"},
+		{cmd: NewStatusCli, args: []string{doc.ID, "feito"}, wantErr: "status inválido"},
+		{cmd: NewStatusCli, args: []string{call.ID, "cancelada"}, wantOutput: "cancelada"},
+		{cmd: NewStatusCli, args: []string{call.ID, "waiting"}, wantErr: "está cancelada; reabra antes"},
+		{cmd: NewStatusCli, args: []string{"missing", "todo"}, wantErr: "não encontrada"},
+		{cmd: NewReopenCli, args: []string{doc.ID}, wantErr: "já está em aberto"},
+		{cmd: NewReopenCli, args: []string{call.ID}, wantOutput: "reaberta"},
+	}
+
+	for _, step := range steps {
+		cmd := step.cmd(service)
+		cmd.SetContext(ctx)
+		cmd.SetArgs(step.args)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		var err error
+		output := captureStdout(func() {
+			err = cmd.Execute()
+		})
+
+		if step.wantErr != "" {
+			if err == nil || !strings.Contains(strings.ToLower(err.Error()), step.wantErr) {
+				t.Fatalf("%v: expected error containing %q, got %v", step.args, step.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%v: expected no error, got %v", step.args, err)
+		}
+		if !strings.Contains(output, step.wantOutput) {
+			t.Fatalf("%v: expected %q in output, got %q", step.args, step.wantOutput, output)
+		}
+	}
+
+	list := NewListCli(service)
+	list.SetContext(ctx)
+	list.SetArgs([]string{"--status", "in_progress"})
+	output := captureStdout(func() {
+		if err := list.Execute(); err != nil {
+			t.Fatalf("list --status: %v", err)
+		}
+	})
+	if !strings.Contains(output, "| > Status: em andamento") || strings.Contains(output, "Ligar") {
+		t.Fatalf("expected only Doc with --status in_progress, got %q", output)
+	}
+}
+
+func TestNewNextCli(t *testing.T) {
+	ctx := context.Background()
+	service := taskApi.NewService(repository.NewMemoryStore())
+
+	run := func(args ...string) (string, error) {
+		cmd := NewNextCli(service)
+		cmd.SetContext(ctx)
+		cmd.SetArgs(args)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		var err error
+		output := captureStdout(func() {
+			err = cmd.Execute()
+		})
+		return output, err
+	}
+
+	if output, err := run(); err != nil || !strings.Contains(output, "Nada em aberto") {
+		t.Fatalf("empty next = %q, %v", output, err)
+	}
+
+	service.CreateTask(ctx, models.Task{Title: "Regar plantas", Priority: models.PriorityLow})
+	service.CreateTask(ctx, models.Task{Title: "Pagar boleto", Priority: models.PriorityHigh})
+
+	output, err := run("-n", "1")
+	if err != nil {
+		t.Fatalf("next: %v", err)
+	}
+	if !strings.Contains(output, "1. Pagar boleto") || !strings.Contains(output, "prioridade alta") || strings.Contains(output, "Regar plantas") {
+		t.Fatalf("expected only the high priority task with its reason, got %q", output)
+	}
+
+	if _, err := run("--weights", "urgencia=2"); err == nil || !strings.Contains(err.Error(), "--weights inválido") {
+		t.Fatalf("expected invalid weights error, got %v", err)
+	}
+}
+
+func TestNewPlanCli(t *testing.T) {
+	ctx := context.Background()
+	service := taskApi.NewService(repository.NewMemoryStore())
+
+	add := NewAddCli(service)
+	add.SetContext(ctx)
+	add.SetArgs([]string{"--estimate", "1h30m", "--due", time.Now().AddDate(0, 0, 3).Format("02/01/2006")})
+	add.SilenceUsage = true
+	add.SilenceErrors = true
+	var err error
+	var output string
+	withStdin(strings.Join([]string{"Relatório", "", "alta", "", ""}, "\n"), func() {
+		output = captureStdout(func() {
+			err = add.Execute()
+		})
+	})
+	if err != nil || !strings.Contains(output, "Estimativa: 1h30") {
+		t.Fatalf("add --estimate = %q, %v", output, err)
+	}
+
+	run := func(args ...string) (string, error) {
+		cmd := NewPlanCli(service)
+		cmd.SetContext(ctx)
+		cmd.SetArgs(args)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+		var err error
+		output := captureStdout(func() {
+			err = cmd.Execute()
+		})
+		return output, err
+	}
+
+	output, err = run("amanhã", "--accept")
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	if !strings.Contains(output, "09:00–10:30  Relatório (1h30)") || !strings.Contains(output, "lembrete ajustado em 1 tarefa(s)") {
+		t.Fatalf("expected the report at 09:00 and the plan accepted, got %q", output)
+	}
+	tasks, _ := service.List(ctx)
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	if y, m, d := tomorrow.Date(); len(tasks) != 1 || !tasks[0].ReminderAt.Equal(time.Date(y, m, d, 9, 0, 0, 0, time.Local)) {
+		t.Fatalf("expected reminder moved to 09:00 tomorrow, got %+v", tasks)
+	}
+
+	if _, err := run("01/01/2000"); err == nil || !strings.Contains(err.Error(), "dia inválido") {
+		t.Fatalf("expected past day to fail, got %v", err)
+	}
+}
+
+func TestNewTimerCli(t *testing.T) {
+	ctx := context.Background()
+	store := repository.NewMemoryStore()
+	service := taskApi.NewService(store)
+	doc, _ := service.CreateTask(ctx, models.Task{Title: "Doc", Priority: models.PriorityLow})
+	call, _ := service.CreateTask(ctx, models.Task{Title: "Ligar", Priority: models.PriorityLow})
+
+	y, m, d := time.Now().AddDate(0, 0, -1).Date()
+	start := time.Date(y, m, d, 12, 0, 0, 0, time.Local)
+	stop := start.Add(45 * time.Minute)
+	if err := store.CreateTimeEntry(ctx, &models.TimeEntry{TaskID: doc.ID, StartedAt: start, StoppedAt: &stop}); err != nil {
+		t.Fatalf("create entry: %v", err)
+	}
+	yesterday := start.Format(time.DateOnly)
+
+	steps := []struct {
+		cmd        func(*taskApi.Service) *cobra.Command
+		args       []string
+		wantOutput string
+		wantErr    string
+	}{
+		{cmd: NewStatusCli, args: nil, wantOutput: "Nenhum cronômetro rodando"},
+		{cmd: NewStopCli, args: nil, wantErr: "nenhum cronômetro rodando"},
+		{cmd: NewStartCli, args: []string{doc.ID, "--note", "revisão"}, wantOutput: "Cronômetro iniciado"},
+		{cmd: NewStartCli, args: []string{doc.ID}, wantErr: "já está rodando"},
+		{cmd: NewStatusCli, args: nil, wantOutput: "rodando desde"},
+		{cmd: NewStatusCli, args: []string{doc.ID}, wantErr: "informe <id> e <status>"},
+		{cmd: NewStartCli, args: []string{call.ID}, wantOutput: "Cronômetro parado na tarefa " + doc.ID},
+		{cmd: NewStopCli, args: nil, wantOutput: "Cronômetro parado em: Ligar"},
+		{cmd: NewStartCli, args: []string{"missing"}, wantErr: "não encontrada"},
+		{cmd: NewTimesheetCli, args: []string{"--from", yesterday, "--to", yesterday}, wantOutput: "| > sem projeto: 45min"},
+		{cmd: NewTimesheetCli, args: []string{"--from", yesterday, "--to", yesterday, "--format", "csv"}, wantOutput: "sem projeto," + doc.ID + ",Doc,0.75"},
+		{cmd: NewTimesheetCli, args: []string{"--format", "xml"}, wantErr: "formato inválido"},
+		{cmd: NewTimesheetCli, args: []string{"--from", "amanhã"}, wantErr: "período inválido"},
+	}
+
+	for _, step := range steps {
+		cmd := step.cmd(service)
+		cmd.SetContext(ctx)
+		cmd.SetArgs(step.args)
+		cmd.SilenceUsage = true
+		cmd.SilenceErrors = true
+
+		var err error
+		output := captureStdout(func() {
+			err = cmd.Execute()
+		})
+
+		if step.wantErr != "" {
+			if err == nil || !strings.Contains(strings.ToLower(err.Error()), step.wantErr) {
+				t.Fatalf("%s %v: expected error containing %q, got %v", cmd.Name(), step.args, step.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%s %v: expected no error, got %v", cmd.Name(), step.args, err)
+		}
+		if !strings.Contains(output, step.wantOutput) {
+			t.Fatalf("%s %v: expected output containing %q, got %q", cmd.Name(), step.args, step.wantOutput, output)
+		}
+	}
+
+	got, _ := service.GetByID(ctx, doc.ID)
+	if got.Status != models.StatusInProgress || got.TrackedSeconds < 45*60 {
+		t.Fatalf("expected doc in progress with 45min tracked, got %s %d", got.Status, got.TrackedSeconds)
+	}
+}
@@ -2,9 +2,10 @@ package cli
 
 import (
 	"bufio"
+	"errors"
 	"fmt"
 	"log"
-	"os"
+	"strings"
 
 	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
 	"github.com/andre-felipe-wonsik-alves/internal/models"
@@ -17,7 +18,7 @@ func NewCompleteCli(service *taskApi.Service) *cobra.Command {
 		Short: "Completa uma task por meio do ID.",
 		RunE: func(cli *cobra.Command, args []string) error {
 			ctx := cli.Context()
-			reader := bufio.NewReader(os.Stdin)
+			reader := bufio.NewReader(cli.InOrStdin())
 
 			tasks, err := service.List(ctx)
 
@@ -27,7 +28,9 @@ func NewCompleteCli(service *taskApi.Service) *cobra.Command {
 
 			}
 
+			versions := make(map[string]int64, len(tasks))
 			for _, task := range tasks {
+				versions[task.ID] = task.Version
 				err := showTaskId(task)
 
 				if err != nil {
@@ -41,8 +44,37 @@ func NewCompleteCli(service *taskApi.Service) *cobra.Command {
 				return err
 			}
 
-			service.Complete(ctx, ID)
-			return nil
+			// A versão é a da listagem: se a tarefa mudou enquanto o usuário
+			// escolhia, o conflito aparece em vez de sobrescrever a mudança.
+			version := versions[ID]
+			for {
+				completed, err := service.CompleteIfMatch(ctx, ID, version)
+				if err == nil {
+					fmt.Printf("\nTarefa %s concluída!\n", ID)
+					if completed != nil && completed.Progress != nil {
+						fmt.Printf("Subtarefas concluídas: %s\n", formatProgress(*completed.Progress))
+					}
+					if completed != nil && len(completed.BlockedBy) > 0 {
+						fmt.Printf("Atenção: ainda dependia de tarefas em aberto: %s\n", strings.Join(completed.BlockedBy, ", "))
+					}
+					return nil
+				}
+				if errors.Is(err, taskApi.ErrOpenSubtasks) {
+					return fmt.Errorf("a tarefa %s tem subtarefas em aberto; conclua-as antes", ID)
+				}
+				if errors.Is(err, taskApi.ErrInvalidTransition) {
+					return transitionError(ctx, service, ID, models.StatusDone)
+				}
+				if errors.Is(err, taskApi.ErrTaskBlocked) {
+					return fmt.Errorf("a tarefa %s depende de tarefas em aberto; conclua-as antes (veja advisor-go deps list %s)", ID, ID)
+				}
+
+				current, err := offerRetryOnConflict(ctx, service, reader, ID, err)
+				if err != nil {
+					return err
+				}
+				version = current.Version
+			}
 		},
 	}
 }
@@ -50,6 +82,12 @@ func NewCompleteCli(service *taskApi.Service) *cobra.Command {
 func showTaskId(task models.Task) error {
 	fmt.Println("\n______________________________")
 	fmt.Printf("ID: %s \n| > Título: %s\n", task.ID, task.Title)
+	if task.Progress != nil {
+		fmt.Printf("| > Subtarefas: %s\n", formatProgress(*task.Progress))
+	}
+	if task.Blocked {
+		fmt.Printf("| > Bloqueada por: %s\n", strings.Join(task.BlockedBy, ", "))
+	}
 	fmt.Println()
 
 	return nil
@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+
+	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// offerRetryOnConflict mostra a versão atual da tarefa quando err é um
+// conflito de versão e pergunta se a operação deve ser refeita sobre ela.
+// Devolve a tarefa recarregada quando o usuário aceita.
+func offerRetryOnConflict(ctx context.Context, service *taskApi.Service, reader *bufio.Reader, id string, err error) (*models.Task, error) {
+	if !errors.Is(err, taskApi.ErrVersionConflict) {
+		return nil, err
+	}
+
+	current, getErr := service.GetByID(ctx, id)
+	if getErr != nil {
+		return nil, getErr
+	}
+	if current == nil {
+		return nil, taskApi.ErrTaskNotFound
+	}
+
+	fmt.Println("\nA tarefa foi alterada por outra operação enquanto você trabalhava. Versão atual:")
+	fmt.Printf("ID: %s \n| > Título: %s\n| > Status: %s\n| > Lembrar em: %s\n| > Versão: %d\n",
+		current.ID, current.Title, statusLabel(current.Status), current.ReminderAt.Local().Format("02/01/2006 15:04"), current.Version)
+
+	answer, promptErr := prompt(reader, "Tentar de novo com a versão atual? [s/N]: ")
+	if promptErr != nil {
+		return nil, promptErr
+	}
+	if !isYes(answer) {
+		return nil, err
+	}
+	return current, nil
+}
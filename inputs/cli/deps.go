@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"fmt"
+
+	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"github.com/spf13/cobra"
+)
+
+func NewDependencyCli(service *taskApi.Service) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "deps",
+		Short: "Gerencia as dependências entre tarefas (X só começa depois de Y).",
+		Example: "  advisor-go deps add 8f3edff7 1c2d3e4f   (8f3edff7 depende de 1c2d3e4f)\n" +
+			"  advisor-go deps list 8f3edff7\n  advisor-go list --ready",
+	}
+
+	cmd.AddCommand(newDependencyListCli(service))
+	cmd.AddCommand(newDependencyAddCli(service))
+	cmd.AddCommand(newDependencyRemoveCli(service))
+
+	return cmd
+}
+
+func newDependencyListCli(service *taskApi.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list <id>",
+		Short: "Mostra de quais tarefas ela depende e quais dependem dela.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cli *cobra.Command, args []string) error {
+			deps, err := service.Dependencies(cli.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			fmt.Println("\nDepende de:")
+			showDependencies(deps.DependsOn)
+			fmt.Println("\nBloqueia:")
+			showDependencies(deps.Blocks)
+			return nil
+		},
+	}
+}
+
+func showDependencies(tasks []models.Task) {
+	if len(tasks) == 0 {
+		fmt.Println("  (nenhuma)")
+		return
+	}
+	for _, t := range tasks {
+		status := "em aberto"
+		if t.Done {
+			status = "concluída"
+		}
+		fmt.Printf("  %s  %s (%s)\n", t.ID, t.Title, status)
+	}
+}
+
+func newDependencyAddCli(service *taskApi.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <id> <depende-de>",
+		Short: "Faz a tarefa depender de outra: ela fica bloqueada até a outra ser concluída.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cli *cobra.Command, args []string) error {
+			updated, err := service.AddDependency(cli.Context(), args[0], args[1])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\nTarefa %s agora depende de %s.\n", updated.Title, args[1])
+			showBlocked(updated)
+			return nil
+		},
+	}
+}
+
+func newDependencyRemoveCli(service *taskApi.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <id> <depende-de>",
+		Short: "Desfaz a dependência entre as tarefas.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cli *cobra.Command, args []string) error {
+			updated, err := service.RemoveDependency(cli.Context(), args[0], args[1])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\nTarefa %s não depende mais de %s.\n", updated.Title, args[1])
+			showBlocked(updated)
+			return nil
+		},
+	}
+}
+
+func showBlocked(t *models.Task) {
+	if t.Blocked {
+		fmt.Printf("Bloqueada por %d tarefa(s) em aberto.\n", len(t.BlockedBy))
+		return
+	}
+	fmt.Println("Pronta para começar.")
+}
@@ -0,0 +1,63 @@
+package cli
+
+import (
+	"fmt"
+
+	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var historyActionLabels = map[models.HistoryAction]string{
+	models.HistoryCreate:   "criada",
+	models.HistoryUpdate:   "alterada",
+	models.HistoryComplete: "concluída",
+	models.HistorySnooze:   "adiada",
+	models.HistoryDelete:   "apagada",
+	models.HistoryRestore:  "restaurada",
+}
+
+func NewHistoryCli(service *taskApi.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "history <id>",
+		Short: "Mostra quem mudou o quê em uma tarefa, e quando.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cli *cobra.Command, args []string) error {
+			changes, err := service.History(cli.Context(), args[0])
+			if err != nil {
+				return err
+			}
+
+			if len(changes) == 0 {
+				fmt.Println("\nNenhuma alteração registrada.")
+				return nil
+			}
+
+			// Mudanças da mesma operação saem juntas, sob um único cabeçalho.
+			for i, change := range changes {
+				if i == 0 || !sameOperation(changes[i-1], change) {
+					label := historyActionLabels[change.Action]
+					if label == "" {
+						label = string(change.Action)
+					}
+					fmt.Println("\n<===---===>")
+					fmt.Printf("%s | %s (%s)\n", change.CreatedAt.Local().Format("02/01/2006 15:04:05"), label, change.Source)
+				}
+				fmt.Printf("| > %s: %s -> %s\n", change.Field, historyValue(change.Before), historyValue(change.After))
+			}
+
+			return nil
+		},
+	}
+}
+
+func sameOperation(a, b models.TaskChange) bool {
+	return a.Action == b.Action && a.Source == b.Source && a.CreatedAt.Equal(b.CreatedAt)
+}
+
+func historyValue(v models.AuditValue) string {
+	if v == "" || v == "null" {
+		return "(vazio)"
+	}
+	return string(v)
+}
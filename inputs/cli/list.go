@@ -1,46 +1,227 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
-	"log"
+	"strings"
+	"time"
 
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
 	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
 	"github.com/andre-felipe-wonsik-alves/internal/models"
 	"github.com/spf13/cobra"
 )
 
+type listFlags struct {
+	done, pending, root      bool
+	ready                    bool
+	priority, parent         string
+	reminderFrom, reminderTo string
+	createdFrom, createdTo   string
+	sort, cursor             string
+	tags, statuses           []string
+	limit                    int
+}
+
 func NewListCli(service *taskApi.Service) *cobra.Command {
-	return &cobra.Command{
-		Use:   "list",
-		Short: "Lista todas as tarefas.",
+	var flags listFlags
+
+	cmd := &cobra.Command{
+		Use:     "list",
+		Short:   "Lista as tarefas, com filtros e paginação.",
+		Example: "  advisor-go list --pending --sort -priority\n  advisor-go list --reminder-from 01/06/2025 --reminder-to 08/06/2025 --sort reminder_at\n  advisor-go list --tag homelab --tag -urgente\n  advisor-go list --project homelab\n  advisor-go list --ready --sort -priority\n  advisor-go list --pending --sort due_at\n  advisor-go list --status in_progress --status waiting",
 		RunE: func(cli *cobra.Command, args []string) error {
 			ctx := cli.Context()
-			tasks, err := service.List(ctx)
 
+			query, err := flags.query()
 			if err != nil {
-				log.Fatalln("Um erro aconteceu durante a busca das Tasks: ", err)
-				return nil
-
+				return err
+			}
+			project, err := selectedProject(cli, service)
+			if err != nil {
+				return err
+			}
+			if project != nil {
+				query.ProjectID = &project.ID
+				fmt.Printf("\nProjeto %s: %s\n", project.Name, formatCounts(project.Counts))
 			}
 
-			for _, task := range tasks {
-				err := showTask(task)
+			page, err := service.ListPage(ctx, query, flags.cursor)
+			if err != nil {
+				return err
+			}
 
-				if err != nil {
-					log.Fatalln("Um erro aconteceu durante a listagem: ", err)
-					return nil
+			if len(page.Tasks) == 0 {
+				fmt.Println("\nNenhuma tarefa encontrada.")
+				return nil
+			}
+			for _, task := range page.Tasks {
+				if err := showTask(task); err != nil {
+					return err
 				}
 			}
 
+			if page.NextCursor != "" {
+				fmt.Printf("\nHá mais tarefas. Próxima página: repita o comando com --cursor %s\n", page.NextCursor)
+			}
+
 			return nil
 		},
 	}
+
+	cmd.Flags().BoolVar(&flags.done, "done", false, "Só tarefas concluídas")
+	cmd.Flags().BoolVar(&flags.pending, "pending", false, "Só tarefas pendentes")
+	cmd.Flags().StringArrayVar(&flags.statuses, "status", nil, "Só tarefas neste status (todo, in_progress, blocked, waiting, done, cancelled); pode repetir")
+	cmd.Flags().BoolVar(&flags.root, "root", false, "Só tarefas sem pai")
+	cmd.Flags().BoolVar(&flags.ready, "ready", false, "Só tarefas pendentes sem dependências em aberto")
+	cmd.Flags().StringVarP(&flags.priority, "priority", "p", "", "Só tarefas desta prioridade (baixa, media, alta)")
+	cmd.Flags().StringVar(&flags.parent, "parent", "", "Só subtarefas desta tarefa (ID)")
+	cmd.Flags().StringVar(&flags.reminderFrom, "reminder-from", "", "Lembrete a partir de (DD/MM/AAAA [HH:MM])")
+	cmd.Flags().StringVar(&flags.reminderTo, "reminder-to", "", "Lembrete antes de (DD/MM/AAAA [HH:MM])")
+	cmd.Flags().StringVar(&flags.createdFrom, "created-from", "", "Criada a partir de (DD/MM/AAAA [HH:MM])")
+	cmd.Flags().StringVar(&flags.createdTo, "created-to", "", "Criada antes de (DD/MM/AAAA [HH:MM])")
+	cmd.Flags().StringArrayVarP(&flags.tags, "tag", "t", nil, "Só tarefas com esta tag; '-' na frente exclui a tag. Pode repetir")
+	cmd.Flags().StringVarP(&flags.sort, "sort", "s", "-created_at", "Ordenação: created_at, priority, reminder_at ou due_at; '-' na frente para decrescente")
+	cmd.Flags().IntVarP(&flags.limit, "limit", "n", 0, fmt.Sprintf("Tarefas por página (0 lista todas; com --cursor, %d)", taskApi.DefaultPageSize))
+	cmd.Flags().StringVar(&flags.cursor, "cursor", "", "Continua de onde a página anterior parou")
+
+	return cmd
+}
+
+func (f listFlags) query() (task.ListQuery, error) {
+	query := task.ListQuery{RootOnly: f.root, Ready: f.ready, Limit: f.limit}
+
+	switch {
+	case f.done && f.pending:
+		return query, errors.New("use --done ou --pending, não os dois")
+	case f.done && f.ready:
+		return query, errors.New("--ready já lista só tarefas pendentes; não use com --done")
+	case f.done:
+		query.Done = &f.done
+	case f.pending:
+		query.Done = new(bool)
+	}
+
+	if f.priority != "" {
+		priority, err := task.ParsePriority(f.priority)
+		if err != nil {
+			return query, err
+		}
+		query.Priority = &priority
+	}
+	if f.parent != "" {
+		query.ParentID = &f.parent
+	}
+	for _, raw := range f.statuses {
+		status, err := task.ParseStatus(raw)
+		if err != nil {
+			return query, fmt.Errorf("--status inválido %q", raw)
+		}
+		query.Statuses = append(query.Statuses, status)
+	}
+
+	var err error
+	if query.Tags, err = task.ParseTagFilter(f.tags); err != nil {
+		return query, err
+	}
+	if query.Sort, query.Ascending, err = task.ParseSort(f.sort); err != nil {
+		return query, fmt.Errorf("--sort inválido %q", f.sort)
+	}
+
+	dates := []struct {
+		name  string
+		value string
+		dest  **time.Time
+	}{
+		{"--reminder-from", f.reminderFrom, &query.ReminderFrom},
+		{"--reminder-to", f.reminderTo, &query.ReminderTo},
+		{"--created-from", f.createdFrom, &query.CreatedFrom},
+		{"--created-to", f.createdTo, &query.CreatedTo},
+	}
+	for _, d := range dates {
+		if d.value == "" {
+			continue
+		}
+		parsed, err := parseDateFlag(d.value)
+		if err != nil {
+			return query, fmt.Errorf("%s inválido %q (use DD/MM/AAAA ou DD/MM/AAAA HH:MM)", d.name, d.value)
+		}
+		*d.dest = &parsed
+	}
+
+	return query, nil
+}
+
+func parseDateFlag(input string) (time.Time, error) {
+	if parsed, err := parseReminder(input); err == nil {
+		return parsed, nil
+	}
+	return time.ParseInLocation("02/01/2006", input, time.Local)
 }
 
 func showTask(task models.Task) error {
 	fmt.Println("\n<===---===>")
 	fmt.Printf("ID: %s \n| > Título: %s\n| > Descrição: %s\n| > Prioridade: %s \n| > Lembrete: %s", task.ID, task.Title, task.Description, task.Priority, task.ReminderAt)
 	fmt.Println()
+	if task.Status != "" {
+		fmt.Printf("| > Status: %s\n", statusLabel(task.Status))
+	}
+	if task.DueAt != nil {
+		fmt.Printf("| > Prazo: %s\n", formatDue(task))
+	}
+	if task.EstimateMinutes > 0 {
+		fmt.Printf("| > Estimativa: %s\n", formatEstimate(task.EstimateMinutes))
+	}
+	if task.TrackedSeconds > 0 {
+		fmt.Printf("| > Tempo registrado: %s\n", formatEstimate(int(task.TrackedSeconds/60)))
+	}
+	if task.Progress != nil {
+		fmt.Printf("| > Subtarefas: %s\n", formatProgress(*task.Progress))
+	}
+	if len(task.Tags) > 0 {
+		fmt.Printf("| > Tags: %s\n", formatTags(task.Tags))
+	}
+	if task.Blocked {
+		fmt.Printf("| > Bloqueada por: %s\n", strings.Join(task.BlockedBy, ", "))
+	}
 
 	return nil
 }
+
+// formatDue mostra o prazo, só com a data se for de dia inteiro, e a
+// situação dele ("vence hoje", "atrasada"...).
+func formatDue(t models.Task) string {
+	layout := "02/01/2006 15:04"
+	if t.DueAllDay {
+		layout = "02/01/2006"
+	}
+	due := t.DueAt.In(time.Local).Format(layout)
+	switch {
+	case t.Overdue:
+		return due + " (atrasada)"
+	case t.DueToday:
+		return due + " (vence hoje)"
+	case t.DueSoon:
+		return due + " (vence em breve)"
+	}
+	return due
+}
+
+// formatEstimate mostra a estimativa como "45min" ou "1h30".
+func formatEstimate(minutes int) string {
+	switch {
+	case minutes < 60:
+		return fmt.Sprintf("%dmin", minutes)
+	case minutes%60 == 0:
+		return fmt.Sprintf("%dh", minutes/60)
+	}
+	return fmt.Sprintf("%dh%02d", minutes/60, minutes%60)
+}
+
+// formatProgress mostra o progresso como "2/5 (40%)".
+func formatProgress(p models.Progress) string {
+	if p.Total == 0 {
+		return "0/0"
+	}
+	return fmt.Sprintf("%d/%d (%d%%)", p.Done, p.Total, p.Done*100/p.Total)
+}
@@ -7,6 +7,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
 	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
 	"github.com/andre-felipe-wonsik-alves/internal/controllers/task/repository"
 	"github.com/andre-felipe-wonsik-alves/internal/database"
@@ -14,17 +15,44 @@ import (
 	"github.com/spf13/cobra"
 )
 
-func NewRootCli(taskSvc *taskApi.Service) *cobra.Command {
+func NewRootCli(taskSvc *taskApi.Service, migrator *database.Migrator) *cobra.Command {
 	root := &cobra.Command{
 		Use:   "advisor-go",
 		Short: "Uma CLI para gerenciar tarefas com lembretes :D",
 		Long:  "…",
+		PersistentPreRunE: func(cli *cobra.Command, args []string) error {
+			if isMigrateCommand(cli) {
+				return nil
+			}
+			return migrator.EnsureUpToDate(cli.Context())
+		},
 	}
 
+	addProjectFlag(root)
+
 	root.AddCommand(NewAddCli(taskSvc))
 	root.AddCommand(NewListCli(taskSvc))
+	root.AddCommand(NewNextCli(taskSvc))
+	root.AddCommand(NewPlanCli(taskSvc))
 	root.AddCommand(NewCompleteCli(taskSvc))
+	root.AddCommand(NewStatusCli(taskSvc))
+	root.AddCommand(NewReopenCli(taskSvc))
+	root.AddCommand(NewStartCli(taskSvc))
+	root.AddCommand(NewStopCli(taskSvc))
+	root.AddCommand(NewTimesheetCli(taskSvc))
+	root.AddCommand(NewSnoozeCli(taskSvc))
+	root.AddCommand(NewMoveCli(taskSvc))
+	root.AddCommand(NewTrashCli(taskSvc))
+	root.AddCommand(NewRestoreCli(taskSvc))
+	root.AddCommand(NewPurgeCli(taskSvc))
+	root.AddCommand(NewHistoryCli(taskSvc))
+	root.AddCommand(NewSearchCli(taskSvc))
+	root.AddCommand(NewTagCli(taskSvc))
+	root.AddCommand(NewProjectCli(taskSvc))
+	root.AddCommand(NewDependencyCli(taskSvc))
+	root.AddCommand(NewWatchCli(taskSvc))
 	root.AddCommand(NewDeployAPICli(taskSvc))
+	root.AddCommand(NewMigrateCli(migrator))
 
 	return root
 }
@@ -32,7 +60,7 @@ func NewRootCli(taskSvc *taskApi.Service) *cobra.Command {
 func Execute() {
 	misc.PrintBanner()
 
-	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	ctx, stop := signal.NotifyContext(task.WithSource(context.Background(), task.SourceCLI), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
 	db, err := database.Connect()
@@ -41,12 +69,24 @@ func Execute() {
 		os.Exit(1)
 	}
 
-	database.AutoMigrate(db)
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		fmt.Println("Erro ao carregar as migrations:", err)
+		os.Exit(1)
+	}
 
-	repo := repository.NewDBStore(db)
-	taskSvc := taskApi.NewService(repo)
+	var repo taskApi.Store = repository.NewDBStore(db)
+	if db.Dialector.Name() == database.DriverSQLite {
+		repo = repository.NewSQLiteStore(db)
+	}
+	config, err := taskApi.ConfigFromEnv()
+	if err != nil {
+		fmt.Println("Erro na configuração:", err)
+		os.Exit(1)
+	}
+	taskSvc := taskApi.NewServiceWithConfig(repo, config)
 
-	root := NewRootCli(taskSvc)
+	root := NewRootCli(taskSvc, migrator)
 
 	if err := root.ExecuteContext(ctx); err != nil {
 		fmt.Println(err)
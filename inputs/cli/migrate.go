@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/andre-felipe-wonsik-alves/internal/database"
+	"github.com/spf13/cobra"
+)
+
+const migrateCommand = "migrate"
+
+func NewMigrateCli(migrator *database.Migrator) *cobra.Command {
+	migrate := &cobra.Command{
+		Use:   migrateCommand,
+		Short: "Gerencia as migrations do banco de dados.",
+	}
+
+	migrate.AddCommand(&cobra.Command{
+		Use:   "up",
+		Short: "Aplica todas as migrations pendentes.",
+		RunE: func(cli *cobra.Command, args []string) error {
+			applied, err := migrator.Up(cli.Context())
+			for _, m := range applied {
+				fmt.Printf("| > aplicada: %04d_%s\n", m.Version, m.Name)
+			}
+			if err != nil {
+				return err
+			}
+			if len(applied) == 0 {
+				fmt.Println("Nenhuma migration pendente.")
+			}
+			return nil
+		},
+	})
+
+	var steps int
+	down := &cobra.Command{
+		Use:   "down",
+		Short: "Desfaz as últimas migrations aplicadas.",
+		RunE: func(cli *cobra.Command, args []string) error {
+			reverted, err := migrator.Down(cli.Context(), steps)
+			for _, m := range reverted {
+				fmt.Printf("| > desfeita: %04d_%s\n", m.Version, m.Name)
+			}
+			if err != nil {
+				return err
+			}
+			if len(reverted) == 0 {
+				fmt.Println("Nenhuma migration para desfazer.")
+			}
+			return nil
+		},
+	}
+	down.Flags().IntVarP(&steps, "steps", "n", 1, "Quantidade de migrations a desfazer")
+	migrate.AddCommand(down)
+
+	migrate.AddCommand(&cobra.Command{
+		Use:   "status",
+		Short: "Mostra quais migrations já foram aplicadas.",
+		RunE: func(cli *cobra.Command, args []string) error {
+			status, err := migrator.Status(cli.Context())
+			if err != nil {
+				return err
+			}
+			for _, s := range status {
+				applied := "pendente"
+				if s.AppliedAt != nil {
+					applied = "aplicada em " + s.AppliedAt.Local().Format("02/01/2006 15:04")
+				}
+				fmt.Printf("%04d_%s | > %s\n", s.Version, s.Name, applied)
+			}
+			return nil
+		},
+	})
+
+	var dir string
+	create := &cobra.Command{
+		Use:   "create <nome>",
+		Short: "Cria os arquivos up/down da próxima migration.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cli *cobra.Command, args []string) error {
+			created, err := database.CreateMigration(dir, args[0])
+			for _, path := range created {
+				fmt.Printf("| > criado: %s\n", path)
+			}
+			return err
+		},
+	}
+	create.Flags().StringVar(&dir, "dir", "internal/database/migrations", "Diretório das migrations (um subdiretório por dialeto)")
+	migrate.AddCommand(create)
+
+	return migrate
+}
+
+func isMigrateCommand(cmd *cobra.Command) bool {
+	for c := cmd; c != nil; c = c.Parent() {
+		if c.Name() == migrateCommand {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/spf13/cobra"
+)
+
+func NewMoveCli(service *taskApi.Service) *cobra.Command {
+	var toRoot bool
+
+	cmd := &cobra.Command{
+		Use:     "move <id> [id-do-novo-pai]",
+		Short:   "Move uma tarefa, com as subtarefas, para baixo de outra tarefa ou para a raiz.",
+		Example: "  advisor-go move 8f3edff7 1c2d3e4f\n  advisor-go move 8f3edff7 --root",
+		Args:    cobra.RangeArgs(1, 2),
+		RunE: func(cli *cobra.Command, args []string) error {
+			ctx := cli.Context()
+			id := args[0]
+
+			var parentID *string
+			switch {
+			case len(args) == 2 && toRoot:
+				return errors.New("use o novo pai ou --root, não os dois")
+			case len(args) == 2:
+				parentID = &args[1]
+			case !toRoot:
+				return errors.New("informe o ID do novo pai ou --root")
+			}
+
+			moved, err := service.Move(ctx, id, parentID, 0)
+			for err != nil {
+				if _, err = offerRetryOnConflict(ctx, service, bufio.NewReader(cli.InOrStdin()), id, err); err != nil {
+					return err
+				}
+				moved, err = service.Move(ctx, id, parentID, 0)
+			}
+
+			path, err := service.Breadcrumbs(ctx, moved.ID)
+			if err != nil {
+				return err
+			}
+			titles := make([]string, 0, len(path)+1)
+			for _, ancestor := range path {
+				titles = append(titles, ancestor.Title)
+			}
+			titles = append(titles, moved.Title)
+
+			fmt.Println("\nTarefa movida!")
+			fmt.Printf("ID: %s\n", moved.ID)
+			fmt.Printf("Caminho: %s\n", strings.Join(titles, " > "))
+			if moved.Progress != nil {
+				fmt.Printf("Subtarefas levadas junto: %d\n", moved.Progress.Total)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&toRoot, "root", false, "Desvincula a tarefa do pai, tornando-a raiz")
+
+	return cmd
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/spf13/cobra"
+)
+
+func NewNextCli(service *taskApi.Service) *cobra.Command {
+	var limit int
+	var weights string
+
+	cmd := &cobra.Command{
+		Use:     "next",
+		Short:   "Sugere o que fazer agora, explicando o porquê de cada sugestão.",
+		Example: "  advisor-go next\n  advisor-go next -n 10\n  advisor-go next --weights due=6,age=0",
+		Args:    cobra.NoArgs,
+		RunE: func(cli *cobra.Command, args []string) error {
+			advice, err := service.Advice(cli.Context(), limit, weights)
+			if err != nil {
+				if errors.Is(err, taskApi.ErrInvalidWeights) {
+					return fmt.Errorf("--weights inválido %q (ex.: due=5,priority=2; fatores: priority, due, reminder, age, blocked, progress)", weights)
+				}
+				if errors.Is(err, taskApi.ErrInvalidInput) {
+					return fmt.Errorf("--limit deve estar entre 1 e %d", taskApi.MaxPageSize)
+				}
+				return err
+			}
+
+			if len(advice) == 0 {
+				fmt.Println("\nNada em aberto. Aproveite!")
+				return nil
+			}
+
+			for _, a := range advice {
+				fmt.Println("\n<===---===>")
+				fmt.Printf("%d. %s\n| > ID: %s\n| > %s\n", a.Rank, a.Task.Title, a.Task.ID, a.Explanation)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVarP(&limit, "limit", "n", taskApi.DefaultAdviceLimit, "Quantas sugestões mostrar")
+	cmd.Flags().StringVar(&weights, "weights", "", "Troca pesos só nesta consulta (ex.: due=5,age=0)")
+
+	return cmd
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/spf13/cobra"
+)
+
+func NewPlanCli(service *taskApi.Service) *cobra.Command {
+	var accept bool
+
+	cmd := &cobra.Command{
+		Use:     "plan [hoje|amanhã|DD/MM/AAAA]",
+		Short:   "Monta a agenda do dia com as tarefas em aberto.",
+		Example: "  advisor-go plan today\n  advisor-go plan amanhã\n  advisor-go plan 15/06/2025 --accept",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cli *cobra.Command, args []string) error {
+			ctx := cli.Context()
+
+			input := ""
+			if len(args) == 1 {
+				input = args[0]
+			}
+			day, err := task.ParsePlanDate(input, time.Now())
+			if err != nil {
+				return fmt.Errorf("dia inválido %q (use hoje, amanhã ou DD/MM/AAAA a partir de hoje)", input)
+			}
+
+			plan, err := service.Plan(ctx, day)
+			if err != nil {
+				return err
+			}
+			showPlan(plan)
+
+			if !accept {
+				return nil
+			}
+			var slots []task.PlanSlot
+			for _, b := range plan.Blocks {
+				if !b.Fixed {
+					slots = append(slots, b.PlanSlot)
+				}
+			}
+			if len(slots) == 0 {
+				fmt.Println("\nNada a ajustar: nenhuma tarefa foi encaixada.")
+				return nil
+			}
+			updated, err := service.AcceptPlan(ctx, slots)
+			if err != nil {
+				if errors.Is(err, taskApi.ErrTaskNotFound) || errors.Is(err, taskApi.ErrTaskAlreadyDone) {
+					return fmt.Errorf("a agenda mudou enquanto era montada; rode advisor-go plan de novo")
+				}
+				return err
+			}
+			fmt.Printf("\nAgenda aceita: lembrete ajustado em %d tarefa(s).\n", len(updated))
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&accept, "accept", false, "Aceita a agenda, levando o lembrete de cada tarefa para o início do seu bloco")
+
+	return cmd
+}
+
+func showPlan(plan *task.Plan) {
+	fmt.Printf("\nAgenda de %s (%s–%s)\n", plan.Start.Local().Format("02/01/2006"), plan.Start.Local().Format("15:04"), plan.End.Local().Format("15:04"))
+	if len(plan.Blocks) == 0 {
+		fmt.Println("\nNenhuma tarefa coube na agenda.")
+	}
+	for _, b := range plan.Blocks {
+		label := b.Reason
+		if b.Fixed {
+			label = "[lembrete]"
+		}
+		fmt.Printf("\n%s–%s  %s (%s)\n| > ID: %s\n| > %s\n", b.Start.Local().Format("15:04"), b.End.Local().Format("15:04"), b.Title, formatEstimate(b.Minutes()), b.TaskID, label)
+	}
+
+	if len(plan.Unscheduled) > 0 {
+		fmt.Println("\nFicaram de fora:")
+		for _, s := range plan.Unscheduled {
+			fmt.Printf("  - %s: %s\n", s.Title, s.Reason)
+		}
+	}
+	fmt.Printf("\nTempo livre: %s\n", formatEstimate(plan.FreeMinutes))
+}
@@ -0,0 +1,259 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	env "github.com/andre-felipe-wonsik-alves/internal"
+	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"github.com/spf13/cobra"
+)
+
+const projectFlag = "project"
+
+// addProjectFlag coloca o --project em cmd e em todos os subcomandos. O
+// padrão vem de TASK_DEFAULT_PROJECT; --project "" ignora o padrão.
+func addProjectFlag(cmd *cobra.Command) {
+	cmd.PersistentFlags().StringP(projectFlag, "P", env.GetEnv("TASK_DEFAULT_PROJECT", ""),
+		"Projeto (nome ou ID) das tarefas; o padrão vem de TASK_DEFAULT_PROJECT")
+}
+
+// selectedProject resolve o projeto do --project; nil quando não há.
+func selectedProject(cli *cobra.Command, service *taskApi.Service) (*models.Project, error) {
+	flag := cli.Flags().Lookup(projectFlag)
+	if flag == nil || strings.TrimSpace(flag.Value.String()) == "" {
+		return nil, nil
+	}
+	project, err := service.ResolveProject(cli.Context(), flag.Value.String())
+	if err != nil {
+		return nil, fmt.Errorf("projeto %q: %w", flag.Value.String(), err)
+	}
+	return project, nil
+}
+
+// formatCounts mostra as contagens como "3 abertas, 2 concluídas"; as
+// canceladas só aparecem quando houver.
+func formatCounts(c *models.ProjectCounts) string {
+	if c == nil {
+		return "sem tarefas"
+	}
+	if c.Cancelled > 0 {
+		return fmt.Sprintf("%d abertas, %d concluídas, %d canceladas", c.Open, c.Done, c.Cancelled)
+	}
+	return fmt.Sprintf("%d abertas, %d concluídas", c.Open, c.Done)
+}
+
+func showProject(project models.Project) {
+	fmt.Println("\n<===---===>")
+	fmt.Printf("ID: %s \n| > Nome: %s\n", project.ID, project.Name)
+	if project.Description != "" {
+		fmt.Printf("| > Descrição: %s\n", project.Description)
+	}
+	fmt.Printf("| > Tarefas: %s\n", formatCounts(project.Counts))
+	if project.DefaultPriority != "" {
+		fmt.Printf("| > Prioridade padrão: %s\n", project.DefaultPriority)
+	}
+	if project.DefaultReminderOffset != "" {
+		fmt.Printf("| > Lembrete padrão: %s depois da criação\n", project.DefaultReminderOffset)
+	}
+	if project.Archived {
+		fmt.Println("| > Arquivado")
+	}
+}
+
+type projectFlags struct {
+	name, description, priority, remind string
+}
+
+// changes devolve só os campos passados na linha de comando.
+func (f projectFlags) changes(cli *cobra.Command) map[string]any {
+	changes := map[string]any{}
+	for flag, column := range map[string]string{
+		"name":        "name",
+		"description": "description",
+		"priority":    "default_priority",
+		"remind":      "default_reminder_offset",
+	} {
+		if cli.Flags().Changed(flag) {
+			changes[column], _ = cli.Flags().GetString(flag)
+		}
+	}
+	return changes
+}
+
+func NewProjectCli(service *taskApi.Service) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "project",
+		Short: "Gerencia os projetos (quadros de tarefas).",
+		Example: "  advisor-go project create homelab --priority alta --remind 1d\n" +
+			"  advisor-go project list --archived\n  advisor-go project archive homelab",
+	}
+
+	cmd.AddCommand(newProjectListCli(service))
+	cmd.AddCommand(newProjectCreateCli(service))
+	cmd.AddCommand(newProjectShowCli(service))
+	cmd.AddCommand(newProjectEditCli(service))
+	cmd.AddCommand(newProjectArchiveCli(service, true))
+	cmd.AddCommand(newProjectArchiveCli(service, false))
+	cmd.AddCommand(newProjectDeleteCli(service))
+
+	return cmd
+}
+
+func newProjectListCli(service *taskApi.Service) *cobra.Command {
+	var archived bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "Lista os projetos com as contagens de tarefas.",
+		Args:  cobra.NoArgs,
+		RunE: func(cli *cobra.Command, args []string) error {
+			projects, err := service.ListProjects(cli.Context(), archived)
+			if err != nil {
+				return err
+			}
+			if len(projects) == 0 {
+				fmt.Println("\nNenhum projeto criado.")
+				return nil
+			}
+			for _, project := range projects {
+				showProject(project)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&archived, "archived", false, "Incluir os projetos arquivados")
+
+	return cmd
+}
+
+func addProjectFields(cmd *cobra.Command, flags *projectFlags) {
+	cmd.Flags().StringVar(&flags.description, "description", "", "Descrição do projeto")
+	cmd.Flags().StringVar(&flags.priority, "priority", "", "Prioridade padrão das tarefas novas (baixa, media, alta); vazio tira")
+	cmd.Flags().StringVar(&flags.remind, "remind", "", "Lembrete padrão das tarefas novas, depois da criação (ex.: 2h, 1d); vazio tira")
+}
+
+func newProjectCreateCli(service *taskApi.Service) *cobra.Command {
+	var flags projectFlags
+
+	cmd := &cobra.Command{
+		Use:   "create <nome>",
+		Short: "Cria um projeto.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cli *cobra.Command, args []string) error {
+			project, err := service.CreateProject(cli.Context(), models.Project{
+				Name:                  args[0],
+				Description:           flags.description,
+				DefaultPriority:       models.Priority(flags.priority),
+				DefaultReminderOffset: flags.remind,
+			})
+			if err != nil {
+				return err
+			}
+			fmt.Println("\nProjeto criado!")
+			showProject(*project)
+			return nil
+		},
+	}
+
+	addProjectFields(cmd, &flags)
+
+	return cmd
+}
+
+func newProjectShowCli(service *taskApi.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "show <projeto>",
+		Short: "Mostra um projeto (nome ou ID) e as contagens de tarefas.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cli *cobra.Command, args []string) error {
+			project, err := service.ResolveProject(cli.Context(), args[0])
+			if err != nil {
+				return err
+			}
+			showProject(*project)
+			return nil
+		},
+	}
+}
+
+func newProjectEditCli(service *taskApi.Service) *cobra.Command {
+	var flags projectFlags
+
+	cmd := &cobra.Command{
+		Use:     "edit <projeto>",
+		Short:   "Altera um projeto (nome ou ID).",
+		Example: "  advisor-go project edit homelab --name servidores --remind \"\"",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cli *cobra.Command, args []string) error {
+			ctx := cli.Context()
+			changes := flags.changes(cli)
+			if len(changes) == 0 {
+				return fmt.Errorf("nada para alterar: use --name, --description, --priority ou --remind")
+			}
+			project, err := service.ResolveProject(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			project, err = service.PatchProject(ctx, project.ID, changes)
+			if err != nil {
+				return err
+			}
+			fmt.Println("\nProjeto atualizado!")
+			showProject(*project)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&flags.name, "name", "", "Novo nome")
+	addProjectFields(cmd, &flags)
+
+	return cmd
+}
+
+func newProjectArchiveCli(service *taskApi.Service, archive bool) *cobra.Command {
+	use, short, done := "archive <projeto>", "Arquiva um projeto: ele some da listagem e não recebe tarefas novas.", "arquivado"
+	if !archive {
+		use, short, done = "unarchive <projeto>", "Tira um projeto do arquivo.", "desarquivado"
+	}
+
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cli *cobra.Command, args []string) error {
+			ctx := cli.Context()
+			project, err := service.ResolveProject(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			if _, err := service.PatchProject(ctx, project.ID, map[string]any{"archived": archive}); err != nil {
+				return err
+			}
+			fmt.Printf("\nProjeto %s %s.\n", project.Name, done)
+			return nil
+		},
+	}
+}
+
+func newProjectDeleteCli(service *taskApi.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <projeto>",
+		Short: "Apaga um projeto; as tarefas dele ficam sem projeto.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cli *cobra.Command, args []string) error {
+			ctx := cli.Context()
+			project, err := service.ResolveProject(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			if err := service.DeleteProject(ctx, project.ID); err != nil {
+				return err
+			}
+			fmt.Printf("\nProjeto %s apagado.\n", project.Name)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/spf13/cobra"
+)
+
+// Destaque dos termos no terminal: negrito amarelo.
+var terminalHighlight = strings.NewReplacer(task.HighlightStart, "\033[1;33m", task.HighlightStop, "\033[0m")
+
+// terminalSnippet troca os marcadores pelo destaque e desfaz o escape do HTML,
+// que só serve para quem insere o snippet numa página.
+func terminalSnippet(snippet string) string {
+	return html.UnescapeString(terminalHighlight.Replace(snippet))
+}
+
+func NewSearchCli(service *taskApi.Service) *cobra.Command {
+	var limit int
+	var tagValues []string
+
+	cmd := &cobra.Command{
+		Use:     "search <termos>",
+		Short:   "Busca tarefas pelo título e pela descrição.",
+		Example: "  advisor-go search café\n  advisor-go search reunião equipe --limit 5\n  advisor-go search backup --tag homelab --project casa",
+		Args:    cobra.MinimumNArgs(1),
+		RunE: func(cli *cobra.Command, args []string) error {
+			var filter task.SearchFilter
+			tags, err := task.ParseTagFilter(tagValues)
+			if err != nil {
+				return err
+			}
+			filter.Tags = tags
+			project, err := selectedProject(cli, service)
+			if err != nil {
+				return err
+			}
+			if project != nil {
+				filter.ProjectID = &project.ID
+			}
+			results, err := service.Search(cli.Context(), strings.Join(args, " "), filter, limit)
+			if err != nil {
+				return err
+			}
+
+			if len(results) == 0 {
+				fmt.Println("\nNenhuma tarefa encontrada.")
+				return nil
+			}
+
+			for _, r := range results {
+				fmt.Println("\n<===---===>")
+				fmt.Printf("ID: %s \n| > Título: %s\n| > Trecho: %s\n| > Relevância: %.2f\n", r.ID, r.Title, terminalSnippet(r.Snippet), r.Rank)
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringArrayVarP(&tagValues, "tag", "t", nil, "Só tarefas com esta tag; '-' na frente exclui a tag. Pode repetir")
+	cmd.Flags().IntVarP(&limit, "limit", "n", taskApi.DefaultSearchLimit, "Máximo de resultados")
+
+	return cmd
+}
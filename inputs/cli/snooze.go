@@ -0,0 +1,45 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/spf13/cobra"
+)
+
+func NewSnoozeCli(service *taskApi.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:     "snooze <id> <duração>",
+		Short:   "Adia o lembrete de uma tarefa (ex.: 10m, 1h, amanhã 9:00).",
+		Example: "  advisor-go snooze 8f3edff7 10m\n  advisor-go snooze 8f3edff7 amanhã 9:00",
+		Args:    cobra.MinimumNArgs(2),
+		RunE: func(cli *cobra.Command, args []string) error {
+			ctx := cli.Context()
+
+			until, err := task.ParseSnooze(strings.Join(args[1:], " "), time.Now())
+			if err != nil {
+				return err
+			}
+
+			snoozed, err := service.Snooze(ctx, args[0], until)
+			for err != nil {
+				if _, err = offerRetryOnConflict(ctx, service, bufio.NewReader(cli.InOrStdin()), args[0], err); err != nil {
+					return err
+				}
+				snoozed, err = service.Snooze(ctx, args[0], until)
+			}
+
+			fmt.Println("\nLembrete adiado!")
+			fmt.Printf("ID: %s\n", snoozed.ID)
+			fmt.Printf("Título: %s\n", snoozed.Title)
+			fmt.Printf("Lembrar em: %s\n", snoozed.ReminderAt.Format("02/01/2006 15:04"))
+			fmt.Printf("Adiamentos: %d\n", snoozed.SnoozeCount)
+
+			return nil
+		},
+	}
+}
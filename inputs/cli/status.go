@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"github.com/spf13/cobra"
+)
+
+var statusLabels = map[models.TaskStatus]string{
+	models.StatusTodo:       "a fazer",
+	models.StatusInProgress: "em andamento",
+	models.StatusBlocked:    "bloqueada",
+	models.StatusWaiting:    "aguardando",
+	models.StatusDone:       "concluída",
+	models.StatusCancelled:  "cancelada",
+}
+
+func statusLabel(status models.TaskStatus) string {
+	if label, ok := statusLabels[status]; ok {
+		return label
+	}
+	return string(status)
+}
+
+func NewStatusCli(service *taskApi.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:     "status [<id> <status>]",
+		Short:   "Muda o status de uma tarefa (todo, in_progress, blocked, waiting, done, cancelled); sem argumentos, mostra o cronômetro.",
+		Example: "  advisor-go status\n  advisor-go status 8f3edff7 in_progress\n  advisor-go status 8f3edff7 aguardando\n  advisor-go status 8f3edff7 cancelled",
+		Args: func(cli *cobra.Command, args []string) error {
+			if len(args) != 0 && len(args) != 2 {
+				return fmt.Errorf("informe <id> e <status>, ou nada para ver o cronômetro")
+			}
+			return nil
+		},
+		RunE: func(cli *cobra.Command, args []string) error {
+			ctx := cli.Context()
+			if len(args) == 0 {
+				return showTimerStatus(ctx, service)
+			}
+			id := args[0]
+
+			status, err := task.ParseStatus(args[1])
+			if err != nil {
+				return fmt.Errorf("status inválido %q (use todo, in_progress, blocked, waiting, done ou cancelled)", args[1])
+			}
+
+			updated, err := service.SetStatus(ctx, id, 0, status)
+			for err != nil {
+				if errors.Is(err, taskApi.ErrInvalidTransition) {
+					return transitionError(ctx, service, id, status)
+				}
+				if _, err = offerRetryOnConflict(ctx, service, bufio.NewReader(cli.InOrStdin()), id, err); err != nil {
+					return err
+				}
+				updated, err = service.SetStatus(ctx, id, 0, status)
+			}
+			if updated == nil {
+				return taskApi.ErrTaskNotFound
+			}
+
+			fmt.Printf("\nTarefa %s: %s\n", updated.ID, statusLabel(updated.Status))
+			return nil
+		},
+	}
+}
+
+// transitionError explica, pelo status atual da tarefa, por que ela não pode
+// passar para status.
+func transitionError(ctx context.Context, service *taskApi.Service, id string, status models.TaskStatus) error {
+	current, err := service.GetByID(ctx, id)
+	if err != nil || current == nil {
+		return fmt.Errorf("a tarefa %s não pode ficar %s", id, statusLabel(status))
+	}
+	if current.Status.Closed() {
+		return fmt.Errorf("a tarefa %s está %s; reabra antes com advisor-go reopen %s", id, statusLabel(current.Status), id)
+	}
+	return fmt.Errorf("a tarefa %s está %s e não pode ficar %s", id, statusLabel(current.Status), statusLabel(status))
+}
+
+func NewReopenCli(service *taskApi.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "reopen <id>",
+		Short: "Reabre uma tarefa concluída ou cancelada.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cli *cobra.Command, args []string) error {
+			ctx := cli.Context()
+			id := args[0]
+
+			reopened, err := service.Reopen(ctx, id, 0)
+			for err != nil {
+				if errors.Is(err, taskApi.ErrTaskNotClosed) {
+					return fmt.Errorf("a tarefa %s já está em aberto", id)
+				}
+				if _, err = offerRetryOnConflict(ctx, service, bufio.NewReader(cli.InOrStdin()), id, err); err != nil {
+					return err
+				}
+				reopened, err = service.Reopen(ctx, id, 0)
+			}
+			if reopened == nil {
+				return taskApi.ErrTaskNotFound
+			}
+
+			fmt.Printf("\nTarefa %s reaberta!\n", reopened.ID)
+			return nil
+		},
+	}
+}
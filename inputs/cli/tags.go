@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"github.com/spf13/cobra"
+)
+
+// tagColorCodes traduz task.TagColors para os códigos ANSI de cor de texto.
+var tagColorCodes = map[string]string{
+	"red":     "31",
+	"green":   "32",
+	"yellow":  "33",
+	"blue":    "34",
+	"magenta": "35",
+	"cyan":    "36",
+	"white":   "37",
+	"gray":    "90",
+}
+
+// formatTags mostra as tags como "#casa #homelab", cada uma na sua cor.
+func formatTags(tags []models.Tag) string {
+	formatted := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		formatted = append(formatted, colorTag(tag))
+	}
+	return strings.Join(formatted, " ")
+}
+
+func colorTag(tag models.Tag) string {
+	if code, ok := tagColorCodes[tag.Color]; ok {
+		return "\033[" + code + "m#" + tag.Name + "\033[0m"
+	}
+	return "#" + tag.Name
+}
+
+func NewTagCli(service *taskApi.Service) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tag",
+		Short: "Gerencia as tags e as tags das tarefas.",
+		Example: "  advisor-go tag list\n  advisor-go tag create homelab --color cyan\n" +
+			"  advisor-go tag add 8f3edff7 homelab urgente\n  advisor-go tag remove 8f3edff7 urgente",
+	}
+
+	cmd.AddCommand(newTagListCli(service))
+	cmd.AddCommand(newTagCreateCli(service))
+	cmd.AddCommand(newTagColorCli(service))
+	cmd.AddCommand(newTagDeleteCli(service))
+	cmd.AddCommand(newTagAddCli(service))
+	cmd.AddCommand(newTagRemoveCli(service))
+
+	return cmd
+}
+
+func newTagListCli(service *taskApi.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "Lista as tags.",
+		Args:  cobra.NoArgs,
+		RunE: func(cli *cobra.Command, args []string) error {
+			tags, err := service.ListTags(cli.Context())
+			if err != nil {
+				return err
+			}
+			if len(tags) == 0 {
+				fmt.Println("\nNenhuma tag criada.")
+				return nil
+			}
+
+			fmt.Println()
+			for _, tag := range tags {
+				fmt.Println(colorTag(tag))
+			}
+			return nil
+		},
+	}
+}
+
+func newTagCreateCli(service *taskApi.Service) *cobra.Command {
+	var color string
+
+	cmd := &cobra.Command{
+		Use:   "create <nome>",
+		Short: "Cria uma tag.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cli *cobra.Command, args []string) error {
+			tag, err := service.CreateTag(cli.Context(), args[0], color)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\nTag %s criada.\n", colorTag(*tag))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&color, "color", "", "Cor da tag ("+strings.Join(task.TagColors, ", ")+")")
+
+	return cmd
+}
+
+func newTagColorCli(service *taskApi.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "color <nome> <cor>",
+		Short: "Troca a cor de uma tag (" + strings.Join(task.TagColors, ", ") + "; \"\" tira a cor).",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cli *cobra.Command, args []string) error {
+			ctx := cli.Context()
+			tag, err := service.GetTagByName(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			tag, err = service.PatchTag(ctx, tag.ID, map[string]any{"color": args[1]})
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\nTag %s atualizada.\n", colorTag(*tag))
+			return nil
+		},
+	}
+}
+
+func newTagDeleteCli(service *taskApi.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <nome>",
+		Short: "Apaga uma tag e a tira de todas as tarefas.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cli *cobra.Command, args []string) error {
+			ctx := cli.Context()
+			tag, err := service.GetTagByName(ctx, args[0])
+			if err != nil {
+				return err
+			}
+			if err := service.DeleteTag(ctx, tag.ID); err != nil {
+				return err
+			}
+			fmt.Printf("\nTag #%s apagada.\n", tag.Name)
+			return nil
+		},
+	}
+}
+
+func newTagAddCli(service *taskApi.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "add <id-da-tarefa> <tag>...",
+		Short: "Coloca tags numa tarefa, criando as que não existem.",
+		Args:  cobra.MinimumNArgs(2),
+		RunE: func(cli *cobra.Command, args []string) error {
+			tagged, err := service.TagTask(cli.Context(), args[0], args[1:])
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\nTarefa %s: %s\n", tagged.Title, formatTags(tagged.Tags))
+			return nil
+		},
+	}
+}
+
+func newTagRemoveCli(service *taskApi.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove <id-da-tarefa> <tag>",
+		Short: "Tira uma tag de uma tarefa.",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cli *cobra.Command, args []string) error {
+			untagged, err := service.UntagTask(cli.Context(), args[0], args[1])
+			if err != nil {
+				return err
+			}
+			if len(untagged.Tags) == 0 {
+				fmt.Printf("\nTarefa %s está sem tags.\n", untagged.Title)
+				return nil
+			}
+			fmt.Printf("\nTarefa %s: %s\n", untagged.Title, formatTags(untagged.Tags))
+			return nil
+		},
+	}
+}
@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/spf13/cobra"
+)
+
+func NewStartCli(service *taskApi.Service) *cobra.Command {
+	var note string
+
+	cmd := &cobra.Command{
+		Use:     "start <id>",
+		Short:   "Inicia o cronômetro de uma tarefa (parando o que estiver rodando).",
+		Example: "  advisor-go start 8f3edff7\n  advisor-go start 8f3edff7 --note \"revisando o PR\"",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cli *cobra.Command, args []string) error {
+			timer, err := service.StartTimer(cli.Context(), args[0], note)
+			if err != nil {
+				switch {
+				case errors.Is(err, taskApi.ErrTaskAlreadyDone):
+					return fmt.Errorf("a tarefa %s está concluída ou cancelada", args[0])
+				case errors.Is(err, taskApi.ErrTimerRunning):
+					return fmt.Errorf("o cronômetro já está rodando na tarefa %s", args[0])
+				case errors.Is(err, taskApi.ErrTimerConflict):
+					return fmt.Errorf("outro cronômetro começou a rodar ao mesmo tempo; tente de novo")
+				}
+				return err
+			}
+
+			if timer.Stopped != nil {
+				fmt.Printf("\nCronômetro parado na tarefa %s (%s).\n", timer.Stopped.TaskID, formatElapsed(timer.Stopped.Duration(time.Now())))
+			}
+			fmt.Printf("\nCronômetro iniciado às %s em: %s\n| > ID: %s\n", timer.Entry.StartedAt.Local().Format("15:04"), timer.Task.Title, timer.Task.ID)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&note, "note", "n", "", "Anotação do registro de tempo")
+
+	return cmd
+}
+
+func NewStopCli(service *taskApi.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop",
+		Short: "Para o cronômetro rodando.",
+		Args:  cobra.NoArgs,
+		RunE: func(cli *cobra.Command, args []string) error {
+			timer, err := service.StopTimer(cli.Context())
+			if err != nil {
+				if errors.Is(err, taskApi.ErrNoRunningTimer) {
+					return fmt.Errorf("nenhum cronômetro rodando; inicie um com advisor-go start <id>")
+				}
+				return err
+			}
+
+			fmt.Printf("\nCronômetro parado em: %s\n| > Duração: %s\n| > Total na tarefa: %s\n",
+				timer.Task.Title, formatElapsed(time.Duration(timer.ElapsedSeconds)*time.Second), formatEstimate(int(timer.Task.TrackedSeconds/60)))
+			return nil
+		},
+	}
+}
+
+// showTimerStatus mostra o cronômetro rodando e quanto foi registrado hoje.
+func showTimerStatus(ctx context.Context, service *taskApi.Service) error {
+	timer, err := service.RunningTimer(ctx)
+	if err != nil {
+		return err
+	}
+	if timer == nil {
+		fmt.Println("\nNenhum cronômetro rodando.")
+	} else {
+		fmt.Printf("\nCronômetro rodando desde %s em: %s\n| > ID: %s\n| > Decorrido: %s\n",
+			timer.Entry.StartedAt.Local().Format("15:04"), timer.Task.Title, timer.Task.ID, formatElapsed(time.Duration(timer.ElapsedSeconds)*time.Second))
+	}
+
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	sheet, err := service.Timesheet(ctx, today, today.AddDate(0, 0, 1))
+	if err != nil {
+		return err
+	}
+	fmt.Printf("\nRegistrado hoje: %s\n", formatElapsed(time.Duration(sheet.Seconds)*time.Second))
+	return nil
+}
+
+func NewTimesheetCli(service *taskApi.Service) *cobra.Command {
+	var from, to, format string
+
+	cmd := &cobra.Command{
+		Use:     "timesheet",
+		Short:   "Mostra ou exporta a folha de horas, por dia e projeto.",
+		Example: "  advisor-go timesheet\n  advisor-go timesheet --from 01/06/2025 --to 30/06/2025 --format csv > junho.csv",
+		Args:    cobra.NoArgs,
+		RunE: func(cli *cobra.Command, args []string) error {
+			if format != "table" && format != "csv" && format != "json" {
+				return fmt.Errorf("formato inválido %q (use table, csv ou json)", format)
+			}
+			now := time.Now()
+			if to == "" {
+				to = now.Format(time.DateOnly)
+			}
+			if from == "" {
+				from = now.AddDate(0, 0, 1-taskApi.DefaultTimesheetDays).Format(time.DateOnly)
+			}
+			start, end, err := task.ParsePeriod(from, to, time.Local)
+			if err != nil {
+				return fmt.Errorf("período inválido (use DD/MM/AAAA ou AAAA-MM-DD, com --from até --to)")
+			}
+
+			sheet, err := service.Timesheet(cli.Context(), start, end)
+			if err != nil {
+				return err
+			}
+
+			switch format {
+			case "csv":
+				return sheet.WriteCSV(os.Stdout)
+			case "json":
+				out := json.NewEncoder(os.Stdout)
+				out.SetIndent("", "  ")
+				return out.Encode(sheet)
+			}
+			showTimesheet(sheet)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&from, "from", "", "Primeiro dia (padrão: 6 dias atrás)")
+	cmd.Flags().StringVar(&to, "to", "", "Último dia (padrão: hoje)")
+	cmd.Flags().StringVarP(&format, "format", "f", "table", "Formato da saída: table, csv ou json")
+
+	return cmd
+}
+
+func showTimesheet(sheet *task.Timesheet) {
+	fmt.Printf("\nFolha de horas de %s a %s\n", formatDay(sheet.From), formatDay(sheet.To))
+	if len(sheet.Days) == 0 {
+		fmt.Println("\nNenhum tempo registrado no período.")
+		return
+	}
+	for _, day := range sheet.Days {
+		fmt.Printf("\n%s — %s\n", formatDay(day.Date), formatSeconds(day.Seconds))
+		for _, p := range day.Projects {
+			fmt.Printf("| > %s: %s\n", p.Project, formatSeconds(p.Seconds))
+			for _, t := range p.Tasks {
+				fmt.Printf("|   - %s: %s\n", t.Title, formatSeconds(t.Seconds))
+			}
+		}
+	}
+	fmt.Printf("\nTotal: %s\n", formatSeconds(sheet.Seconds))
+}
+
+// formatElapsed mostra uma duração curta como "12min" ou "1h05"; abaixo de um
+// minuto, em segundos.
+func formatElapsed(d time.Duration) string {
+	if d < time.Minute {
+		return fmt.Sprintf("%ds", int(d/time.Second))
+	}
+	return formatEstimate(int(d / time.Minute))
+}
+
+func formatSeconds(seconds int64) string {
+	return formatElapsed(time.Duration(seconds) * time.Second)
+}
+
+func formatDay(date string) string {
+	day, err := time.Parse(time.DateOnly, date)
+	if err != nil {
+		return date
+	}
+	return day.Format("02/01/2006")
+}
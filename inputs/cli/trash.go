@@ -0,0 +1,137 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/spf13/cobra"
+)
+
+func NewTrashCli(service *taskApi.Service) *cobra.Command {
+	return &cobra.Command{
+		Use:   "trash",
+		Short: "Lista as tarefas apagadas.",
+		RunE: func(cli *cobra.Command, args []string) error {
+			tasks, err := service.Trash(cli.Context())
+			if err != nil {
+				return err
+			}
+
+			if len(tasks) == 0 {
+				fmt.Println("\nA lixeira está vazia.")
+				return nil
+			}
+
+			for _, t := range tasks {
+				fmt.Println("\n<===---===>")
+				fmt.Printf("ID: %s \n| > Título: %s\n| > Apagada em: %s\n", t.ID, t.Title, t.DeletedAt.Time.Local().Format("02/01/2006 15:04"))
+				if t.ParentID != nil {
+					fmt.Printf("| > Subtarefa de: %s\n", *t.ParentID)
+				}
+			}
+
+			return nil
+		},
+	}
+}
+
+func NewRestoreCli(service *taskApi.Service) *cobra.Command {
+	var withChildren bool
+
+	cmd := &cobra.Command{
+		Use:   "restore <id>",
+		Short: "Tira uma tarefa da lixeira.",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cli *cobra.Command, args []string) error {
+			ctx := cli.Context()
+			id := args[0]
+
+			if !cli.Flags().Changed("children") {
+				children, err := service.DeletedChildren(ctx, id)
+				if err != nil {
+					return err
+				}
+				if len(children) > 0 {
+					reader := bufio.NewReader(cli.InOrStdin())
+					answer, err := prompt(reader, fmt.Sprintf("A tarefa tem %d subtarefa(s) na lixeira. Restaurar também? [s/N]: ", len(children)))
+					if err != nil {
+						return err
+					}
+					withChildren = isYes(answer)
+				}
+			}
+
+			restored, err := service.Restore(ctx, id, withChildren)
+			if err != nil {
+				return err
+			}
+
+			fmt.Println("\nTarefa restaurada!")
+			fmt.Printf("ID: %s\n", restored.ID)
+			fmt.Printf("Título: %s\n", restored.Title)
+			if len(restored.Children) > 0 {
+				fmt.Printf("Subtarefas: %d\n", len(restored.Children))
+			}
+
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&withChildren, "children", "c", false, "Restaurar também as subtarefas apagadas (sem perguntar)")
+
+	return cmd
+}
+
+func NewPurgeCli(service *taskApi.Service) *cobra.Command {
+	var olderThan string
+
+	cmd := &cobra.Command{
+		Use:     "purge [id]",
+		Short:   "Apaga de vez tarefas da lixeira.",
+		Example: "  advisor-go purge 8f3edff7\n  advisor-go purge --older-than 30d",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cli *cobra.Command, args []string) error {
+			ctx := cli.Context()
+
+			if len(args) == 1 {
+				if err := service.Purge(ctx, args[0]); err != nil {
+					return err
+				}
+				fmt.Printf("\nTarefa %s apagada definitivamente.\n", args[0])
+				return nil
+			}
+
+			if olderThan == "" {
+				return errors.New("informe o ID da tarefa ou --older-than")
+			}
+			age, err := task.ParseOffset(olderThan)
+			if err != nil || age < 0 {
+				return fmt.Errorf("--older-than inválido %q (ex.: 30d, 2w, 72h)", olderThan)
+			}
+
+			purged, err := service.PurgeOlderThan(ctx, age)
+			if err != nil {
+				return err
+			}
+			fmt.Printf("\n%d tarefa(s) apagada(s) definitivamente.\n", purged)
+
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&olderThan, "older-than", "", "Apaga o que está na lixeira há mais tempo que isso (ex.: 30d, 2w, 72h)")
+
+	return cmd
+}
+
+func isYes(answer string) bool {
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "s", "sim", "y", "yes":
+		return true
+	}
+	return false
+}
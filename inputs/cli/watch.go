@@ -0,0 +1,30 @@
+package cli
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/notify"
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/reminder"
+	taskApi "github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/spf13/cobra"
+)
+
+func NewWatchCli(service *taskApi.Service) *cobra.Command {
+	var interval time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Monitora os lembretes e notifica as tarefas vencidas.",
+		RunE: func(cli *cobra.Command, args []string) error {
+			fmt.Println("Monitorando lembretes... (Ctrl+C para sair)")
+
+			scheduler := reminder.NewScheduler(service, notify.NewRegistryFromEnv(), interval)
+			return scheduler.Run(cli.Context())
+		},
+	}
+
+	cmd.Flags().DurationVarP(&interval, "interval", "i", reminder.DefaultInterval, "Intervalo entre as verificações de lembretes")
+
+	return cmd
+}
@@ -0,0 +1,41 @@
+package notify
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// CommandNotifier executa um comando de shell com os dados da tarefa nas
+// variáveis de ambiente TASK_ID, TASK_TITLE, TASK_DESCRIPTION e TASK_PRIORITY.
+type CommandNotifier struct {
+	command string
+}
+
+func NewCommandNotifier(command string) *CommandNotifier {
+	return &CommandNotifier{command: command}
+}
+
+func (n *CommandNotifier) Name() string {
+	return ChannelCommand
+}
+
+func (n *CommandNotifier) Notify(ctx context.Context, t models.Task) error {
+	cmd := exec.CommandContext(ctx, "sh", "-c", n.command)
+	cmd.Env = append(os.Environ(),
+		"TASK_ID="+t.ID,
+		"TASK_TITLE="+t.Title,
+		"TASK_DESCRIPTION="+t.Description,
+		"TASK_PRIORITY="+string(t.Priority),
+	)
+
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+package notify
+
+import (
+	"net/http"
+	"os"
+	"strings"
+
+	env "github.com/andre-felipe-wonsik-alves/internal"
+)
+
+// NewRegistryFromEnv registra o terminal e todos os canais que tiverem
+// configuração nas variáveis NOTIFY_*.
+func NewRegistryFromEnv() *Registry {
+	registry := NewRegistry(splitList(env.GetEnv("NOTIFY_DEFAULT_CHANNELS", ChannelTerminal))...)
+	client := &http.Client{Timeout: DefaultTimeout}
+
+	registry.Register(NewTerminalNotifier(os.Stdout))
+
+	if url := env.GetEnv("NOTIFY_WEBHOOK_URL", ""); url != "" {
+		registry.Register(NewWebhookNotifier(url, client))
+	}
+
+	if host := env.GetEnv("NOTIFY_SMTP_HOST", ""); host != "" {
+		registry.Register(NewEmailNotifier(SMTPConfig{
+			Host:     host,
+			Port:     env.GetEnv("NOTIFY_SMTP_PORT", "587"),
+			User:     env.GetEnv("NOTIFY_SMTP_USER", ""),
+			Password: env.GetEnv("NOTIFY_SMTP_PASSWORD", ""),
+			From:     env.GetEnv("NOTIFY_SMTP_FROM", ""),
+			To:       splitList(env.GetEnv("NOTIFY_SMTP_TO", "")),
+		}))
+	}
+
+	if url := env.GetEnv("NOTIFY_NTFY_URL", ""); url != "" {
+		registry.Register(NewNtfyNotifier(url, env.GetEnv("NOTIFY_NTFY_TOKEN", ""), client))
+	}
+
+	if url := env.GetEnv("NOTIFY_GOTIFY_URL", ""); url != "" {
+		registry.Register(NewGotifyNotifier(url, env.GetEnv("NOTIFY_GOTIFY_TOKEN", ""), client))
+	}
+
+	if command := env.GetEnv("NOTIFY_COMMAND", ""); command != "" {
+		registry.Register(NewCommandNotifier(command))
+	}
+
+	return registry
+}
+
+func splitList(input string) []string {
+	var items []string
+	for _, item := range strings.Split(input, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,120 @@
+package notify
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+type SMTPConfig struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+	From     string
+	To       []string
+}
+
+type EmailNotifier struct {
+	cfg      SMTPConfig
+	sendMail func(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewEmailNotifier(cfg SMTPConfig) *EmailNotifier {
+	return &EmailNotifier{cfg: cfg, sendMail: sendMail}
+}
+
+func (n *EmailNotifier) Name() string {
+	return ChannelEmail
+}
+
+func (n *EmailNotifier) Notify(ctx context.Context, t models.Task) error {
+	var auth smtp.Auth
+	if n.cfg.User != "" {
+		auth = smtp.PlainAuth("", n.cfg.User, n.cfg.Password, n.cfg.Host)
+	}
+
+	return n.sendMail(ctx, net.JoinHostPort(n.cfg.Host, n.cfg.Port), auth, n.cfg.From, n.cfg.To, emailMessage(n.cfg, t))
+}
+
+// emailMessage monta o e-mail do lembrete. O título vai no Subject codificado
+// e sem quebras de linha, para não virar cabeçalho.
+func emailMessage(cfg SMTPConfig, t models.Task) []byte {
+	subject := mime.QEncoding.Encode("utf-8", "[advisor-go] Lembrete: "+singleLine(t.Title))
+	return []byte(fmt.Sprintf(
+		"From: %s\r\nTo: %s\r\nSubject: %s\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		cfg.From, strings.Join(cfg.To, ", "), subject, message(t),
+	))
+}
+
+// sendMail faz o mesmo que smtp.SendMail, mas presa ao ctx: o prazo dele vale
+// para a conexão inteira e cancelar fecha a conexão, sem deixar o envio
+// rodando em segundo plano.
+func sendMail(ctx context.Context, addr string, a smtp.Auth, from string, to []string, msg []byte) (err error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return err
+		}
+	}
+	stop := context.AfterFunc(ctx, func() { conn.Close() })
+	defer stop()
+	defer func() {
+		if err != nil && ctx.Err() != nil {
+			err = ctx.Err()
+		}
+	}()
+
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return err
+	}
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		return err
+	}
+	defer c.Close()
+
+	if ok, _ := c.Extension("STARTTLS"); ok {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if a != nil {
+		if ok, _ := c.Extension("AUTH"); ok {
+			if err := c.Auth(a); err != nil {
+				return err
+			}
+		}
+	}
+	if err := c.Mail(from); err != nil {
+		return err
+	}
+	for _, rcpt := range to {
+		if err := c.Rcpt(rcpt); err != nil {
+			return err
+		}
+	}
+	w, err := c.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(msg); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return c.Quit()
+}
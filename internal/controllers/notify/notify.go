@@ -1,15 +1,116 @@
 package notify
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"strings"
 
 	"github.com/andre-felipe-wonsik-alves/internal/models"
 )
 
-func NotifyToTerminal(t models.Task) {
-	fmt.Printf("\n[ TAREFA VENCIDA ]\nTítulo: %s \n| > Prioridade: %s\n", t.Title, t.Priority)
+const (
+	ChannelTerminal = "terminal"
+	ChannelWebhook  = "webhook"
+	ChannelEmail    = "email"
+	ChannelNtfy     = "ntfy"
+	ChannelGotify   = "gotify"
+	ChannelCommand  = "command"
+)
+
+var (
+	ErrUnknownChannel       = errors.New("canal de notificação desconhecido")
+	ErrChannelNotConfigured = errors.New("canal de notificação não configurado")
+)
+
+var knownChannels = []string{
+	ChannelTerminal,
+	ChannelWebhook,
+	ChannelEmail,
+	ChannelNtfy,
+	ChannelGotify,
+	ChannelCommand,
+}
+
+type Notifier interface {
+	Name() string
+	Notify(ctx context.Context, t models.Task) error
+}
+
+type Result struct {
+	Channel string
+	Err     error
+}
+
+func (r Result) OK() bool {
+	return r.Err == nil
+}
+
+func ParseChannels(input []string) (models.ChannelList, error) {
+	var channels models.ChannelList
+	seen := map[string]bool{}
+
+	for _, raw := range input {
+		name := strings.ToLower(strings.TrimSpace(raw))
+		if name == "" || seen[name] {
+			continue
+		}
+		if !isKnownChannel(name) {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownChannel, name)
+		}
+		seen[name] = true
+		channels = append(channels, name)
+	}
+
+	return channels, nil
+}
+
+func isKnownChannel(name string) bool {
+	for _, known := range knownChannels {
+		if known == name {
+			return true
+		}
+	}
+	return false
+}
+
+type TerminalNotifier struct {
+	out io.Writer
+}
+
+func NewTerminalNotifier(out io.Writer) *TerminalNotifier {
+	if out == nil {
+		out = os.Stdout
+	}
+	return &TerminalNotifier{out: out}
+}
+
+func (n *TerminalNotifier) Name() string {
+	return ChannelTerminal
+}
+
+func (n *TerminalNotifier) Notify(_ context.Context, t models.Task) error {
+	fmt.Fprintf(n.out, "\n[ TAREFA VENCIDA ]\nTítulo: %s \n| > Prioridade: %s\n", t.Title, t.Priority)
+
+	if t.Description != "" {
+		fmt.Fprintf(n.out, "| > Descrição: %s\n", t.Description)
+	}
+	return nil
+}
+
+// singleLine troca as quebras de linha por espaços, para o título poder ir
+// num cabeçalho sem virar outro cabeçalho.
+func singleLine(s string) string {
+	return strings.NewReplacer("\r", " ", "\n", " ").Replace(s)
+}
 
+func message(t models.Task) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Prioridade: %s", t.Priority)
 	if t.Description != "" {
-		fmt.Printf("| > Descrição: %s\n", t.Description)
+		fmt.Fprintf(&b, "\n%s", t.Description)
 	}
+	return b.String()
 }
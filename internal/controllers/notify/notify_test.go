@@ -0,0 +1,241 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"mime"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+type fakeNotifier struct {
+	name  string
+	err   error
+	calls int
+}
+
+func (f *fakeNotifier) Name() string {
+	return f.name
+}
+
+func (f *fakeNotifier) Notify(_ context.Context, _ models.Task) error {
+	f.calls++
+	return f.err
+}
+
+func TestParseChannels(t *testing.T) {
+	t.Parallel()
+
+	got, err := ParseChannels([]string{" Terminal ", "ntfy", "", "terminal"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(got) != 2 || got[0] != ChannelTerminal || got[1] != ChannelNtfy {
+		t.Fatalf("channels = %v, want [terminal ntfy]", got)
+	}
+
+	if _, err := ParseChannels([]string{"pombo"}); !errors.Is(err, ErrUnknownChannel) {
+		t.Fatalf("expected ErrUnknownChannel, got %v", err)
+	}
+}
+
+func TestRegistryDispatch(t *testing.T) {
+	t.Parallel()
+
+	t.Run("failing channel does not block the others", func(t *testing.T) {
+		t.Parallel()
+
+		failing := &fakeNotifier{name: ChannelWebhook, err: errors.New("timeout")}
+		terminal := &fakeNotifier{name: ChannelTerminal}
+
+		registry := NewRegistry()
+		registry.Register(failing)
+		registry.Register(terminal)
+
+		results := registry.Dispatch(context.Background(), models.Task{
+			Channels: models.ChannelList{ChannelWebhook, ChannelTerminal, ChannelEmail},
+		})
+
+		if len(results) != 3 {
+			t.Fatalf("results = %d, want 3", len(results))
+		}
+		if results[0].Channel != ChannelWebhook || results[0].OK() {
+			t.Fatalf("webhook result = %+v, want failure", results[0])
+		}
+		if results[1].Channel != ChannelTerminal || !results[1].OK() {
+			t.Fatalf("terminal result = %+v, want success", results[1])
+		}
+		if !errors.Is(results[2].Err, ErrChannelNotConfigured) {
+			t.Fatalf("email result = %+v, want ErrChannelNotConfigured", results[2])
+		}
+		if terminal.calls != 1 {
+			t.Fatalf("terminal calls = %d, want 1", terminal.calls)
+		}
+	})
+
+	t.Run("uses default channels when task has none", func(t *testing.T) {
+		t.Parallel()
+
+		terminal := &fakeNotifier{name: ChannelTerminal}
+		registry := NewRegistry()
+		registry.Register(terminal)
+
+		results := registry.Dispatch(context.Background(), models.Task{})
+
+		if len(results) != 1 || !results[0].OK() || terminal.calls != 1 {
+			t.Fatalf("results = %+v, calls = %d", results, terminal.calls)
+		}
+	})
+}
+
+func TestTerminalNotifier(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	err := NewTerminalNotifier(&out).Notify(context.Background(), models.Task{
+		Title:       "Backup",
+		Description: "Rodar o restic",
+		Priority:    models.PriorityHigh,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out.String(), "Título: Backup") || !strings.Contains(out.String(), "Descrição: Rodar o restic") {
+		t.Fatalf("unexpected output: %q", out.String())
+	}
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("posts task as json", func(t *testing.T) {
+		t.Parallel()
+
+		var got map[string]any
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+				t.Errorf("failed to decode body: %v", err)
+			}
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer server.Close()
+
+		err := NewWebhookNotifier(server.URL, server.Client()).Notify(context.Background(), models.Task{ID: "task-1"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got["event"] != "task.reminder" {
+			t.Fatalf("event = %v, want task.reminder", got["event"])
+		}
+	})
+
+	t.Run("non 2xx is an error", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadGateway)
+		}))
+		defer server.Close()
+
+		err := NewWebhookNotifier(server.URL, server.Client()).Notify(context.Background(), models.Task{ID: "task-1"})
+		if err == nil {
+			t.Fatal("expected error, got nil")
+		}
+	})
+}
+
+func TestNtfyNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("multi-line title stays in one header", func(t *testing.T) {
+		t.Parallel()
+
+		var got http.Header
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = r.Header.Clone()
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer server.Close()
+
+		task := models.Task{Title: "Pagar\r\nX-Injected: sim\nção", Priority: models.PriorityHigh}
+		if err := NewNtfyNotifier(server.URL, "", server.Client()).Notify(context.Background(), task); err != nil {
+			t.Fatalf("notify: %v", err)
+		}
+		if got.Get("X-Injected") != "" {
+			t.Fatalf("title injected a header: %v", got)
+		}
+		title, err := new(mime.WordDecoder).DecodeHeader(got.Get("Title"))
+		if err != nil {
+			t.Fatalf("decode title: %v", err)
+		}
+		if title != "Pagar  X-Injected: sim ção" {
+			t.Fatalf("title = %q", title)
+		}
+	})
+}
+
+func TestEmailNotifier(t *testing.T) {
+	t.Parallel()
+
+	t.Run("title cannot inject headers", func(t *testing.T) {
+		t.Parallel()
+
+		var sent []byte
+		notifier := NewEmailNotifier(SMTPConfig{Host: "smtp.local", Port: "25", From: "advisor@local", To: []string{"eu@local"}})
+		notifier.sendMail = func(_ context.Context, _ string, _ smtp.Auth, _ string, _ []string, msg []byte) error {
+			sent = msg
+			return nil
+		}
+
+		if err := notifier.Notify(context.Background(), models.Task{Title: "Pagar\r\nBcc: todos@local", Priority: models.PriorityHigh}); err != nil {
+			t.Fatalf("notify: %v", err)
+		}
+		headers, _, _ := strings.Cut(string(sent), "\r\n\r\n")
+		if strings.Contains(headers, "\r\nBcc:") || strings.Count(headers, "\r\n") != 3 {
+			t.Fatalf("title injected headers: %q", headers)
+		}
+	})
+
+	t.Run("gives up at the context deadline", func(t *testing.T) {
+		t.Parallel()
+
+		// Servidor que aceita a conexão e nunca responde.
+		ln, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("listen: %v", err)
+		}
+		defer ln.Close()
+		go func() {
+			for {
+				conn, err := ln.Accept()
+				if err != nil {
+					return
+				}
+				defer conn.Close()
+			}
+		}()
+
+		host, port, _ := net.SplitHostPort(ln.Addr().String())
+		notifier := NewEmailNotifier(SMTPConfig{Host: host, Port: port, From: "advisor@local", To: []string{"eu@local"}})
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		start := time.Now()
+		err = notifier.Notify(ctx, models.Task{Title: "Pagar"})
+		if !errors.Is(err, context.DeadlineExceeded) {
+			t.Fatalf("err = %v, want deadline exceeded", err)
+		}
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Fatalf("notify took %v after the deadline", elapsed)
+		}
+	})
+}
@@ -0,0 +1,105 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+type NtfyNotifier struct {
+	topicURL string
+	token    string
+	client   *http.Client
+}
+
+func NewNtfyNotifier(topicURL, token string, client *http.Client) *NtfyNotifier {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &NtfyNotifier{topicURL: topicURL, token: token, client: client}
+}
+
+func (n *NtfyNotifier) Name() string {
+	return ChannelNtfy
+}
+
+func (n *NtfyNotifier) Notify(ctx context.Context, t models.Task) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, n.topicURL, strings.NewReader(message(t)))
+	if err != nil {
+		return err
+	}
+	// Mesmo tratamento do Subject do e-mail; o ntfy decodifica RFC 2047.
+	req.Header.Set("Title", mime.QEncoding.Encode("utf-8", singleLine(t.Title)))
+	req.Header.Set("Priority", ntfyPriority(t.Priority))
+	req.Header.Set("Tags", "alarm_clock")
+	if n.token != "" {
+		req.Header.Set("Authorization", "Bearer "+n.token)
+	}
+
+	return send(n.client, req)
+}
+
+func ntfyPriority(p models.Priority) string {
+	switch p {
+	case models.PriorityHigh:
+		return "high"
+	case models.PriorityLow:
+		return "low"
+	default:
+		return "default"
+	}
+}
+
+type GotifyNotifier struct {
+	serverURL string
+	token     string
+	client    *http.Client
+}
+
+func NewGotifyNotifier(serverURL, token string, client *http.Client) *GotifyNotifier {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return &GotifyNotifier{serverURL: strings.TrimRight(serverURL, "/"), token: token, client: client}
+}
+
+func (n *GotifyNotifier) Name() string {
+	return ChannelGotify
+}
+
+func (n *GotifyNotifier) Notify(ctx context.Context, t models.Task) error {
+	body, err := json.Marshal(map[string]any{
+		"title":    t.Title,
+		"message":  message(t),
+		"priority": gotifyPriority(t.Priority),
+	})
+	if err != nil {
+		return err
+	}
+
+	endpoint := n.serverURL + "/message?token=" + url.QueryEscape(n.token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return send(n.client, req)
+}
+
+func gotifyPriority(p models.Priority) int {
+	switch p {
+	case models.PriorityHigh:
+		return 8
+	case models.PriorityLow:
+		return 2
+	default:
+		return 5
+	}
+}
@@ -0,0 +1,77 @@
+package notify
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+const DefaultTimeout = 10 * time.Second
+
+type Registry struct {
+	mu        sync.RWMutex
+	notifiers map[string]Notifier
+	defaults  []string
+	timeout   time.Duration
+}
+
+func NewRegistry(defaults ...string) *Registry {
+	if len(defaults) == 0 {
+		defaults = []string{ChannelTerminal}
+	}
+	return &Registry{
+		notifiers: map[string]Notifier{},
+		defaults:  defaults,
+		timeout:   DefaultTimeout,
+	}
+}
+
+func (r *Registry) Register(n Notifier) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.notifiers[n.Name()] = n
+}
+
+func (r *Registry) Get(name string) (Notifier, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	n, ok := r.notifiers[name]
+	return n, ok
+}
+
+// Dispatch entrega a tarefa em todos os canais dela (ou nos canais padrão) em
+// paralelo. Um canal com falha não impede os demais; o resultado de cada um é
+// devolvido na mesma ordem dos canais.
+func (r *Registry) Dispatch(ctx context.Context, t models.Task) []Result {
+	channels := []string(t.Channels)
+	if len(channels) == 0 {
+		channels = r.defaults
+	}
+
+	results := make([]Result, len(channels))
+	var wg sync.WaitGroup
+
+	for i, name := range channels {
+		results[i].Channel = name
+
+		n, ok := r.Get(name)
+		if !ok {
+			results[i].Err = ErrChannelNotConfigured
+			continue
+		}
+
+		wg.Add(1)
+		go func(i int, n Notifier) {
+			defer wg.Done()
+
+			notifyCtx, cancel := context.WithTimeout(ctx, r.timeout)
+			defer cancel()
+			results[i].Err = n.Notify(notifyCtx, t)
+		}(i, n)
+	}
+
+	wg.Wait()
+	return results
+}
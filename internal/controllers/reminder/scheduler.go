@@ -0,0 +1,131 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/notify"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+const (
+	DefaultInterval = 30 * time.Second
+	// MaxAttempts limita as entregas de um lembrete cujos canais falham; a
+	// espera entre elas começa em RetryDelay e dobra a cada falha.
+	MaxAttempts = 5
+	RetryDelay  = time.Minute
+)
+
+type Source interface {
+	PendingReminders(ctx context.Context, now time.Time) ([]models.Reminder, error)
+	MarkReminderSent(ctx context.Context, id string, sentAt time.Time) (bool, error)
+	RecordReminderFailure(ctx context.Context, id string, channels models.ChannelList, reason string, retryAt *time.Time) error
+}
+
+type Dispatcher interface {
+	Dispatch(ctx context.Context, t models.Task) []notify.Result
+}
+
+type Scheduler struct {
+	source     Source
+	dispatcher Dispatcher
+	interval   time.Duration
+	now        func() time.Time
+}
+
+func NewScheduler(source Source, dispatcher Dispatcher, interval time.Duration) *Scheduler {
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+	return &Scheduler{
+		source:     source,
+		dispatcher: dispatcher,
+		interval:   interval,
+		now:        time.Now,
+	}
+}
+
+// Run verifica os lembretes a cada intervalo até o contexto ser cancelado.
+func (s *Scheduler) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Tick(ctx); err != nil && ctx.Err() == nil {
+			log.Println("[ ERRO ] Problema ao processar lembretes:", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Tick dispara os lembretes vencidos uma única vez. O lembrete é marcado como
+// entregue antes do disparo, assim outra instância (ou um restart) não
+// notifica a mesma tarefa de novo. Um lembrete com canal próprio só é
+// entregue nesse canal. Se algum canal falhar, a falha fica registrada e o
+// lembrete volta para a fila só com esses canais, até MaxAttempts tentativas.
+func (s *Scheduler) Tick(ctx context.Context) (int, error) {
+	now := s.now()
+
+	reminders, err := s.source.PendingReminders(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, r := range reminders {
+		if r.Task == nil {
+			continue
+		}
+
+		marked, err := s.source.MarkReminderSent(ctx, r.ID, now)
+		if err != nil {
+			return sent, err
+		}
+		if !marked {
+			continue
+		}
+
+		t := *r.Task
+		switch {
+		case len(r.RetryChannels) > 0:
+			t.Channels = r.RetryChannels
+		case r.Channel != "":
+			t.Channels = models.ChannelList{r.Channel}
+		}
+
+		var failed models.ChannelList
+		var errs []error
+		for _, result := range s.dispatcher.Dispatch(ctx, t) {
+			if !result.OK() {
+				log.Printf("[ ERRO ] Falha ao notificar a tarefa %s pelo canal %s: %v\n", t.ID, result.Channel, result.Err)
+				failed = append(failed, result.Channel)
+				errs = append(errs, fmt.Errorf("%s: %w", result.Channel, result.Err))
+			}
+		}
+		if len(failed) == 0 {
+			sent++
+			continue
+		}
+
+		var retryAt *time.Time
+		if r.Attempts+1 < MaxAttempts {
+			at := now.Add(RetryDelay << r.Attempts)
+			retryAt = &at
+		} else {
+			log.Printf("[ ERRO ] Lembrete %s da tarefa %s desistiu depois de %d tentativas\n", r.ID, t.ID, MaxAttempts)
+		}
+		if err := s.source.RecordReminderFailure(ctx, r.ID, failed, errors.Join(errs...).Error(), retryAt); err != nil {
+			return sent, err
+		}
+	}
+
+	return sent, nil
+}
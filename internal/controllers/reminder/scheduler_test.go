@@ -0,0 +1,275 @@
+package reminder
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/notify"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+type dispatchFunc func(ctx context.Context, t models.Task) []notify.Result
+
+func (f dispatchFunc) Dispatch(ctx context.Context, t models.Task) []notify.Result {
+	return f(ctx, t)
+}
+
+func noopDispatch(context.Context, models.Task) []notify.Result {
+	return nil
+}
+
+type fakeSource struct {
+	pending []models.Reminder
+	listErr error
+	sent    map[string]time.Time
+}
+
+func (f *fakeSource) PendingReminders(_ context.Context, now time.Time) ([]models.Reminder, error) {
+	if f.listErr != nil {
+		return nil, f.listErr
+	}
+	var due []models.Reminder
+	for _, r := range f.pending {
+		if _, ok := f.sent[r.ID]; ok {
+			continue
+		}
+		if !r.NextAttempt().After(now) {
+			due = append(due, r)
+		}
+	}
+	return due, nil
+}
+
+func (f *fakeSource) MarkReminderSent(_ context.Context, id string, sentAt time.Time) (bool, error) {
+	if _, ok := f.sent[id]; ok {
+		return false, nil
+	}
+	f.sent[id] = sentAt
+	return true, nil
+}
+
+func (f *fakeSource) RecordReminderFailure(_ context.Context, id string, channels models.ChannelList, reason string, retryAt *time.Time) error {
+	for i := range f.pending {
+		if r := &f.pending[i]; r.ID == id {
+			r.Attempts++
+			r.RetryChannels = channels
+			r.LastError = reason
+			r.RetryAt = retryAt
+		}
+	}
+	if retryAt != nil {
+		delete(f.sent, id)
+	}
+	return nil
+}
+
+func TestSchedulerTick(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 1, 5, 10, 0, 0, 0, time.UTC)
+	task := &models.Task{ID: "task-1", Channels: models.ChannelList{notify.ChannelTerminal}}
+
+	t.Run("notifies due reminders only once", func(t *testing.T) {
+		t.Parallel()
+
+		source := &fakeSource{
+			pending: []models.Reminder{
+				{ID: "rem-1", TaskID: "task-1", Task: task, FireAt: now.Add(-time.Minute)},
+				{ID: "rem-2", TaskID: "task-1", Task: task, FireAt: now.Add(time.Hour)},
+			},
+			sent: map[string]time.Time{},
+		}
+		var notified []string
+		scheduler := NewScheduler(source, dispatchFunc(func(_ context.Context, task models.Task) []notify.Result {
+			notified = append(notified, task.ID)
+			return []notify.Result{{Channel: notify.ChannelTerminal}}
+		}), time.Second)
+		scheduler.now = func() time.Time { return now }
+
+		for i := 0; i < 2; i++ {
+			if _, err := scheduler.Tick(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		if len(notified) != 1 || notified[0] != "task-1" {
+			t.Fatalf("notified = %v, want [task-1]", notified)
+		}
+		if !source.sent["rem-1"].Equal(now) {
+			t.Fatalf("sent at = %v, want %v", source.sent["rem-1"], now)
+		}
+		if _, ok := source.sent["rem-2"]; ok {
+			t.Fatal("future reminder should not be sent")
+		}
+	})
+
+	t.Run("reminder channel overrides task channels", func(t *testing.T) {
+		t.Parallel()
+
+		source := &fakeSource{
+			pending: []models.Reminder{{ID: "rem-1", Task: task, Channel: notify.ChannelNtfy, FireAt: now}},
+			sent:    map[string]time.Time{},
+		}
+		var channels models.ChannelList
+		scheduler := NewScheduler(source, dispatchFunc(func(_ context.Context, task models.Task) []notify.Result {
+			channels = task.Channels
+			return nil
+		}), time.Second)
+		scheduler.now = func() time.Time { return now }
+
+		if _, err := scheduler.Tick(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(channels) != 1 || channels[0] != notify.ChannelNtfy {
+			t.Fatalf("channels = %v, want [ntfy]", channels)
+		}
+		if len(task.Channels) != 1 || task.Channels[0] != notify.ChannelTerminal {
+			t.Fatalf("task channels were modified: %v", task.Channels)
+		}
+	})
+
+	t.Run("skips reminders already claimed", func(t *testing.T) {
+		t.Parallel()
+
+		source := &fakeSource{
+			pending: []models.Reminder{{ID: "rem-1", Task: task, FireAt: now.Add(-time.Minute)}},
+			sent:    map[string]time.Time{},
+		}
+		scheduler := NewScheduler(source, dispatchFunc(func(_ context.Context, task models.Task) []notify.Result {
+			t.Fatalf("unexpected notification for %s", task.ID)
+			return nil
+		}), time.Second)
+		scheduler.now = func() time.Time { return now }
+		source.sent["rem-1"] = now
+
+		sent, err := scheduler.Tick(context.Background())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sent != 0 {
+			t.Fatalf("sent = %d, want 0", sent)
+		}
+	})
+
+	t.Run("retries only the failed channels", func(t *testing.T) {
+		t.Parallel()
+
+		both := &models.Task{ID: "task-2", Channels: models.ChannelList{notify.ChannelTerminal, notify.ChannelNtfy}}
+		source := &fakeSource{
+			pending: []models.Reminder{{ID: "rem-1", Task: both, FireAt: now}},
+			sent:    map[string]time.Time{},
+		}
+		var calls []models.ChannelList
+		ntfyDown := true
+		scheduler := NewScheduler(source, dispatchFunc(func(_ context.Context, task models.Task) []notify.Result {
+			calls = append(calls, task.Channels)
+			var results []notify.Result
+			for _, ch := range task.Channels {
+				result := notify.Result{Channel: ch}
+				if ch == notify.ChannelNtfy && ntfyDown {
+					result.Err = errors.New("ntfy fora do ar")
+				}
+				results = append(results, result)
+			}
+			return results
+		}), time.Second)
+
+		tick := func(at time.Time) int {
+			t.Helper()
+			scheduler.now = func() time.Time { return at }
+			sent, err := scheduler.Tick(context.Background())
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			return sent
+		}
+
+		if sent := tick(now); sent != 0 {
+			t.Fatalf("sent with a failed channel = %d, want 0", sent)
+		}
+		failed := source.pending[0]
+		if failed.Attempts != 1 || failed.RetryAt == nil || !failed.RetryAt.Equal(now.Add(RetryDelay)) || failed.LastError == "" {
+			t.Fatalf("failure not recorded: %+v", failed)
+		}
+		if _, ok := source.sent["rem-1"]; ok {
+			t.Fatal("failed reminder should be pending again")
+		}
+		tick(now.Add(RetryDelay / 2))
+
+		ntfyDown = false
+		if sent := tick(now.Add(RetryDelay)); sent != 1 {
+			t.Fatalf("sent on retry = %d, want 1", sent)
+		}
+		if len(calls) != 2 || len(calls[1]) != 1 || calls[1][0] != notify.ChannelNtfy {
+			t.Fatalf("dispatched channels = %v, want the retry only on ntfy", calls)
+		}
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		t.Parallel()
+
+		source := &fakeSource{
+			pending: []models.Reminder{{ID: "rem-1", Task: task, FireAt: now}},
+			sent:    map[string]time.Time{},
+		}
+		calls := 0
+		scheduler := NewScheduler(source, dispatchFunc(func(_ context.Context, task models.Task) []notify.Result {
+			calls++
+			return []notify.Result{{Channel: notify.ChannelTerminal, Err: errors.New("falhou")}}
+		}), time.Second)
+
+		at := now
+		for range MaxAttempts + 2 {
+			scheduler.now = func() time.Time { return at }
+			if _, err := scheduler.Tick(context.Background()); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			at = at.Add(time.Hour)
+		}
+
+		if calls != MaxAttempts {
+			t.Fatalf("dispatches = %d, want %d", calls, MaxAttempts)
+		}
+		if r := source.pending[0]; r.Attempts != MaxAttempts || r.RetryAt != nil {
+			t.Fatalf("reminder after giving up = %+v", r)
+		}
+		if _, ok := source.sent["rem-1"]; !ok {
+			t.Fatal("reminder should stay claimed after giving up")
+		}
+	})
+
+	t.Run("propagates source error", func(t *testing.T) {
+		t.Parallel()
+
+		wantErr := errors.New("db down")
+		scheduler := NewScheduler(&fakeSource{listErr: wantErr}, dispatchFunc(noopDispatch), time.Second)
+
+		if _, err := scheduler.Tick(context.Background()); !errors.Is(err, wantErr) {
+			t.Fatalf("expected %v, got %v", wantErr, err)
+		}
+	})
+}
+
+func TestSchedulerRunStopsOnCancel(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler := NewScheduler(&fakeSource{sent: map[string]time.Time{}}, dispatchFunc(noopDispatch), time.Millisecond)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- scheduler.Run(ctx)
+	}()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("scheduler did not stop after cancel")
+	}
+}
@@ -0,0 +1,232 @@
+package task
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+var ErrInvalidWeights = errors.New("pesos inválidos")
+
+// Janelas em que cada fator vai de 0 a 1: o prazo pesa cada vez mais na última
+// semana, o lembrete nas últimas 24 horas e a idade até um mês.
+const (
+	dueHorizon      = 7 * 24 * time.Hour
+	reminderHorizon = 24 * time.Hour
+	ageHorizon      = 30 * 24 * time.Hour
+)
+
+// AdviceWeights são os pesos dos fatores da pontuação do advisor. Cada fator
+// vale de 0 a 1 antes do peso; o de bloqueio desconta. Peso 0 desliga o fator.
+type AdviceWeights struct {
+	Priority float64 `json:"priority"`
+	Due      float64 `json:"due"`
+	Reminder float64 `json:"reminder"`
+	Age      float64 `json:"age"`
+	Blocked  float64 `json:"blocked"`
+	Progress float64 `json:"progress"`
+}
+
+func DefaultAdviceWeights() AdviceWeights {
+	return AdviceWeights{Priority: 3, Due: 4, Reminder: 2, Age: 1, Blocked: 5, Progress: 1.5}
+}
+
+// ParseAdviceWeights aplica sobre base os pesos no formato "due=5,age=0.5".
+// Aceita os nomes em inglês ou português (prioridade, prazo, lembrete, idade,
+// bloqueio, progresso).
+func ParseAdviceWeights(spec string, base AdviceWeights) (AdviceWeights, error) {
+	weights := base
+	for part := range strings.SplitSeq(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, raw, ok := strings.Cut(part, "=")
+		if !ok {
+			return base, ErrInvalidWeights
+		}
+		value, err := strconv.ParseFloat(strings.TrimSpace(raw), 64)
+		if err != nil || value < 0 || math.IsInf(value, 0) || math.IsNaN(value) {
+			return base, ErrInvalidWeights
+		}
+
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case "priority", "prioridade":
+			weights.Priority = value
+		case "due", "prazo":
+			weights.Due = value
+		case "reminder", "lembrete":
+			weights.Reminder = value
+		case "age", "idade":
+			weights.Age = value
+		case "blocked", "bloqueio":
+			weights.Blocked = value
+		case "progress", "progresso":
+			weights.Progress = value
+		default:
+			return base, ErrInvalidWeights
+		}
+	}
+	return weights, nil
+}
+
+// AdviceReason é quanto um fator somou (ou descontou) na pontuação.
+type AdviceReason struct {
+	Factor string  `json:"factor" enums:"priority,due,reminder,age,blocked,progress"`
+	Points float64 `json:"points"`
+	Text   string  `json:"text"`
+}
+
+// Advice é uma tarefa sugerida, na posição Rank (1 é a primeira a fazer).
+type Advice struct {
+	Rank        int            `json:"rank"`
+	Score       float64        `json:"score"`
+	Task        models.Task    `json:"task"`
+	Reasons     []AdviceReason `json:"reasons"`
+	Explanation string         `json:"explanation"`
+}
+
+// Rank pontua e ordena as tarefas em aberto, da mais para a menos
+// recomendada; as fechadas ficam de fora. Empates vão para a mais antiga.
+// Blocked, BlockedBy e Progress precisam estar calculados.
+func Rank(tasks []models.Task, weights AdviceWeights, now time.Time) []Advice {
+	advice := make([]Advice, 0, len(tasks))
+	for _, t := range tasks {
+		if t.Status.Closed() {
+			continue
+		}
+		advice = append(advice, score(t, weights, now))
+	}
+
+	slices.SortStableFunc(advice, func(a, b Advice) int {
+		if c := cmp.Compare(b.Score, a.Score); c != 0 {
+			return c
+		}
+		if c := a.Task.CreatedAt.Compare(b.Task.CreatedAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Task.ID, b.Task.ID)
+	})
+	for i := range advice {
+		advice[i].Rank = i + 1
+		advice[i].Explanation = explain(advice[i])
+	}
+	return advice
+}
+
+func score(t models.Task, weights AdviceWeights, now time.Time) Advice {
+	reasons := []AdviceReason{}
+	add := func(factor string, weight, value float64, text string) {
+		if weight == 0 || value == 0 {
+			return
+		}
+		reasons = append(reasons, AdviceReason{Factor: factor, Points: round(weight * value), Text: text})
+	}
+
+	switch t.Priority {
+	case models.PriorityHigh:
+		add("priority", weights.Priority, 1, "prioridade alta")
+	case models.PriorityMedium:
+		add("priority", weights.Priority, 0.5, "prioridade média")
+	}
+
+	if deadline, ok := DueDeadline(t, now.Location()); ok {
+		left := deadline.Sub(now)
+		switch {
+		case left <= 0:
+			add("due", weights.Due, 1, "atrasada há "+humanize(-left))
+		case left < dueHorizon:
+			add("due", weights.Due, 1-float64(left)/float64(dueHorizon), "vence em "+humanize(left))
+		}
+	}
+
+	if !t.ReminderAt.IsZero() {
+		left := t.ReminderAt.Sub(now)
+		switch {
+		case left <= 0:
+			add("reminder", weights.Reminder, 1, "lembrete passou há "+humanize(-left))
+		case left < reminderHorizon:
+			add("reminder", weights.Reminder, 1-float64(left)/float64(reminderHorizon), "lembrete em "+humanize(left))
+		}
+	}
+
+	if age := now.Sub(t.CreatedAt); age >= 24*time.Hour {
+		add("age", weights.Age, min(float64(age)/float64(ageHorizon), 1), "criada há "+humanize(age))
+	}
+
+	switch {
+	case t.Blocked:
+		add("blocked", weights.Blocked, -1, fmt.Sprintf("depende de %d tarefa(s) em aberto", len(t.BlockedBy)))
+	case t.Status == models.StatusBlocked:
+		add("blocked", weights.Blocked, -1, "marcada como bloqueada")
+	case t.Status == models.StatusWaiting:
+		add("blocked", weights.Blocked, -1, "aguardando outra pessoa")
+	}
+
+	switch {
+	case t.Progress != nil && t.Progress.Total > 0 && t.Progress.Done > 0:
+		add("progress", weights.Progress, float64(t.Progress.Done)/float64(t.Progress.Total),
+			fmt.Sprintf("%d de %d subtarefas concluídas", t.Progress.Done, t.Progress.Total))
+	case t.Status == models.StatusInProgress:
+		add("progress", weights.Progress, 0.5, "já está em andamento")
+	}
+
+	total := 0.0
+	for _, r := range reasons {
+		total += r.Points
+	}
+	// Os fatores que mais pesaram vêm primeiro na explicação.
+	slices.SortStableFunc(reasons, func(a, b AdviceReason) int {
+		return cmp.Compare(math.Abs(b.Points), math.Abs(a.Points))
+	})
+	return Advice{Score: round(total), Task: t, Reasons: reasons}
+}
+
+// explain monta a frase do porquê da posição, ex.: "1ª sugestão (7.5 pontos):
+// atrasada há 2 dias, prioridade alta e criada há 12 dias.".
+func explain(a Advice) string {
+	head := fmt.Sprintf("%dª sugestão (%s pontos)", a.Rank, strconv.FormatFloat(a.Score, 'f', -1, 64))
+	if len(a.Reasons) == 0 {
+		return head + ": nada se destaca; entra pela ordem de criação."
+	}
+
+	texts := make([]string, len(a.Reasons))
+	for i, r := range a.Reasons {
+		texts[i] = r.Text
+	}
+	list := texts[0]
+	if n := len(texts); n > 1 {
+		list = strings.Join(texts[:n-1], ", ") + " e " + texts[n-1]
+	}
+	return head + ": " + list + "."
+}
+
+// humanize escreve d na maior unidade inteira: "3 dias", "5 horas" ou
+// "20 minutos".
+func humanize(d time.Duration) string {
+	unit := func(n int, singular, plural string) string {
+		if n == 1 {
+			return "1 " + singular
+		}
+		return strconv.Itoa(n) + " " + plural
+	}
+	switch {
+	case d >= 24*time.Hour:
+		return unit(int(d/(24*time.Hour)), "dia", "dias")
+	case d >= time.Hour:
+		return unit(int(d/time.Hour), "hora", "horas")
+	default:
+		return unit(max(int(d/time.Minute), 1), "minuto", "minutos")
+	}
+}
+
+func round(v float64) float64 {
+	return math.Round(v*100) / 100
+}
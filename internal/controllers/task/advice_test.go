@@ -0,0 +1,101 @@
+package task
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+func TestParseAdviceWeights(t *testing.T) {
+	base := DefaultAdviceWeights()
+
+	tests := []struct {
+		spec    string
+		want    func(AdviceWeights) AdviceWeights
+		wantErr bool
+	}{
+		{spec: "", want: func(w AdviceWeights) AdviceWeights { return w }},
+		{spec: "due=5, age=0.5", want: func(w AdviceWeights) AdviceWeights { w.Due, w.Age = 5, 0.5; return w }},
+		{spec: "prioridade=0,bloqueio=10", want: func(w AdviceWeights) AdviceWeights { w.Priority, w.Blocked = 0, 10; return w }},
+		{spec: "due", wantErr: true},
+		{spec: "due=-1", wantErr: true},
+		{spec: "due=muito", wantErr: true},
+		{spec: "urgency=2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			got, err := ParseAdviceWeights(tt.spec, base)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidWeights) || got != base {
+					t.Fatalf("got %+v, %v; want base, ErrInvalidWeights", got, err)
+				}
+				return
+			}
+			if err != nil || got != tt.want(base) {
+				t.Fatalf("got %+v, %v; want %+v", got, err, tt.want(base))
+			}
+		})
+	}
+}
+
+func TestRank(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time { t := now.Add(d); return &t }
+	base := models.Task{Priority: models.PriorityLow, Status: models.StatusTodo, CreatedAt: now.Add(-time.Hour)}
+	with := func(id string, change func(*models.Task)) models.Task {
+		t := base
+		t.ID = id
+		change(&t)
+		return t
+	}
+
+	tasks := []models.Task{
+		with("plain", func(*models.Task) {}),
+		with("high", func(t *models.Task) { t.Priority = models.PriorityHigh }),
+		with("overdue", func(t *models.Task) { t.DueAt = at(-48 * time.Hour) }),
+		with("blocked", func(t *models.Task) {
+			t.Priority = models.PriorityHigh
+			t.Blocked, t.BlockedBy = true, []string{"x"}
+		}),
+		with("done", func(t *models.Task) { t.Priority = models.PriorityHigh; t.Status = models.StatusDone }),
+		with("started", func(t *models.Task) { t.Progress = &models.Progress{Done: 1, Total: 2} }),
+		with("old", func(t *models.Task) { t.CreatedAt = now.Add(-60 * 24 * time.Hour) }),
+	}
+
+	advice := Rank(tasks, DefaultAdviceWeights(), now)
+	var order []string
+	for i, a := range advice {
+		order = append(order, a.Task.ID)
+		if a.Rank != i+1 {
+			t.Fatalf("advice %d has rank %d", i, a.Rank)
+		}
+	}
+	want := "overdue high old started plain blocked"
+	if got := strings.Join(order, " "); got != want {
+		t.Fatalf("order = %q, want %q", got, want)
+	}
+
+	overdue := advice[0]
+	if overdue.Score != 4 || len(overdue.Reasons) != 1 || overdue.Reasons[0].Factor != "due" {
+		t.Fatalf("overdue advice = %+v", overdue)
+	}
+	if overdue.Explanation != "1ª sugestão (4 pontos): atrasada há 2 dias." {
+		t.Fatalf("explanation = %q", overdue.Explanation)
+	}
+	if blocked := advice[len(advice)-1]; blocked.Score != -2 || !strings.Contains(blocked.Explanation, "depende de 1 tarefa(s) em aberto e prioridade alta") {
+		t.Fatalf("blocked advice = %+v", blocked)
+	}
+	if plain := advice[4]; len(plain.Reasons) != 0 || !strings.Contains(plain.Explanation, "nada se destaca") {
+		t.Fatalf("plain advice = %+v", plain)
+	}
+
+	noDue := DefaultAdviceWeights()
+	noDue.Due = 0
+	if got := Rank(tasks, noDue, now); got[0].Task.ID != "high" {
+		t.Fatalf("without due weight first = %s, want high", got[0].Task.ID)
+	}
+}
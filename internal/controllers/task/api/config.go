@@ -0,0 +1,151 @@
+package api
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	env "github.com/andre-felipe-wonsik-alves/internal"
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+)
+
+// DefaultMaxDepth é quantos níveis de subtarefas cabem abaixo de uma tarefa
+// raiz.
+const DefaultMaxDepth = 10
+
+// DefaultDueSoon é quanto antes do prazo a tarefa passa a vencer "em breve".
+const DefaultDueSoon = 48 * time.Hour
+
+// DefaultEstimate é quanto o planner reserva para a tarefa sem estimativa.
+const DefaultEstimate = 30 * time.Minute
+
+// DefaultWorkHours é o horário de trabalho usado pelo planner.
+var DefaultWorkHours = task.WorkHours{Start: 9 * time.Hour, End: 18 * time.Hour}
+
+// CompletionPolicy diz o que acontece com as subtarefas em aberto quando a
+// tarefa pai é concluída.
+type CompletionPolicy string
+
+const (
+	// CompletionIndependent conclui só a tarefa pedida.
+	CompletionIndependent CompletionPolicy = "independent"
+	// CompletionBlock recusa concluir enquanto houver subtarefa em aberto.
+	CompletionBlock CompletionPolicy = "block"
+	// CompletionCascade conclui junto as subtarefas em aberto.
+	CompletionCascade CompletionPolicy = "cascade"
+)
+
+func ParseCompletionPolicy(s string) (CompletionPolicy, error) {
+	switch policy := CompletionPolicy(s); policy {
+	case CompletionIndependent, CompletionBlock, CompletionCascade:
+		return policy, nil
+	}
+	return "", fmt.Errorf("política de conclusão inválida %q (use independent, block ou cascade)", s)
+}
+
+// DependencyPolicy diz o que acontece ao concluir uma tarefa que ainda tem
+// dependências em aberto.
+type DependencyPolicy string
+
+const (
+	// DependencyRefuse recusa a conclusão com ErrTaskBlocked.
+	DependencyRefuse DependencyPolicy = "refuse"
+	// DependencyWarn conclui mesmo assim; a tarefa volta com BlockedBy
+	// preenchido para quem chamou avisar.
+	DependencyWarn DependencyPolicy = "warn"
+)
+
+func ParseDependencyPolicy(s string) (DependencyPolicy, error) {
+	switch policy := DependencyPolicy(s); policy {
+	case DependencyRefuse, DependencyWarn:
+		return policy, nil
+	}
+	return "", fmt.Errorf("política de dependências inválida %q (use refuse ou warn)", s)
+}
+
+type Config struct {
+	MaxDepth   int
+	Completion CompletionPolicy
+	// AutoCompleteParent conclui a tarefa pai quando a última subtarefa em
+	// aberto dela é concluída.
+	AutoCompleteParent bool
+	Dependencies       DependencyPolicy
+	// DueSoon é a janela do due_soon; 0 desliga o aviso.
+	DueSoon time.Duration
+	// Advice são os pesos da pontuação do advisor.
+	Advice task.AdviceWeights
+	// WorkHours é a janela do dia que o planner preenche; Estimate é a
+	// duração da tarefa sem estimativa.
+	WorkHours task.WorkHours
+	Estimate  time.Duration
+}
+
+func DefaultConfig() Config {
+	return Config{
+		MaxDepth:     DefaultMaxDepth,
+		Completion:   CompletionIndependent,
+		Dependencies: DependencyRefuse,
+		DueSoon:      DefaultDueSoon,
+		Advice:       task.DefaultAdviceWeights(),
+		WorkHours:    DefaultWorkHours,
+		Estimate:     DefaultEstimate,
+	}
+}
+
+// ConfigFromEnv lê a configuração do Service das variáveis de ambiente
+// (TASK_MAX_DEPTH, TASK_COMPLETION_POLICY, TASK_AUTO_COMPLETE_PARENT,
+// TASK_DEPENDENCY_POLICY, TASK_DUE_SOON, TASK_ADVICE_WEIGHTS, TASK_WORK_HOURS
+// e TASK_DEFAULT_ESTIMATE).
+func ConfigFromEnv() (Config, error) {
+	cfg := DefaultConfig()
+
+	maxDepth, err := strconv.Atoi(env.GetEnv("TASK_MAX_DEPTH", strconv.Itoa(cfg.MaxDepth)))
+	if err != nil || maxDepth < 1 {
+		return cfg, fmt.Errorf("TASK_MAX_DEPTH inválido: deve ser um inteiro maior que zero")
+	}
+	cfg.MaxDepth = maxDepth
+
+	completion, err := ParseCompletionPolicy(env.GetEnv("TASK_COMPLETION_POLICY", string(cfg.Completion)))
+	if err != nil {
+		return cfg, fmt.Errorf("TASK_COMPLETION_POLICY: %w", err)
+	}
+	cfg.Completion = completion
+
+	autoComplete, err := strconv.ParseBool(env.GetEnv("TASK_AUTO_COMPLETE_PARENT", strconv.FormatBool(cfg.AutoCompleteParent)))
+	if err != nil {
+		return cfg, fmt.Errorf("TASK_AUTO_COMPLETE_PARENT inválido: use true ou false")
+	}
+	cfg.AutoCompleteParent = autoComplete
+
+	dependencies, err := ParseDependencyPolicy(env.GetEnv("TASK_DEPENDENCY_POLICY", string(cfg.Dependencies)))
+	if err != nil {
+		return cfg, fmt.Errorf("TASK_DEPENDENCY_POLICY: %w", err)
+	}
+	cfg.Dependencies = dependencies
+
+	dueSoon, err := task.ParseOffset(env.GetEnv("TASK_DUE_SOON", cfg.DueSoon.String()))
+	if err != nil || dueSoon < 0 {
+		return cfg, fmt.Errorf("TASK_DUE_SOON inválido: use uma duração como 48h ou 2d")
+	}
+	cfg.DueSoon = dueSoon
+
+	advice, err := task.ParseAdviceWeights(env.GetEnv("TASK_ADVICE_WEIGHTS", ""), cfg.Advice)
+	if err != nil {
+		return cfg, fmt.Errorf("TASK_ADVICE_WEIGHTS inválido: use pesos como due=5,priority=2")
+	}
+	cfg.Advice = advice
+
+	workHours, err := task.ParseWorkHours(env.GetEnv("TASK_WORK_HOURS", cfg.WorkHours.String()))
+	if err != nil {
+		return cfg, fmt.Errorf("TASK_WORK_HOURS inválido: use o horário como 09:00-18:00")
+	}
+	cfg.WorkHours = workHours
+
+	estimate, err := task.ParseEstimate(env.GetEnv("TASK_DEFAULT_ESTIMATE", cfg.Estimate.String()))
+	if err != nil || estimate == 0 {
+		return cfg, fmt.Errorf("TASK_DEFAULT_ESTIMATE inválido: use uma duração como 30m ou 1h")
+	}
+	cfg.Estimate = time.Duration(estimate) * time.Minute
+
+	return cfg, nil
+}
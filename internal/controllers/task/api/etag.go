@@ -0,0 +1,64 @@
+package api
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+var errInvalidIfMatch = errors.New("If-Match deve ser uma ETag de tarefa, ex.: \"3\"")
+
+// taskETag é a ETag de uma tarefa: a versão entre aspas.
+func taskETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// listETag resume ids e versões de uma lista; muda quando qualquer tarefa muda.
+func listETag(tasks []models.Task) string {
+	h := sha1.New()
+	for _, t := range tasks {
+		fmt.Fprintf(h, "%s:%d;", t.ID, t.Version)
+	}
+	return `W/"` + hex.EncodeToString(h.Sum(nil))[:16] + `"`
+}
+
+func setTaskETag(w http.ResponseWriter, t *models.Task) {
+	if t != nil {
+		w.Header().Set("ETag", taskETag(t.Version))
+	}
+}
+
+// ifMatchVersion lê a versão esperada do If-Match. Sem cabeçalho ou com "*"
+// devolve 0, que significa "sem precondição".
+func ifMatchVersion(r *http.Request) (int64, error) {
+	raw := strings.TrimSpace(r.Header.Get("If-Match"))
+	if raw == "" || raw == "*" {
+		return 0, nil
+	}
+
+	raw = strings.TrimPrefix(raw, "W/")
+	unquoted, err := strconv.Unquote(raw)
+	if err != nil {
+		return 0, errInvalidIfMatch
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version <= 0 {
+		return 0, errInvalidIfMatch
+	}
+	return version, nil
+}
+
+// respondConflict responde 412 com a ETag atual, para o cliente recarregar e
+// tentar de novo.
+func (h *TaskHandler) respondConflict(w http.ResponseWriter, r *http.Request, id string, err error) {
+	if current, getErr := h.taskService.GetByID(r.Context(), id); getErr == nil {
+		setTaskETag(w, current)
+	}
+	respondError(w, http.StatusPreconditionFailed, "A tarefa foi alterada desde a versão informada em If-Match", err)
+}
@@ -2,11 +2,15 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/notify"
 	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
 	"github.com/go-chi/chi/v5"
 )
 
@@ -19,20 +23,54 @@ func NewTaskHandler(taskService *Service) *TaskHandler {
 }
 
 type CreateTaskRequest struct {
-	Title       string    `json:"title" example:"Reunião importante"`
-	Description string    `json:"description" example:"Apresentar projeto ao time"`
-	Priority    string    `json:"priority" example:"high" enums:"low,medium,high"`
-	ReminderAt  time.Time `json:"reminder_at" example:"2025-12-27T15:00:00Z"`
-	ParentID    *string   `json:"parent_id,omitempty" example:"8f3edff7-f3fe-4ab1-a60a-f35efcdfbf70"`
+	Title       string            `json:"title" example:"Reunião importante"`
+	Description string            `json:"description" example:"Apresentar projeto ao time"`
+	Priority    string            `json:"priority" example:"high" enums:"low,medium,high"`
+	ReminderAt  time.Time         `json:"reminder_at" example:"2025-12-27T15:00:00Z"`
+	DueAt       string            `json:"due_at,omitempty" example:"2025-12-31"`
+	Estimate    int               `json:"estimate_minutes,omitempty" example:"45"`
+	ParentID    *string           `json:"parent_id,omitempty" example:"8f3edff7-f3fe-4ab1-a60a-f35efcdfbf70"`
+	ProjectID   *string           `json:"project_id,omitempty" example:"3b1f5c2e-9d4a-4f7e-8c61-0a2d7e9b4c13"`
+	Channels    []string          `json:"channels,omitempty" example:"terminal,ntfy"`
+	Recurrence  string            `json:"recurrence,omitempty" example:"FREQ=WEEKLY;BYDAY=MO"`
+	Reminders   []ReminderRequest `json:"reminders,omitempty"`
+	Tags        []string          `json:"tags,omitempty" example:"homelab,casa"`
 }
 
 type PatchTaskRequest struct {
-	Title       *string    `json:"title,omitempty"`
-	Description *string    `json:"description,omitempty"`
-	Priority    *string    `json:"priority,omitempty" enums:"low,medium,high"`
-	ReminderAt  *time.Time `json:"reminder_at,omitempty"`
-	Done        *bool      `json:"done,omitempty"`
-	ParentID    *string    `json:"parent_id,omitempty"`
+	Title       *string        `json:"title,omitempty"`
+	Description *string        `json:"description,omitempty"`
+	Priority    *string        `json:"priority,omitempty" enums:"low,medium,high"`
+	ReminderAt  *time.Time     `json:"reminder_at,omitempty"`
+	DueAt       NullableString `json:"due_at,omitempty" swaggertype:"string" extensions:"x-nullable" example:"2025-12-31T18:00:00Z"`
+	Estimate    *int           `json:"estimate_minutes,omitempty" example:"90"`
+	Done        *bool          `json:"done,omitempty"`
+	Status      *string        `json:"status,omitempty" enums:"todo,in_progress,blocked,waiting,done,cancelled"`
+	ParentID    NullableString `json:"parent_id,omitempty" swaggertype:"string" extensions:"x-nullable"`
+	ProjectID   NullableString `json:"project_id,omitempty" swaggertype:"string" extensions:"x-nullable"`
+	Channels    *[]string      `json:"channels,omitempty"`
+	Recurrence  *string        `json:"recurrence,omitempty" example:"monthly"`
+}
+
+// NullableString distingue, num corpo JSON, o campo ausente (Set false) do
+// campo null (Set true e Value nil). Em parent_id e project_id, null desvincula
+// a tarefa do pai ou do projeto; em due_at, tira o prazo.
+type NullableString struct {
+	Set   bool
+	Value *string
+}
+
+func (n *NullableString) UnmarshalJSON(data []byte) error {
+	n.Set = true
+	n.Value = nil
+	if string(data) == "null" {
+		return nil
+	}
+	return json.Unmarshal(data, &n.Value)
+}
+
+type SnoozeTaskRequest struct {
+	Duration string `json:"duration" example:"10m"`
 }
 
 type ErrorResponse struct {
@@ -40,21 +78,71 @@ type ErrorResponse struct {
 	Message string `json:"message,omitempty" example:"ID inválido fornecido"`
 }
 
-// @Summary     Listar todas as tarefas
-// @Description Retorna lista de todas as tarefas cadastradas
+// @Summary     Listar tarefas
+// @Description Retorna as tarefas filtradas, ordenadas e paginadas. Os intervalos incluem o início e excluem o fim, em RFC3339 ou duração relativa a agora (ex.: -24h). A próxima página vem no cabeçalho Link (rel="next") e em X-Next-Cursor
 // @Tags        Tasks
 // @Produce     json
+// @Param       done          query bool   false "Filtra por concluída"
+// @Param       priority      query string false "Filtra por prioridade" Enums(low, medium, high)
+// @Param       status        query []string false "Filtra por status; pode repetir (ex.: status=todo&status=in_progress)" collectionFormat(multi) Enums(todo, in_progress, blocked, waiting, done, cancelled)
+// @Param       parent_id     query string false "Só subtarefas desta tarefa"
+// @Param       project_id    query string false "Só tarefas deste projeto"
+// @Param       root          query bool   false "Só tarefas sem pai"
+// @Param       reminder_from query string false "Lembrete a partir de"
+// @Param       reminder_to   query string false "Lembrete antes de"
+// @Param       created_from  query string false "Criada a partir de"
+// @Param       created_to    query string false "Criada antes de"
+// @Param       tag           query []string false "Só tarefas com a tag; '-' na frente exclui (ex.: tag=homelab&tag=-urgente)" collectionFormat(multi)
+// @Param       sort          query string false "Ordenação; '-' na frente para decrescente (padrão -created_at). Em due_at, as tarefas sem prazo vêm por último na ordem crescente" Enums(created_at, -created_at, priority, -priority, reminder_at, -reminder_at, due_at, -due_at)
+// @Param       limit         query int    false "Tamanho da página (máximo 500). Sem limit nem cursor, lista todas; só com cursor, o padrão é 50"
+// @Param       cursor        query string false "Cursor da próxima página (X-Next-Cursor)"
 // @Success     200 {array} models.Task
+// @Header      200 {string} ETag "Resumo das versões da lista"
+// @Header      200 {string} Link "URL da próxima página (rel=next)"
+// @Header      200 {string} X-Next-Cursor "Cursor da próxima página; ausente na última"
+// @Failure     400 {object} ErrorResponse
 // @Failure     500 {object} ErrorResponse
 // @Router      /tasks [get]
 func (h *TaskHandler) ListTasks(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
-	tasks, err := h.taskService.List(ctx)
+
+	query, err := parseListQuery(r, time.Now())
 	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Erro ao carregar tarefas", err)
+		respondError(w, http.StatusBadRequest, "Parâmetros de listagem inválidos", err)
+		return
+	}
+
+	page, err := h.taskService.ListPage(ctx, query, r.URL.Query().Get("cursor"))
+	if err != nil {
+		respondListError(w, err)
 		return
 	}
-	respondJSON(w, http.StatusOK, tasks)
+	respondPage(w, r, page)
+}
+
+func respondListError(w http.ResponseWriter, err error) {
+	switch err {
+	case ErrInvalidCursor:
+		respondError(w, http.StatusBadRequest, "Cursor inválido para esta listagem", nil)
+	case ErrInvalidInput, ErrInvalidSort:
+		respondError(w, http.StatusBadRequest, "Parâmetros de listagem inválidos", err)
+	default:
+		respondError(w, http.StatusInternalServerError, "Erro ao carregar tarefas", err)
+	}
+}
+
+// respondPage devolve a página com os cabeçalhos de paginação e o ETag.
+func respondPage(w http.ResponseWriter, r *http.Request, page *TaskPage) {
+	if page.NextCursor != "" {
+		next := *r.URL
+		values := next.Query()
+		values.Set("cursor", page.NextCursor)
+		next.RawQuery = values.Encode()
+		w.Header().Set("Link", fmt.Sprintf(`<%s>; rel="next"`, next.RequestURI()))
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+	}
+	w.Header().Set("ETag", listETag(page.Tasks))
+	respondJSON(w, http.StatusOK, page.Tasks)
 }
 
 // @Summary     Listar subtarefas de uma tarefa
@@ -83,13 +171,15 @@ func (h *TaskHandler) ListSubtasks(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary     Criar nova tarefa
-// @Description Adiciona uma nova tarefa ao sistema
+// @Description Adiciona uma nova tarefa ao sistema. Sem reminders explícitos, um lembrete é criado no próprio reminder_at. O due_at aceita só a data (prazo de dia inteiro) ou data e hora
 // @Tags        Tasks
 // @Accept      json
 // @Produce     json
 // @Param       task body CreateTaskRequest true "Dados da tarefa"
 // @Success     201 {object} models.Task
 // @Failure     400 {object} ErrorResponse
+// @Failure     404 {object} ErrorResponse
+// @Failure     409 {object} ErrorResponse
 // @Failure     500 {object} ErrorResponse
 // @Router      /tasks [post]
 func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
@@ -104,27 +194,110 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	priority, err := task.ParsePriority(req.Priority)
-	if err != nil {
-		respondError(w, http.StatusBadRequest, "Prioridade inválida", err)
-		return
+	// Sem prioridade, a tarefa de um projeto fica com a prioridade padrão dele.
+	var priority models.Priority
+	if req.Priority != "" || req.ProjectID == nil {
+		parsed, err := task.ParsePriority(req.Priority)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Prioridade inválida", err)
+			return
+		}
+		priority = parsed
 	}
 
 	if req.ParentID != nil && strings.TrimSpace(*req.ParentID) == "" {
 		respondError(w, http.StatusBadRequest, "parent_id inválido", nil)
 		return
 	}
+	if req.ProjectID != nil && strings.TrimSpace(*req.ProjectID) == "" {
+		respondError(w, http.StatusBadRequest, "project_id inválido", nil)
+		return
+	}
+
+	channels, err := notify.ParseChannels(req.Channels)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Canal de notificação inválido", err)
+		return
+	}
+
+	var dueAt *time.Time
+	var dueAllDay bool
+	if strings.TrimSpace(req.DueAt) != "" {
+		parsed, allDay, err := task.ParseDue(req.DueAt, time.Local)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Prazo inválido", err)
+			return
+		}
+		dueAt, dueAllDay = &parsed, allDay
+	}
 
-	newTask, err := h.taskService.CreateWithParent(r.Context(), req.Title, req.Description, priority, req.ReminderAt, req.ParentID)
+	tags := make([]models.Tag, 0, len(req.Tags))
+	for _, name := range req.Tags {
+		tags = append(tags, models.Tag{Name: name})
+	}
+
+	reminders := make([]models.Reminder, 0, len(req.Reminders))
+	for _, rr := range req.Reminders {
+		reminder, err := rr.toModel()
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Canal de notificação inválido", err)
+			return
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	newTask, err := h.taskService.CreateTask(r.Context(), models.Task{
+		Title:           req.Title,
+		Description:     req.Description,
+		Priority:        priority,
+		ReminderAt:      req.ReminderAt,
+		DueAt:           dueAt,
+		DueAllDay:       dueAllDay,
+		EstimateMinutes: req.Estimate,
+		ParentID:        req.ParentID,
+		ProjectID:       req.ProjectID,
+		Channels:        channels,
+		Recurrence:      strings.TrimSpace(req.Recurrence),
+		Reminders:       reminders,
+		Tags:            tags,
+	})
 	if err != nil {
 		if err == ErrParentTaskNotFound {
 			respondError(w, http.StatusNotFound, "Tarefa pai não encontrada", nil)
 			return
 		}
+		if err == ErrMaxDepthExceeded {
+			respondError(w, http.StatusConflict, "Profundidade máxima de subtarefas excedida", nil)
+			return
+		}
 		if err == ErrInvalidInput {
 			respondError(w, http.StatusBadRequest, "Dados inválidos", err)
 			return
 		}
+		if err == ErrInvalidRecurrence {
+			respondError(w, http.StatusBadRequest, "Recorrência inválida", err)
+			return
+		}
+		if err == ErrInvalidEstimate {
+			respondError(w, http.StatusBadRequest, "Estimativa inválida (de 0 a 1440 minutos)", err)
+			return
+		}
+		if err == ErrInvalidReminder {
+			respondError(w, http.StatusBadRequest, "Lembrete inválido", err)
+			return
+		}
+		if err == ErrInvalidTag {
+			respondError(w, http.StatusBadRequest, "Tag inválida", err)
+			return
+		}
+		if err == ErrProjectNotFound {
+			respondError(w, http.StatusNotFound, "Projeto não encontrado", nil)
+			return
+		}
+		if err == ErrProjectArchived {
+			respondError(w, http.StatusConflict, "O projeto está arquivado", nil)
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Erro ao criar tarefa", err)
 		return
 	}
@@ -138,6 +311,7 @@ func (h *TaskHandler) CreateTask(w http.ResponseWriter, r *http.Request) {
 // @Produce     json
 // @Param       id path string true "ID da tarefa"
 // @Success     200 {object} models.Task
+// @Header      200 {string} ETag "Versão da tarefa, para usar em If-Match"
 // @Failure     404 {object} ErrorResponse
 // @Failure     500 {object} ErrorResponse
 // @Router      /tasks/{id} [get]
@@ -154,25 +328,40 @@ func (h *TaskHandler) GetTask(w http.ResponseWriter, r *http.Request) {
 		respondError(w, http.StatusInternalServerError, "Erro ao buscar tarefa", err)
 		return
 	}
+	if t == nil {
+		respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
+		return
+	}
 
+	setTaskETag(w, t)
 	respondJSON(w, http.StatusOK, t)
 }
 
 // @Summary     Atualizar campos específicos de uma tarefa
-// @Description Atualiza dados de uma tarefa existente
+// @Description Atualiza dados de uma tarefa existente. Com If-Match, só aplica se a tarefa ainda estiver na versão informada. status segue as transições permitidas (409 quando não pode); done true equivale a concluir e done false reabre uma tarefa concluída
 // @Tags        Tasks
 // @Accept      json
 // @Produce     json
-// @Param       id path string true "ID da tarefa"
-// @Param       task body PatchTaskRequest true "Dados para atualização"
+// @Param       id       path   string           true  "ID da tarefa"
+// @Param       If-Match header string           false "ETag lida em GET /tasks/{id}"
+// @Param       task     body   PatchTaskRequest true  "Dados para atualização"
 // @Success     200 {object} models.Task
+// @Header      200 {string} ETag "Nova versão da tarefa"
 // @Failure     400 {object} ErrorResponse
 // @Failure     404 {object} ErrorResponse
+// @Failure     409 {object} ErrorResponse
+// @Failure     412 {object} ErrorResponse
 // @Failure     500 {object} ErrorResponse
 // @Router      /tasks/{id} [patch]
 func (h *TaskHandler) PatchTask(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		respondError(w, http.StatusPreconditionFailed, "If-Match inválido", err)
+		return
+	}
+
 	var req PatchTaskRequest
 	dec := json.NewDecoder(r.Body)
 	dec.DisallowUnknownFields()
@@ -193,18 +382,64 @@ func (h *TaskHandler) PatchTask(w http.ResponseWriter, r *http.Request) {
 	if req.Done != nil {
 		changes["done"] = *req.Done
 	}
+	if req.Status != nil {
+		status, err := task.ParseStatus(*req.Status)
+		if err != nil {
+			http.Error(w, "status inválido", http.StatusBadRequest)
+			return
+		}
+		changes["status"] = status
+	}
 	if req.Priority != nil {
 		changes["priority"] = *req.Priority
 	}
 	if req.ReminderAt != nil {
-		changes["reminderAt"] = *req.ReminderAt
+		changes["reminder_at"] = *req.ReminderAt
 	}
-	if req.ParentID != nil {
-		if strings.TrimSpace(*req.ParentID) == "" {
+	if req.Estimate != nil {
+		changes["estimate_minutes"] = *req.Estimate
+	}
+	if req.DueAt.Set {
+		changes["due_at"], changes["due_all_day"] = nil, false
+		if req.DueAt.Value != nil {
+			dueAt, allDay, err := task.ParseDue(*req.DueAt.Value, time.Local)
+			if err != nil {
+				http.Error(w, "prazo inválido", http.StatusBadRequest)
+				return
+			}
+			changes["due_at"], changes["due_all_day"] = dueAt, allDay
+		}
+	}
+	if req.ParentID.Set {
+		if req.ParentID.Value == nil {
+			changes["parent_id"] = nil
+		} else if strings.TrimSpace(*req.ParentID.Value) == "" {
 			http.Error(w, "parent_id inválido", http.StatusBadRequest)
 			return
+		} else {
+			changes["parent_id"] = *req.ParentID.Value
+		}
+	}
+	if req.ProjectID.Set {
+		if req.ProjectID.Value == nil {
+			changes["project_id"] = nil
+		} else if strings.TrimSpace(*req.ProjectID.Value) == "" {
+			http.Error(w, "project_id inválido", http.StatusBadRequest)
+			return
+		} else {
+			changes["project_id"] = *req.ProjectID.Value
 		}
-		changes["parent_id"] = *req.ParentID
+	}
+	if req.Channels != nil {
+		channels, err := notify.ParseChannels(*req.Channels)
+		if err != nil {
+			http.Error(w, "canal de notificação inválido", http.StatusBadRequest)
+			return
+		}
+		changes["channels"] = channels
+	}
+	if req.Recurrence != nil {
+		changes["recurrence"] = strings.TrimSpace(*req.Recurrence)
 	}
 
 	if len(changes) == 0 {
@@ -212,17 +447,57 @@ func (h *TaskHandler) PatchTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	task, err := h.taskService.Patch(r.Context(), id, changes)
+	task, err := h.taskService.PatchIfMatch(r.Context(), id, version, changes)
 
 	if err != nil {
+		if err == ErrVersionConflict {
+			h.respondConflict(w, r, id, nil)
+			return
+		}
 		if err == ErrParentTaskNotFound {
 			http.Error(w, "tarefa pai não encontrada", http.StatusNotFound)
 			return
 		}
+		if err == ErrTaskCycle {
+			http.Error(w, "a tarefa não pode ficar abaixo de uma das próprias subtarefas", http.StatusConflict)
+			return
+		}
+		if err == ErrMaxDepthExceeded {
+			http.Error(w, "profundidade máxima de subtarefas excedida", http.StatusConflict)
+			return
+		}
+		if err == ErrOpenSubtasks {
+			http.Error(w, "a tarefa tem subtarefas em aberto", http.StatusConflict)
+			return
+		}
+		if err == ErrTaskBlocked {
+			http.Error(w, "a tarefa tem dependências em aberto", http.StatusConflict)
+			return
+		}
+		if err == ErrInvalidTransition {
+			http.Error(w, "transição de status não permitida", http.StatusConflict)
+			return
+		}
 		if err == ErrInvalidInput {
 			http.Error(w, "dados inválidos", http.StatusBadRequest)
 			return
 		}
+		if err == ErrInvalidRecurrence {
+			http.Error(w, "recorrência inválida", http.StatusBadRequest)
+			return
+		}
+		if err == ErrInvalidEstimate {
+			http.Error(w, "estimativa inválida", http.StatusBadRequest)
+			return
+		}
+		if err == ErrProjectNotFound {
+			http.Error(w, "projeto não encontrado", http.StatusNotFound)
+			return
+		}
+		if err == ErrProjectArchived {
+			http.Error(w, "o projeto está arquivado", http.StatusConflict)
+			return
+		}
 		http.Error(w, "erro ao atualizar", http.StatusInternalServerError)
 		return
 	}
@@ -231,24 +506,40 @@ func (h *TaskHandler) PatchTask(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	setTaskETag(w, task)
+	if changes["done"] == true {
+		warnOpenBlockers(w, task)
+	}
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(task)
 }
 
 // @Summary     Deletar tarefa
-// @Description Remove uma tarefa do sistema
+// @Description Remove uma tarefa do sistema (vai para a lixeira). Com If-Match, só remove se a tarefa ainda estiver na versão informada
 // @Tags        Tasks
 // @Produce     json
-// @Param       id path string true "ID da tarefa"
+// @Param       id       path   string true  "ID da tarefa"
+// @Param       If-Match header string false "ETag lida em GET /tasks/{id}"
 // @Success     204 "Tarefa removida com sucesso"
 // @Failure     404 {object} ErrorResponse
+// @Failure     412 {object} ErrorResponse
 // @Failure     500 {object} ErrorResponse
 // @Router      /tasks/{id} [delete]
 func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	err := h.taskService.Delete(r.Context(), id)
+	version, err := ifMatchVersion(r)
 	if err != nil {
+		respondError(w, http.StatusPreconditionFailed, "If-Match inválido", err)
+		return
+	}
+
+	err = h.taskService.DeleteIfMatch(r.Context(), id, version)
+	if err != nil {
+		if err == ErrVersionConflict {
+			h.respondConflict(w, r, id, nil)
+			return
+		}
 		if err == ErrTaskNotFound {
 			respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
 			return
@@ -261,46 +552,187 @@ func (h *TaskHandler) DeleteTask(w http.ResponseWriter, r *http.Request) {
 }
 
 // @Summary     Marcar tarefa como concluída
-// @Description Marca uma tarefa específica como concluída
+// @Description Marca uma tarefa específica como concluída. Se ela for recorrente, a próxima ocorrência é criada com o lembrete recalculado. As subtarefas em aberto seguem a política de conclusão configurada: recusar (409), concluir junto ou ignorar. Com dependências em aberto, a conclusão é recusada (409) ou, com a política warn, feita com o header Warning
 // @Tags        Tasks
 // @Produce     json
-// @Param       id path string true "ID da tarefa"
+// @Param       id       path   string true  "ID da tarefa"
+// @Param       If-Match header string false "ETag lida em GET /tasks/{id}"
 // @Success     200 {object} models.Task
+// @Header      200 {string} ETag "Nova versão da tarefa"
+// @Header      200 {string} Warning "Presente quando a tarefa foi concluída com dependências em aberto"
 // @Failure     404 {object} ErrorResponse
+// @Failure     409 {object} ErrorResponse
+// @Failure     412 {object} ErrorResponse
 // @Failure     500 {object} ErrorResponse
 // @Router      /tasks/{id}/complete [patch]
 func (h *TaskHandler) CompleteTask(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 
-	completed, err := h.taskService.Complete(r.Context(), id)
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		respondError(w, http.StatusPreconditionFailed, "If-Match inválido", err)
+		return
+	}
+
+	completed, err := h.taskService.CompleteIfMatch(r.Context(), id, version)
 	if err != nil {
+		if err == ErrVersionConflict {
+			h.respondConflict(w, r, id, nil)
+			return
+		}
 		if err == ErrTaskNotFound {
 			respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
 			return
 		}
+		if err == ErrOpenSubtasks {
+			respondError(w, http.StatusConflict, "A tarefa tem subtarefas em aberto", nil)
+			return
+		}
+		if err == ErrTaskBlocked {
+			respondError(w, http.StatusConflict, "A tarefa tem dependências em aberto", nil)
+			return
+		}
+		if err == ErrInvalidTransition {
+			respondError(w, http.StatusConflict, "Tarefa cancelada não pode ser concluída; reabra antes", nil)
+			return
+		}
 		respondError(w, http.StatusInternalServerError, "Erro ao completar tarefa", err)
 		return
 	}
 
+	if completed == nil {
+		respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
+		return
+	}
+
+	setTaskETag(w, completed)
+	warnOpenBlockers(w, completed)
 	respondJSON(w, http.StatusOK, completed)
 }
 
+// @Summary     Adiar lembrete de uma tarefa
+// @Description Move o reminder_at para frente (os lembretes relativos acompanham), libera um novo disparo e incrementa o contador de adiamentos. duration aceita "10m", "1h", "2d", "amanhã 9:00", "hoje 18:30" ou "02/01/2006 15:04"
+// @Tags        Tasks
+// @Accept      json
+// @Produce     json
+// @Param       id   path string            true "ID da tarefa"
+// @Param       body body SnoozeTaskRequest true "Quanto adiar"
+// @Success     200 {object} models.Task
+// @Failure     400 {object} ErrorResponse
+// @Failure     404 {object} ErrorResponse
+// @Failure     409 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/{id}/snooze [post]
+func (h *TaskHandler) SnoozeTask(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req SnoozeTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "JSON inválido", err)
+		return
+	}
+
+	until, err := task.ParseSnooze(req.Duration, time.Now())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Duração inválida", err)
+		return
+	}
+
+	snoozed, err := h.taskService.Snooze(r.Context(), id, until)
+	if err != nil {
+		switch err {
+		case ErrTaskNotFound:
+			respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
+		case ErrTaskAlreadyDone:
+			respondError(w, http.StatusConflict, "Tarefa já concluída", nil)
+		case ErrTaskCancelled:
+			respondError(w, http.StatusConflict, "Tarefa cancelada", nil)
+		case ErrVersionConflict:
+			respondError(w, http.StatusConflict, "A tarefa foi alterada durante o adiamento, tente de novo", nil)
+		case ErrInvalidInput:
+			respondError(w, http.StatusBadRequest, "O novo lembrete precisa estar no futuro", nil)
+		default:
+			respondError(w, http.StatusInternalServerError, "Erro ao adiar lembrete", err)
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, snoozed)
+}
+
 // @Summary     Listar tarefas vencidas
-// @Description Retorna todas as tarefas cujo lembrete já passou
+// @Description Retorna as tarefas cujo lembrete cai na janela informada (por padrão, tudo que já venceu). before/after aceitam RFC3339 ou uma duração relativa a agora (ex.: 1h, -30m)
 // @Tags        Tasks
 // @Produce     json
+// @Param       before       query string false "Limite superior do lembrete (padrão: agora)" example(1h)
+// @Param       after        query string false "Limite inferior do lembrete" example(2025-12-27T00:00:00Z)
+// @Param       include_done query bool   false "Inclui tarefas concluídas"
+// @Param       priority     query string false "Filtra por prioridade" Enums(low, medium, high)
 // @Success     200 {array} models.Task
+// @Failure     400 {object} ErrorResponse
 // @Failure     500 {object} ErrorResponse
 // @Router      /tasks/due [get]
-// func (h *TaskHandler) GetDueTasks(w http.ResponseWriter, r *http.Request) {
-// 	dueTasks, err := h.taskService.GetDue()
-// 	if err != nil {
-// 		respondError(w, http.StatusInternalServerError, "Erro ao buscar tarefas vencidas", err)
-// 		return
-// 	}
-
-// 	respondJSON(w, http.StatusOK, dueTasks)
-// }
+func (h *TaskHandler) GetDueTasks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	now := time.Now()
+	filter := task.DueFilter{}
+
+	if raw := query.Get("before"); raw != "" {
+		before, err := parseTimeParam(raw, now)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Parâmetro before inválido", err)
+			return
+		}
+		filter.Before = before
+	}
+	if raw := query.Get("after"); raw != "" {
+		after, err := parseTimeParam(raw, now)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Parâmetro after inválido", err)
+			return
+		}
+		filter.After = &after
+	}
+	if raw := query.Get("include_done"); raw != "" {
+		includeDone, err := strconv.ParseBool(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Parâmetro include_done inválido", err)
+			return
+		}
+		filter.IncludeDone = includeDone
+	}
+	if raw := query.Get("priority"); raw != "" {
+		priority, err := task.ParsePriority(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Prioridade inválida", err)
+			return
+		}
+		filter.Priority = &priority
+	}
+
+	dueTasks, err := h.taskService.GetDue(r.Context(), filter)
+	if err != nil {
+		if err == ErrInvalidInput {
+			respondError(w, http.StatusBadRequest, "Janela de tempo inválida", err)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Erro ao buscar tarefas vencidas", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, dueTasks)
+}
+
+func parseTimeParam(raw string, now time.Time) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, raw); err == nil {
+		return t, nil
+	}
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return now.Add(d), nil
+}
 
 func respondJSON(w http.ResponseWriter, status int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+)
+
+// @Summary     O que fazer agora
+// @Description Ordena as tarefas em aberto pela pontuação do advisor (prioridade, prazo, lembrete, idade, bloqueio e progresso das subtarefas) e devolve as primeiras, cada uma com os pontos de cada fator e a explicação da posição. Os pesos padrão vêm de TASK_ADVICE_WEIGHTS; weights troca alguns só nesta consulta
+// @Tags        Advice
+// @Produce     json
+// @Param       limit   query int    false "Máximo de sugestões (padrão 5, máximo 500)"
+// @Param       weights query string false "Pesos desta consulta (ex.: due=5,age=0)"
+// @Success     200 {array} task.Advice
+// @Failure     400 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /advice [get]
+func (h *TaskHandler) GetAdvice(w http.ResponseWriter, r *http.Request) {
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Parâmetro limit inválido", err)
+			return
+		}
+		limit = parsed
+	}
+
+	advice, err := h.taskService.Advice(r.Context(), limit, r.URL.Query().Get("weights"))
+	if err != nil {
+		switch err {
+		case ErrInvalidInput:
+			respondError(w, http.StatusBadRequest, "Informe um limit entre 1 e 500", nil)
+		case ErrInvalidWeights:
+			respondError(w, http.StatusBadRequest, "Parâmetro weights inválido (ex.: due=5,age=0)", err)
+		default:
+			respondError(w, http.StatusInternalServerError, "Erro ao montar as sugestões", err)
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, advice)
+}
@@ -0,0 +1,149 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+type AddDependencyRequest struct {
+	DependsOn string `json:"depends_on" example:"8f3edff7-5d4f-4a51-9d43-6f2c8a1f0b21"`
+}
+
+// respondDependencyError traduz os erros de dependência do Service; devolve
+// false se err não é um deles.
+func respondDependencyError(w http.ResponseWriter, err error) bool {
+	switch err {
+	case ErrTaskNotFound:
+		respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
+	case ErrDependencyNotFound:
+		respondError(w, http.StatusNotFound, "Dependência não encontrada", nil)
+	case ErrDependencyCycle:
+		respondError(w, http.StatusConflict, "A dependência fecharia um ciclo entre as tarefas", nil)
+	default:
+		return false
+	}
+	return true
+}
+
+// warnOpenBlockers avisa, no header Warning, que a tarefa foi concluída com
+// dependências em aberto (política warn).
+func warnOpenBlockers(w http.ResponseWriter, t *models.Task) {
+	if t.Done && len(t.BlockedBy) > 0 {
+		w.Header().Set("Warning", `199 - "tarefa concluída com dependências em aberto: `+strings.Join(t.BlockedBy, ", ")+`"`)
+	}
+}
+
+// @Summary     Listar dependências de uma tarefa
+// @Description Retorna as tarefas de que ela depende (depends_on) e as que dependem dela (blocks). As que estão na lixeira ficam de fora
+// @Tags        Dependencies
+// @Produce     json
+// @Param       id path string true "ID da tarefa"
+// @Success     200 {object} TaskDependencies
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/{id}/dependencies [get]
+func (h *TaskHandler) ListDependencies(w http.ResponseWriter, r *http.Request) {
+	deps, err := h.taskService.Dependencies(r.Context(), chi.URLParam(r, "id"))
+	if err != nil {
+		if !respondDependencyError(w, err) {
+			respondError(w, http.StatusInternalServerError, "Erro ao listar dependências", err)
+		}
+		return
+	}
+	respondJSON(w, http.StatusOK, deps)
+}
+
+// @Summary     Adicionar dependência
+// @Description A tarefa passa a depender de depends_on: fica bloqueada até ela ser concluída. Adicionar de novo não é erro; fechar um ciclo é recusado (409)
+// @Tags        Dependencies
+// @Accept      json
+// @Produce     json
+// @Param       id   path string               true "ID da tarefa"
+// @Param       body body AddDependencyRequest true "Tarefa de que ela depende"
+// @Success     200 {object} models.Task
+// @Header      200 {string} ETag "Nova versão da tarefa"
+// @Failure     400 {object} ErrorResponse
+// @Failure     404 {object} ErrorResponse
+// @Failure     409 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/{id}/dependencies [post]
+func (h *TaskHandler) AddDependency(w http.ResponseWriter, r *http.Request) {
+	var req AddDependencyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "JSON inválido", err)
+		return
+	}
+	if strings.TrimSpace(req.DependsOn) == "" {
+		respondError(w, http.StatusBadRequest, "depends_on é obrigatório", nil)
+		return
+	}
+
+	updated, err := h.taskService.AddDependency(r.Context(), chi.URLParam(r, "id"), strings.TrimSpace(req.DependsOn))
+	if err != nil {
+		if !respondDependencyError(w, err) {
+			respondError(w, http.StatusInternalServerError, "Erro ao adicionar dependência", err)
+		}
+		return
+	}
+	setTaskETag(w, updated)
+	respondJSON(w, http.StatusOK, updated)
+}
+
+// @Summary     Remover dependência
+// @Tags        Dependencies
+// @Produce     json
+// @Param       id          path string true "ID da tarefa"
+// @Param       dependsOnID path string true "ID da tarefa de que ela depende"
+// @Success     200 {object} models.Task
+// @Header      200 {string} ETag "Nova versão da tarefa"
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/{id}/dependencies/{dependsOnID} [delete]
+func (h *TaskHandler) RemoveDependency(w http.ResponseWriter, r *http.Request) {
+	updated, err := h.taskService.RemoveDependency(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "dependsOnID"))
+	if err != nil {
+		if !respondDependencyError(w, err) {
+			respondError(w, http.StatusInternalServerError, "Erro ao remover dependência", err)
+		}
+		return
+	}
+	setTaskETag(w, updated)
+	respondJSON(w, http.StatusOK, updated)
+}
+
+// @Summary     Listar tarefas prontas
+// @Description Igual a GET /tasks, restrito às tarefas em aberto sem dependências em aberto: aceita os mesmos filtros, ordenação e paginação
+// @Tags        Dependencies
+// @Produce     json
+// @Param       priority   query string false "Filtra por prioridade" Enums(low, medium, high)
+// @Param       root       query bool   false "Só tarefas sem pai"
+// @Param       project_id query string false "Só tarefas do projeto"
+// @Param       tag        query []string false "Só tarefas com a tag; '-' na frente exclui" collectionFormat(multi)
+// @Param       sort       query string false "Ordenação; '-' na frente para decrescente (padrão -created_at)"
+// @Param       limit      query int    false "Tamanho da página (máximo 500). Sem limit nem cursor, lista todas; só com cursor, o padrão é 50"
+// @Param       cursor     query string false "Cursor da próxima página (X-Next-Cursor)"
+// @Success     200 {array} models.Task
+// @Header      200 {string} ETag "Resumo das versões da lista"
+// @Header      200 {string} X-Next-Cursor "Cursor da próxima página; ausente na última"
+// @Failure     400 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/ready [get]
+func (h *TaskHandler) ListReadyTasks(w http.ResponseWriter, r *http.Request) {
+	query, err := parseListQuery(r, time.Now())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Parâmetros de listagem inválidos", err)
+		return
+	}
+
+	page, err := h.taskService.ReadyPage(r.Context(), query, r.URL.Query().Get("cursor"))
+	if err != nil {
+		respondListError(w, err)
+		return
+	}
+	respondPage(w, r, page)
+}
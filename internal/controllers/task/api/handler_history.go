@@ -0,0 +1,43 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
+)
+
+// AuditSource registra o ID da requisição (do middleware.RequestID) como
+// origem das alterações feitas por ela. Deve vir depois do RequestID.
+func AuditSource(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		source := task.APISource(middleware.GetReqID(r.Context()))
+		next.ServeHTTP(w, r.WithContext(task.WithSource(r.Context(), source)))
+	})
+}
+
+// @Summary     Histórico da tarefa
+// @Description Retorna as mudanças registradas da tarefa, campo a campo, da mais antiga para a mais recente
+// @Tags        Tasks
+// @Produce     json
+// @Param       id path string true "ID da tarefa"
+// @Success     200 {array} models.TaskChange
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/{id}/history [get]
+func (h *TaskHandler) TaskHistory(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	changes, err := h.taskService.History(r.Context(), id)
+	if err != nil {
+		if err == ErrTaskNotFound {
+			respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Erro ao buscar histórico", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, changes)
+}
@@ -0,0 +1,99 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+)
+
+// parseListQuery monta a task.ListQuery a partir dos parâmetros de GET /tasks.
+func parseListQuery(r *http.Request, now time.Time) (task.ListQuery, error) {
+	params := r.URL.Query()
+	var query task.ListQuery
+
+	boolParam := func(name string, dest *bool) error {
+		if raw := params.Get(name); raw != "" {
+			parsed, err := strconv.ParseBool(raw)
+			if err != nil {
+				return fmt.Errorf("parâmetro %s inválido", name)
+			}
+			*dest = parsed
+		}
+		return nil
+	}
+	timeParam := func(name string) (*time.Time, error) {
+		raw := params.Get(name)
+		if raw == "" {
+			return nil, nil
+		}
+		parsed, err := parseTimeParam(raw, now)
+		if err != nil {
+			return nil, fmt.Errorf("parâmetro %s inválido", name)
+		}
+		return &parsed, nil
+	}
+
+	if params.Has("done") {
+		var done bool
+		if err := boolParam("done", &done); err != nil {
+			return query, err
+		}
+		query.Done = &done
+	}
+	if err := boolParam("root", &query.RootOnly); err != nil {
+		return query, err
+	}
+	if raw := params.Get("priority"); raw != "" {
+		priority, err := task.ParsePriority(raw)
+		if err != nil {
+			return query, fmt.Errorf("parâmetro priority inválido")
+		}
+		query.Priority = &priority
+	}
+	for _, raw := range params["status"] {
+		status, err := task.ParseStatus(raw)
+		if err != nil {
+			return query, fmt.Errorf("parâmetro status inválido")
+		}
+		query.Statuses = append(query.Statuses, status)
+	}
+	if raw := params.Get("parent_id"); raw != "" {
+		query.ParentID = &raw
+	}
+	if raw := params.Get("project_id"); raw != "" {
+		query.ProjectID = &raw
+	}
+	tags, err := task.ParseTagFilter(params["tag"])
+	if err != nil {
+		return query, fmt.Errorf("parâmetro tag inválido")
+	}
+	query.Tags = tags
+
+	if query.ReminderFrom, err = timeParam("reminder_from"); err != nil {
+		return query, err
+	}
+	if query.ReminderTo, err = timeParam("reminder_to"); err != nil {
+		return query, err
+	}
+	if query.CreatedFrom, err = timeParam("created_from"); err != nil {
+		return query, err
+	}
+	if query.CreatedTo, err = timeParam("created_to"); err != nil {
+		return query, err
+	}
+
+	if raw := params.Get("sort"); raw != "" {
+		if query.Sort, query.Ascending, err = task.ParseSort(raw); err != nil {
+			return query, fmt.Errorf("parâmetro sort inválido")
+		}
+	}
+	if raw := params.Get("limit"); raw != "" {
+		if query.Limit, err = strconv.Atoi(raw); err != nil {
+			return query, fmt.Errorf("parâmetro limit inválido")
+		}
+	}
+	return query, nil
+}
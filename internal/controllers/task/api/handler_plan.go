@@ -0,0 +1,79 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+)
+
+type AcceptPlanRequest struct {
+	Blocks []task.PlanSlot `json:"blocks"`
+}
+
+// @Summary     Agenda do dia
+// @Description Encaixa as tarefas em aberto no horário de trabalho (TASK_WORK_HOURS) do dia, na ordem do advisor. Tarefas com lembrete no dia ficam presas a ele; as sem estimate_minutes levam TASK_DEFAULT_ESTIMATE; bloqueadas e as que não cabem vêm em unscheduled
+// @Tags        Plan
+// @Produce     json
+// @Param       date query string false "Dia a planejar: 2006-01-02, 02/01/2006, today ou tomorrow (padrão hoje)"
+// @Success     200 {object} task.Plan
+// @Failure     400 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /plan [get]
+func (h *TaskHandler) GetPlan(w http.ResponseWriter, r *http.Request) {
+	day, err := task.ParsePlanDate(r.URL.Query().Get("date"), time.Now())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Parâmetro date inválido (use 2006-01-02 a partir de hoje)", err)
+		return
+	}
+
+	plan, err := h.taskService.Plan(r.Context(), day)
+	if err != nil {
+		if err == ErrInvalidPlanDate {
+			respondError(w, http.StatusBadRequest, "Parâmetro date inválido (use 2006-01-02 a partir de hoje)", err)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Erro ao montar a agenda", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, plan)
+}
+
+// @Summary     Aceitar a agenda
+// @Description Leva o lembrete de cada tarefa para o início do seu bloco. Envie os blocks devolvidos por GET /plan (ou só os escolhidos); nenhuma tarefa é alterada se alguma não puder ser
+// @Tags        Plan
+// @Accept      json
+// @Produce     json
+// @Param       body body AcceptPlanRequest true "Blocos aceitos (task_id e start)"
+// @Success     200 {array} models.Task "Tarefas cujo lembrete mudou"
+// @Failure     400 {object} ErrorResponse
+// @Failure     404 {object} ErrorResponse
+// @Failure     409 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /plan/accept [post]
+func (h *TaskHandler) AcceptPlan(w http.ResponseWriter, r *http.Request) {
+	var req AcceptPlanRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "JSON inválido", err)
+		return
+	}
+
+	updated, err := h.taskService.AcceptPlan(r.Context(), req.Blocks)
+	if err != nil {
+		switch err {
+		case ErrInvalidInput:
+			respondError(w, http.StatusBadRequest, "Informe os blocks com task_id e start", nil)
+		case ErrTaskNotFound:
+			respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
+		case ErrTaskAlreadyDone:
+			respondError(w, http.StatusConflict, "A agenda tem tarefa concluída ou cancelada", nil)
+		default:
+			respondError(w, http.StatusInternalServerError, "Erro ao aceitar a agenda", err)
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, updated)
+}
@@ -0,0 +1,229 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+type CreateProjectRequest struct {
+	Name                  string `json:"name" example:"homelab"`
+	Description           string `json:"description,omitempty" example:"Servidores e rede de casa"`
+	DefaultPriority       string `json:"default_priority,omitempty" example:"medium" enums:"low,medium,high"`
+	DefaultReminderOffset string `json:"default_reminder_offset,omitempty" example:"1d"`
+}
+
+type PatchProjectRequest struct {
+	Name                  *string `json:"name,omitempty" example:"homelab"`
+	Description           *string `json:"description,omitempty"`
+	Archived              *bool   `json:"archived,omitempty"`
+	DefaultPriority       *string `json:"default_priority,omitempty" example:"high" enums:"low,medium,high"`
+	DefaultReminderOffset *string `json:"default_reminder_offset,omitempty" example:"2h"`
+}
+
+// respondProjectError traduz os erros de projeto do Service; devolve false se
+// err não é um deles.
+func respondProjectError(w http.ResponseWriter, err error) bool {
+	switch err {
+	case ErrProjectNotFound:
+		respondError(w, http.StatusNotFound, "Projeto não encontrado", nil)
+	case ErrProjectExists:
+		respondError(w, http.StatusConflict, "Já existe um projeto com esse nome", nil)
+	case ErrInvalidProject:
+		respondError(w, http.StatusBadRequest, "Projeto inválido", err)
+	default:
+		return false
+	}
+	return true
+}
+
+// @Summary     Listar projetos
+// @Description Retorna os projetos em ordem alfabética, com as contagens de tarefas. Os arquivados só vêm com archived=true
+// @Tags        Projects
+// @Produce     json
+// @Param       archived query bool false "Incluir os projetos arquivados"
+// @Success     200 {array} models.Project
+// @Failure     400 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /projects [get]
+func (h *TaskHandler) ListProjects(w http.ResponseWriter, r *http.Request) {
+	includeArchived := false
+	if raw := r.URL.Query().Get("archived"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Parâmetro archived inválido", err)
+			return
+		}
+		includeArchived = parsed
+	}
+
+	projects, err := h.taskService.ListProjects(r.Context(), includeArchived)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Erro ao listar projetos", err)
+		return
+	}
+	respondJSON(w, http.StatusOK, projects)
+}
+
+// @Summary     Criar projeto
+// @Description Cria um projeto. A prioridade e o lembrete padrão (relativo à criação, ex.: 1d) valem para as tarefas novas que chegam sem eles
+// @Tags        Projects
+// @Accept      json
+// @Produce     json
+// @Param       project body CreateProjectRequest true "Dados do projeto"
+// @Success     201 {object} models.Project
+// @Failure     400 {object} ErrorResponse
+// @Failure     409 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /projects [post]
+func (h *TaskHandler) CreateProject(w http.ResponseWriter, r *http.Request) {
+	var req CreateProjectRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "JSON inválido", err)
+		return
+	}
+
+	project, err := h.taskService.CreateProject(r.Context(), models.Project{
+		Name:                  req.Name,
+		Description:           req.Description,
+		DefaultPriority:       models.Priority(req.DefaultPriority),
+		DefaultReminderOffset: req.DefaultReminderOffset,
+	})
+	if err != nil {
+		if !respondProjectError(w, err) {
+			respondError(w, http.StatusInternalServerError, "Erro ao criar projeto", err)
+		}
+		return
+	}
+	respondJSON(w, http.StatusCreated, project)
+}
+
+// @Summary     Buscar projeto
+// @Tags        Projects
+// @Produce     json
+// @Param       projectID path string true "ID do projeto"
+// @Success     200 {object} models.Project
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /projects/{projectID} [get]
+func (h *TaskHandler) GetProject(w http.ResponseWriter, r *http.Request) {
+	project, err := h.taskService.GetProject(r.Context(), chi.URLParam(r, "projectID"))
+	if err != nil {
+		if !respondProjectError(w, err) {
+			respondError(w, http.StatusInternalServerError, "Erro ao buscar projeto", err)
+		}
+		return
+	}
+	respondJSON(w, http.StatusOK, project)
+}
+
+// @Summary     Atualizar projeto
+// @Description Altera os dados do projeto. archived=true arquiva: o projeto sai da listagem padrão e não recebe tarefas novas. Valores vazios em default_priority e default_reminder_offset tiram o padrão
+// @Tags        Projects
+// @Accept      json
+// @Produce     json
+// @Param       projectID path string              true "ID do projeto"
+// @Param       project   body PatchProjectRequest true "Campos para atualizar"
+// @Success     200 {object} models.Project
+// @Failure     400 {object} ErrorResponse
+// @Failure     404 {object} ErrorResponse
+// @Failure     409 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /projects/{projectID} [patch]
+func (h *TaskHandler) PatchProject(w http.ResponseWriter, r *http.Request) {
+	var req PatchProjectRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "JSON inválido", err)
+		return
+	}
+
+	changes := map[string]any{}
+	if req.Name != nil {
+		changes["name"] = *req.Name
+	}
+	if req.Description != nil {
+		changes["description"] = *req.Description
+	}
+	if req.Archived != nil {
+		changes["archived"] = *req.Archived
+	}
+	if req.DefaultPriority != nil {
+		changes["default_priority"] = *req.DefaultPriority
+	}
+	if req.DefaultReminderOffset != nil {
+		changes["default_reminder_offset"] = *req.DefaultReminderOffset
+	}
+	if len(changes) == 0 {
+		respondError(w, http.StatusBadRequest, "Nenhum campo para atualizar", nil)
+		return
+	}
+
+	project, err := h.taskService.PatchProject(r.Context(), chi.URLParam(r, "projectID"), changes)
+	if err != nil {
+		if !respondProjectError(w, err) {
+			respondError(w, http.StatusInternalServerError, "Erro ao atualizar projeto", err)
+		}
+		return
+	}
+	respondJSON(w, http.StatusOK, project)
+}
+
+// @Summary     Apagar projeto
+// @Description Apaga o projeto. As tarefas dele continuam existindo, sem projeto
+// @Tags        Projects
+// @Param       projectID path string true "ID do projeto"
+// @Success     204 "Projeto apagado"
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /projects/{projectID} [delete]
+func (h *TaskHandler) DeleteProject(w http.ResponseWriter, r *http.Request) {
+	if err := h.taskService.DeleteProject(r.Context(), chi.URLParam(r, "projectID")); err != nil {
+		if !respondProjectError(w, err) {
+			respondError(w, http.StatusInternalServerError, "Erro ao apagar projeto", err)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary     Listar tarefas do projeto
+// @Description Igual a GET /tasks, restrito às tarefas do projeto: aceita os mesmos filtros, ordenação e paginação
+// @Tags        Projects
+// @Produce     json
+// @Param       projectID path  string true  "ID do projeto"
+// @Param       done      query bool   false "Filtra por concluída"
+// @Param       priority  query string false "Filtra por prioridade" Enums(low, medium, high)
+// @Param       root      query bool   false "Só tarefas sem pai"
+// @Param       tag       query []string false "Só tarefas com a tag; '-' na frente exclui" collectionFormat(multi)
+// @Param       sort      query string false "Ordenação; '-' na frente para decrescente (padrão -created_at)"
+// @Param       limit     query int    false "Tamanho da página (máximo 500). Sem limit nem cursor, lista todas; só com cursor, o padrão é 50"
+// @Param       cursor    query string false "Cursor da próxima página (X-Next-Cursor)"
+// @Success     200 {array} models.Task
+// @Header      200 {string} ETag "Resumo das versões da lista"
+// @Header      200 {string} X-Next-Cursor "Cursor da próxima página; ausente na última"
+// @Failure     400 {object} ErrorResponse
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /projects/{projectID}/tasks [get]
+func (h *TaskHandler) ListProjectTasks(w http.ResponseWriter, r *http.Request) {
+	query, err := parseListQuery(r, time.Now())
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Parâmetros de listagem inválidos", err)
+		return
+	}
+
+	page, err := h.taskService.ListProjectTasks(r.Context(), chi.URLParam(r, "projectID"), query, r.URL.Query().Get("cursor"))
+	if err != nil {
+		if !respondProjectError(w, err) {
+			respondListError(w, err)
+		}
+		return
+	}
+	respondPage(w, r, page)
+}
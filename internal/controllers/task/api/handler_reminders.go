@@ -0,0 +1,203 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/notify"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+type ReminderRequest struct {
+	At      *time.Time `json:"at,omitempty" example:"2025-12-27T14:00:00Z"`
+	Offset  string     `json:"offset,omitempty" example:"-1h"`
+	Anchor  string     `json:"anchor,omitempty" enums:"reminder,due" example:"due"`
+	Channel string     `json:"channel,omitempty" example:"ntfy"`
+}
+
+type PatchReminderRequest struct {
+	At      *time.Time `json:"at,omitempty"`
+	Offset  *string    `json:"offset,omitempty" example:"-1d"`
+	Anchor  *string    `json:"anchor,omitempty" enums:"reminder,due"`
+	Channel *string    `json:"channel,omitempty" example:"email"`
+}
+
+func (r ReminderRequest) toModel() (models.Reminder, error) {
+	channel, err := parseChannel(r.Channel)
+	if err != nil {
+		return models.Reminder{}, err
+	}
+	return models.Reminder{
+		At:      r.At,
+		Offset:  strings.TrimSpace(r.Offset),
+		Anchor:  strings.TrimSpace(r.Anchor),
+		Channel: channel,
+	}, nil
+}
+
+func parseChannel(input string) (string, error) {
+	channels, err := notify.ParseChannels([]string{input})
+	if err != nil || len(channels) == 0 {
+		return "", err
+	}
+	return channels[0], nil
+}
+
+// @Summary     Listar lembretes de uma tarefa
+// @Description Retorna os lembretes da tarefa ordenados pelo horário de disparo
+// @Tags        Reminders
+// @Produce     json
+// @Param       id path string true "ID da tarefa"
+// @Success     200 {array} models.Reminder
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/{id}/reminders [get]
+func (h *TaskHandler) ListReminders(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	reminders, err := h.taskService.ListReminders(r.Context(), id)
+	if err != nil {
+		if err == ErrTaskNotFound {
+			respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Erro ao listar lembretes", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, reminders)
+}
+
+// @Summary     Adicionar lembrete a uma tarefa
+// @Description Cria um lembrete absoluto (at) ou relativo (offset, ex.: -1d, -1h, 0) ao reminder_at da tarefa ou, com anchor "due", ao prazo (due_at)
+// @Tags        Reminders
+// @Accept      json
+// @Produce     json
+// @Param       id       path string          true "ID da tarefa"
+// @Param       reminder body ReminderRequest true "Dados do lembrete"
+// @Success     201 {object} models.Reminder
+// @Failure     400 {object} ErrorResponse
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/{id}/reminders [post]
+func (h *TaskHandler) CreateReminder(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req ReminderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "JSON inválido", err)
+		return
+	}
+
+	reminder, err := req.toModel()
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Canal de notificação inválido", err)
+		return
+	}
+
+	created, err := h.taskService.AddReminder(r.Context(), id, reminder)
+	if err != nil {
+		switch err {
+		case ErrTaskNotFound:
+			respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
+		case ErrInvalidReminder:
+			respondError(w, http.StatusBadRequest, "Lembrete inválido", err)
+		default:
+			respondError(w, http.StatusInternalServerError, "Erro ao criar lembrete", err)
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, created)
+}
+
+// @Summary     Atualizar lembrete
+// @Description Altera o horário (at, ou offset e anchor) e/ou o canal de um lembrete. Alterar o horário faz o lembrete voltar a ficar pendente
+// @Tags        Reminders
+// @Accept      json
+// @Produce     json
+// @Param       id         path string               true "ID da tarefa"
+// @Param       reminderID path string               true "ID do lembrete"
+// @Param       reminder   body PatchReminderRequest true "Dados para atualização"
+// @Success     200 {object} models.Reminder
+// @Failure     400 {object} ErrorResponse
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/{id}/reminders/{reminderID} [patch]
+func (h *TaskHandler) PatchReminder(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	reminderID := chi.URLParam(r, "reminderID")
+
+	var req PatchReminderRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "JSON inválido", err)
+		return
+	}
+
+	var channel *string
+	if req.Channel != nil {
+		parsed, err := parseChannel(*req.Channel)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Canal de notificação inválido", err)
+			return
+		}
+		channel = &parsed
+	}
+	if req.Offset != nil {
+		offset := strings.TrimSpace(*req.Offset)
+		req.Offset = &offset
+	}
+
+	if req.Anchor != nil {
+		anchor := strings.TrimSpace(*req.Anchor)
+		req.Anchor = &anchor
+	}
+
+	updated, err := h.taskService.UpdateReminder(r.Context(), id, reminderID, req.At, req.Offset, req.Anchor, channel)
+	if err != nil {
+		switch err {
+		case ErrTaskNotFound:
+			respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
+		case ErrReminderNotFound:
+			respondError(w, http.StatusNotFound, "Lembrete não encontrado", nil)
+		case ErrInvalidReminder:
+			respondError(w, http.StatusBadRequest, "Lembrete inválido", err)
+		default:
+			respondError(w, http.StatusInternalServerError, "Erro ao atualizar lembrete", err)
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, updated)
+}
+
+// @Summary     Remover lembrete
+// @Description Remove um lembrete da tarefa
+// @Tags        Reminders
+// @Produce     json
+// @Param       id         path string true "ID da tarefa"
+// @Param       reminderID path string true "ID do lembrete"
+// @Success     204 "Lembrete removido com sucesso"
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/{id}/reminders/{reminderID} [delete]
+func (h *TaskHandler) DeleteReminder(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+	reminderID := chi.URLParam(r, "reminderID")
+
+	if err := h.taskService.DeleteReminder(r.Context(), id, reminderID); err != nil {
+		if err == ErrReminderNotFound {
+			respondError(w, http.StatusNotFound, "Lembrete não encontrado", nil)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Erro ao remover lembrete", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
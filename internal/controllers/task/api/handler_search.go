@@ -0,0 +1,57 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+)
+
+// @Summary     Buscar tarefas
+// @Description Busca textual no título e na descrição (português e inglês). Os resultados vêm ordenados por relevância, com os termos destacados no snippet entre <mark> e </mark>. O resto do snippet vem com o HTML escapado, então pode ser inserido como HTML
+// @Tags        Tasks
+// @Produce     json
+// @Param       q     query string true  "Termos da busca"
+// @Param       limit query int    false "Máximo de resultados (padrão 20, máximo 100)"
+// @Param       tag        query []string false "Só tarefas com a tag; '-' na frente exclui (ex.: tag=homelab&tag=-urgente)" collectionFormat(multi)
+// @Param       project_id query string   false "Só tarefas deste projeto"
+// @Success     200 {array} task.SearchResult
+// @Failure     400 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/search [get]
+func (h *TaskHandler) SearchTasks(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+
+	limit := 0
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Parâmetro limit inválido", err)
+			return
+		}
+		limit = parsed
+	}
+
+	var filter task.SearchFilter
+	tags, err := task.ParseTagFilter(r.URL.Query()["tag"])
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Parâmetro tag inválido", err)
+		return
+	}
+	filter.Tags = tags
+	if raw := r.URL.Query().Get("project_id"); raw != "" {
+		filter.ProjectID = &raw
+	}
+
+	results, err := h.taskService.Search(r.Context(), query, filter, limit)
+	if err != nil {
+		if err == ErrInvalidInput {
+			respondError(w, http.StatusBadRequest, "Informe os termos em q e um limit entre 1 e 100", nil)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Erro ao buscar tarefas", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, results)
+}
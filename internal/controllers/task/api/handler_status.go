@@ -0,0 +1,128 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/go-chi/chi/v5"
+)
+
+type SetStatusRequest struct {
+	Status string `json:"status" example:"in_progress" enums:"todo,in_progress,blocked,waiting,done,cancelled"`
+}
+
+// respondStatusError responde os erros de transição de status; devolve false
+// se err não for um deles.
+func (h *TaskHandler) respondStatusError(w http.ResponseWriter, r *http.Request, id string, err error) bool {
+	switch err {
+	case ErrVersionConflict:
+		h.respondConflict(w, r, id, nil)
+	case ErrInvalidStatus:
+		respondError(w, http.StatusBadRequest, "Status inválido", err)
+	case ErrInvalidTransition:
+		respondError(w, http.StatusConflict, "Transição de status não permitida", err)
+	case ErrTaskNotClosed:
+		respondError(w, http.StatusConflict, "A tarefa não está concluída nem cancelada", nil)
+	case ErrOpenSubtasks:
+		respondError(w, http.StatusConflict, "A tarefa tem subtarefas em aberto", nil)
+	case ErrTaskBlocked:
+		respondError(w, http.StatusConflict, "A tarefa tem dependências em aberto", nil)
+	default:
+		return false
+	}
+	return true
+}
+
+// @Summary     Mudar o status de uma tarefa
+// @Description Leva a tarefa para outro status do fluxo (todo, in_progress, blocked, waiting, done, cancelled). Concluída ou cancelada, a tarefa só volta para todo. Ir para done tem o mesmo efeito de PATCH /tasks/{id}/complete. started_at, completed_at e cancelled_at são preenchidos na transição
+// @Tags        Tasks
+// @Accept      json
+// @Produce     json
+// @Param       id       path   string           true  "ID da tarefa"
+// @Param       If-Match header string           false "ETag lida em GET /tasks/{id}"
+// @Param       body     body   SetStatusRequest true  "Novo status"
+// @Success     200 {object} models.Task
+// @Header      200 {string} ETag "Nova versão da tarefa"
+// @Failure     400 {object} ErrorResponse
+// @Failure     404 {object} ErrorResponse
+// @Failure     409 {object} ErrorResponse
+// @Failure     412 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/{id}/status [patch]
+func (h *TaskHandler) SetTaskStatus(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		respondError(w, http.StatusPreconditionFailed, "If-Match inválido", err)
+		return
+	}
+
+	var req SetStatusRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "JSON inválido", err)
+		return
+	}
+	status, err := task.ParseStatus(req.Status)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Status inválido", err)
+		return
+	}
+
+	updated, err := h.taskService.SetStatus(r.Context(), id, version, status)
+	if err != nil {
+		if h.respondStatusError(w, r, id, err) {
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Erro ao mudar o status", err)
+		return
+	}
+	if updated == nil {
+		respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
+		return
+	}
+
+	setTaskETag(w, updated)
+	warnOpenBlockers(w, updated)
+	respondJSON(w, http.StatusOK, updated)
+}
+
+// @Summary     Reabrir tarefa
+// @Description Volta uma tarefa concluída ou cancelada para todo, limpando completed_at e cancelled_at
+// @Tags        Tasks
+// @Produce     json
+// @Param       id       path   string true  "ID da tarefa"
+// @Param       If-Match header string false "ETag lida em GET /tasks/{id}"
+// @Success     200 {object} models.Task
+// @Header      200 {string} ETag "Nova versão da tarefa"
+// @Failure     404 {object} ErrorResponse
+// @Failure     409 {object} ErrorResponse
+// @Failure     412 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/{id}/reopen [patch]
+func (h *TaskHandler) ReopenTask(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		respondError(w, http.StatusPreconditionFailed, "If-Match inválido", err)
+		return
+	}
+
+	reopened, err := h.taskService.Reopen(r.Context(), id, version)
+	if err != nil {
+		if h.respondStatusError(w, r, id, err) {
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Erro ao reabrir tarefa", err)
+		return
+	}
+	if reopened == nil {
+		respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
+		return
+	}
+
+	setTaskETag(w, reopened)
+	respondJSON(w, http.StatusOK, reopened)
+}
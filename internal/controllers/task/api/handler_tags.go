@@ -0,0 +1,221 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type CreateTagRequest struct {
+	Name  string `json:"name" example:"homelab"`
+	Color string `json:"color,omitempty" example:"cyan" enums:"red,green,yellow,blue,magenta,cyan,white,gray"`
+}
+
+type PatchTagRequest struct {
+	Name  *string `json:"name,omitempty" example:"servidores"`
+	Color *string `json:"color,omitempty" example:"blue" enums:"red,green,yellow,blue,magenta,cyan,white,gray"`
+}
+
+type TagTaskRequest struct {
+	Tags []string `json:"tags" example:"homelab,urgente"`
+}
+
+// respondTagError traduz os erros de tag do Service; devolve false se err não
+// é um deles.
+func respondTagError(w http.ResponseWriter, err error) bool {
+	switch err {
+	case ErrTagNotFound:
+		respondError(w, http.StatusNotFound, "Tag não encontrada", nil)
+	case ErrTaskNotFound:
+		respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
+	case ErrTagExists:
+		respondError(w, http.StatusConflict, "Já existe uma tag com esse nome", nil)
+	case ErrInvalidTag:
+		respondError(w, http.StatusBadRequest, "Tag inválida", err)
+	case ErrInvalidTagColor:
+		respondError(w, http.StatusBadRequest, "Cor inválida", err)
+	default:
+		return false
+	}
+	return true
+}
+
+// @Summary     Listar tags
+// @Description Retorna todas as tags em ordem alfabética
+// @Tags        Tags
+// @Produce     json
+// @Success     200 {array} models.Tag
+// @Failure     500 {object} ErrorResponse
+// @Router      /tags [get]
+func (h *TaskHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.taskService.ListTags(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Erro ao listar tags", err)
+		return
+	}
+	respondJSON(w, http.StatusOK, tags)
+}
+
+// @Summary     Criar tag
+// @Description Cria uma tag. O nome é guardado sem "#" e em minúsculas
+// @Tags        Tags
+// @Accept      json
+// @Produce     json
+// @Param       tag body CreateTagRequest true "Dados da tag"
+// @Success     201 {object} models.Tag
+// @Failure     400 {object} ErrorResponse
+// @Failure     409 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tags [post]
+func (h *TaskHandler) CreateTag(w http.ResponseWriter, r *http.Request) {
+	var req CreateTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "JSON inválido", err)
+		return
+	}
+
+	tag, err := h.taskService.CreateTag(r.Context(), req.Name, req.Color)
+	if err != nil {
+		if !respondTagError(w, err) {
+			respondError(w, http.StatusInternalServerError, "Erro ao criar tag", err)
+		}
+		return
+	}
+	respondJSON(w, http.StatusCreated, tag)
+}
+
+// @Summary     Buscar tag
+// @Tags        Tags
+// @Produce     json
+// @Param       tagID path string true "ID da tag"
+// @Success     200 {object} models.Tag
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tags/{tagID} [get]
+func (h *TaskHandler) GetTag(w http.ResponseWriter, r *http.Request) {
+	tag, err := h.taskService.GetTag(r.Context(), chi.URLParam(r, "tagID"))
+	if err != nil {
+		if !respondTagError(w, err) {
+			respondError(w, http.StatusInternalServerError, "Erro ao buscar tag", err)
+		}
+		return
+	}
+	respondJSON(w, http.StatusOK, tag)
+}
+
+// @Summary     Atualizar tag
+// @Description Renomeia a tag e/ou troca a cor. As tarefas continuam com ela
+// @Tags        Tags
+// @Accept      json
+// @Produce     json
+// @Param       tagID path string          true "ID da tag"
+// @Param       tag   body PatchTagRequest true "Campos para atualizar"
+// @Success     200 {object} models.Tag
+// @Failure     400 {object} ErrorResponse
+// @Failure     404 {object} ErrorResponse
+// @Failure     409 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tags/{tagID} [patch]
+func (h *TaskHandler) PatchTag(w http.ResponseWriter, r *http.Request) {
+	var req PatchTagRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "JSON inválido", err)
+		return
+	}
+
+	changes := map[string]any{}
+	if req.Name != nil {
+		changes["name"] = *req.Name
+	}
+	if req.Color != nil {
+		changes["color"] = *req.Color
+	}
+	if len(changes) == 0 {
+		respondError(w, http.StatusBadRequest, "Nenhum campo para atualizar", nil)
+		return
+	}
+
+	tag, err := h.taskService.PatchTag(r.Context(), chi.URLParam(r, "tagID"), changes)
+	if err != nil {
+		if !respondTagError(w, err) {
+			respondError(w, http.StatusInternalServerError, "Erro ao atualizar tag", err)
+		}
+		return
+	}
+	respondJSON(w, http.StatusOK, tag)
+}
+
+// @Summary     Apagar tag
+// @Description Apaga a tag e a tira de todas as tarefas
+// @Tags        Tags
+// @Param       tagID path string true "ID da tag"
+// @Success     204 "Tag apagada"
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tags/{tagID} [delete]
+func (h *TaskHandler) DeleteTag(w http.ResponseWriter, r *http.Request) {
+	if err := h.taskService.DeleteTag(r.Context(), chi.URLParam(r, "tagID")); err != nil {
+		if !respondTagError(w, err) {
+			respondError(w, http.StatusInternalServerError, "Erro ao apagar tag", err)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// @Summary     Colocar tags numa tarefa
+// @Description Vincula as tags à tarefa pelo nome; as que não existem são criadas
+// @Tags        Tags
+// @Accept      json
+// @Produce     json
+// @Param       id   path string         true "ID da tarefa"
+// @Param       body body TagTaskRequest true "Nomes das tags"
+// @Success     200 {object} models.Task
+// @Header      200 {string} ETag "Nova versão da tarefa"
+// @Failure     400 {object} ErrorResponse
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/{id}/tags [post]
+func (h *TaskHandler) TagTask(w http.ResponseWriter, r *http.Request) {
+	var req TagTaskRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		respondError(w, http.StatusBadRequest, "JSON inválido", err)
+		return
+	}
+
+	tagged, err := h.taskService.TagTask(r.Context(), chi.URLParam(r, "id"), req.Tags)
+	if err != nil {
+		if !respondTagError(w, err) {
+			respondError(w, http.StatusInternalServerError, "Erro ao colocar tags", err)
+		}
+		return
+	}
+	setTaskETag(w, tagged)
+	respondJSON(w, http.StatusOK, tagged)
+}
+
+// @Summary     Tirar tag de uma tarefa
+// @Tags        Tags
+// @Produce     json
+// @Param       id  path string true "ID da tarefa"
+// @Param       tag path string true "Nome da tag"
+// @Success     200 {object} models.Task
+// @Header      200 {string} ETag "Nova versão da tarefa"
+// @Failure     400 {object} ErrorResponse
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/{id}/tags/{tag} [delete]
+func (h *TaskHandler) UntagTask(w http.ResponseWriter, r *http.Request) {
+	untagged, err := h.taskService.UntagTask(r.Context(), chi.URLParam(r, "id"), chi.URLParam(r, "tag"))
+	if err != nil {
+		if !respondTagError(w, err) {
+			respondError(w, http.StatusInternalServerError, "Erro ao tirar tag", err)
+		}
+		return
+	}
+	setTaskETag(w, untagged)
+	respondJSON(w, http.StatusOK, untagged)
+}
@@ -7,62 +7,53 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task/repository"
 	"github.com/andre-felipe-wonsik-alves/internal/models"
 	"github.com/go-chi/chi/v5"
+	"github.com/go-chi/chi/v5/middleware"
 )
 
-type stubStore struct {
-	createFn func(ctx context.Context, task *models.Task) error
-	getFn    func(ctx context.Context, id string) (*models.Task, error)
-	listFn   func(ctx context.Context) ([]models.Task, error)
-	patchFn  func(ctx context.Context, id string, changes map[string]any) (*models.Task, error)
-	deleteFn func(ctx context.Context, id string) error
-
-	lastCreated     *models.Task
-	lastPatchID     string
-	lastPatchChange map[string]any
+// brokenStore simula o banco fora do ar para as escritas e listagens; as
+// leituras por ID continuam funcionando.
+type brokenStore struct {
+	*repository.MemoryStore
 }
 
-func (s *stubStore) Create(ctx context.Context, task *models.Task) error {
-	s.lastCreated = task
-	if s.createFn != nil {
-		return s.createFn(ctx, task)
-	}
-	return nil
+var errDatabaseDown = errors.New("db down")
+
+func (s brokenStore) List(ctx context.Context, query task.ListQuery) ([]models.Task, error) {
+	return nil, errDatabaseDown
 }
 
-func (s *stubStore) GetByID(ctx context.Context, id string) (*models.Task, error) {
-	if s.getFn != nil {
-		return s.getFn(ctx, id)
-	}
-	return nil, errors.New("not implemented")
+func (s brokenStore) Patch(ctx context.Context, id string, changes map[string]any) (*models.Task, error) {
+	return nil, errDatabaseDown
 }
 
-func (s *stubStore) List(ctx context.Context) ([]models.Task, error) {
-	if s.listFn != nil {
-		return s.listFn(ctx)
-	}
-	return nil, errors.New("not implemented")
+func (s brokenStore) PatchIfVersion(ctx context.Context, id string, version int64, changes map[string]any) (*models.Task, error) {
+	return nil, errDatabaseDown
 }
 
-func (s *stubStore) Patch(ctx context.Context, id string, changes map[string]any) (*models.Task, error) {
-	s.lastPatchID = id
-	s.lastPatchChange = changes
-	if s.patchFn != nil {
-		return s.patchFn(ctx, id, changes)
-	}
-	return nil, nil
+func (s brokenStore) Delete(ctx context.Context, id string) error {
+	return errDatabaseDown
 }
 
-func (s *stubStore) Delete(ctx context.Context, id string) error {
-	if s.deleteFn != nil {
-		return s.deleteFn(ctx, id)
+// createTask grava uma tarefa direto no store, para os testes de handler.
+func createTask(t *testing.T, store Store, task models.Task) *models.Task {
+	t.Helper()
+	if task.Priority == "" {
+		task.Priority = models.PriorityLow
+	}
+	created, err := NewService(store).CreateTask(context.Background(), task)
+	if err != nil {
+		t.Fatalf("create task: %v", err)
 	}
-	return nil
+	return created
 }
 
 func newRequestWithID(method, path, id string, body *bytes.Reader) *http.Request {
@@ -83,11 +74,8 @@ func decodeError(t *testing.T, rec *httptest.ResponseRecorder) ErrorResponse {
 
 func TestTaskHandler_ListTasks(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
-		store := &stubStore{
-			listFn: func(ctx context.Context) ([]models.Task, error) {
-				return []models.Task{{ID: "1", Title: "A"}}, nil
-			},
-		}
+		store := repository.NewMemoryStore()
+		created := createTask(t, store, models.Task{Title: "A"})
 		handler := NewTaskHandler(NewService(store))
 
 		rec := httptest.NewRecorder()
@@ -103,18 +91,13 @@ func TestTaskHandler_ListTasks(t *testing.T) {
 		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
 			t.Fatalf("failed to decode response: %v", err)
 		}
-		if len(got) != 1 || got[0].ID != "1" {
+		if len(got) != 1 || got[0].ID != created.ID {
 			t.Fatalf("unexpected tasks: %+v", got)
 		}
 	})
 
 	t.Run("error", func(t *testing.T) {
-		store := &stubStore{
-			listFn: func(ctx context.Context) ([]models.Task, error) {
-				return nil, errors.New("db down")
-			},
-		}
-		handler := NewTaskHandler(NewService(store))
+		handler := NewTaskHandler(NewService(brokenStore{repository.NewMemoryStore()}))
 
 		rec := httptest.NewRecorder()
 		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
@@ -133,12 +116,7 @@ func TestTaskHandler_ListTasks(t *testing.T) {
 
 func TestTaskHandler_ListSubtasks(t *testing.T) {
 	t.Run("not found", func(t *testing.T) {
-		store := &stubStore{
-			getFn: func(ctx context.Context, id string) (*models.Task, error) {
-				return nil, nil
-			},
-		}
-		handler := NewTaskHandler(NewService(store))
+		handler := NewTaskHandler(NewService(repository.NewMemoryStore()))
 
 		rec := httptest.NewRecorder()
 		req := newRequestWithID(http.MethodGet, "/task/1/subtasks", "1", bytes.NewReader(nil))
@@ -155,22 +133,14 @@ func TestTaskHandler_ListSubtasks(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		parentID := "parent-1"
-		store := &stubStore{
-			getFn: func(ctx context.Context, id string) (*models.Task, error) {
-				return &models.Task{
-					ID: parentID,
-					Children: []models.Task{
-						{ID: "child-1", Title: "A"},
-						{ID: "child-2", Title: "B"},
-					},
-				}, nil
-			},
-		}
+		store := repository.NewMemoryStore()
+		parent := createTask(t, store, models.Task{Title: "parent"})
+		first := createTask(t, store, models.Task{Title: "A", ParentID: &parent.ID})
+		second := createTask(t, store, models.Task{Title: "B", ParentID: &parent.ID})
 		handler := NewTaskHandler(NewService(store))
 
 		rec := httptest.NewRecorder()
-		req := newRequestWithID(http.MethodGet, "/task/parent-1/subtasks", parentID, bytes.NewReader(nil))
+		req := newRequestWithID(http.MethodGet, "/task/"+parent.ID+"/subtasks", parent.ID, bytes.NewReader(nil))
 
 		handler.ListSubtasks(rec, req)
 
@@ -181,7 +151,11 @@ func TestTaskHandler_ListSubtasks(t *testing.T) {
 		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
 			t.Fatalf("failed to decode response: %v", err)
 		}
-		if len(got) != 2 || got[0].ID != "child-1" || got[1].ID != "child-2" {
+		ids := map[string]bool{}
+		for _, child := range got {
+			ids[child.ID] = true
+		}
+		if len(got) != 2 || !ids[first.ID] || !ids[second.ID] {
 			t.Fatalf("unexpected subtasks: %+v", got)
 		}
 	})
@@ -189,8 +163,7 @@ func TestTaskHandler_ListSubtasks(t *testing.T) {
 
 func TestTaskHandler_CreateTask(t *testing.T) {
 	t.Run("invalid json", func(t *testing.T) {
-		store := &stubStore{}
-		handler := NewTaskHandler(NewService(store))
+		handler := NewTaskHandler(NewService(repository.NewMemoryStore()))
 
 		rec := httptest.NewRecorder()
 		req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader("{"))
@@ -207,8 +180,7 @@ func TestTaskHandler_CreateTask(t *testing.T) {
 	})
 
 	t.Run("missing title", func(t *testing.T) {
-		store := &stubStore{}
-		handler := NewTaskHandler(NewService(store))
+		handler := NewTaskHandler(NewService(repository.NewMemoryStore()))
 
 		rec := httptest.NewRecorder()
 		req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"title":"","priority":"low"}`))
@@ -225,8 +197,7 @@ func TestTaskHandler_CreateTask(t *testing.T) {
 	})
 
 	t.Run("invalid priority", func(t *testing.T) {
-		store := &stubStore{}
-		handler := NewTaskHandler(NewService(store))
+		handler := NewTaskHandler(NewService(repository.NewMemoryStore()))
 
 		rec := httptest.NewRecorder()
 		req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"title":"A","priority":"invalid"}`))
@@ -243,15 +214,7 @@ func TestTaskHandler_CreateTask(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		store := &stubStore{
-			createFn: func(ctx context.Context, task *models.Task) error {
-				task.ID = "task-1"
-				return nil
-			},
-			getFn: func(ctx context.Context, id string) (*models.Task, error) {
-				return &models.Task{ID: id, Title: "A"}, nil
-			},
-		}
+		store := repository.NewMemoryStore()
 		handler := NewTaskHandler(NewService(store))
 
 		reminder := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
@@ -264,27 +227,23 @@ func TestTaskHandler_CreateTask(t *testing.T) {
 		if rec.Code != http.StatusCreated {
 			t.Fatalf("status = %d, want %d", rec.Code, http.StatusCreated)
 		}
-		if store.lastCreated == nil || store.lastCreated.Priority != models.PriorityHigh {
-			t.Fatalf("priority in create = %v, want %v", store.lastCreated.Priority, models.PriorityHigh)
-		}
 		var got models.Task
 		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
 			t.Fatalf("failed to decode response: %v", err)
 		}
-		if got.ID != "task-1" || got.Title != "A" {
+		if got.ID == "" || got.Title != "A" {
 			t.Fatalf("unexpected task: %+v", got)
 		}
+		stored, err := store.GetByID(context.Background(), got.ID)
+		if err != nil || stored == nil || stored.Priority != models.PriorityHigh {
+			t.Fatalf("stored task = %+v, %v; want priority %v", stored, err, models.PriorityHigh)
+		}
 	})
 }
 
 func TestTaskHandler_GetTask(t *testing.T) {
 	t.Run("not found", func(t *testing.T) {
-		store := &stubStore{
-			getFn: func(ctx context.Context, id string) (*models.Task, error) {
-				return nil, errors.New("missing")
-			},
-		}
-		handler := NewTaskHandler(NewService(store))
+		handler := NewTaskHandler(NewService(repository.NewMemoryStore()))
 
 		rec := httptest.NewRecorder()
 		req := newRequestWithID(http.MethodGet, "/tasks/1", "1", bytes.NewReader(nil))
@@ -301,15 +260,12 @@ func TestTaskHandler_GetTask(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		store := &stubStore{
-			getFn: func(ctx context.Context, id string) (*models.Task, error) {
-				return &models.Task{ID: id, Title: "A"}, nil
-			},
-		}
+		store := repository.NewMemoryStore()
+		created := createTask(t, store, models.Task{Title: "A"})
 		handler := NewTaskHandler(NewService(store))
 
 		rec := httptest.NewRecorder()
-		req := newRequestWithID(http.MethodGet, "/tasks/1", "1", bytes.NewReader(nil))
+		req := newRequestWithID(http.MethodGet, "/tasks/"+created.ID, created.ID, bytes.NewReader(nil))
 
 		handler.GetTask(rec, req)
 
@@ -320,16 +276,15 @@ func TestTaskHandler_GetTask(t *testing.T) {
 		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
 			t.Fatalf("failed to decode response: %v", err)
 		}
-		if got.ID != "1" {
-			t.Fatalf("id = %q, want %q", got.ID, "1")
+		if got.ID != created.ID {
+			t.Fatalf("id = %q, want %q", got.ID, created.ID)
 		}
 	})
 }
 
 func TestTaskHandler_PatchTask(t *testing.T) {
 	t.Run("invalid json", func(t *testing.T) {
-		store := &stubStore{}
-		handler := NewTaskHandler(NewService(store))
+		handler := NewTaskHandler(NewService(repository.NewMemoryStore()))
 
 		rec := httptest.NewRecorder()
 		req := newRequestWithID(http.MethodPatch, "/tasks/1", "1", bytes.NewReader([]byte("{")))
@@ -345,8 +300,7 @@ func TestTaskHandler_PatchTask(t *testing.T) {
 	})
 
 	t.Run("no fields", func(t *testing.T) {
-		store := &stubStore{}
-		handler := NewTaskHandler(NewService(store))
+		handler := NewTaskHandler(NewService(repository.NewMemoryStore()))
 
 		rec := httptest.NewRecorder()
 		req := newRequestWithID(http.MethodPatch, "/tasks/1", "1", bytes.NewReader([]byte(`{}`)))
@@ -362,15 +316,12 @@ func TestTaskHandler_PatchTask(t *testing.T) {
 	})
 
 	t.Run("patch error", func(t *testing.T) {
-		store := &stubStore{
-			patchFn: func(ctx context.Context, id string, changes map[string]any) (*models.Task, error) {
-				return nil, errors.New("boom")
-			},
-		}
+		store := brokenStore{repository.NewMemoryStore()}
+		created := createTask(t, store.MemoryStore, models.Task{Title: "A"})
 		handler := NewTaskHandler(NewService(store))
 
 		rec := httptest.NewRecorder()
-		req := newRequestWithID(http.MethodPatch, "/tasks/1", "1", bytes.NewReader([]byte(`{"title":"A"}`)))
+		req := newRequestWithID(http.MethodPatch, "/tasks/"+created.ID, created.ID, bytes.NewReader([]byte(`{"title":"B"}`)))
 
 		handler.PatchTask(rec, req)
 
@@ -383,12 +334,7 @@ func TestTaskHandler_PatchTask(t *testing.T) {
 	})
 
 	t.Run("not found", func(t *testing.T) {
-		store := &stubStore{
-			patchFn: func(ctx context.Context, id string, changes map[string]any) (*models.Task, error) {
-				return nil, nil
-			},
-		}
-		handler := NewTaskHandler(NewService(store))
+		handler := NewTaskHandler(NewService(repository.NewMemoryStore()))
 
 		rec := httptest.NewRecorder()
 		req := newRequestWithID(http.MethodPatch, "/tasks/1", "1", bytes.NewReader([]byte(`{"title":"A"}`)))
@@ -404,44 +350,39 @@ func TestTaskHandler_PatchTask(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		store := &stubStore{
-			patchFn: func(ctx context.Context, id string, changes map[string]any) (*models.Task, error) {
-				return &models.Task{ID: id, Title: "Updated"}, nil
-			},
-		}
+		store := repository.NewMemoryStore()
+		created := createTask(t, store, models.Task{Title: "A"})
 		handler := NewTaskHandler(NewService(store))
 
 		rec := httptest.NewRecorder()
-		req := newRequestWithID(http.MethodPatch, "/tasks/1", "1", bytes.NewReader([]byte(`{"title":"Updated","priority":"high"}`)))
+		req := newRequestWithID(http.MethodPatch, "/tasks/"+created.ID, created.ID, bytes.NewReader([]byte(`{"title":"Updated","priority":"high"}`)))
 
 		handler.PatchTask(rec, req)
 
 		if rec.Code != http.StatusOK {
 			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
 		}
-		if store.lastPatchID != "1" {
-			t.Fatalf("patch id = %q, want %q", store.lastPatchID, "1")
+		stored, err := store.GetByID(context.Background(), created.ID)
+		if err != nil || stored == nil {
+			t.Fatalf("get patched task: %+v, %v", stored, err)
 		}
-		if store.lastPatchChange["title"] != "Updated" {
-			t.Fatalf("title change = %v, want %v", store.lastPatchChange["title"], "Updated")
+		if stored.Title != "Updated" {
+			t.Fatalf("title = %q, want %q", stored.Title, "Updated")
 		}
-		if store.lastPatchChange["priority"] != "high" {
-			t.Fatalf("priority change = %v, want %v", store.lastPatchChange["priority"], "high")
+		if stored.Priority != models.PriorityHigh {
+			t.Fatalf("priority = %v, want %v", stored.Priority, models.PriorityHigh)
 		}
 	})
 }
 
 func TestTaskHandler_DeleteTask(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
-		store := &stubStore{
-			deleteFn: func(ctx context.Context, id string) error {
-				return errors.New("db down")
-			},
-		}
+		store := brokenStore{repository.NewMemoryStore()}
+		created := createTask(t, store.MemoryStore, models.Task{Title: "A"})
 		handler := NewTaskHandler(NewService(store))
 
 		rec := httptest.NewRecorder()
-		req := newRequestWithID(http.MethodDelete, "/tasks/1", "1", bytes.NewReader(nil))
+		req := newRequestWithID(http.MethodDelete, "/tasks/"+created.ID, created.ID, bytes.NewReader(nil))
 
 		handler.DeleteTask(rec, req)
 
@@ -455,35 +396,32 @@ func TestTaskHandler_DeleteTask(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		store := &stubStore{
-			deleteFn: func(ctx context.Context, id string) error {
-				return nil
-			},
-		}
+		store := repository.NewMemoryStore()
+		created := createTask(t, store, models.Task{Title: "A"})
 		handler := NewTaskHandler(NewService(store))
 
 		rec := httptest.NewRecorder()
-		req := newRequestWithID(http.MethodDelete, "/tasks/1", "1", bytes.NewReader(nil))
+		req := newRequestWithID(http.MethodDelete, "/tasks/"+created.ID, created.ID, bytes.NewReader(nil))
 
 		handler.DeleteTask(rec, req)
 
 		if rec.Code != http.StatusNoContent {
 			t.Fatalf("status = %d, want %d", rec.Code, http.StatusNoContent)
 		}
+		if stored, err := store.GetByID(context.Background(), created.ID); err != nil || stored != nil {
+			t.Fatalf("deleted task = %+v, %v; want nil", stored, err)
+		}
 	})
 }
 
 func TestTaskHandler_CompleteTask(t *testing.T) {
 	t.Run("error", func(t *testing.T) {
-		store := &stubStore{
-			patchFn: func(ctx context.Context, id string, changes map[string]any) (*models.Task, error) {
-				return nil, errors.New("db down")
-			},
-		}
+		store := brokenStore{repository.NewMemoryStore()}
+		created := createTask(t, store.MemoryStore, models.Task{Title: "A"})
 		handler := NewTaskHandler(NewService(store))
 
 		rec := httptest.NewRecorder()
-		req := newRequestWithID(http.MethodPatch, "/tasks/1/complete", "1", bytes.NewReader(nil))
+		req := newRequestWithID(http.MethodPatch, "/tasks/"+created.ID+"/complete", created.ID, bytes.NewReader(nil))
 
 		handler.CompleteTask(rec, req)
 
@@ -497,15 +435,12 @@ func TestTaskHandler_CompleteTask(t *testing.T) {
 	})
 
 	t.Run("success", func(t *testing.T) {
-		store := &stubStore{
-			patchFn: func(ctx context.Context, id string, changes map[string]any) (*models.Task, error) {
-				return &models.Task{ID: id, Done: true}, nil
-			},
-		}
+		store := repository.NewMemoryStore()
+		created := createTask(t, store, models.Task{Title: "A"})
 		handler := NewTaskHandler(NewService(store))
 
 		rec := httptest.NewRecorder()
-		req := newRequestWithID(http.MethodPatch, "/tasks/1/complete", "1", bytes.NewReader(nil))
+		req := newRequestWithID(http.MethodPatch, "/tasks/"+created.ID+"/complete", created.ID, bytes.NewReader(nil))
 
 		handler.CompleteTask(rec, req)
 
@@ -521,3 +456,1396 @@ func TestTaskHandler_CompleteTask(t *testing.T) {
 		}
 	})
 }
+
+func TestTaskHandler_GetDueTasks(t *testing.T) {
+	// due monta tarefas com lembrete relativo a agora e devolve os IDs por
+	// título.
+	due := func(t *testing.T, store *repository.MemoryStore) map[string]string {
+		t.Helper()
+		now := time.Now()
+		ids := map[string]string{}
+		for _, tt := range []struct {
+			title    string
+			in       time.Duration
+			priority models.Priority
+			done     bool
+		}{
+			{"overdue", -time.Hour, models.PriorityHigh, false},
+			{"overdue low", -time.Hour, models.PriorityLow, false},
+			{"overdue done", -2 * time.Hour, models.PriorityHigh, true},
+			{"soon", 30 * time.Minute, models.PriorityHigh, false},
+			{"later", 2 * time.Hour, models.PriorityHigh, false},
+		} {
+			created := createTask(t, store, models.Task{Title: tt.title, Priority: tt.priority, ReminderAt: now.Add(tt.in)})
+			if tt.done {
+				if _, err := NewService(store).Complete(context.Background(), created.ID); err != nil {
+					t.Fatalf("complete: %v", err)
+				}
+			}
+			ids[tt.title] = created.ID
+		}
+		return ids
+	}
+	get := func(t *testing.T, handler *TaskHandler, query string) []string {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler.GetDueTasks(rec, httptest.NewRequest(http.MethodGet, "/tasks/due"+query, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		var got []models.Task
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		ids := make([]string, 0, len(got))
+		for _, tk := range got {
+			ids = append(ids, tk.ID)
+		}
+		return ids
+	}
+
+	t.Run("defaults to overdue open tasks", func(t *testing.T) {
+		store := repository.NewMemoryStore()
+		ids := due(t, store)
+
+		got := get(t, NewTaskHandler(NewService(store)), "")
+
+		if len(got) != 2 || !slices.Contains(got, ids["overdue"]) || !slices.Contains(got, ids["overdue low"]) {
+			t.Fatalf("due tasks = %v, want the two overdue open ones", got)
+		}
+	})
+
+	t.Run("parses window and filters", func(t *testing.T) {
+		store := repository.NewMemoryStore()
+		ids := due(t, store)
+
+		got := get(t, NewTaskHandler(NewService(store)), "?before=1h&after=2025-01-01T00:00:00Z&include_done=true&priority=alta")
+
+		want := []string{ids["overdue done"], ids["overdue"], ids["soon"]}
+		if !slices.Equal(got, want) {
+			t.Fatalf("due tasks = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("invalid params", func(t *testing.T) {
+		for _, query := range []string{"before=amanha", "include_done=talvez", "priority=urgente", "before=-1h&after=1h"} {
+			handler := NewTaskHandler(NewService(repository.NewMemoryStore()))
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/tasks/due?"+query, nil)
+
+			handler.GetDueTasks(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("%s: status = %d, want %d", query, rec.Code, http.StatusBadRequest)
+			}
+		}
+	})
+}
+
+func TestTaskHandler_SnoozeTask(t *testing.T) {
+	t.Run("invalid duration", func(t *testing.T) {
+		handler := NewTaskHandler(NewService(repository.NewMemoryStore()))
+
+		rec := httptest.NewRecorder()
+		req := newRequestWithID(http.MethodPost, "/tasks/1/snooze", "1", bytes.NewReader([]byte(`{"duration":"depois"}`)))
+
+		handler.SnoozeTask(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+		errResp := decodeError(t, rec)
+		if errResp.Error != "Duração inválida" {
+			t.Fatalf("error = %q, want %q", errResp.Error, "Duração inválida")
+		}
+	})
+
+	t.Run("done task", func(t *testing.T) {
+		store := repository.NewMemoryStore()
+		created := createTask(t, store, models.Task{Title: "A"})
+		if _, err := NewService(store).Complete(context.Background(), created.ID); err != nil {
+			t.Fatalf("complete: %v", err)
+		}
+		handler := NewTaskHandler(NewService(store))
+
+		rec := httptest.NewRecorder()
+		req := newRequestWithID(http.MethodPost, "/tasks/"+created.ID+"/snooze", created.ID, bytes.NewReader([]byte(`{"duration":"10m"}`)))
+
+		handler.SnoozeTask(rec, req)
+
+		if rec.Code != http.StatusConflict {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusConflict)
+		}
+	})
+
+	t.Run("success", func(t *testing.T) {
+		ctx := context.Background()
+		store := repository.NewMemoryStore()
+		created := createTask(t, store, models.Task{Title: "A"})
+		if _, err := store.Patch(ctx, created.ID, map[string]any{"snooze_count": 2}); err != nil {
+			t.Fatalf("patch: %v", err)
+		}
+		handler := NewTaskHandler(NewService(store))
+
+		start := time.Now()
+		rec := httptest.NewRecorder()
+		req := newRequestWithID(http.MethodPost, "/tasks/"+created.ID+"/snooze", created.ID, bytes.NewReader([]byte(`{"duration":"1h"}`)))
+
+		handler.SnoozeTask(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		var got models.Task
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if got.ReminderAt.Before(start.Add(time.Hour)) {
+			t.Fatalf("reminder_at = %v, want about one hour from now", got.ReminderAt)
+		}
+		pending, err := store.PendingReminders(ctx, got.ReminderAt)
+		if err != nil || len(pending) != 1 || !pending[0].FireAt.Equal(got.ReminderAt) {
+			t.Fatalf("expected pending reminder at %v, got %+v, %v", got.ReminderAt, pending, err)
+		}
+		if got.SnoozeCount != 3 {
+			t.Fatalf("snooze_count = %d, want 3", got.SnoozeCount)
+		}
+	})
+}
+
+func TestTaskHandler_Trash(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*TaskHandler, *Service, *models.Task, *models.Task) {
+		t.Helper()
+		service := NewService(repository.NewMemoryStore())
+		parent, err := service.CreateTask(ctx, models.Task{Title: "parent", Priority: models.PriorityLow})
+		if err != nil {
+			t.Fatalf("create parent: %v", err)
+		}
+		child, err := service.CreateTask(ctx, models.Task{Title: "child", Priority: models.PriorityLow, ParentID: &parent.ID})
+		if err != nil {
+			t.Fatalf("create child: %v", err)
+		}
+		for _, id := range []string{child.ID, parent.ID} {
+			if err := service.Delete(ctx, id); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+		}
+		return NewTaskHandler(service), service, parent, child
+	}
+
+	t.Run("list", func(t *testing.T) {
+		handler, _, parent, _ := setup(t)
+
+		rec := httptest.NewRecorder()
+		handler.ListTrash(rec, httptest.NewRequest(http.MethodGet, "/trash", nil))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		var trashed []TrashedTask
+		if err := json.NewDecoder(rec.Body).Decode(&trashed); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(trashed) != 2 {
+			t.Fatalf("trash = %d, want 2", len(trashed))
+		}
+		found := false
+		for _, tk := range trashed {
+			if tk.DeletedAt.IsZero() {
+				t.Fatalf("expected deleted_at in response, got %+v", tk)
+			}
+			found = found || tk.ID == parent.ID
+		}
+		if !found {
+			t.Fatalf("expected parent in trash, got %+v", trashed)
+		}
+	})
+
+	t.Run("restore with children", func(t *testing.T) {
+		handler, _, parent, child := setup(t)
+
+		rec := httptest.NewRecorder()
+		req := newRequestWithID(http.MethodPost, "/tasks/"+parent.ID+"/restore?children=true", parent.ID, bytes.NewReader(nil))
+		handler.RestoreTask(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		var restored models.Task
+		if err := json.NewDecoder(rec.Body).Decode(&restored); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(restored.Children) != 1 || restored.Children[0].ID != child.ID {
+			t.Fatalf("expected child restored, got %+v", restored.Children)
+		}
+	})
+
+	t.Run("restore invalid children flag", func(t *testing.T) {
+		handler, _, parent, _ := setup(t)
+
+		rec := httptest.NewRecorder()
+		req := newRequestWithID(http.MethodPost, "/tasks/"+parent.ID+"/restore?children=talvez", parent.ID, bytes.NewReader(nil))
+		handler.RestoreTask(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("restore outside trash", func(t *testing.T) {
+		handler, _, _, _ := setup(t)
+
+		rec := httptest.NewRecorder()
+		req := newRequestWithID(http.MethodPost, "/tasks/missing/restore", "missing", bytes.NewReader(nil))
+		handler.RestoreTask(rec, req)
+
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+		}
+		if errResp := decodeError(t, rec); errResp.Error != "Tarefa não está na lixeira" {
+			t.Fatalf("error = %q", errResp.Error)
+		}
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		handler, service, parent, _ := setup(t)
+
+		rec := httptest.NewRecorder()
+		handler.PurgeTask(rec, newRequestWithID(http.MethodDelete, "/trash/"+parent.ID, parent.ID, bytes.NewReader(nil)))
+		if rec.Code != http.StatusNoContent {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusNoContent)
+		}
+		if trash, _ := service.Trash(ctx); len(trash) != 1 {
+			t.Fatalf("trash = %d, want 1", len(trash))
+		}
+
+		rec = httptest.NewRecorder()
+		handler.PurgeTask(rec, newRequestWithID(http.MethodDelete, "/trash/"+parent.ID, parent.ID, bytes.NewReader(nil)))
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("second purge status = %d, want %d", rec.Code, http.StatusNotFound)
+		}
+	})
+}
+
+func TestTaskHandler_IfMatch(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*TaskHandler, *models.Task) {
+		t.Helper()
+		service := NewService(repository.NewMemoryStore())
+		created, err := service.CreateTask(ctx, models.Task{Title: "A", Priority: models.PriorityLow})
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		return NewTaskHandler(service), created
+	}
+	withIfMatch := func(req *http.Request, etag string) *http.Request {
+		if etag != "" {
+			req.Header.Set("If-Match", etag)
+		}
+		return req
+	}
+
+	t.Run("get sets etag", func(t *testing.T) {
+		handler, created := setup(t)
+
+		rec := httptest.NewRecorder()
+		handler.GetTask(rec, newRequestWithID(http.MethodGet, "/tasks/"+created.ID, created.ID, bytes.NewReader(nil)))
+
+		if got := rec.Header().Get("ETag"); got != `"1"` {
+			t.Fatalf("etag = %q, want %q", got, `"1"`)
+		}
+	})
+
+	t.Run("list etag changes with versions", func(t *testing.T) {
+		handler, created := setup(t)
+
+		list := func() string {
+			rec := httptest.NewRecorder()
+			handler.ListTasks(rec, httptest.NewRequest(http.MethodGet, "/tasks", nil))
+			return rec.Header().Get("ETag")
+		}
+		before := list()
+		if _, err := handler.taskService.Patch(ctx, created.ID, map[string]any{"title": "B"}); err != nil {
+			t.Fatalf("patch: %v", err)
+		}
+		if after := list(); before == "" || before == after {
+			t.Fatalf("list etag should change after a patch: %q -> %q", before, after)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		ifMatch  string
+		call     func(h *TaskHandler, w http.ResponseWriter, r *http.Request)
+		method   string
+		body     string
+		wantCode int
+		wantETag string
+	}{
+		{name: "patch current", ifMatch: `"1"`, call: (*TaskHandler).PatchTask, method: http.MethodPatch, body: `{"title":"B"}`, wantCode: http.StatusOK, wantETag: `"2"`},
+		{name: "patch weak", ifMatch: `W/"1"`, call: (*TaskHandler).PatchTask, method: http.MethodPatch, body: `{"title":"B"}`, wantCode: http.StatusOK, wantETag: `"2"`},
+		{name: "patch without precondition", call: (*TaskHandler).PatchTask, method: http.MethodPatch, body: `{"title":"B"}`, wantCode: http.StatusOK, wantETag: `"2"`},
+		{name: "patch stale", ifMatch: `"7"`, call: (*TaskHandler).PatchTask, method: http.MethodPatch, body: `{"title":"B"}`, wantCode: http.StatusPreconditionFailed, wantETag: `"1"`},
+		{name: "patch invalid", ifMatch: "abc", call: (*TaskHandler).PatchTask, method: http.MethodPatch, body: `{"title":"B"}`, wantCode: http.StatusPreconditionFailed},
+		{name: "complete current", ifMatch: `"1"`, call: (*TaskHandler).CompleteTask, method: http.MethodPatch, wantCode: http.StatusOK, wantETag: `"2"`},
+		{name: "complete stale", ifMatch: `"7"`, call: (*TaskHandler).CompleteTask, method: http.MethodPatch, wantCode: http.StatusPreconditionFailed, wantETag: `"1"`},
+		{name: "delete current", ifMatch: `"1"`, call: (*TaskHandler).DeleteTask, method: http.MethodDelete, wantCode: http.StatusNoContent},
+		{name: "delete stale", ifMatch: `"7"`, call: (*TaskHandler).DeleteTask, method: http.MethodDelete, wantCode: http.StatusPreconditionFailed, wantETag: `"1"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler, created := setup(t)
+
+			rec := httptest.NewRecorder()
+			req := newRequestWithID(tt.method, "/tasks/"+created.ID, created.ID, bytes.NewReader([]byte(tt.body)))
+			tt.call(handler, rec, withIfMatch(req, tt.ifMatch))
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if got := rec.Header().Get("ETag"); got != tt.wantETag {
+				t.Fatalf("etag = %q, want %q", got, tt.wantETag)
+			}
+		})
+	}
+}
+
+func TestTaskHandler_TaskHistory(t *testing.T) {
+	service := NewService(repository.NewMemoryStore())
+	handler := NewTaskHandler(service)
+
+	// A criação passa pelo RequestID e pelo AuditSource, como no router.
+	create := middleware.RequestID(AuditSource(http.HandlerFunc(handler.CreateTask)))
+	rec := httptest.NewRecorder()
+	create.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader([]byte(`{"title":"A","priority":"low"}`))))
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create status = %d (%s)", rec.Code, rec.Body.String())
+	}
+	var created models.Task
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.TaskHistory(rec, newRequestWithID(http.MethodGet, "/tasks/"+created.ID+"/history", created.ID, bytes.NewReader(nil)))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		var changes []map[string]any
+		if err := json.NewDecoder(rec.Body).Decode(&changes); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(changes) == 0 {
+			t.Fatalf("expected history entries")
+		}
+		first := changes[0]
+		if first["field"] != "title" || first["before"] != nil || first["after"] != "A" {
+			t.Fatalf("unexpected first change %+v", first)
+		}
+		if source, _ := first["source"].(string); !strings.HasPrefix(source, "api:") || len(source) <= len("api:") {
+			t.Fatalf("source = %q, want api:<request id>", source)
+		}
+	})
+
+	t.Run("long request id", func(t *testing.T) {
+		// O cabeçalho vem do cliente e não pode estourar task_history.source.
+		req := httptest.NewRequest(http.MethodPost, "/tasks", bytes.NewReader([]byte(`{"title":"B","priority":"low"}`)))
+		req.Header.Set(middleware.RequestIDHeader, strings.Repeat("x", 300)+"\r\n\x00fim")
+		rec := httptest.NewRecorder()
+		create.ServeHTTP(rec, req)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("create status = %d (%s)", rec.Code, rec.Body.String())
+		}
+		var other models.Task
+		if err := json.NewDecoder(rec.Body).Decode(&other); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+
+		history, err := service.History(context.Background(), other.ID)
+		if err != nil {
+			t.Fatalf("history: %v", err)
+		}
+		if len(history) == 0 {
+			t.Fatalf("expected history entries")
+		}
+		source := history[0].Source
+		if len(source) > task.MaxSourceLength || !strings.HasPrefix(source, "api:xxx") {
+			t.Fatalf("source = %q (%d bytes), want api:<request id> within %d bytes", source, len(source), task.MaxSourceLength)
+		}
+		if strings.ContainsAny(source, "\r\n\x00") {
+			t.Fatalf("source %q keeps control characters", source)
+		}
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.TaskHistory(rec, newRequestWithID(http.MethodGet, "/tasks/x/history", "x", bytes.NewReader(nil)))
+
+		if rec.Code != http.StatusNotFound {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusNotFound)
+		}
+	})
+}
+
+func TestTaskHandler_SearchTasks(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	created, err := service.CreateTask(ctx, models.Task{Title: "Comprar café", Description: "no mercado", Priority: models.PriorityLow})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	handler := NewTaskHandler(service)
+
+	tests := []struct {
+		name     string
+		query    string
+		wantCode int
+	}{
+		{name: "success", query: "?q=cafe", wantCode: http.StatusOK},
+		{name: "missing q", query: "", wantCode: http.StatusBadRequest},
+		{name: "invalid limit", query: "?q=cafe&limit=x", wantCode: http.StatusBadRequest},
+		{name: "limit out of range", query: "?q=cafe&limit=1000", wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.SearchTasks(rec, httptest.NewRequest(http.MethodGet, "/tasks/search"+tt.query, nil))
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+
+			var results []task.SearchResult
+			if err := json.NewDecoder(rec.Body).Decode(&results); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if len(results) != 1 || results[0].ID != created.ID {
+				t.Fatalf("unexpected results %+v", results)
+			}
+			if results[0].Snippet != "Comprar <mark>café</mark> no mercado" || results[0].Rank <= 0 {
+				t.Fatalf("unexpected rank/snippet %+v", results[0])
+			}
+		})
+	}
+}
+
+func TestTaskHandler_ListTasksQuery(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	for _, p := range []models.Priority{models.PriorityLow, models.PriorityHigh, models.PriorityMedium} {
+		if _, err := service.CreateTask(ctx, models.Task{Title: string(p), Priority: p}); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+	handler := NewTaskHandler(service)
+
+	list := func(t *testing.T, target string) (*httptest.ResponseRecorder, []models.Task) {
+		t.Helper()
+		rec := httptest.NewRecorder()
+		handler.ListTasks(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		var tasks []models.Task
+		if rec.Code == http.StatusOK {
+			if err := json.NewDecoder(rec.Body).Decode(&tasks); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+		}
+		return rec, tasks
+	}
+
+	t.Run("sort and paginate", func(t *testing.T) {
+		rec, tasks := list(t, "/api/v1/tasks?sort=-priority&limit=2")
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		if len(tasks) != 2 || tasks[0].Priority != models.PriorityHigh || tasks[1].Priority != models.PriorityMedium {
+			t.Fatalf("unexpected first page %+v", tasks)
+		}
+
+		next := rec.Header().Get("X-Next-Cursor")
+		link := rec.Header().Get("Link")
+		if next == "" || !strings.HasPrefix(link, "</api/v1/tasks?") || !strings.HasSuffix(link, `>; rel="next"`) {
+			t.Fatalf("expected next cursor and Link, got %q / %q", next, link)
+		}
+
+		target := strings.TrimSuffix(strings.TrimPrefix(link, "<"), `>; rel="next"`)
+		rec, tasks = list(t, target)
+		if rec.Code != http.StatusOK || len(tasks) != 1 || tasks[0].Priority != models.PriorityLow {
+			t.Fatalf("unexpected last page %d %+v", rec.Code, tasks)
+		}
+		if rec.Header().Get("Link") != "" || rec.Header().Get("X-Next-Cursor") != "" {
+			t.Fatalf("last page should not link to a next one")
+		}
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		_, tasks := list(t, "/api/v1/tasks?priority=alta&done=false&root=true")
+		if len(tasks) != 1 || tasks[0].Priority != models.PriorityHigh {
+			t.Fatalf("unexpected tasks %+v", tasks)
+		}
+	})
+
+	for _, target := range []string{
+		"/api/v1/tasks?done=talvez",
+		"/api/v1/tasks?priority=urgente",
+		"/api/v1/tasks?sort=title",
+		"/api/v1/tasks?limit=abc",
+		"/api/v1/tasks?limit=100000",
+		"/api/v1/tasks?created_from=ontem",
+		"/api/v1/tasks?cursor=xyz",
+	} {
+		t.Run("bad request "+target, func(t *testing.T) {
+			if rec, _ := list(t, target); rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d", rec.Code, http.StatusBadRequest)
+			}
+		})
+	}
+}
+
+func TestTaskHandler_GetTaskTree(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	root, _ := service.CreateTask(ctx, models.Task{Title: "root", Priority: models.PriorityLow})
+	child, _ := service.CreateTask(ctx, models.Task{Title: "child", Priority: models.PriorityLow, ParentID: &root.ID})
+	grandchild, _ := service.CreateTask(ctx, models.Task{Title: "grandchild", Priority: models.PriorityLow, ParentID: &child.ID})
+	handler := NewTaskHandler(service)
+
+	t.Run("success", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		handler.GetTaskTree(rec, newRequestWithID(http.MethodGet, "/tasks/"+child.ID+"/tree", child.ID, bytes.NewReader(nil)))
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+		}
+		var got TaskTree
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		if len(got.Breadcrumbs) != 1 || got.Breadcrumbs[0].ID != root.ID || got.Breadcrumbs[0].Title != "root" {
+			t.Fatalf("unexpected breadcrumbs %+v", got.Breadcrumbs)
+		}
+		if got.Task == nil || got.Task.ID != child.ID || len(got.Task.Children) != 1 || got.Task.Children[0].ID != grandchild.ID {
+			t.Fatalf("unexpected tree %+v", got.Task)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		id       string
+		query    string
+		wantCode int
+	}{
+		{name: "not found", id: "missing", wantCode: http.StatusNotFound},
+		{name: "invalid depth", id: root.ID, query: "?depth=x", wantCode: http.StatusBadRequest},
+		{name: "depth too large", id: root.ID, query: "?depth=1000", wantCode: http.StatusBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.GetTaskTree(rec, newRequestWithID(http.MethodGet, "/tasks/"+tt.id+"/tree"+tt.query, tt.id, bytes.NewReader(nil)))
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+		})
+	}
+
+	t.Run("patch rejects cycle", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		body := bytes.NewReader([]byte(`{"parent_id":"` + grandchild.ID + `"}`))
+		handler.PatchTask(rec, newRequestWithID(http.MethodPatch, "/tasks/"+root.ID, root.ID, body))
+		if rec.Code != http.StatusConflict {
+			t.Fatalf("status = %d, want %d", rec.Code, http.StatusConflict)
+		}
+	})
+}
+
+func TestTaskHandler_CompleteTaskWithOpenSubtasks(t *testing.T) {
+	ctx := context.Background()
+	service := NewServiceWithConfig(repository.NewMemoryStore(), Config{MaxDepth: DefaultMaxDepth, Completion: CompletionBlock})
+	parent, _ := service.CreateTask(ctx, models.Task{Title: "parent", Priority: models.PriorityLow})
+	child, _ := service.CreateTask(ctx, models.Task{Title: "child", Priority: models.PriorityLow, ParentID: &parent.ID})
+	handler := NewTaskHandler(service)
+
+	rec := httptest.NewRecorder()
+	handler.CompleteTask(rec, newRequestWithID(http.MethodPatch, "/tasks/"+parent.ID+"/complete", parent.ID, bytes.NewReader(nil)))
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusConflict)
+	}
+
+	if _, err := service.Complete(ctx, child.ID); err != nil {
+		t.Fatalf("complete child: %v", err)
+	}
+	rec = httptest.NewRecorder()
+	handler.CompleteTask(rec, newRequestWithID(http.MethodPatch, "/tasks/"+parent.ID+"/complete", parent.ID, bytes.NewReader(nil)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	var got models.Task
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !got.Done || got.Progress == nil || got.Progress.Done != 1 || got.Progress.Total != 1 {
+		t.Fatalf("unexpected response %+v", got)
+	}
+}
+
+func TestTaskHandler_MoveTask(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	home, _ := service.CreateTask(ctx, models.Task{Title: "home", Priority: models.PriorityLow})
+	work, _ := service.CreateTask(ctx, models.Task{Title: "work", Priority: models.PriorityLow})
+	branch, _ := service.CreateTask(ctx, models.Task{Title: "branch", Priority: models.PriorityLow, ParentID: &home.ID})
+	leaf, _ := service.CreateTask(ctx, models.Task{Title: "leaf", Priority: models.PriorityLow, ParentID: &branch.ID})
+	handler := NewTaskHandler(service)
+
+	tests := []struct {
+		name        string
+		id          string
+		body        string
+		wantCode    int
+		wantPath    []string
+		wantParent  string
+		wantSubtask bool
+	}{
+		{name: "under another task", id: branch.ID, body: `{"parent_id":"` + work.ID + `"}`, wantCode: http.StatusOK, wantPath: []string{work.ID}, wantSubtask: true},
+		{name: "to root with null", id: branch.ID, body: `{"parent_id":null}`, wantCode: http.StatusOK, wantPath: []string{}, wantSubtask: true},
+		{name: "to root with empty body", id: leaf.ID, wantCode: http.StatusOK, wantPath: []string{}},
+		{name: "empty parent", id: branch.ID, body: `{"parent_id":""}`, wantCode: http.StatusBadRequest},
+		{name: "missing task", id: "missing", body: `{}`, wantCode: http.StatusNotFound},
+		{name: "missing parent", id: branch.ID, body: `{"parent_id":"missing"}`, wantCode: http.StatusNotFound},
+		{name: "cycle", id: home.ID, body: `{"parent_id":"` + home.ID + `"}`, wantCode: http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.MoveTask(rec, newRequestWithID(http.MethodPost, "/tasks/"+tt.id+"/move", tt.id, bytes.NewReader([]byte(tt.body))))
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if tt.wantCode != http.StatusOK {
+				return
+			}
+			if rec.Header().Get("ETag") == "" {
+				t.Fatalf("expected ETag header")
+			}
+			var got TaskTree
+			if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if len(got.Breadcrumbs) != len(tt.wantPath) {
+				t.Fatalf("breadcrumbs = %+v, want %v", got.Breadcrumbs, tt.wantPath)
+			}
+			for i, id := range tt.wantPath {
+				if got.Breadcrumbs[i].ID != id {
+					t.Fatalf("breadcrumbs = %+v, want %v", got.Breadcrumbs, tt.wantPath)
+				}
+			}
+			if tt.wantSubtask && (len(got.Task.Children) != 1 || got.Task.Children[0].ID != leaf.ID) {
+				t.Fatalf("expected subtree to move along, got %+v", got.Task)
+			}
+		})
+	}
+}
+
+func TestTaskHandler_PatchTaskDetach(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	parent, _ := service.CreateTask(ctx, models.Task{Title: "parent", Priority: models.PriorityLow})
+	child, _ := service.CreateTask(ctx, models.Task{Title: "child", Priority: models.PriorityLow, ParentID: &parent.ID})
+	handler := NewTaskHandler(service)
+
+	rec := httptest.NewRecorder()
+	handler.PatchTask(rec, newRequestWithID(http.MethodPatch, "/tasks/"+child.ID, child.ID, bytes.NewReader([]byte(`{"parent_id":null}`))))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d (%s)", rec.Code, http.StatusOK, rec.Body.String())
+	}
+	var got models.Task
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got.ParentID != nil {
+		t.Fatalf("expected parent_id cleared, got %v", *got.ParentID)
+	}
+
+	// Sem parent_id no corpo, o pai não é tocado.
+	rec = httptest.NewRecorder()
+	handler.PatchTask(rec, newRequestWithID(http.MethodPatch, "/tasks/"+parent.ID, parent.ID, bytes.NewReader([]byte(`{"title":"renomeada"}`))))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+func TestTaskHandler_Tags(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	created, _ := service.CreateTask(ctx, models.Task{Title: "backup", Priority: models.PriorityLow})
+	handler := NewTaskHandler(service)
+
+	router := chi.NewRouter()
+	router.Get("/tags", handler.ListTags)
+	router.Post("/tags", handler.CreateTag)
+	router.Patch("/tags/{tagID}", handler.PatchTag)
+	router.Delete("/tags/{tagID}", handler.DeleteTag)
+	router.Post("/tasks/{id}/tags", handler.TagTask)
+	router.Delete("/tasks/{id}/tags/{tag}", handler.UntagTask)
+	router.Get("/tasks", handler.ListTasks)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, bytes.NewReader([]byte(body))))
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/tags", `{"name":"#HomeLab","color":"cyan"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create tag status = %d (%s)", rec.Code, rec.Body.String())
+	}
+	var homelab models.Tag
+	if err := json.NewDecoder(rec.Body).Decode(&homelab); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if homelab.Name != "homelab" || homelab.Color != "cyan" {
+		t.Fatalf("unexpected tag %+v", homelab)
+	}
+
+	rec = do(http.MethodPost, "/tasks/"+created.ID+"/tags", `{"tags":["homelab","urgente"]}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("tag task status = %d (%s)", rec.Code, rec.Body.String())
+	}
+	if etag := rec.Header().Get("ETag"); etag != taskETag(created.Version+1) {
+		t.Fatalf("ETag = %q, want the new version", etag)
+	}
+
+	rec = do(http.MethodGet, "/tasks?tag=homelab&tag=-urgente", "")
+	var listed []models.Task
+	if err := json.NewDecoder(rec.Body).Decode(&listed); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusOK || len(listed) != 0 {
+		t.Fatalf("expected excluded tag to filter the task out, got %d %+v", rec.Code, listed)
+	}
+
+	rec = do(http.MethodDelete, "/tasks/"+created.ID+"/tags/urgente", "")
+	var untagged models.Task
+	if err := json.NewDecoder(rec.Body).Decode(&untagged); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusOK || len(untagged.Tags) != 1 || untagged.Tags[0].Name != "homelab" {
+		t.Fatalf("untag = %d %+v", rec.Code, untagged.Tags)
+	}
+
+	errs := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+	}{
+		{"duplicate tag", http.MethodPost, "/tags", `{"name":"homelab"}`, http.StatusConflict},
+		{"invalid name", http.MethodPost, "/tags", `{"name":"com espaço"}`, http.StatusBadRequest},
+		{"invalid color", http.MethodPost, "/tags", `{"name":"nova","color":"rosa"}`, http.StatusBadRequest},
+		{"patch without fields", http.MethodPatch, "/tags/" + homelab.ID, `{}`, http.StatusBadRequest},
+		{"patch missing tag", http.MethodPatch, "/tags/missing", `{"color":"red"}`, http.StatusNotFound},
+		{"tag missing task", http.MethodPost, "/tasks/missing/tags", `{"tags":["x"]}`, http.StatusNotFound},
+		{"untag missing tag", http.MethodDelete, "/tasks/" + created.ID + "/tags/nope", "", http.StatusNotFound},
+		{"invalid filter", http.MethodGet, "/tasks?tag=a%20b", "", http.StatusBadRequest},
+		{"delete missing tag", http.MethodDelete, "/tags/missing", "", http.StatusNotFound},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := do(tt.method, tt.path, tt.body); rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+		})
+	}
+
+	if rec := do(http.MethodDelete, "/tags/"+homelab.ID, ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete tag status = %d", rec.Code)
+	}
+}
+
+func TestTaskHandler_Projects(t *testing.T) {
+	service := NewService(repository.NewMemoryStore())
+	handler := NewTaskHandler(service)
+
+	router := chi.NewRouter()
+	router.Get("/projects", handler.ListProjects)
+	router.Post("/projects", handler.CreateProject)
+	router.Get("/projects/{projectID}", handler.GetProject)
+	router.Patch("/projects/{projectID}", handler.PatchProject)
+	router.Delete("/projects/{projectID}", handler.DeleteProject)
+	router.Get("/projects/{projectID}/tasks", handler.ListProjectTasks)
+	router.Post("/tasks", handler.CreateTask)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, bytes.NewReader([]byte(body))))
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/projects", `{"name":"homelab","default_priority":"high","default_reminder_offset":"1d"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create project status = %d (%s)", rec.Code, rec.Body.String())
+	}
+	var homelab models.Project
+	if err := json.NewDecoder(rec.Body).Decode(&homelab); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	rec = do(http.MethodPost, "/tasks", `{"title":"backup","project_id":"`+homelab.ID+`"}`)
+	if rec.Code != http.StatusCreated {
+		t.Fatalf("create task status = %d (%s)", rec.Code, rec.Body.String())
+	}
+	var created models.Task
+	if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if created.Priority != models.PriorityHigh || created.ProjectID == nil || *created.ProjectID != homelab.ID {
+		t.Fatalf("expected task with project defaults, got %+v", created)
+	}
+
+	rec = do(http.MethodGet, "/projects/"+homelab.ID, "")
+	var got models.Project
+	if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusOK || got.Counts == nil || got.Counts.Open != 1 {
+		t.Fatalf("get project = %d %+v", rec.Code, got.Counts)
+	}
+
+	rec = do(http.MethodGet, "/projects/"+homelab.ID+"/tasks", "")
+	var listed []models.Task
+	if err := json.NewDecoder(rec.Body).Decode(&listed); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusOK || len(listed) != 1 || listed[0].ID != created.ID {
+		t.Fatalf("project tasks = %d %+v", rec.Code, listed)
+	}
+
+	if rec := do(http.MethodPatch, "/projects/"+homelab.ID, `{"archived":true}`); rec.Code != http.StatusOK {
+		t.Fatalf("archive status = %d (%s)", rec.Code, rec.Body.String())
+	}
+	rec = do(http.MethodGet, "/projects", "")
+	var active []models.Project
+	if err := json.NewDecoder(rec.Body).Decode(&active); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusOK || len(active) != 0 {
+		t.Fatalf("expected archived project hidden, got %d %+v", rec.Code, active)
+	}
+
+	errs := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+	}{
+		{"duplicate project", http.MethodPost, "/projects", `{"name":"homelab"}`, http.StatusConflict},
+		{"blank name", http.MethodPost, "/projects", `{"name":" "}`, http.StatusBadRequest},
+		{"invalid default priority", http.MethodPost, "/projects", `{"name":"x","default_priority":"urgente"}`, http.StatusBadRequest},
+		{"patch without fields", http.MethodPatch, "/projects/" + homelab.ID, `{}`, http.StatusBadRequest},
+		{"get missing project", http.MethodGet, "/projects/missing", "", http.StatusNotFound},
+		{"tasks of missing project", http.MethodGet, "/projects/missing/tasks", "", http.StatusNotFound},
+		{"invalid archived param", http.MethodGet, "/projects?archived=talvez", "", http.StatusBadRequest},
+		{"task in archived project", http.MethodPost, "/tasks", `{"title":"x","project_id":"` + homelab.ID + `"}`, http.StatusConflict},
+		{"task in missing project", http.MethodPost, "/tasks", `{"title":"x","project_id":"missing"}`, http.StatusNotFound},
+		{"task without priority or project", http.MethodPost, "/tasks", `{"title":"x"}`, http.StatusBadRequest},
+		{"delete missing project", http.MethodDelete, "/projects/missing", "", http.StatusNotFound},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := do(tt.method, tt.path, tt.body); rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+		})
+	}
+
+	if rec := do(http.MethodDelete, "/projects/"+homelab.ID, ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("delete project status = %d", rec.Code)
+	}
+}
+
+func TestTaskHandler_Dependencies(t *testing.T) {
+	ctx := context.Background()
+	store := repository.NewMemoryStore()
+	refuse := NewTaskHandler(NewService(store))
+	warn := NewTaskHandler(NewServiceWithConfig(store, Config{MaxDepth: DefaultMaxDepth, Dependencies: DependencyWarn}))
+	service := NewService(store)
+	design, _ := service.CreateTask(ctx, models.Task{Title: "design", Priority: models.PriorityLow})
+	build, _ := service.CreateTask(ctx, models.Task{Title: "build", Priority: models.PriorityLow})
+
+	router := chi.NewRouter()
+	router.Get("/tasks/ready", refuse.ListReadyTasks)
+	router.Get("/tasks/{id}/dependencies", refuse.ListDependencies)
+	router.Post("/tasks/{id}/dependencies", refuse.AddDependency)
+	router.Delete("/tasks/{id}/dependencies/{dependsOnID}", refuse.RemoveDependency)
+	router.Patch("/tasks/{id}/complete", refuse.CompleteTask)
+	router.Patch("/warn/{id}/complete", warn.CompleteTask)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, bytes.NewReader([]byte(body))))
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/tasks/"+build.ID+"/dependencies", `{"depends_on":"`+design.ID+`"}`)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("add dependency status = %d (%s)", rec.Code, rec.Body.String())
+	}
+	var blocked models.Task
+	if err := json.NewDecoder(rec.Body).Decode(&blocked); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if !blocked.Blocked || len(blocked.BlockedBy) != 1 || rec.Header().Get("ETag") != taskETag(build.Version+1) {
+		t.Fatalf("expected blocked task with new ETag, got %+v (%s)", blocked, rec.Header().Get("ETag"))
+	}
+
+	rec = do(http.MethodGet, "/tasks/"+design.ID+"/dependencies", "")
+	var deps TaskDependencies
+	if err := json.NewDecoder(rec.Body).Decode(&deps); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusOK || len(deps.DependsOn) != 0 || len(deps.Blocks) != 1 || deps.Blocks[0].ID != build.ID {
+		t.Fatalf("dependencies of design = %d %+v", rec.Code, deps)
+	}
+
+	rec = do(http.MethodGet, "/tasks/ready", "")
+	var ready []models.Task
+	if err := json.NewDecoder(rec.Body).Decode(&ready); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusOK || len(ready) != 1 || ready[0].ID != design.ID {
+		t.Fatalf("ready = %d %+v", rec.Code, ready)
+	}
+
+	errs := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+	}{
+		{"cycle", http.MethodPost, "/tasks/" + design.ID + "/dependencies", `{"depends_on":"` + build.ID + `"}`, http.StatusConflict},
+		{"blank depends_on", http.MethodPost, "/tasks/" + design.ID + "/dependencies", `{"depends_on":" "}`, http.StatusBadRequest},
+		{"missing dependency", http.MethodPost, "/tasks/" + design.ID + "/dependencies", `{"depends_on":"missing"}`, http.StatusNotFound},
+		{"missing task", http.MethodGet, "/tasks/missing/dependencies", "", http.StatusNotFound},
+		{"remove missing", http.MethodDelete, "/tasks/" + design.ID + "/dependencies/" + build.ID, "", http.StatusNotFound},
+		{"complete blocked", http.MethodPatch, "/tasks/" + build.ID + "/complete", "", http.StatusConflict},
+		{"invalid ready sort", http.MethodGet, "/tasks/ready?sort=title", "", http.StatusBadRequest},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := do(tt.method, tt.path, tt.body); rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+		})
+	}
+
+	rec = do(http.MethodPatch, "/warn/"+build.ID+"/complete", "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Header().Get("Warning"), design.ID) {
+		t.Fatalf("complete with warn policy = %d, Warning %q", rec.Code, rec.Header().Get("Warning"))
+	}
+
+	if rec := do(http.MethodDelete, "/tasks/"+build.ID+"/dependencies/"+design.ID, ""); rec.Code != http.StatusOK {
+		t.Fatalf("remove dependency status = %d (%s)", rec.Code, rec.Body.String())
+	}
+}
+
+func TestTaskHandler_DueDates(t *testing.T) {
+	handler := NewTaskHandler(NewService(repository.NewMemoryStore()))
+	router := chi.NewRouter()
+	router.Get("/tasks", handler.ListTasks)
+	router.Post("/tasks", handler.CreateTask)
+	router.Patch("/tasks/{id}", handler.PatchTask)
+	router.Post("/tasks/{id}/reminders", handler.CreateReminder)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, bytes.NewReader([]byte(body))))
+		return rec
+	}
+	create := func(body string) models.Task {
+		t.Helper()
+		rec := do(http.MethodPost, "/tasks", body)
+		if rec.Code != http.StatusCreated {
+			t.Fatalf("create status = %d (%s)", rec.Code, rec.Body.String())
+		}
+		var created models.Task
+		if err := json.NewDecoder(rec.Body).Decode(&created); err != nil {
+			t.Fatalf("decode: %v", err)
+		}
+		return created
+	}
+
+	yesterday := time.Now().AddDate(0, 0, -1).Format(time.DateOnly)
+	late := create(`{"title":"atrasada","priority":"low","due_at":"` + yesterday + `"}`)
+	if late.DueAt == nil || !late.DueAllDay || !late.Overdue {
+		t.Fatalf("expected all-day overdue task, got %+v", late)
+	}
+	dueAt := time.Now().Add(96 * time.Hour).UTC().Truncate(time.Second)
+	later := create(`{"title":"depois","priority":"low","due_at":"` + dueAt.Format(time.RFC3339) + `"}`)
+	if later.DueAt == nil || !later.DueAt.Equal(dueAt) || later.DueAllDay || later.Overdue || later.DueSoon {
+		t.Fatalf("expected timed task due in 4 days, got %+v", later)
+	}
+	undated := create(`{"title":"sem prazo","priority":"low"}`)
+
+	if rec := do(http.MethodPost, "/tasks", `{"title":"x","priority":"low","due_at":"logo"}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid due_at status = %d, want 400", rec.Code)
+	}
+
+	rec := do(http.MethodPost, "/tasks/"+later.ID+"/reminders", `{"offset":"-1d","anchor":"due"}`)
+	var reminder models.Reminder
+	if err := json.NewDecoder(rec.Body).Decode(&reminder); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusCreated || !reminder.FireAt.Equal(dueAt.Add(-24*time.Hour)) || reminder.Anchor != models.AnchorDue {
+		t.Fatalf("due reminder = %d %+v", rec.Code, reminder)
+	}
+	if rec := do(http.MethodPost, "/tasks/"+undated.ID+"/reminders", `{"offset":"-1d","anchor":"due"}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("due reminder without due_at status = %d, want 400", rec.Code)
+	}
+
+	rec = do(http.MethodGet, "/tasks?sort=due_at", "")
+	var tasks []models.Task
+	if err := json.NewDecoder(rec.Body).Decode(&tasks); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusOK || len(tasks) != 3 || tasks[0].ID != late.ID || tasks[1].ID != later.ID || tasks[2].ID != undated.ID {
+		t.Fatalf("sort=due_at = %d %+v", rec.Code, tasks)
+	}
+	if !tasks[0].Overdue {
+		t.Fatalf("expected overdue flag on list, got %+v", tasks[0])
+	}
+
+	rec = do(http.MethodPatch, "/tasks/"+late.ID, `{"due_at":null}`)
+	var cleared models.Task
+	if err := json.NewDecoder(rec.Body).Decode(&cleared); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusOK || cleared.DueAt != nil || cleared.DueAllDay || cleared.Overdue {
+		t.Fatalf("clear due_at = %d %+v", rec.Code, cleared)
+	}
+	if rec := do(http.MethodPatch, "/tasks/"+late.ID, `{"due_at":"amanhã"}`); rec.Code != http.StatusBadRequest {
+		t.Fatalf("invalid due_at patch status = %d, want 400", rec.Code)
+	}
+}
+
+func TestTaskHandler_Status(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	handler := NewTaskHandler(service)
+	doc, _ := service.CreateTask(ctx, models.Task{Title: "doc", Priority: models.PriorityLow})
+	call, _ := service.CreateTask(ctx, models.Task{Title: "ligar", Priority: models.PriorityLow})
+
+	router := chi.NewRouter()
+	router.Get("/tasks", handler.ListTasks)
+	router.Patch("/tasks/{id}", handler.PatchTask)
+	router.Patch("/tasks/{id}/status", handler.SetTaskStatus)
+	router.Patch("/tasks/{id}/reopen", handler.ReopenTask)
+	router.Patch("/tasks/{id}/complete", handler.CompleteTask)
+
+	do := func(method, path, body string, header ...string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req := httptest.NewRequest(method, path, bytes.NewReader([]byte(body)))
+		if len(header) == 2 {
+			req.Header.Set(header[0], header[1])
+		}
+		router.ServeHTTP(rec, req)
+		return rec
+	}
+	decode := func(rec *httptest.ResponseRecorder) models.Task {
+		t.Helper()
+		var got models.Task
+		if err := json.NewDecoder(rec.Body).Decode(&got); err != nil {
+			t.Fatalf("decode: %v (%s)", err, rec.Body.String())
+		}
+		return got
+	}
+
+	rec := do(http.MethodPatch, "/tasks/"+doc.ID+"/status", `{"status":"em andamento"}`, "If-Match", taskETag(doc.Version))
+	started := decode(rec)
+	if rec.Code != http.StatusOK || started.Status != models.StatusInProgress || started.StartedAt == nil || rec.Header().Get("ETag") != taskETag(doc.Version+1) {
+		t.Fatalf("set status = %d %+v (%s)", rec.Code, started, rec.Header().Get("ETag"))
+	}
+
+	rec = do(http.MethodPatch, "/tasks/"+call.ID, `{"status":"cancelled"}`)
+	cancelled := decode(rec)
+	if rec.Code != http.StatusOK || cancelled.Status != models.StatusCancelled || cancelled.CancelledAt == nil || cancelled.Done {
+		t.Fatalf("patch status = %d %+v", rec.Code, cancelled)
+	}
+
+	rec = do(http.MethodGet, "/tasks?status=in_progress&status=cancelled", "")
+	var tasks []models.Task
+	if err := json.NewDecoder(rec.Body).Decode(&tasks); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusOK || len(tasks) != 2 {
+		t.Fatalf("status filter = %d %+v", rec.Code, tasks)
+	}
+
+	errs := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+	}{
+		{"invalid status", http.MethodPatch, "/tasks/" + doc.ID + "/status", `{"status":"feito"}`, http.StatusBadRequest},
+		{"invalid patch status", http.MethodPatch, "/tasks/" + doc.ID, `{"status":"feito"}`, http.StatusBadRequest},
+		{"invalid status filter", http.MethodGet, "/tasks?status=feito", "", http.StatusBadRequest},
+		{"cancelled to waiting", http.MethodPatch, "/tasks/" + call.ID + "/status", `{"status":"waiting"}`, http.StatusConflict},
+		{"complete cancelled", http.MethodPatch, "/tasks/" + call.ID + "/complete", "", http.StatusConflict},
+		{"reopen open task", http.MethodPatch, "/tasks/" + doc.ID + "/reopen", "", http.StatusConflict},
+		{"reopen missing", http.MethodPatch, "/tasks/missing/reopen", "", http.StatusNotFound},
+		{"status missing", http.MethodPatch, "/tasks/missing/status", `{"status":"todo"}`, http.StatusNotFound},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := do(tt.method, tt.path, tt.body); rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+		})
+	}
+
+	if rec := do(http.MethodPatch, "/tasks/"+call.ID+"/reopen", "", "If-Match", taskETag(call.Version)); rec.Code != http.StatusPreconditionFailed {
+		t.Fatalf("stale reopen status = %d, want 412", rec.Code)
+	}
+	rec = do(http.MethodPatch, "/tasks/"+call.ID+"/reopen", "")
+	reopened := decode(rec)
+	if rec.Code != http.StatusOK || reopened.Status != models.StatusTodo || reopened.CancelledAt != nil {
+		t.Fatalf("reopen = %d %+v", rec.Code, reopened)
+	}
+
+	rec = do(http.MethodPatch, "/tasks/"+call.ID+"/complete", "")
+	completed := decode(rec)
+	if rec.Code != http.StatusOK || completed.Status != models.StatusDone || !completed.Done || completed.CompletedAt == nil {
+		t.Fatalf("complete = %d %+v", rec.Code, completed)
+	}
+}
+
+func TestTaskHandler_Advice(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	handler := NewTaskHandler(service)
+	low, _ := service.CreateTask(ctx, models.Task{Title: "baixa", Priority: models.PriorityLow, ReminderAt: time.Now().Add(-time.Hour)})
+	high, _ := service.CreateTask(ctx, models.Task{Title: "alta", Priority: models.PriorityHigh})
+
+	router := chi.NewRouter()
+	router.Get("/advice", handler.GetAdvice)
+
+	tests := []struct {
+		name     string
+		path     string
+		wantCode int
+		wantIDs  []string
+	}{
+		{"default", "/advice", http.StatusOK, []string{high.ID, low.ID}},
+		{"limit", "/advice?limit=1", http.StatusOK, []string{high.ID}},
+		{"weights", "/advice?weights=priority=0", http.StatusOK, []string{low.ID, high.ID}},
+		{"invalid limit", "/advice?limit=x", http.StatusBadRequest, nil},
+		{"limit too large", "/advice?limit=501", http.StatusBadRequest, nil},
+		{"invalid weights", "/advice?weights=urgency=1", http.StatusBadRequest, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+			if tt.wantIDs == nil {
+				return
+			}
+
+			var advice []task.Advice
+			if err := json.NewDecoder(rec.Body).Decode(&advice); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			var ids []string
+			for _, a := range advice {
+				ids = append(ids, a.Task.ID)
+				if a.Explanation == "" {
+					t.Fatalf("expected explanation for %s", a.Task.ID)
+				}
+			}
+			if strings.Join(ids, " ") != strings.Join(tt.wantIDs, " ") {
+				t.Fatalf("ids = %v, want %v", ids, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestTaskHandler_Plan(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	handler := NewTaskHandler(service)
+	report, _ := service.CreateTask(ctx, models.Task{Title: "relatório", Priority: models.PriorityHigh, EstimateMinutes: 90})
+
+	router := chi.NewRouter()
+	router.Post("/tasks", handler.CreateTask)
+	router.Patch("/tasks/{id}", handler.PatchTask)
+	router.Get("/plan", handler.GetPlan)
+	router.Post("/plan/accept", handler.AcceptPlan)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, bytes.NewReader([]byte(body))))
+		return rec
+	}
+
+	rec := do(http.MethodGet, "/plan?date=tomorrow", "")
+	var plan task.Plan
+	if err := json.NewDecoder(rec.Body).Decode(&plan); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	tomorrow := time.Now().AddDate(0, 0, 1).Format(time.DateOnly)
+	if rec.Code != http.StatusOK || plan.Date != tomorrow || len(plan.Blocks) != 1 || plan.Blocks[0].TaskID != report.ID || plan.Blocks[0].Minutes() != 90 {
+		t.Fatalf("plan = %d %+v", rec.Code, plan)
+	}
+
+	body, _ := json.Marshal(map[string]any{"blocks": plan.Blocks})
+	rec = do(http.MethodPost, "/plan/accept", string(body))
+	var updated []models.Task
+	if err := json.NewDecoder(rec.Body).Decode(&updated); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusOK || len(updated) != 1 || !updated[0].ReminderAt.Equal(plan.Blocks[0].Start) {
+		t.Fatalf("accept = %d %+v", rec.Code, updated)
+	}
+
+	errs := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+	}{
+		{"invalid date", http.MethodGet, "/plan?date=depois", "", http.StatusBadRequest},
+		{"past date", http.MethodGet, "/plan?date=2000-01-01", "", http.StatusBadRequest},
+		{"accept without blocks", http.MethodPost, "/plan/accept", `{"blocks":[]}`, http.StatusBadRequest},
+		{"accept missing task", http.MethodPost, "/plan/accept", `{"blocks":[{"task_id":"missing","start":"2030-01-01T09:00:00Z"}]}`, http.StatusNotFound},
+		{"create with invalid estimate", http.MethodPost, "/tasks", `{"title":"x","priority":"low","estimate_minutes":-1}`, http.StatusBadRequest},
+		{"patch with invalid estimate", http.MethodPatch, "/tasks/" + report.ID, `{"estimate_minutes":5000}`, http.StatusBadRequest},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := do(tt.method, tt.path, tt.body); rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestTaskHandler_Time(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	handler := NewTaskHandler(service)
+	report, _ := service.CreateTask(ctx, models.Task{Title: "relatório", Priority: models.PriorityHigh})
+
+	router := chi.NewRouter()
+	router.Get("/tasks/{id}", handler.GetTask)
+	router.Post("/tasks/{id}/time/start", handler.StartTimer)
+	router.Post("/time/stop", handler.StopTimer)
+	router.Get("/time/running", handler.GetRunningTimer)
+	router.Get("/time/entries", handler.ListTimeEntries)
+	router.Get("/time/timesheet", handler.GetTimesheet)
+
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, httptest.NewRequest(method, path, bytes.NewReader([]byte(body))))
+		return rec
+	}
+
+	if rec := do(http.MethodGet, "/time/running", ""); rec.Code != http.StatusNoContent {
+		t.Fatalf("running with no timer = %d, want 204", rec.Code)
+	}
+
+	rec := do(http.MethodPost, "/tasks/"+report.ID+"/time/start", `{"note":"rascunho"}`)
+	var started Timer
+	if err := json.NewDecoder(rec.Body).Decode(&started); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusCreated || started.Entry.TaskID != report.ID || started.Entry.Note != "rascunho" {
+		t.Fatalf("start = %d %+v", rec.Code, started)
+	}
+
+	rec = do(http.MethodGet, "/time/running", "")
+	var running Timer
+	if err := json.NewDecoder(rec.Body).Decode(&running); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusOK || running.Entry.ID != started.Entry.ID || running.Task.Title != "relatório" {
+		t.Fatalf("running = %d %+v", rec.Code, running)
+	}
+
+	if rec := do(http.MethodPost, "/time/stop", ""); rec.Code != http.StatusOK {
+		t.Fatalf("stop = %d (%s)", rec.Code, rec.Body.String())
+	}
+
+	rec = do(http.MethodGet, "/time/entries?task_id="+report.ID+"&from=-1h", "")
+	var entries []models.TimeEntry
+	if err := json.NewDecoder(rec.Body).Decode(&entries); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if rec.Code != http.StatusOK || len(entries) != 1 || entries[0].StoppedAt == nil {
+		t.Fatalf("entries = %d %+v", rec.Code, entries)
+	}
+
+	rec = do(http.MethodGet, "/tasks/"+report.ID, "")
+	if rec.Code != http.StatusOK || !strings.Contains(rec.Body.String(), `"tracked_seconds":`) {
+		t.Fatalf("task = %d %s; want tracked_seconds", rec.Code, rec.Body.String())
+	}
+
+	rec = do(http.MethodGet, "/time/timesheet", "")
+	var sheet task.Timesheet
+	if err := json.NewDecoder(rec.Body).Decode(&sheet); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	today := time.Now().Format(time.DateOnly)
+	if rec.Code != http.StatusOK || sheet.To != today || sheet.From != time.Now().AddDate(0, 0, -6).Format(time.DateOnly) {
+		t.Fatalf("timesheet = %d %+v", rec.Code, sheet)
+	}
+
+	rec = do(http.MethodGet, "/time/timesheet?from="+today+"&to="+today+"&format=csv", "")
+	if rec.Code != http.StatusOK || !strings.HasPrefix(rec.Header().Get("Content-Type"), "text/csv") ||
+		!strings.HasPrefix(rec.Body.String(), "date,project,task_id,task,hours\n") {
+		t.Fatalf("csv = %d %q", rec.Code, rec.Body.String())
+	}
+
+	errs := []struct {
+		name     string
+		method   string
+		path     string
+		body     string
+		wantCode int
+	}{
+		{"start missing task", http.MethodPost, "/tasks/missing/time/start", "", http.StatusNotFound},
+		{"start invalid json", http.MethodPost, "/tasks/" + report.ID + "/time/start", "{", http.StatusBadRequest},
+		{"stop without timer", http.MethodPost, "/time/stop", "", http.StatusConflict},
+		{"entries of missing task", http.MethodGet, "/time/entries?task_id=missing", "", http.StatusNotFound},
+		{"entries with invalid from", http.MethodGet, "/time/entries?from=ontem", "", http.StatusBadRequest},
+		{"entries with inverted period", http.MethodGet, "/time/entries?from=-1h&to=-2h", "", http.StatusBadRequest},
+		{"timesheet with inverted period", http.MethodGet, "/time/timesheet?from=2025-06-10&to=2025-06-01", "", http.StatusBadRequest},
+		{"timesheet with invalid format", http.MethodGet, "/time/timesheet?format=xml", "", http.StatusBadRequest},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			if rec := do(tt.method, tt.path, tt.body); rec.Code != tt.wantCode {
+				t.Fatalf("status = %d, want %d (%s)", rec.Code, tt.wantCode, rec.Body.String())
+			}
+		})
+	}
+}
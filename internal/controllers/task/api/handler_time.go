@@ -0,0 +1,205 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/go-chi/chi/v5"
+)
+
+type StartTimerRequest struct {
+	Note string `json:"note" example:"revisando o PR"`
+}
+
+// DefaultTimesheetDays é quantos dias, até hoje, a folha de horas cobre
+// quando o período não é informado.
+const DefaultTimesheetDays = 7
+
+// @Summary     Iniciar cronômetro
+// @Description Começa a registrar tempo na tarefa. Só um cronômetro roda por vez: o que estiver rodando em outra tarefa é parado e vem em stopped. Tarefa em todo passa para in_progress
+// @Tags        Time
+// @Accept      json
+// @Produce     json
+// @Param       id   path string            true  "ID da tarefa"
+// @Param       body body StartTimerRequest false "Anotação do registro"
+// @Success     201 {object} Timer
+// @Failure     400 {object} ErrorResponse
+// @Failure     404 {object} ErrorResponse
+// @Failure     409 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/{id}/time/start [post]
+func (h *TaskHandler) StartTimer(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	var req StartTimerRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, http.StatusBadRequest, "JSON inválido", err)
+		return
+	}
+
+	timer, err := h.taskService.StartTimer(r.Context(), id, req.Note)
+	if err != nil {
+		switch err {
+		case ErrTaskNotFound:
+			respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
+		case ErrTaskAlreadyDone:
+			respondError(w, http.StatusConflict, "A tarefa está concluída ou cancelada", nil)
+		case ErrTimerRunning:
+			respondError(w, http.StatusConflict, "O cronômetro já está rodando nessa tarefa", nil)
+		case ErrTimerConflict:
+			respondError(w, http.StatusConflict, "Outro cronômetro começou a rodar ao mesmo tempo; tente de novo", nil)
+		default:
+			respondError(w, http.StatusInternalServerError, "Erro ao iniciar o cronômetro", err)
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, timer)
+}
+
+// @Summary     Parar cronômetro
+// @Description Para o cronômetro rodando e devolve o registro fechado
+// @Tags        Time
+// @Produce     json
+// @Success     200 {object} Timer
+// @Failure     409 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /time/stop [post]
+func (h *TaskHandler) StopTimer(w http.ResponseWriter, r *http.Request) {
+	timer, err := h.taskService.StopTimer(r.Context())
+	if err != nil {
+		if err == ErrNoRunningTimer {
+			respondError(w, http.StatusConflict, "Nenhum cronômetro rodando", nil)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Erro ao parar o cronômetro", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, timer)
+}
+
+// @Summary     Cronômetro rodando
+// @Description Devolve o cronômetro rodando, com a tarefa e o tempo decorrido; 204 se nenhum estiver rodando
+// @Tags        Time
+// @Produce     json
+// @Success     200 {object} Timer
+// @Success     204 "Nenhum cronômetro rodando"
+// @Failure     500 {object} ErrorResponse
+// @Router      /time/running [get]
+func (h *TaskHandler) GetRunningTimer(w http.ResponseWriter, r *http.Request) {
+	timer, err := h.taskService.RunningTimer(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Erro ao buscar o cronômetro", err)
+		return
+	}
+	if timer == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, timer)
+}
+
+// @Summary     Listar registros de tempo
+// @Description Lista os registros de tempo, dos mais antigos para os mais novos. from e to pegam os registros que cruzam o intervalo; o que está rodando conta até agora
+// @Tags        Time
+// @Produce     json
+// @Param       task_id query string false "Só os registros da tarefa"
+// @Param       from    query string false "Início: RFC3339 ou duração relativa a agora (ex.: -24h)"
+// @Param       to      query string false "Fim: RFC3339 ou duração relativa a agora"
+// @Success     200 {array} models.TimeEntry
+// @Failure     400 {object} ErrorResponse
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /time/entries [get]
+func (h *TaskHandler) ListTimeEntries(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	now := time.Now()
+
+	var query task.TimeQuery
+	if id := params.Get("task_id"); id != "" {
+		query.TaskID = &id
+	}
+	for name, field := range map[string]**time.Time{"from": &query.From, "to": &query.To} {
+		raw := params.Get(name)
+		if raw == "" {
+			continue
+		}
+		at, err := parseTimeParam(raw, now)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Parâmetro "+name+" inválido (use RFC3339 ou uma duração como -24h)", err)
+			return
+		}
+		*field = &at
+	}
+
+	entries, err := h.taskService.TimeEntries(r.Context(), query)
+	if err != nil {
+		switch err {
+		case ErrTaskNotFound:
+			respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
+		case ErrInvalidPeriod:
+			respondError(w, http.StatusBadRequest, "from precisa ser antes de to", nil)
+		default:
+			respondError(w, http.StatusInternalServerError, "Erro ao listar os registros de tempo", err)
+		}
+		return
+	}
+
+	respondJSON(w, http.StatusOK, entries)
+}
+
+// @Summary     Folha de horas
+// @Description Soma o tempo registrado por dia, projeto e tarefa entre from e to (inclusive). Sem período, cobre os últimos 7 dias até hoje. Em CSV, uma linha por dia, projeto e tarefa com as horas em decimal
+// @Tags        Time
+// @Produce     json
+// @Produce     text/csv
+// @Param       from   query string false "Primeiro dia: 2006-01-02 ou 02/01/2006"
+// @Param       to     query string false "Último dia: 2006-01-02 ou 02/01/2006"
+// @Param       format query string false "json (padrão) ou csv" Enums(json, csv)
+// @Success     200 {object} task.Timesheet
+// @Failure     400 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /time/timesheet [get]
+func (h *TaskHandler) GetTimesheet(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	format := params.Get("format")
+	if format != "" && format != "json" && format != "csv" {
+		respondError(w, http.StatusBadRequest, "Parâmetro format inválido (use json ou csv)", nil)
+		return
+	}
+
+	today := time.Now().Format(time.DateOnly)
+	fromDay, toDay := params.Get("from"), params.Get("to")
+	if toDay == "" {
+		toDay = today
+	}
+	if fromDay == "" {
+		fromDay = time.Now().AddDate(0, 0, 1-DefaultTimesheetDays).Format(time.DateOnly)
+	}
+	from, to, err := task.ParsePeriod(fromDay, toDay, time.Local)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Período inválido (use from e to em 2006-01-02, com from até to)", err)
+		return
+	}
+
+	sheet, err := h.taskService.Timesheet(r.Context(), from, to)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Erro ao montar a folha de horas", err)
+		return
+	}
+
+	if format == "csv" {
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		w.Header().Set("Content-Disposition", `attachment; filename="timesheet-`+sheet.From+`-`+sheet.To+`.csv"`)
+		w.WriteHeader(http.StatusOK)
+		sheet.WriteCSV(w)
+		return
+	}
+	respondJSON(w, http.StatusOK, sheet)
+}
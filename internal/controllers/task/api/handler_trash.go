@@ -0,0 +1,98 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// TrashedTask é uma tarefa da lixeira, com o horário em que foi apagada.
+type TrashedTask struct {
+	models.Task
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// @Summary     Listar lixeira
+// @Description Retorna as tarefas apagadas, das mais recentes para as mais antigas
+// @Tags        Trash
+// @Produce     json
+// @Success     200 {array} TrashedTask
+// @Failure     500 {object} ErrorResponse
+// @Router      /trash [get]
+func (h *TaskHandler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	tasks, err := h.taskService.Trash(r.Context())
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Erro ao listar a lixeira", err)
+		return
+	}
+
+	trashed := make([]TrashedTask, 0, len(tasks))
+	for _, t := range tasks {
+		trashed = append(trashed, TrashedTask{Task: t, DeletedAt: t.DeletedAt.Time})
+	}
+	respondJSON(w, http.StatusOK, trashed)
+}
+
+// @Summary     Restaurar tarefa
+// @Description Tira uma tarefa da lixeira. Com children=true, as subtarefas apagadas dela também são restauradas
+// @Tags        Trash
+// @Produce     json
+// @Param       id       path  string true  "ID da tarefa"
+// @Param       children query bool   false "Restaurar também as subtarefas apagadas"
+// @Success     200 {object} models.Task
+// @Failure     400 {object} ErrorResponse
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/{id}/restore [post]
+func (h *TaskHandler) RestoreTask(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	withChildren := false
+	if raw := r.URL.Query().Get("children"); raw != "" {
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Parâmetro children inválido", err)
+			return
+		}
+		withChildren = parsed
+	}
+
+	restored, err := h.taskService.Restore(r.Context(), id, withChildren)
+	if err != nil {
+		if err == ErrTaskNotInTrash {
+			respondError(w, http.StatusNotFound, "Tarefa não está na lixeira", nil)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Erro ao restaurar tarefa", err)
+		return
+	}
+
+	respondJSON(w, http.StatusOK, restored)
+}
+
+// @Summary     Apagar tarefa de vez
+// @Description Remove definitivamente uma tarefa que está na lixeira, junto com os lembretes dela
+// @Tags        Trash
+// @Produce     json
+// @Param       id path string true "ID da tarefa"
+// @Success     204 "Tarefa apagada definitivamente"
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /trash/{id} [delete]
+func (h *TaskHandler) PurgeTask(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	if err := h.taskService.Purge(r.Context(), id); err != nil {
+		if err == ErrTaskNotInTrash {
+			respondError(w, http.StatusNotFound, "Tarefa não está na lixeira", nil)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Erro ao apagar tarefa", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
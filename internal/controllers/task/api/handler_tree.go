@@ -0,0 +1,150 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"github.com/go-chi/chi/v5"
+)
+
+// Breadcrumb é um passo do caminho da raiz até a tarefa.
+type Breadcrumb struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+}
+
+// TaskTree é a tarefa com as subtarefas aninhadas e o caminho até ela.
+type TaskTree struct {
+	Breadcrumbs []Breadcrumb `json:"breadcrumbs"`
+	Task        *models.Task `json:"task"`
+}
+
+// @Summary     Árvore de subtarefas
+// @Description Retorna a tarefa com todas as subtarefas aninhadas (até depth níveis) e o caminho da raiz até ela
+// @Tags        Tasks
+// @Produce     json
+// @Param       id    path  string true  "ID da tarefa"
+// @Param       depth query int    false "Níveis de subtarefas (padrão e máximo: TASK_MAX_DEPTH)"
+// @Success     200 {object} TaskTree
+// @Failure     400 {object} ErrorResponse
+// @Failure     404 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/{id}/tree [get]
+func (h *TaskHandler) GetTaskTree(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	depth := 0
+	if raw := r.URL.Query().Get("depth"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Parâmetro depth inválido", err)
+			return
+		}
+		depth = parsed
+	}
+
+	tree, err := h.taskService.Tree(r.Context(), id, depth)
+	if err != nil {
+		switch err {
+		case ErrTaskNotFound:
+			respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
+		case ErrInvalidInput:
+			respondError(w, http.StatusBadRequest, "Parâmetro depth fora do limite", nil)
+		default:
+			respondError(w, http.StatusInternalServerError, "Erro ao carregar subtarefas", err)
+		}
+		return
+	}
+
+	h.respondTree(w, r, tree)
+}
+
+func (h *TaskHandler) respondTree(w http.ResponseWriter, r *http.Request, tree *models.Task) {
+	path, err := h.taskService.Breadcrumbs(r.Context(), tree.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Erro ao carregar caminho da tarefa", err)
+		return
+	}
+	breadcrumbs := make([]Breadcrumb, 0, len(path))
+	for _, ancestor := range path {
+		breadcrumbs = append(breadcrumbs, Breadcrumb{ID: ancestor.ID, Title: ancestor.Title})
+	}
+
+	respondJSON(w, http.StatusOK, TaskTree{Breadcrumbs: breadcrumbs, Task: tree})
+}
+
+type MoveTaskRequest struct {
+	// ParentID null ou ausente torna a tarefa raiz.
+	ParentID *string `json:"parent_id" example:"8f3edff7-f3fe-4ab1-a60a-f35efcdfbf70" extensions:"x-nullable"`
+}
+
+// @Summary     Mover tarefa
+// @Description Move a tarefa, com todas as subtarefas, para baixo de outra tarefa (ou para a raiz, com parent_id null). Retorna a árvore já no lugar novo
+// @Tags        Tasks
+// @Accept      json
+// @Produce     json
+// @Param       id       path   string          true  "ID da tarefa"
+// @Param       If-Match header string          false "ETag lida em GET /tasks/{id}"
+// @Param       body     body   MoveTaskRequest true  "Novo pai"
+// @Success     200 {object} TaskTree
+// @Header      200 {string} ETag "Nova versão da tarefa"
+// @Failure     400 {object} ErrorResponse
+// @Failure     404 {object} ErrorResponse
+// @Failure     409 {object} ErrorResponse
+// @Failure     412 {object} ErrorResponse
+// @Failure     500 {object} ErrorResponse
+// @Router      /tasks/{id}/move [post]
+func (h *TaskHandler) MoveTask(w http.ResponseWriter, r *http.Request) {
+	id := chi.URLParam(r, "id")
+
+	version, err := ifMatchVersion(r)
+	if err != nil {
+		respondError(w, http.StatusPreconditionFailed, "If-Match inválido", err)
+		return
+	}
+
+	var req MoveTaskRequest
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	// Corpo vazio é o mesmo que parent_id null.
+	if err := dec.Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, http.StatusBadRequest, "JSON inválido", err)
+		return
+	}
+	if req.ParentID != nil && strings.TrimSpace(*req.ParentID) == "" {
+		respondError(w, http.StatusBadRequest, "parent_id inválido", nil)
+		return
+	}
+
+	moved, err := h.taskService.Move(r.Context(), id, req.ParentID, version)
+	if err != nil {
+		switch err {
+		case ErrVersionConflict:
+			h.respondConflict(w, r, id, nil)
+		case ErrTaskNotFound:
+			respondError(w, http.StatusNotFound, "Tarefa não encontrada", nil)
+		case ErrParentTaskNotFound:
+			respondError(w, http.StatusNotFound, "Tarefa pai não encontrada", nil)
+		case ErrTaskCycle:
+			respondError(w, http.StatusConflict, "A tarefa não pode ficar abaixo de uma das próprias subtarefas", nil)
+		case ErrMaxDepthExceeded:
+			respondError(w, http.StatusConflict, "Profundidade máxima de subtarefas excedida", nil)
+		default:
+			respondError(w, http.StatusInternalServerError, "Erro ao mover tarefa", err)
+		}
+		return
+	}
+
+	tree, err := h.taskService.Tree(r.Context(), moved.ID, 0)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Erro ao carregar subtarefas", err)
+		return
+	}
+	setTaskETag(w, moved)
+	h.respondTree(w, r, tree)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
 	"github.com/andre-felipe-wonsik-alves/internal/models"
 )
 
@@ -13,29 +14,91 @@ var (
 	ErrTaskNotFound       = errors.New("tarefa não encontrada")
 	ErrInvalidInput       = errors.New("dados de entrada inválidos")
 	ErrParentTaskNotFound = errors.New("tarefa pai não encontrada")
+	ErrInvalidRecurrence  = task.ErrInvalidRecurrence
+	ErrTaskAlreadyDone    = errors.New("tarefa já concluída")
+	ErrReminderNotFound   = errors.New("lembrete não encontrado")
+	ErrInvalidReminder    = errors.New("lembrete inválido")
+	ErrTaskNotInTrash     = errors.New("tarefa não está na lixeira")
+	ErrVersionConflict    = task.ErrVersionConflict
+	ErrInvalidSort        = task.ErrInvalidSort
+	ErrInvalidCursor      = task.ErrInvalidCursor
+	ErrTaskCycle          = errors.New("a tarefa não pode ficar abaixo de uma das próprias subtarefas")
+	ErrMaxDepthExceeded   = errors.New("a árvore de subtarefas passaria da profundidade máxima")
+	ErrOpenSubtasks       = errors.New("a tarefa tem subtarefas em aberto")
+	ErrTagNotFound        = errors.New("tag não encontrada")
+	ErrTagExists          = errors.New("já existe uma tag com esse nome")
+	ErrInvalidTag         = task.ErrInvalidTag
+	ErrInvalidTagColor    = task.ErrInvalidTagColor
+	ErrProjectNotFound    = errors.New("projeto não encontrado")
+	ErrProjectExists      = errors.New("já existe um projeto com esse nome")
+	ErrProjectArchived    = errors.New("o projeto está arquivado")
+	ErrInvalidProject     = errors.New("projeto inválido")
+	ErrDependencyCycle    = errors.New("a dependência fecharia um ciclo entre as tarefas")
+	ErrDependencyNotFound = errors.New("dependência não encontrada")
+	ErrTaskBlocked        = errors.New("a tarefa tem dependências em aberto")
+	ErrInvalidStatus      = task.ErrInvalidStatus
+	ErrInvalidTransition  = task.ErrInvalidTransition
+	ErrTaskCancelled      = errors.New("tarefa cancelada")
+	ErrTaskNotClosed      = errors.New("a tarefa não está concluída nem cancelada")
+	ErrInvalidWeights     = task.ErrInvalidWeights
+	ErrInvalidEstimate    = task.ErrInvalidEstimate
+	ErrInvalidPlanDate    = task.ErrInvalidPlanDate
+	ErrTimerRunning       = errors.New("o cronômetro já está rodando nessa tarefa")
+	ErrTimerConflict      = task.ErrTimerConflict
+	ErrNoRunningTimer     = errors.New("nenhum cronômetro rodando")
+	ErrInvalidPeriod      = task.ErrInvalidPeriod
 )
 
-type Store interface {
+// TaskStore guarda as tarefas. Atomic roda fn numa transação: as chamadas
+// com o ctx recebido entram nela.
+type TaskStore interface {
+	Atomic(ctx context.Context, fn func(ctx context.Context) error) error
 	Create(ctx context.Context, task *models.Task) error
 	GetByID(ctx context.Context, id string) (*models.Task, error)
-	List(ctx context.Context) ([]models.Task, error)
+	List(ctx context.Context, query task.ListQuery) ([]models.Task, error)
 	Patch(ctx context.Context, id string, changes map[string]any) (*models.Task, error)
+	PatchIfVersion(ctx context.Context, id string, version int64, changes map[string]any) (*models.Task, error)
 	Delete(ctx context.Context, id string) error
+	DeleteIfVersion(ctx context.Context, id string, version int64) error
+	ListDue(ctx context.Context, filter task.DueFilter) ([]models.Task, error)
+	Search(ctx context.Context, query string, filter task.SearchFilter, limit int) ([]task.SearchResult, error)
+}
+
+// Store reúne tudo o que o Service precisa guardar, separado por
+// funcionalidade.
+type Store interface {
+	TaskStore
+	ReminderStore
+	TrashStore
+	HistoryStore
+	TreeStore
+	TagStore
+	ProjectStore
+	DependencyStore
+	TimeStore
 }
 
 type Service struct {
-	repo Store
+	repo   Store
+	config Config
 }
 
 func NewService(repo Store) *Service {
-	return &Service{repo: repo}
+	return NewServiceWithConfig(repo, DefaultConfig())
+}
+
+func NewServiceWithConfig(repo Store, config Config) *Service {
+	return &Service{repo: repo, config: config}
 }
 
 func (s *Service) List(ctx context.Context) ([]models.Task, error) {
-	tasks, err := s.repo.List(ctx)
+	tasks, err := s.repo.List(ctx, task.ListQuery{})
 	if err != nil {
 		return nil, fmt.Errorf("[ ERRO ] Problema ao listar as Tasks: %w", err)
 	}
+	if err := s.attachComputed(ctx, taskRefs(tasks)...); err != nil {
+		return nil, err
+	}
 	return tasks, nil
 }
 
@@ -47,6 +110,9 @@ func (s *Service) ListSubtasks(ctx context.Context, parentID string) ([]models.T
 	if parent.Children == nil {
 		return []models.Task{}, nil
 	}
+	if err := s.attachComputed(ctx, taskRefs(parent.Children)...); err != nil {
+		return nil, err
+	}
 	return parent.Children, nil
 }
 
@@ -56,7 +122,13 @@ func (s *Service) GetByID(ctx context.Context, id string) (*models.Task, error)
 	if err != nil {
 		return nil, ErrTaskNotFound
 	}
+	if task == nil {
+		return nil, nil
+	}
 
+	if err := s.attachComputed(ctx, append(taskRefs(task.Children), task)...); err != nil {
+		return nil, err
+	}
 	return task, nil
 }
 
@@ -65,29 +137,76 @@ func (s *Service) Create(ctx context.Context, title, description string, priorit
 }
 
 func (s *Service) CreateWithParent(ctx context.Context, title, description string, priority models.Priority, reminderAt time.Time, parentID *string) (*models.Task, error) {
+	return s.CreateTask(ctx, models.Task{
+		Title:       title,
+		Description: description,
+		Priority:    priority,
+		ReminderAt:  reminderAt,
+		ParentID:    parentID,
+	})
+}
+
+func (s *Service) CreateTask(ctx context.Context, newTask models.Task) (*models.Task, error) {
+	parentID := newTask.ParentID
 	if parentID != nil && *parentID == "" {
 		return nil, ErrInvalidInput
 	}
+	if newTask.Recurrence != "" {
+		if _, err := task.ParseRecurrence(newTask.Recurrence); err != nil {
+			return nil, ErrInvalidRecurrence
+		}
+	}
+	if !validEstimate(newTask.EstimateMinutes) {
+		return nil, ErrInvalidEstimate
+	}
 	if parentID != nil {
 		if _, err := s.loadParentTask(ctx, *parentID, ""); err != nil {
 			return nil, err
 		}
 	}
+	if newTask.ProjectID != nil {
+		project, err := s.loadOpenProject(ctx, *newTask.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		if err := applyProjectDefaults(&newTask, project, time.Now()); err != nil {
+			return nil, err
+		}
+	}
 
-	newTask := models.Task{
-		Title:       title,
-		Description: description,
-		Priority:    priority,
-		ReminderAt:  reminderAt,
-		Done:        false,
-		ParentID:    parentID,
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+	// As tags chegam só com o nome; são vinculadas depois do INSERT.
+	var tags []models.Tag
+	if len(newTask.Tags) > 0 {
+		var err error
+		if tags, err = s.resolveTags(ctx, tagNames(newTask.Tags)); err != nil {
+			return nil, err
+		}
+	}
+
+	newTask.ID = ""
+	newTask.Tags = nil
+	newTask.Version = 0
+	newTask.Status = models.StatusTodo
+	newTask.Done = false
+	newTask.StartedAt, newTask.CompletedAt, newTask.CancelledAt = nil, nil, nil
+	newTask.CreatedAt = time.Now()
+	newTask.UpdatedAt = time.Now()
+
+	if len(newTask.Reminders) == 0 && !newTask.ReminderAt.IsZero() {
+		newTask.Reminders = []models.Reminder{{Offset: "0"}}
+	}
+	for i := range newTask.Reminders {
+		if err := scheduleReminder(&newTask.Reminders[i], &newTask); err != nil {
+			return nil, err
+		}
 	}
 
 	if err := s.repo.Create(ctx, &newTask); err != nil {
 		return nil, err
 	}
+	if err := s.attachTags(ctx, newTask.ID, tags); err != nil {
+		return nil, err
+	}
 
 	createdTask, err := s.repo.GetByID(ctx, newTask.ID)
 	if err != nil {
@@ -96,11 +215,35 @@ func (s *Service) CreateWithParent(ctx context.Context, title, description strin
 	if createdTask == nil {
 		return nil, ErrTaskNotFound
 	}
+	if err := s.record(ctx, models.HistoryCreate, nil, createdTask); err != nil {
+		return nil, err
+	}
+	s.attachDue(time.Now(), createdTask)
 	return createdTask, nil
 }
 
 func (s *Service) Patch(ctx context.Context, id string, changes map[string]any) (*models.Task, error) {
-	if value, ok := changes["parent_id"]; ok {
+	return s.PatchIfMatch(ctx, id, 0, changes)
+}
+
+// PatchIfMatch é o Patch com controle de concorrência: com version > 0, a
+// alteração só é aplicada se a tarefa ainda estiver nessa versão. Tudo roda
+// numa transação, para uma conclusão em cascata não ficar pela metade.
+func (s *Service) PatchIfMatch(ctx context.Context, id string, version int64, changes map[string]any) (*models.Task, error) {
+	var patched *models.Task
+	err := s.repo.Atomic(ctx, func(ctx context.Context) (err error) {
+		patched, err = s.patchIfMatch(ctx, id, version, changes)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return patched, nil
+}
+
+func (s *Service) patchIfMatch(ctx context.Context, id string, version int64, changes map[string]any) (*models.Task, error) {
+	// parent_id nil desvincula a tarefa do pai; não há o que validar.
+	if value, ok := changes["parent_id"]; ok && value != nil {
 		parentID, ok := value.(string)
 		if !ok {
 			return nil, ErrInvalidInput
@@ -109,21 +252,112 @@ func (s *Service) Patch(ctx context.Context, id string, changes map[string]any)
 			return nil, err
 		}
 	}
+	// project_id nil tira a tarefa do projeto.
+	if value, ok := changes["project_id"]; ok && value != nil {
+		projectID, ok := value.(string)
+		if !ok {
+			return nil, ErrInvalidInput
+		}
+		if _, err := s.loadOpenProject(ctx, projectID); err != nil {
+			return nil, err
+		}
+	}
+	if value, ok := changes["recurrence"]; ok {
+		recurrence, ok := value.(string)
+		if !ok {
+			return nil, ErrInvalidInput
+		}
+		if recurrence != "" {
+			if _, err := task.ParseRecurrence(recurrence); err != nil {
+				return nil, ErrInvalidRecurrence
+			}
+		}
+	}
 
-	task, err := s.repo.Patch(ctx, id, changes)
+	if value, ok := changes["estimate_minutes"]; ok {
+		if minutes, ok := value.(int); !ok || !validEstimate(minutes) {
+			return nil, ErrInvalidEstimate
+		}
+	}
 
+	before, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("[ ERRO ] Problema dentro do Patch: %w", err)
 	}
+	if err := resolveStatus(before, changes, time.Now()); err != nil {
+		return nil, err
+	}
+
+	// Concluir pelo Patch segue o mesmo caminho de Complete (subtarefas,
+	// recorrência e pais): o resto das mudanças é gravado antes, e as colunas
+	// de status ficam com complete.
+	completing := false
+	if done, _ := changes["done"].(bool); done && before != nil && before.Status != models.StatusDone {
+		if version > 0 && before.Version != version {
+			return nil, ErrVersionConflict
+		}
+		if err := s.prepareCompletion(ctx, id); err != nil {
+			return nil, err
+		}
+		for column := range statusChanges(before, models.StatusDone, time.Now()) {
+			delete(changes, column)
+		}
+		completing = true
+	}
+
+	task := before
+	if !completing || len(changes) > 0 {
+		if task, err = s.patch(ctx, id, version, changes); err != nil {
+			if errors.Is(err, ErrVersionConflict) {
+				return nil, err
+			}
+			return nil, fmt.Errorf("[ ERRO ] Problema dentro do Patch: %w", err)
+		}
+		if task == nil {
+			return nil, nil
+		}
+		_, remind := changes["reminder_at"]
+		_, due := changes["due_at"]
+		if remind || due {
+			if task, err = s.rescheduleReminders(ctx, task); err != nil {
+				return nil, err
+			}
+		}
+		if err := s.record(ctx, models.HistoryUpdate, before, task); err != nil {
+			return nil, err
+		}
+		version = 0
+	}
+	if completing {
+		if task, err = s.complete(ctx, id, task, version); err != nil {
+			return nil, err
+		}
+		if task == nil {
+			return nil, nil
+		}
+		if err := s.completeFinishedParents(ctx, task); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.attachComputed(ctx, task); err != nil {
+		return nil, err
+	}
 	return task, nil
 }
 
+func (s *Service) patch(ctx context.Context, id string, version int64, changes map[string]any) (*models.Task, error) {
+	if version > 0 {
+		return s.repo.PatchIfVersion(ctx, id, version, changes)
+	}
+	return s.repo.Patch(ctx, id, changes)
+}
+
 func (s *Service) loadParentTask(ctx context.Context, parentID, currentID string) (*models.Task, error) {
 	if parentID == "" {
 		return nil, ErrInvalidInput
 	}
 	if currentID != "" && parentID == currentID {
-		return nil, ErrInvalidInput
+		return nil, ErrTaskCycle
 	}
 	parentTask, err := s.repo.GetByID(ctx, parentID)
 	if err != nil {
@@ -132,30 +366,280 @@ func (s *Service) loadParentTask(ctx context.Context, parentID, currentID string
 	if parentTask == nil {
 		return nil, ErrParentTaskNotFound
 	}
+	if err := s.checkPlacement(ctx, parentID, currentID); err != nil {
+		return nil, err
+	}
 	return parentTask, nil
 }
-
 func (s *Service) Delete(ctx context.Context, id string) error {
-	err := s.repo.Delete(ctx, id)
+	return s.DeleteIfMatch(ctx, id, 0)
+}
 
+func (s *Service) DeleteIfMatch(ctx context.Context, id string, version int64) error {
+	before, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return fmt.Errorf("[ ERRO ] Problema dentro do Delete: %w", err)
 	}
 
+	if version > 0 {
+		err = s.repo.DeleteIfVersion(ctx, id, version)
+	} else {
+		err = s.repo.Delete(ctx, id)
+	}
+
+	if err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			return err
+		}
+		return fmt.Errorf("[ ERRO ] Problema dentro do Delete: %w", err)
+	}
+
+	if before != nil {
+		deletedAt := time.Now()
+		return s.recordDeletion(ctx, models.HistoryDelete, id, nil, &deletedAt)
+	}
 	return nil
 }
 
 func (s *Service) Complete(ctx context.Context, id string) (*models.Task, error) {
-	changes := map[string]any{}
-	changes["done"] = true
+	return s.CompleteIfMatch(ctx, id, 0)
+}
 
-	task, err := s.repo.Patch(ctx, id, changes)
+// CompleteIfMatch conclui a tarefa conforme a política de conclusão
+// configurada e devolve a tarefa já com o progresso das subtarefas. A cascata
+// roda numa transação: se alguma subtarefa falhar, nada fica concluído.
+func (s *Service) CompleteIfMatch(ctx context.Context, id string, version int64) (*models.Task, error) {
+	var completed *models.Task
+	err := s.repo.Atomic(ctx, func(ctx context.Context) (err error) {
+		completed, err = s.completeIfMatch(ctx, id, version)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return completed, nil
+}
 
+func (s *Service) completeIfMatch(ctx context.Context, id string, version int64) (*models.Task, error) {
+	before, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, fmt.Errorf("[ ERRO ] Problema ao completar tarefa: %w", err)
 	}
+	if before != nil {
+		// A versão é conferida antes de mexer nas subtarefas, para um conflito
+		// não deixar a cascata feita pela metade.
+		if version > 0 && before.Version != version {
+			return nil, ErrVersionConflict
+		}
+		if !task.CanTransition(before.Status, models.StatusDone) {
+			return nil, ErrInvalidTransition
+		}
+		if err := s.prepareCompletion(ctx, id); err != nil {
+			return nil, err
+		}
+	}
 
-	return task, nil
+	completed, err := s.complete(ctx, id, before, version)
+	if err != nil {
+		return nil, err
+	}
+	if completed == nil {
+		return nil, nil
+	}
+	if err := s.completeFinishedParents(ctx, completed); err != nil {
+		return nil, err
+	}
+	if err := s.attachComputed(ctx, completed); err != nil {
+		return nil, err
+	}
+	return completed, nil
+}
+
+// complete marca a tarefa como concluída, sem olhar as subtarefas nem o pai.
+func (s *Service) complete(ctx context.Context, id string, before *models.Task, version int64) (*models.Task, error) {
+	completed, err := s.patch(ctx, id, version, statusChanges(before, models.StatusDone, time.Now()))
+
+	if err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("[ ERRO ] Problema ao completar tarefa: %w", err)
+	}
+	if completed == nil {
+		return nil, nil
+	}
+
+	if completed.Recurrence != "" {
+		if completed, err = s.advanceRecurrence(ctx, completed); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.record(ctx, models.HistoryComplete, before, completed); err != nil {
+		return nil, err
+	}
+	return completed, nil
+}
+
+// advanceRecurrence cria a próxima ocorrência de uma tarefa recorrente. A
+// ocorrência concluída fica como histórico da série e a regra passa para a
+// nova, assim completar de novo a antiga não gera duplicatas.
+func (s *Service) advanceRecurrence(ctx context.Context, completed *models.Task) (*models.Task, error) {
+	base := completed.ReminderAt
+	if base.IsZero() && completed.DueAt != nil {
+		base = *completed.DueAt
+	}
+	rule, recurrence, err := task.AnchorRecurrence(completed.Recurrence, base)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao calcular próxima ocorrência: %w", err)
+	}
+
+	seriesID := completed.ID
+	if completed.SeriesID != nil {
+		seriesID = *completed.SeriesID
+	}
+
+	// Lembrete, prazo e lembretes absolutos avançam cada um pela regra; o que
+	// não estava definido na concluída continua sem definir.
+	now := time.Now()
+	advance := func(at time.Time) time.Time {
+		return task.NextOccurrence(rule, at, now)
+	}
+
+	next := models.Task{
+		Title:           completed.Title,
+		Description:     completed.Description,
+		Priority:        completed.Priority,
+		Reminders:       shiftReminders(completed.Reminders, advance),
+		Channels:        completed.Channels,
+		Recurrence:      recurrence,
+		SeriesID:        &seriesID,
+		ParentID:        completed.ParentID,
+		ProjectID:       completed.ProjectID,
+		DueAllDay:       completed.DueAllDay,
+		EstimateMinutes: completed.EstimateMinutes,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if !completed.ReminderAt.IsZero() {
+		next.ReminderAt = advance(completed.ReminderAt)
+	}
+	if completed.DueAt != nil {
+		dueAt := advance(*completed.DueAt)
+		next.DueAt = &dueAt
+	}
+	for i := range next.Reminders {
+		if err := scheduleReminder(&next.Reminders[i], &next); err != nil {
+			return nil, err
+		}
+	}
+	if err := s.repo.Create(ctx, &next); err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao criar próxima ocorrência: %w", err)
+	}
+	if err := s.attachTags(ctx, next.ID, completed.Tags); err != nil {
+		return nil, err
+	}
+	next.Tags = completed.Tags
+	if err := s.record(ctx, models.HistoryCreate, nil, &next); err != nil {
+		return nil, err
+	}
+
+	history, err := s.repo.Patch(ctx, completed.ID, map[string]any{
+		"recurrence": "",
+		"series_id":  seriesID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao arquivar ocorrência concluída: %w", err)
+	}
+	return history, nil
+}
+
+// Snooze adia o lembrete para until e libera um novo disparo pelo scheduler.
+// Os lembretes relativos acompanham o novo horário; se nenhum cair em until,
+// um lembrete absoluto é criado.
+func (s *Service) Snooze(ctx context.Context, id string, until time.Time) (*models.Task, error) {
+	if !until.After(time.Now()) {
+		return nil, ErrInvalidInput
+	}
+
+	var snoozed *models.Task
+	err := s.repo.Atomic(ctx, func(ctx context.Context) (err error) {
+		snoozed, err = s.snooze(ctx, id, until)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snoozed, nil
+}
+
+func (s *Service) snooze(ctx context.Context, id string, until time.Time) (*models.Task, error) {
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tarefa para adiar: %w", err)
+	}
+	if current == nil {
+		return nil, ErrTaskNotFound
+	}
+	if current.Done {
+		return nil, ErrTaskAlreadyDone
+	}
+	if current.Status == models.StatusCancelled {
+		return nil, ErrTaskCancelled
+	}
+
+	// Condicional à versão lida: dois snoozes simultâneos não perdem contagem.
+	snoozed, err := s.repo.PatchIfVersion(ctx, id, current.Version, map[string]any{
+		"reminder_at":  until,
+		"snooze_count": current.SnoozeCount + 1,
+	})
+	if err != nil {
+		if errors.Is(err, ErrVersionConflict) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("[ ERRO ] Problema ao adiar lembrete: %w", err)
+	}
+	if snoozed == nil {
+		return nil, ErrTaskNotFound
+	}
+
+	snoozed, err = s.rescheduleReminders(ctx, snoozed)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.record(ctx, models.HistorySnooze, current, snoozed); err != nil {
+		return nil, err
+	}
+	for _, r := range snoozed.Reminders {
+		if r.DeliveredAt == nil && r.FireAt.Equal(until) {
+			return snoozed, nil
+		}
+	}
+
+	reminder := models.Reminder{TaskID: id, At: &until, FireAt: until}
+	if err := s.repo.CreateReminder(ctx, &reminder); err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao adiar lembrete: %w", err)
+	}
+	snoozed.Reminders = append(snoozed.Reminders, reminder)
+	return snoozed, nil
+}
+
+func (s *Service) GetDue(ctx context.Context, filter task.DueFilter) ([]models.Task, error) {
+	if filter.Before.IsZero() {
+		filter.Before = time.Now()
+	}
+	if filter.After != nil && filter.After.After(filter.Before) {
+		return nil, ErrInvalidInput
+	}
+
+	tasks, err := s.repo.ListDue(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tarefas vencidas: %w", err)
+	}
+	if err := s.attachComputed(ctx, taskRefs(tasks)...); err != nil {
+		return nil, err
+	}
+	return tasks, nil
 }
 
 func ParsePriority(s string) (models.Priority, error) {
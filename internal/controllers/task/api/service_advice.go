@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// DefaultAdviceLimit é quantas sugestões o advisor devolve quando o limite
+// não é informado.
+const DefaultAdviceLimit = 5
+
+// AdviceWeights são os pesos configurados do advisor; sem nenhum peso
+// configurado valem os padrões.
+func (s *Service) AdviceWeights() task.AdviceWeights {
+	if s.config.Advice == (task.AdviceWeights{}) {
+		return task.DefaultAdviceWeights()
+	}
+	return s.config.Advice
+}
+
+// Advice sugere até limit tarefas em aberto, da mais para a menos
+// recomendada, cada uma com a explicação da posição. overrides troca alguns
+// pesos só nesta consulta, no formato de task.ParseAdviceWeights.
+func (s *Service) Advice(ctx context.Context, limit int, overrides string) ([]task.Advice, error) {
+	if limit < 0 || limit > MaxPageSize {
+		return nil, ErrInvalidInput
+	}
+	if limit == 0 {
+		limit = DefaultAdviceLimit
+	}
+	weights, err := task.ParseAdviceWeights(overrides, s.AdviceWeights())
+	if err != nil {
+		return nil, err
+	}
+
+	advice, err := s.rankOpen(ctx, weights, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(advice) > limit {
+		advice = advice[:limit]
+	}
+	return advice, nil
+}
+
+// rankOpen pontua todas as tarefas em aberto.
+func (s *Service) rankOpen(ctx context.Context, weights task.AdviceWeights, now time.Time) ([]task.Advice, error) {
+	tasks, err := s.repo.List(ctx, task.ListQuery{Statuses: models.OpenStatuses})
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao listar as Tasks: %w", err)
+	}
+	if err := s.attachComputed(ctx, taskRefs(tasks)...); err != nil {
+		return nil, err
+	}
+	return task.Rank(tasks, weights, now), nil
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// prepareCompletion aplica a política de conclusão às subtarefas de id antes
+// de ela ser concluída: com block, recusa se houver alguma em aberto; com
+// cascade, conclui todas, das mais fundas para as mais rasas. As dependências
+// de id e das subtarefas da cascata são conferidas antes de mexer em qualquer
+// uma; quem chama roda tudo dentro de repo.Atomic.
+func (s *Service) prepareCompletion(ctx context.Context, id string) error {
+	var open []models.Task
+	if s.config.Completion == CompletionBlock || s.config.Completion == CompletionCascade {
+		tree, err := s.repo.GetTree(ctx, id, s.config.MaxDepth)
+		if err != nil {
+			return fmt.Errorf("[ ERRO ] Problema ao carregar subtarefas: %w", err)
+		}
+		open = openSubtasks(tree)
+		if len(open) > 0 && s.config.Completion == CompletionBlock {
+			return ErrOpenSubtasks
+		}
+	}
+
+	ids := []string{id}
+	for _, t := range open {
+		ids = append(ids, t.ID)
+	}
+	if err := s.checkBlockers(ctx, ids...); err != nil {
+		return err
+	}
+
+	for i := range open {
+		if _, err := s.complete(ctx, open[i].ID, &open[i], 0); err != nil {
+			return fmt.Errorf("[ ERRO ] Problema ao concluir subtarefa %s: %w", open[i].ID, err)
+		}
+	}
+	return nil
+}
+
+// openSubtasks devolve as subtarefas em aberto (nem concluídas nem canceladas)
+// da árvore, filhos antes dos pais.
+func openSubtasks(tree *models.Task) []models.Task {
+	if tree == nil {
+		return nil
+	}
+	var open []models.Task
+	for i := range tree.Children {
+		child := tree.Children[i]
+		open = append(open, openSubtasks(&child)...)
+		if !child.Status.Closed() {
+			child.Children = nil
+			open = append(open, child)
+		}
+	}
+	return open
+}
+
+// completeFinishedParents sobe pela árvore concluindo os pais que ficaram sem
+// subtarefas em aberto, quando AutoCompleteParent está ligado.
+func (s *Service) completeFinishedParents(ctx context.Context, completed *models.Task) error {
+	if !s.config.AutoCompleteParent {
+		return nil
+	}
+
+	for parentID := completed.ParentID; parentID != nil; {
+		parent, err := s.repo.GetTree(ctx, *parentID, 1)
+		if err != nil {
+			return fmt.Errorf("[ ERRO ] Problema ao carregar tarefa pai: %w", err)
+		}
+		if parent == nil || parent.Status.Closed() {
+			return nil
+		}
+		for _, child := range parent.Children {
+			if !child.Status.Closed() {
+				return nil
+			}
+		}
+
+		done, err := s.complete(ctx, parent.ID, parent, 0)
+		if err != nil {
+			return fmt.Errorf("[ ERRO ] Problema ao concluir tarefa pai %s: %w", parent.ID, err)
+		}
+		if done == nil {
+			return nil
+		}
+		parentID = done.ParentID
+	}
+	return nil
+}
+
+// attachProgress preenche o progresso das subtarefas de cada tarefa.
+func (s *Service) attachProgress(ctx context.Context, tasks ...*models.Task) error {
+	ids := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		if t != nil {
+			ids = append(ids, t.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	progress, err := s.repo.Progress(ctx, ids, s.config.MaxDepth)
+	if err != nil {
+		return fmt.Errorf("[ ERRO ] Problema ao calcular progresso: %w", err)
+	}
+	for _, t := range tasks {
+		if p, ok := progress[t.ID]; t != nil && ok {
+			t.Progress = &p
+		}
+	}
+	return nil
+}
+
+func taskRefs(tasks []models.Task) []*models.Task {
+	refs := make([]*models.Task, len(tasks))
+	for i := range tasks {
+		refs[i] = &tasks[i]
+	}
+	return refs
+}
+
+// treeRefs devolve a tarefa e todas as subtarefas carregadas abaixo dela.
+func treeRefs(tree *models.Task) []*models.Task {
+	refs := []*models.Task{tree}
+	for i := range tree.Children {
+		refs = append(refs, treeRefs(&tree.Children[i])...)
+	}
+	return refs
+}
@@ -0,0 +1,287 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// DependencyStore guarda as dependências entre tarefas.
+type DependencyStore interface {
+	AddDependency(ctx context.Context, dep *models.TaskDependency) error
+	RemoveDependency(ctx context.Context, taskID, dependsOnID string) (bool, error)
+	DependenciesOf(ctx context.Context, id string) ([]models.TaskDependency, error)
+	ReachableDependencies(ctx context.Context, fromID string) ([]models.TaskDependency, error)
+	OpenBlockers(ctx context.Context, ids []string) (map[string][]string, error)
+}
+
+// TaskDependencies são as tarefas de que uma tarefa depende e as que dependem
+// dela. As que estão na lixeira ficam de fora.
+type TaskDependencies struct {
+	DependsOn []models.Task `json:"depends_on"`
+	Blocks    []models.Task `json:"blocks"`
+}
+
+// AddDependency faz taskID depender de dependsOnID: ela fica bloqueada até a
+// outra ser concluída. Adicionar de novo não é erro.
+func (s *Service) AddDependency(ctx context.Context, taskID, dependsOnID string) (*models.Task, error) {
+	var updated *models.Task
+	err := s.repo.Atomic(ctx, func(ctx context.Context) (err error) {
+		updated, err = s.addDependency(ctx, taskID, dependsOnID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *Service) addDependency(ctx context.Context, taskID, dependsOnID string) (*models.Task, error) {
+	before, err := s.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tarefa: %w", err)
+	}
+	if before == nil {
+		return nil, ErrTaskNotFound
+	}
+	blocker, err := s.repo.GetByID(ctx, dependsOnID)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar dependência: %w", err)
+	}
+	if blocker == nil {
+		return nil, ErrDependencyNotFound
+	}
+
+	edges, err := s.repo.DependenciesOf(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao carregar dependências: %w", err)
+	}
+	current := dependsOnOf(edges, taskID)
+	if slices.Contains(current, dependsOnID) {
+		return s.withComputed(ctx, before)
+	}
+	reachable, err := s.repo.ReachableDependencies(ctx, dependsOnID)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao carregar dependências: %w", err)
+	}
+	if task.DependencyCycle(reachable, taskID, dependsOnID) {
+		return nil, ErrDependencyCycle
+	}
+
+	dep := &models.TaskDependency{TaskID: taskID, DependsOnID: dependsOnID, CreatedAt: time.Now()}
+	if err := s.repo.AddDependency(ctx, dep); err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao adicionar dependência: %w", err)
+	}
+	return s.touchDependencies(ctx, before, current, append(current, dependsOnID))
+}
+
+// RemoveDependency desfaz a dependência de taskID em dependsOnID.
+func (s *Service) RemoveDependency(ctx context.Context, taskID, dependsOnID string) (*models.Task, error) {
+	var updated *models.Task
+	err := s.repo.Atomic(ctx, func(ctx context.Context) (err error) {
+		updated, err = s.removeDependency(ctx, taskID, dependsOnID)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+func (s *Service) removeDependency(ctx context.Context, taskID, dependsOnID string) (*models.Task, error) {
+	before, err := s.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tarefa: %w", err)
+	}
+	if before == nil {
+		return nil, ErrTaskNotFound
+	}
+	edges, err := s.repo.DependenciesOf(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao carregar dependências: %w", err)
+	}
+
+	removed, err := s.repo.RemoveDependency(ctx, taskID, dependsOnID)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao remover dependência: %w", err)
+	}
+	if !removed {
+		return nil, ErrDependencyNotFound
+	}
+
+	current := dependsOnOf(edges, taskID)
+	remaining := make([]string, 0, len(current))
+	for _, id := range current {
+		if id != dependsOnID {
+			remaining = append(remaining, id)
+		}
+	}
+	return s.touchDependencies(ctx, before, current, remaining)
+}
+
+// Dependencies devolve as tarefas de que id depende e as que dependem dela.
+func (s *Service) Dependencies(ctx context.Context, id string) (*TaskDependencies, error) {
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tarefa: %w", err)
+	}
+	if current == nil {
+		return nil, ErrTaskNotFound
+	}
+	edges, err := s.repo.DependenciesOf(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao carregar dependências: %w", err)
+	}
+
+	deps := &TaskDependencies{DependsOn: []models.Task{}, Blocks: []models.Task{}}
+	if len(edges) == 0 {
+		return deps, nil
+	}
+	relatedIDs := make([]string, 0, len(edges))
+	for _, edge := range edges {
+		relatedIDs = append(relatedIDs, edge.TaskID, edge.DependsOnID)
+	}
+	// Uma consulta só para as tarefas relacionadas; as da lixeira não vêm.
+	related, err := s.repo.List(ctx, task.ListQuery{IDs: relatedIDs})
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar dependências: %w", err)
+	}
+	byID := make(map[string]models.Task, len(related))
+	for _, t := range related {
+		byID[t.ID] = t
+	}
+
+	for _, edge := range edges {
+		if t, ok := byID[edge.DependsOnID]; ok && edge.TaskID == id {
+			deps.DependsOn = append(deps.DependsOn, t)
+		}
+		if t, ok := byID[edge.TaskID]; ok && edge.DependsOnID == id {
+			deps.Blocks = append(deps.Blocks, t)
+		}
+	}
+	if err := s.attachComputed(ctx, append(taskRefs(deps.DependsOn), taskRefs(deps.Blocks)...)...); err != nil {
+		return nil, err
+	}
+	return deps, nil
+}
+
+// ReadyPage lista as tarefas em aberto sem dependências em aberto, com os
+// mesmos filtros, ordenação e paginação do ListPage.
+func (s *Service) ReadyPage(ctx context.Context, query task.ListQuery, cursor string) (*TaskPage, error) {
+	query.Ready = true
+	return s.ListPage(ctx, query, cursor)
+}
+
+// touchDependencies sobe a versão da tarefa depois de mudar as dependências,
+// como o touchTags, e registra a lista antiga e a nova no histórico.
+func (s *Service) touchDependencies(ctx context.Context, before *models.Task, old, current []string) (*models.Task, error) {
+	after, err := s.repo.Patch(ctx, before.ID, map[string]any{"updated_at": time.Now()})
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao atualizar tarefa: %w", err)
+	}
+	if after == nil {
+		return nil, ErrTaskNotFound
+	}
+	err = s.appendHistory(ctx, models.HistoryUpdate, before.ID, []models.TaskChange{{
+		Field:  "depends_on",
+		Before: auditValue(old),
+		After:  auditValue(current),
+	}})
+	if err != nil {
+		return nil, err
+	}
+	return s.withComputed(ctx, after)
+}
+
+// checkBlockers aplica a política de dependências antes de concluir ids: com
+// refuse (o padrão), recusa enquanto alguma delas depender de tarefa em
+// aberto que não esteja entre ids (essas são concluídas juntas).
+func (s *Service) checkBlockers(ctx context.Context, ids ...string) error {
+	if s.config.Dependencies == DependencyWarn {
+		return nil
+	}
+	blockers, err := s.repo.OpenBlockers(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("[ ERRO ] Problema ao carregar dependências: %w", err)
+	}
+	for _, id := range ids {
+		for _, blocker := range blockers[id] {
+			if !slices.Contains(ids, blocker) {
+				return ErrTaskBlocked
+			}
+		}
+	}
+	return nil
+}
+
+// attachBlockers preenche BlockedBy com as dependências em aberto de cada
+// tarefa, e Blocked nas que ainda estão em aberto.
+func (s *Service) attachBlockers(ctx context.Context, tasks ...*models.Task) error {
+	ids := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		if t != nil {
+			ids = append(ids, t.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	blockers, err := s.repo.OpenBlockers(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("[ ERRO ] Problema ao carregar dependências: %w", err)
+	}
+	for _, t := range tasks {
+		if t == nil {
+			continue
+		}
+		t.BlockedBy = blockers[t.ID]
+		t.Blocked = !t.Status.Closed() && len(t.BlockedBy) > 0
+	}
+	return nil
+}
+
+// attachComputed preenche os campos calculados da tarefa: o progresso das
+// subtarefas, o estado do prazo, o tempo registrado e o bloqueio por
+// dependências.
+func (s *Service) attachComputed(ctx context.Context, tasks ...*models.Task) error {
+	if err := s.attachProgress(ctx, tasks...); err != nil {
+		return err
+	}
+	now := time.Now()
+	s.attachDue(now, tasks...)
+	if err := s.attachTracked(ctx, now, tasks...); err != nil {
+		return err
+	}
+	return s.attachBlockers(ctx, tasks...)
+}
+
+// attachDue preenche overdue, due_today e due_soon a partir do prazo.
+func (s *Service) attachDue(now time.Time, tasks ...*models.Task) {
+	for _, t := range tasks {
+		state := task.DueStateOf(*t, now, s.config.DueSoon)
+		t.Overdue, t.DueToday, t.DueSoon = state.Overdue, state.Today, state.Soon
+	}
+}
+
+func (s *Service) withComputed(ctx context.Context, t *models.Task) (*models.Task, error) {
+	if err := s.attachComputed(ctx, t); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+// dependsOnOf devolve, na ordem de criação, de quem taskID depende.
+func dependsOnOf(edges []models.TaskDependency, taskID string) []string {
+	ids := []string{}
+	for _, edge := range edges {
+		if edge.TaskID == taskID {
+			ids = append(ids, edge.DependsOnID)
+		}
+	}
+	return ids
+}
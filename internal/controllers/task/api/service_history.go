@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// HistoryStore guarda o histórico de mudanças das tarefas.
+type HistoryStore interface {
+	AppendHistory(ctx context.Context, changes []models.TaskChange) error
+	ListHistory(ctx context.Context, taskID string) ([]models.TaskChange, error)
+}
+
+// Campos da tarefa que não entram no histórico: identidade, relações e
+// metadados que mudam a cada escrita.
+var untrackedFields = map[string]bool{
+	"id":              true,
+	"parent":          true,
+	"children":        true,
+	"reminders":       true,
+	"progress":        true,
+	"blocked":         true,
+	"blocked_by":      true,
+	"overdue":         true,
+	"due_today":       true,
+	"due_soon":        true,
+	"tracked_seconds": true,
+	"version":         true,
+	"created_at":      true,
+	"updated_at":      true,
+}
+
+// History devolve as mudanças registradas para a tarefa, da mais antiga para
+// a mais recente. Tarefas apagadas de vez continuam com histórico.
+func (s *Service) History(ctx context.Context, id string) ([]models.TaskChange, error) {
+	changes, err := s.repo.ListHistory(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar histórico: %w", err)
+	}
+	if len(changes) > 0 {
+		return changes, nil
+	}
+
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar histórico: %w", err)
+	}
+	if current == nil {
+		return nil, ErrTaskNotFound
+	}
+	return []models.TaskChange{}, nil
+}
+
+// record grava no histórico os campos que mudaram de before para after. before
+// nil só é aceito na criação.
+func (s *Service) record(ctx context.Context, action models.HistoryAction, before, after *models.Task) error {
+	if after == nil || (before == nil && action != models.HistoryCreate) {
+		return nil
+	}
+	return s.appendHistory(ctx, action, after.ID, diffTask(before, after))
+}
+
+// recordDeletion registra a entrada ou saída da lixeira como mudança de
+// deleted_at, que não aparece no JSON da tarefa.
+func (s *Service) recordDeletion(ctx context.Context, action models.HistoryAction, id string, before, after *time.Time) error {
+	return s.appendHistory(ctx, action, id, []models.TaskChange{{
+		Field:  "deleted_at",
+		Before: auditValue(before),
+		After:  auditValue(after),
+	}})
+}
+
+func (s *Service) appendHistory(ctx context.Context, action models.HistoryAction, id string, changes []models.TaskChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	source := task.SourceFrom(ctx)
+	now := time.Now()
+	for i := range changes {
+		changes[i].TaskID = id
+		changes[i].Action = action
+		changes[i].Source = source
+		changes[i].CreatedAt = now
+	}
+
+	if err := s.repo.AppendHistory(ctx, changes); err != nil {
+		return fmt.Errorf("[ ERRO ] Problema ao registrar histórico: %w", err)
+	}
+	return nil
+}
+
+// diffTask compara campo a campo pelo JSON de cada um, então campos novos do
+// modelo entram no histórico sem mudanças aqui.
+func diffTask(before, after *models.Task) []models.TaskChange {
+	var changes []models.TaskChange
+
+	afterValue := reflect.ValueOf(after).Elem()
+	var beforeValue reflect.Value
+	if before != nil {
+		beforeValue = reflect.ValueOf(before).Elem()
+	}
+
+	taskType := afterValue.Type()
+	for i := 0; i < taskType.NumField(); i++ {
+		name, _, _ := strings.Cut(taskType.Field(i).Tag.Get("json"), ",")
+		if name == "" || name == "-" || untrackedFields[name] {
+			continue
+		}
+
+		newValue := auditField(afterValue.Field(i))
+		if before == nil {
+			if isEmptyField(afterValue.Field(i)) {
+				continue
+			}
+			changes = append(changes, models.TaskChange{Field: name, After: newValue})
+			continue
+		}
+
+		oldValue := auditField(beforeValue.Field(i))
+		if oldValue != newValue {
+			changes = append(changes, models.TaskChange{Field: name, Before: oldValue, After: newValue})
+		}
+	}
+	return changes
+}
+
+// auditField devolve o valor do campo para o histórico. As tags entram só
+// pelo nome.
+func auditField(field reflect.Value) models.AuditValue {
+	if tags, ok := field.Interface().([]models.Tag); ok {
+		return auditValue(tagNames(tags))
+	}
+	return auditValue(field.Interface())
+}
+
+func isEmptyField(field reflect.Value) bool {
+	return field.IsZero() || (field.Kind() == reflect.Slice && field.Len() == 0)
+}
+
+func auditValue(value any) models.AuditValue {
+	switch v := value.(type) {
+	case time.Time:
+		value = v.UTC()
+	case *time.Time:
+		if v == nil {
+			return models.AuditValue("null")
+		}
+		value = v.UTC()
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return models.AuditValue(fmt.Sprintf("%q", fmt.Sprint(value)))
+	}
+	return models.AuditValue(encoded)
+}
@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 500
+)
+
+// TaskPage é uma página da listagem. NextCursor vazio indica a última página.
+type TaskPage struct {
+	Tasks      []models.Task
+	NextCursor string
+}
+
+// ListPage lista uma página de tarefas conforme query, continuando de cursor
+// (o NextCursor da página anterior, com a mesma ordenação). Sem limite nem
+// cursor lista tudo numa página só; com cursor, query.Limit 0 usa
+// DefaultPageSize.
+func (s *Service) ListPage(ctx context.Context, query task.ListQuery, cursor string) (*TaskPage, error) {
+	if invalidRange(query.ReminderFrom, query.ReminderTo) || invalidRange(query.CreatedFrom, query.CreatedTo) {
+		return nil, ErrInvalidInput
+	}
+	if _, _, err := task.ParseSort(query.SortSpec()); err != nil {
+		return nil, ErrInvalidSort
+	}
+	if query.Limit < 0 || query.Limit > MaxPageSize {
+		return nil, ErrInvalidInput
+	}
+	limit := query.Limit
+	if limit == 0 && cursor != "" {
+		limit = DefaultPageSize
+	}
+
+	query.After = nil
+	if cursor != "" {
+		after, err := task.DecodeCursor(cursor)
+		if err != nil || after.Sort != query.SortSpec() {
+			return nil, ErrInvalidCursor
+		}
+		query.After = &after
+	}
+
+	// Uma tarefa a mais só para saber se existe próxima página.
+	if limit > 0 {
+		query.Limit = limit + 1
+	}
+	tasks, err := s.repo.List(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao listar as Tasks: %w", err)
+	}
+
+	page := &TaskPage{Tasks: tasks}
+	if page.Tasks == nil {
+		page.Tasks = []models.Task{}
+	}
+	if limit > 0 && len(tasks) > limit {
+		page.Tasks = tasks[:limit]
+		page.NextCursor = query.CursorFor(page.Tasks[limit-1]).Encode()
+	}
+	if err := s.attachComputed(ctx, taskRefs(page.Tasks)...); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+func invalidRange(from, to *time.Time) bool {
+	return from != nil && to != nil && from.After(*to)
+}
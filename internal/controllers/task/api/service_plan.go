@@ -0,0 +1,86 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// planConfig é o horário de trabalho e a estimativa padrão do planner; o que
+// não estiver configurado usa os padrões.
+func (s *Service) planConfig() (task.WorkHours, time.Duration) {
+	hours, estimate := s.config.WorkHours, s.config.Estimate
+	if hours == (task.WorkHours{}) {
+		hours = DefaultWorkHours
+	}
+	if estimate <= 0 {
+		estimate = DefaultEstimate
+	}
+	return hours, estimate
+}
+
+// Plan monta a agenda de day (a meia-noite do dia, no fuso da agenda) com as
+// tarefas em aberto, na ordem do advisor.
+func (s *Service) Plan(ctx context.Context, day time.Time) (*task.Plan, error) {
+	now := time.Now().In(day.Location())
+	if day.Before(time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())) {
+		return nil, ErrInvalidPlanDate
+	}
+
+	ranked, err := s.rankOpen(ctx, s.AdviceWeights(), now)
+	if err != nil {
+		return nil, err
+	}
+	hours, estimate := s.planConfig()
+	plan := task.BuildPlan(ranked, day, hours, now, estimate)
+	return &plan, nil
+}
+
+// AcceptPlan leva o lembrete de cada tarefa para o início do seu horário no
+// plano. Confere todas as tarefas antes de alterar qualquer uma; as que já
+// estão com o lembrete no horário ficam como estão.
+func (s *Service) AcceptPlan(ctx context.Context, slots []task.PlanSlot) ([]models.Task, error) {
+	if len(slots) == 0 {
+		return nil, ErrInvalidInput
+	}
+
+	pending := make([]task.PlanSlot, 0, len(slots))
+	for _, slot := range slots {
+		if slot.TaskID == "" || slot.Start.IsZero() {
+			return nil, ErrInvalidInput
+		}
+		current, err := s.repo.GetByID(ctx, slot.TaskID)
+		if err != nil {
+			return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tarefa: %w", err)
+		}
+		if current == nil {
+			return nil, ErrTaskNotFound
+		}
+		if current.Status.Closed() {
+			return nil, ErrTaskAlreadyDone
+		}
+		if !current.ReminderAt.Equal(slot.Start) {
+			pending = append(pending, slot)
+		}
+	}
+
+	updated := make([]models.Task, 0, len(pending))
+	for _, slot := range pending {
+		t, err := s.Patch(ctx, slot.TaskID, map[string]any{"reminder_at": slot.Start})
+		if err != nil {
+			return nil, err
+		}
+		if t == nil {
+			return nil, ErrTaskNotFound
+		}
+		updated = append(updated, *t)
+	}
+	return updated, nil
+}
+
+func validEstimate(minutes int) bool {
+	return minutes >= 0 && minutes <= task.MaxEstimateMinutes
+}
@@ -0,0 +1,278 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"github.com/google/uuid"
+)
+
+// ProjectStore guarda os projetos.
+type ProjectStore interface {
+	ListProjects(ctx context.Context, includeArchived bool) ([]models.Project, error)
+	GetProject(ctx context.Context, id string) (*models.Project, error)
+	GetProjectByName(ctx context.Context, name string) (*models.Project, error)
+	CreateProject(ctx context.Context, project *models.Project) error
+	PatchProject(ctx context.Context, id string, changes map[string]any) (*models.Project, error)
+	DeleteProject(ctx context.Context, id string) (bool, error)
+	ProjectCounts(ctx context.Context, ids []string) (map[string]models.ProjectCounts, error)
+}
+
+const maxProjectNameLength = 100
+
+// ListProjects devolve os projetos com as contagens de tarefas. Os
+// arquivados só vêm com includeArchived.
+func (s *Service) ListProjects(ctx context.Context, includeArchived bool) ([]models.Project, error) {
+	projects, err := s.repo.ListProjects(ctx, includeArchived)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao listar projetos: %w", err)
+	}
+	if projects == nil {
+		return []models.Project{}, nil
+	}
+	refs := make([]*models.Project, 0, len(projects))
+	for i := range projects {
+		refs = append(refs, &projects[i])
+	}
+	if err := s.attachCounts(ctx, refs...); err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+func (s *Service) GetProject(ctx context.Context, id string) (*models.Project, error) {
+	// O Postgres recusa comparar uuid com texto qualquer.
+	if uuid.Validate(id) != nil {
+		return nil, ErrProjectNotFound
+	}
+	project, err := s.repo.GetProject(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar projeto: %w", err)
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+	if err := s.attachCounts(ctx, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// ResolveProject busca o projeto pelo ID ou, se ref não for um ID, pelo nome.
+func (s *Service) ResolveProject(ctx context.Context, ref string) (*models.Project, error) {
+	ref = strings.TrimSpace(ref)
+	if uuid.Validate(ref) == nil {
+		return s.GetProject(ctx, ref)
+	}
+	project, err := s.repo.GetProjectByName(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar projeto: %w", err)
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+	if err := s.attachCounts(ctx, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+func (s *Service) CreateProject(ctx context.Context, project models.Project) (*models.Project, error) {
+	changes := map[string]any{
+		"name":                    project.Name,
+		"default_priority":        string(project.DefaultPriority),
+		"default_reminder_offset": project.DefaultReminderOffset,
+	}
+	if err := normalizeProjectChanges(changes); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetProjectByName(ctx, changes["name"].(string))
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao criar projeto: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrProjectExists
+	}
+
+	project.ID = ""
+	project.Name = changes["name"].(string)
+	project.Description = strings.TrimSpace(project.Description)
+	project.DefaultPriority = changes["default_priority"].(models.Priority)
+	project.DefaultReminderOffset = changes["default_reminder_offset"].(string)
+	project.Counts = nil
+	project.CreatedAt = time.Now()
+	project.UpdatedAt = time.Now()
+	if err := s.repo.CreateProject(ctx, &project); err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao criar projeto: %w", err)
+	}
+	project.Counts = &models.ProjectCounts{}
+	return &project, nil
+}
+
+// PatchProject altera name, description, archived, default_priority e/ou
+// default_reminder_offset. Renomear para o nome de outro projeto é recusado
+// com ErrProjectExists.
+func (s *Service) PatchProject(ctx context.Context, id string, changes map[string]any) (*models.Project, error) {
+	if uuid.Validate(id) != nil {
+		return nil, ErrProjectNotFound
+	}
+	if err := normalizeProjectChanges(changes); err != nil {
+		return nil, err
+	}
+	if name, ok := changes["name"].(string); ok {
+		existing, err := s.repo.GetProjectByName(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("[ ERRO ] Problema ao alterar projeto: %w", err)
+		}
+		if existing != nil && existing.ID != id {
+			return nil, ErrProjectExists
+		}
+	}
+
+	project, err := s.repo.PatchProject(ctx, id, changes)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao alterar projeto: %w", err)
+	}
+	if project == nil {
+		return nil, ErrProjectNotFound
+	}
+	if err := s.attachCounts(ctx, project); err != nil {
+		return nil, err
+	}
+	return project, nil
+}
+
+// DeleteProject apaga o projeto; as tarefas dele continuam, sem projeto.
+func (s *Service) DeleteProject(ctx context.Context, id string) error {
+	if uuid.Validate(id) != nil {
+		return ErrProjectNotFound
+	}
+	deleted, err := s.repo.DeleteProject(ctx, id)
+	if err != nil {
+		return fmt.Errorf("[ ERRO ] Problema ao apagar projeto: %w", err)
+	}
+	if !deleted {
+		return ErrProjectNotFound
+	}
+	return nil
+}
+
+// ListProjectTasks é o ListPage restrito às tarefas do projeto.
+func (s *Service) ListProjectTasks(ctx context.Context, projectID string, query task.ListQuery, cursor string) (*TaskPage, error) {
+	if _, err := s.GetProject(ctx, projectID); err != nil {
+		return nil, err
+	}
+	query.ProjectID = &projectID
+	return s.ListPage(ctx, query, cursor)
+}
+
+// loadOpenProject garante que o projeto existe e aceita tarefas.
+func (s *Service) loadOpenProject(ctx context.Context, id string) (*models.Project, error) {
+	if id == "" {
+		return nil, ErrInvalidInput
+	}
+	project, err := s.GetProject(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if project.Archived {
+		return nil, ErrProjectArchived
+	}
+	return project, nil
+}
+
+// applyProjectDefaults preenche a prioridade e o lembrete que a tarefa nova
+// não trouxe com os padrões do projeto.
+func applyProjectDefaults(t *models.Task, project *models.Project, now time.Time) error {
+	if t.Priority == "" {
+		t.Priority = project.DefaultPriority
+	}
+	if t.ReminderAt.IsZero() && project.DefaultReminderOffset != "" {
+		offset, err := task.ParseOffset(project.DefaultReminderOffset)
+		if err != nil {
+			return fmt.Errorf("[ ERRO ] Lembrete padrão do projeto %s: %w", project.Name, err)
+		}
+		t.ReminderAt = now.Add(offset)
+	}
+	return nil
+}
+
+// normalizeProjectChanges valida e normaliza, no lugar, os campos editáveis
+// presentes em changes.
+func normalizeProjectChanges(changes map[string]any) error {
+	for column, value := range changes {
+		switch column {
+		case "name":
+			name, ok := value.(string)
+			name = strings.TrimSpace(name)
+			if !ok || name == "" || utf8.RuneCountInString(name) > maxProjectNameLength {
+				return ErrInvalidProject
+			}
+			changes[column] = name
+		case "description":
+			description, ok := value.(string)
+			if !ok {
+				return ErrInvalidProject
+			}
+			changes[column] = strings.TrimSpace(description)
+		case "archived":
+			if _, ok := value.(bool); !ok {
+				return ErrInvalidProject
+			}
+		case "default_priority":
+			raw, ok := value.(string)
+			if !ok {
+				return ErrInvalidProject
+			}
+			var priority models.Priority
+			if strings.TrimSpace(raw) != "" {
+				parsed, err := task.ParsePriority(raw)
+				if err != nil {
+					return ErrInvalidProject
+				}
+				priority = parsed
+			}
+			changes[column] = priority
+		case "default_reminder_offset":
+			raw, ok := value.(string)
+			if !ok {
+				return ErrInvalidProject
+			}
+			raw = strings.ToLower(strings.TrimSpace(raw))
+			if raw != "" {
+				if offset, err := task.ParseOffset(raw); err != nil || offset < 0 {
+					return ErrInvalidProject
+				}
+			}
+			changes[column] = raw
+		default:
+			return ErrInvalidProject
+		}
+	}
+	return nil
+}
+
+func (s *Service) attachCounts(ctx context.Context, projects ...*models.Project) error {
+	if len(projects) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(projects))
+	for _, p := range projects {
+		ids = append(ids, p.ID)
+	}
+	counts, err := s.repo.ProjectCounts(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("[ ERRO ] Problema ao contar tarefas dos projetos: %w", err)
+	}
+	for _, p := range projects {
+		c := counts[p.ID]
+		p.Counts = &c
+	}
+	return nil
+}
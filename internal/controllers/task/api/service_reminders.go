@@ -0,0 +1,276 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// ReminderStore guarda os lembretes das tarefas e a fila do scheduler.
+type ReminderStore interface {
+	ListReminders(ctx context.Context, taskID string) ([]models.Reminder, error)
+	GetReminder(ctx context.Context, taskID, id string) (*models.Reminder, error)
+	CreateReminder(ctx context.Context, reminder *models.Reminder) error
+	PatchReminder(ctx context.Context, id string, changes map[string]any) (*models.Reminder, error)
+	DeleteReminder(ctx context.Context, id string) error
+	PendingReminders(ctx context.Context, now time.Time) ([]models.Reminder, error)
+	MarkReminderSent(ctx context.Context, id string, sentAt time.Time) (bool, error)
+	RecordReminderFailure(ctx context.Context, id string, channels models.ChannelList, reason string, retryAt *time.Time) error
+}
+
+func (s *Service) ListReminders(ctx context.Context, taskID string) ([]models.Reminder, error) {
+	if _, err := s.mustGetTask(ctx, taskID); err != nil {
+		return nil, err
+	}
+
+	reminders, err := s.repo.ListReminders(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao listar lembretes: %w", err)
+	}
+	return reminders, nil
+}
+
+func (s *Service) AddReminder(ctx context.Context, taskID string, reminder models.Reminder) (*models.Reminder, error) {
+	current, err := s.mustGetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	reminder.ID = ""
+	reminder.TaskID = taskID
+	reminder.DeliveredAt = nil
+	if err := scheduleReminder(&reminder, current); err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateReminder(ctx, &reminder); err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao criar lembrete: %w", err)
+	}
+	return &reminder, nil
+}
+
+// UpdateReminder troca o horário (absoluto ou relativo, e a âncora do relativo)
+// e/ou o canal de um lembrete. Mudando o horário, o lembrete volta a ficar
+// pendente.
+func (s *Service) UpdateReminder(ctx context.Context, taskID, id string, at *time.Time, offset, anchor, channel *string) (*models.Reminder, error) {
+	current, err := s.mustGetTask(ctx, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	reminder, err := s.repo.GetReminder(ctx, taskID, id)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar lembrete: %w", err)
+	}
+	if reminder == nil {
+		return nil, ErrReminderNotFound
+	}
+
+	changes := map[string]any{}
+	if at != nil || offset != nil || anchor != nil {
+		if at != nil || offset != nil {
+			reminder.At = at
+			reminder.Offset = ""
+			if offset != nil {
+				reminder.Offset = *offset
+			}
+		}
+		// Um horário absoluto não tem âncora.
+		if at != nil {
+			reminder.Anchor = ""
+		}
+		if anchor != nil {
+			reminder.Anchor = *anchor
+		}
+		if err := scheduleReminder(reminder, current); err != nil {
+			return nil, err
+		}
+		changes["at"] = reminder.At
+		changes["fire_offset"] = reminder.Offset
+		changes["anchor"] = reminder.Anchor
+		changes["fire_at"] = reminder.FireAt
+		resetDelivery(changes)
+	}
+	if channel != nil {
+		changes["channel"] = *channel
+	}
+	if len(changes) == 0 {
+		return nil, ErrInvalidReminder
+	}
+
+	updated, err := s.repo.PatchReminder(ctx, id, changes)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao atualizar lembrete: %w", err)
+	}
+	if updated == nil {
+		return nil, ErrReminderNotFound
+	}
+	return updated, nil
+}
+
+func (s *Service) DeleteReminder(ctx context.Context, taskID, id string) error {
+	reminder, err := s.repo.GetReminder(ctx, taskID, id)
+	if err != nil {
+		return fmt.Errorf("[ ERRO ] Problema ao buscar lembrete: %w", err)
+	}
+	if reminder == nil {
+		return ErrReminderNotFound
+	}
+
+	if err := s.repo.DeleteReminder(ctx, id); err != nil {
+		return fmt.Errorf("[ ERRO ] Problema ao remover lembrete: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) PendingReminders(ctx context.Context, now time.Time) ([]models.Reminder, error) {
+	reminders, err := s.repo.PendingReminders(ctx, now)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar lembretes pendentes: %w", err)
+	}
+	return reminders, nil
+}
+
+func (s *Service) MarkReminderSent(ctx context.Context, id string, sentAt time.Time) (bool, error) {
+	marked, err := s.repo.MarkReminderSent(ctx, id, sentAt)
+	if err != nil {
+		return false, fmt.Errorf("[ ERRO ] Problema ao registrar envio do lembrete: %w", err)
+	}
+	return marked, nil
+}
+
+func (s *Service) RecordReminderFailure(ctx context.Context, id string, channels models.ChannelList, reason string, retryAt *time.Time) error {
+	if err := s.repo.RecordReminderFailure(ctx, id, channels, reason, retryAt); err != nil {
+		return fmt.Errorf("[ ERRO ] Problema ao registrar falha do lembrete: %w", err)
+	}
+	return nil
+}
+
+// resetDelivery devolve o lembrete à fila e esquece as falhas de entrega
+// anteriores.
+func resetDelivery(changes map[string]any) {
+	changes["delivered_at"] = nil
+	changes["attempts"] = 0
+	changes["retry_at"] = nil
+	changes["retry_channels"] = models.ChannelList(nil)
+	changes["last_error"] = ""
+}
+
+// rescheduleReminders recalcula os lembretes relativos depois que o
+// reminder_at ou o prazo da tarefa mudou. Os que passam a cair no futuro
+// voltam a ficar pendentes.
+func (s *Service) rescheduleReminders(ctx context.Context, t *models.Task) (*models.Task, error) {
+	now := time.Now()
+	changed := false
+
+	for _, r := range t.Reminders {
+		if !r.IsRelative() {
+			continue
+		}
+		offset, err := task.ParseOffset(r.Offset)
+		if err != nil {
+			continue
+		}
+		anchor, ok := reminderAnchor(r, t)
+		if !ok {
+			continue
+		}
+		fireAt := anchor.Add(offset)
+		if fireAt.Equal(r.FireAt) {
+			continue
+		}
+
+		changes := map[string]any{"fire_at": fireAt}
+		if fireAt.After(now) {
+			resetDelivery(changes)
+		}
+		if _, err := s.repo.PatchReminder(ctx, r.ID, changes); err != nil {
+			return nil, fmt.Errorf("[ ERRO ] Problema ao reagendar lembretes: %w", err)
+		}
+		changed = true
+	}
+
+	if !changed {
+		return t, nil
+	}
+	return s.reload(ctx, t.ID)
+}
+
+func (s *Service) reload(ctx context.Context, id string) (*models.Task, error) {
+	t, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao recarregar tarefa: %w", err)
+	}
+	if t == nil {
+		return nil, ErrTaskNotFound
+	}
+	return t, nil
+}
+
+func (s *Service) mustGetTask(ctx context.Context, id string) (*models.Task, error) {
+	t, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tarefa: %w", err)
+	}
+	if t == nil {
+		return nil, ErrTaskNotFound
+	}
+	return t, nil
+}
+
+// scheduleReminder valida o lembrete e calcula o FireAt a partir do horário
+// absoluto ou do deslocamento sobre o lembrete principal ou o prazo de t.
+func scheduleReminder(r *models.Reminder, t *models.Task) error {
+	switch r.Anchor {
+	case "", models.AnchorReminder, models.AnchorDue:
+	default:
+		return ErrInvalidReminder
+	}
+	if r.At != nil {
+		if r.Offset != "" || r.Anchor != "" {
+			return ErrInvalidReminder
+		}
+		r.FireAt = *r.At
+		return nil
+	}
+
+	offset, err := task.ParseOffset(r.Offset)
+	if err != nil {
+		return ErrInvalidReminder
+	}
+	anchor, ok := reminderAnchor(*r, t)
+	if !ok {
+		return ErrInvalidReminder
+	}
+	r.FireAt = anchor.Add(offset)
+	return nil
+}
+
+// reminderAnchor é o horário a partir do qual o Offset de r é contado.
+func reminderAnchor(r models.Reminder, t *models.Task) (time.Time, bool) {
+	if r.RelativeToDue() {
+		if t.DueAt == nil {
+			return time.Time{}, false
+		}
+		return *t.DueAt, true
+	}
+	return t.ReminderAt, !t.ReminderAt.IsZero()
+}
+
+// shiftReminders copia os lembretes para a próxima ocorrência de uma tarefa
+// recorrente, levando os absolutos para a próxima data com advance.
+func shiftReminders(reminders []models.Reminder, advance func(time.Time) time.Time) []models.Reminder {
+	var shifted []models.Reminder
+	for _, r := range reminders {
+		next := models.Reminder{Offset: r.Offset, Anchor: r.Anchor, Channel: r.Channel}
+		if r.At != nil {
+			at := advance(*r.At)
+			next.At = &at
+		}
+		shifted = append(shifted, next)
+	}
+	return shifted
+}
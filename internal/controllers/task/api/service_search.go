@@ -0,0 +1,38 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+)
+
+const (
+	DefaultSearchLimit = 20
+	MaxSearchLimit     = 100
+)
+
+// Search busca query no título e na descrição das tarefas que passam no
+// filtro, das mais relevantes para as menos. limit 0 usa DefaultSearchLimit.
+func (s *Service) Search(ctx context.Context, query string, filter task.SearchFilter, limit int) ([]task.SearchResult, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrInvalidInput
+	}
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit < 0 || limit > MaxSearchLimit {
+		return nil, ErrInvalidInput
+	}
+
+	results, err := s.repo.Search(ctx, query, filter, limit)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tarefas: %w", err)
+	}
+	if results == nil {
+		return []task.SearchResult{}, nil
+	}
+	return results, nil
+}
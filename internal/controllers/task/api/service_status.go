@@ -0,0 +1,147 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// SetStatus leva a tarefa para status, se a transição for permitida. Concluir
+// passa pelo mesmo caminho do Complete (dependências, subtarefas e
+// recorrência); ir de uma tarefa fechada para todo é reabrir.
+func (s *Service) SetStatus(ctx context.Context, id string, version int64, status models.TaskStatus) (*models.Task, error) {
+	if !task.ValidStatus(status) {
+		return nil, ErrInvalidStatus
+	}
+	if status == models.StatusDone {
+		return s.CompleteIfMatch(ctx, id, version)
+	}
+
+	before, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tarefa: %w", err)
+	}
+	if before == nil {
+		return nil, nil
+	}
+	return s.transition(ctx, before, version, status)
+}
+
+// Reopen volta uma tarefa concluída ou cancelada para todo.
+func (s *Service) Reopen(ctx context.Context, id string, version int64) (*models.Task, error) {
+	before, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tarefa: %w", err)
+	}
+	if before == nil {
+		return nil, nil
+	}
+	if !before.Status.Closed() {
+		return nil, ErrTaskNotClosed
+	}
+	return s.transition(ctx, before, version, models.StatusTodo)
+}
+
+func (s *Service) transition(ctx context.Context, before *models.Task, version int64, status models.TaskStatus) (*models.Task, error) {
+	if version > 0 && before.Version != version {
+		return nil, ErrVersionConflict
+	}
+	if !task.CanTransition(before.Status, status) {
+		return nil, ErrInvalidTransition
+	}
+
+	action := models.HistoryStatus
+	if before.Status.Closed() && !status.Closed() {
+		action = models.HistoryReopen
+	}
+
+	var updated *models.Task
+	err := s.repo.Atomic(ctx, func(ctx context.Context) (err error) {
+		updated, err = s.patch(ctx, before.ID, version, statusChanges(before, status, time.Now()))
+		if err != nil {
+			if errors.Is(err, ErrVersionConflict) {
+				return err
+			}
+			return fmt.Errorf("[ ERRO ] Problema ao mudar o status: %w", err)
+		}
+		if updated == nil {
+			return nil
+		}
+		return s.record(ctx, action, before, updated)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if updated == nil {
+		return nil, nil
+	}
+	return s.withComputed(ctx, updated)
+}
+
+// resolveStatus traduz, num Patch, o done da API v1 para status e troca o
+// status pelas colunas da transição. done false só reabre tarefa concluída.
+func resolveStatus(before *models.Task, changes map[string]any, now time.Time) error {
+	value, ok := changes["status"]
+	if !ok {
+		done, hasDone := changes["done"].(bool)
+		if !hasDone || before == nil {
+			return nil
+		}
+		switch {
+		case done:
+			value = models.StatusDone
+		case before.Status == models.StatusDone:
+			value = models.StatusTodo
+		default:
+			delete(changes, "done")
+			return nil
+		}
+	}
+
+	status, ok := value.(models.TaskStatus)
+	if !ok {
+		return ErrInvalidInput
+	}
+	if !task.ValidStatus(status) {
+		return ErrInvalidStatus
+	}
+	if before == nil {
+		return nil
+	}
+	if !task.CanTransition(before.Status, status) {
+		return ErrInvalidTransition
+	}
+	maps.Copy(changes, statusChanges(before, status, now))
+	return nil
+}
+
+// statusChanges são as colunas gravadas ao levar before para status: o
+// próprio status, o done derivado dele e os horários de início, conclusão e
+// cancelamento.
+func statusChanges(before *models.Task, status models.TaskStatus, now time.Time) map[string]any {
+	changes := map[string]any{"status": status, "done": status == models.StatusDone}
+	if before != nil && before.Status == status {
+		return changes
+	}
+
+	switch status {
+	case models.StatusDone:
+		changes["completed_at"] = now
+		changes["cancelled_at"] = nil
+	case models.StatusCancelled:
+		changes["cancelled_at"] = now
+		changes["completed_at"] = nil
+	default:
+		changes["completed_at"] = nil
+		changes["cancelled_at"] = nil
+		if status == models.StatusInProgress && (before == nil || before.StartedAt == nil) {
+			changes["started_at"] = now
+		}
+	}
+	return changes
+}
@@ -0,0 +1,264 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// TagStore guarda as tags e os vínculos delas com as tarefas.
+type TagStore interface {
+	ListTags(ctx context.Context) ([]models.Tag, error)
+	GetTag(ctx context.Context, id string) (*models.Tag, error)
+	GetTagByName(ctx context.Context, name string) (*models.Tag, error)
+	CreateTag(ctx context.Context, tag *models.Tag) error
+	PatchTag(ctx context.Context, id string, changes map[string]any) (*models.Tag, error)
+	DeleteTag(ctx context.Context, id string) (bool, error)
+	AttachTag(ctx context.Context, taskID, tagID string) error
+	DetachTag(ctx context.Context, taskID, tagID string) (bool, error)
+}
+
+func (s *Service) ListTags(ctx context.Context) ([]models.Tag, error) {
+	tags, err := s.repo.ListTags(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao listar tags: %w", err)
+	}
+	if tags == nil {
+		return []models.Tag{}, nil
+	}
+	return tags, nil
+}
+
+func (s *Service) GetTag(ctx context.Context, id string) (*models.Tag, error) {
+	tag, err := s.repo.GetTag(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tag: %w", err)
+	}
+	if tag == nil {
+		return nil, ErrTagNotFound
+	}
+	return tag, nil
+}
+
+// GetTagByName busca a tag pelo nome, aceitando "#" e maiúsculas.
+func (s *Service) GetTagByName(ctx context.Context, name string) (*models.Tag, error) {
+	name, err := task.NormalizeTag(name)
+	if err != nil {
+		return nil, ErrInvalidTag
+	}
+	tag, err := s.repo.GetTagByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tag: %w", err)
+	}
+	if tag == nil {
+		return nil, ErrTagNotFound
+	}
+	return tag, nil
+}
+
+func (s *Service) CreateTag(ctx context.Context, name, color string) (*models.Tag, error) {
+	name, err := task.NormalizeTag(name)
+	if err != nil {
+		return nil, ErrInvalidTag
+	}
+	color = strings.ToLower(strings.TrimSpace(color))
+	if !task.ValidTagColor(color) {
+		return nil, ErrInvalidTagColor
+	}
+
+	existing, err := s.repo.GetTagByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao criar tag: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrTagExists
+	}
+
+	tag := models.Tag{Name: name, Color: color, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+	if err := s.repo.CreateTag(ctx, &tag); err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao criar tag: %w", err)
+	}
+	return &tag, nil
+}
+
+// PatchTag altera name e/ou color. Renomear para o nome de outra tag é
+// recusado com ErrTagExists.
+func (s *Service) PatchTag(ctx context.Context, id string, changes map[string]any) (*models.Tag, error) {
+	if value, ok := changes["name"]; ok {
+		raw, ok := value.(string)
+		if !ok {
+			return nil, ErrInvalidTag
+		}
+		name, err := task.NormalizeTag(raw)
+		if err != nil {
+			return nil, ErrInvalidTag
+		}
+		existing, err := s.repo.GetTagByName(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("[ ERRO ] Problema ao alterar tag: %w", err)
+		}
+		if existing != nil && existing.ID != id {
+			return nil, ErrTagExists
+		}
+		changes["name"] = name
+	}
+	if value, ok := changes["color"]; ok {
+		raw, ok := value.(string)
+		if !ok {
+			return nil, ErrInvalidTagColor
+		}
+		color := strings.ToLower(strings.TrimSpace(raw))
+		if !task.ValidTagColor(color) {
+			return nil, ErrInvalidTagColor
+		}
+		changes["color"] = color
+	}
+
+	tag, err := s.repo.PatchTag(ctx, id, changes)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao alterar tag: %w", err)
+	}
+	if tag == nil {
+		return nil, ErrTagNotFound
+	}
+	return tag, nil
+}
+
+// DeleteTag apaga a tag e a tira de todas as tarefas.
+func (s *Service) DeleteTag(ctx context.Context, id string) error {
+	deleted, err := s.repo.DeleteTag(ctx, id)
+	if err != nil {
+		return fmt.Errorf("[ ERRO ] Problema ao apagar tag: %w", err)
+	}
+	if !deleted {
+		return ErrTagNotFound
+	}
+	return nil
+}
+
+// TagTask coloca as tags na tarefa, criando as que ainda não existem.
+func (s *Service) TagTask(ctx context.Context, taskID string, names []string) (*models.Task, error) {
+	if len(names) == 0 {
+		return nil, ErrInvalidTag
+	}
+	before, err := s.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tarefa: %w", err)
+	}
+	if before == nil {
+		return nil, ErrTaskNotFound
+	}
+
+	tags, err := s.resolveTags(ctx, names)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.attachTags(ctx, taskID, tags); err != nil {
+		return nil, err
+	}
+	return s.touchTags(ctx, before)
+}
+
+// UntagTask tira a tag da tarefa. Tirar uma tag que a tarefa não tem não é
+// erro; só a tag inexistente é.
+func (s *Service) UntagTask(ctx context.Context, taskID, name string) (*models.Task, error) {
+	name, err := task.NormalizeTag(name)
+	if err != nil {
+		return nil, ErrInvalidTag
+	}
+	before, err := s.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tarefa: %w", err)
+	}
+	if before == nil {
+		return nil, ErrTaskNotFound
+	}
+	tag, err := s.repo.GetTagByName(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tag: %w", err)
+	}
+	if tag == nil {
+		return nil, ErrTagNotFound
+	}
+
+	detached, err := s.repo.DetachTag(ctx, taskID, tag.ID)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao tirar tag da tarefa: %w", err)
+	}
+	if !detached {
+		if err := s.attachComputed(ctx, before); err != nil {
+			return nil, err
+		}
+		return before, nil
+	}
+	return s.touchTags(ctx, before)
+}
+
+// touchTags sobe a versão da tarefa depois de mudar as tags (o ETag precisa
+// mudar junto com a representação) e registra a mudança no histórico.
+func (s *Service) touchTags(ctx context.Context, before *models.Task) (*models.Task, error) {
+	after, err := s.repo.Patch(ctx, before.ID, map[string]any{"updated_at": time.Now()})
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao atualizar tarefa: %w", err)
+	}
+	if after == nil {
+		return nil, ErrTaskNotFound
+	}
+	if err := s.record(ctx, models.HistoryUpdate, before, after); err != nil {
+		return nil, err
+	}
+	if err := s.attachComputed(ctx, after); err != nil {
+		return nil, err
+	}
+	return after, nil
+}
+
+// resolveTags normaliza os nomes e devolve as tags, criando as que faltam.
+func (s *Service) resolveTags(ctx context.Context, names []string) ([]models.Tag, error) {
+	var tags []models.Tag
+	seen := map[string]bool{}
+	for _, raw := range names {
+		name, err := task.NormalizeTag(raw)
+		if err != nil {
+			return nil, ErrInvalidTag
+		}
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+
+		tag, err := s.repo.GetTagByName(ctx, name)
+		if err != nil {
+			return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tag: %w", err)
+		}
+		if tag == nil {
+			tag = &models.Tag{Name: name, CreatedAt: time.Now(), UpdatedAt: time.Now()}
+			if err := s.repo.CreateTag(ctx, tag); err != nil {
+				return nil, fmt.Errorf("[ ERRO ] Problema ao criar tag: %w", err)
+			}
+		}
+		tags = append(tags, *tag)
+	}
+	return tags, nil
+}
+
+func (s *Service) attachTags(ctx context.Context, taskID string, tags []models.Tag) error {
+	for _, tag := range tags {
+		if err := s.repo.AttachTag(ctx, taskID, tag.ID); err != nil {
+			return fmt.Errorf("[ ERRO ] Problema ao colocar tag na tarefa: %w", err)
+		}
+	}
+	return nil
+}
+
+func tagNames(tags []models.Tag) []string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return names
+}
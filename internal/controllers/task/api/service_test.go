@@ -3,180 +3,110 @@ package api
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task/repository"
 	"github.com/andre-felipe-wonsik-alves/internal/models"
 )
 
-type fakeStore struct {
-	createFn func(ctx context.Context, task *models.Task) error
-	getFn    func(ctx context.Context, id string) (*models.Task, error)
+// failingCreateStore falha ao gravar tarefas novas.
+type failingCreateStore struct {
+	*repository.MemoryStore
+	err error
 }
 
-func (f *fakeStore) Create(ctx context.Context, task *models.Task) error {
-	if f.createFn == nil {
-		return nil
-	}
-	return f.createFn(ctx, task)
+func (f failingCreateStore) Create(ctx context.Context, task *models.Task) error {
+	return f.err
 }
 
-func (f *fakeStore) GetByID(ctx context.Context, id string) (*models.Task, error) {
-	if f.getFn == nil {
-		return nil, nil
-	}
-	return f.getFn(ctx, id)
-}
+func TestServiceCreate(t *testing.T) {
+	t.Parallel()
 
-func (f *fakeStore) List(ctx context.Context) ([]models.Task, error) {
-	return nil, nil
-}
+	reminderAt := time.Date(2024, 9, 10, 12, 30, 0, 0, time.UTC)
 
-func (f *fakeStore) Patch(ctx context.Context, id string, changes map[string]any) (*models.Task, error) {
-	return nil, nil
-}
+	t.Run("creates task with expected fields", func(t *testing.T) {
+		t.Parallel()
 
-func (f *fakeStore) Delete(ctx context.Context, id string) error {
-	return nil
-}
+		service := NewService(repository.NewMemoryStore())
 
-func TestServiceCreate(t *testing.T) {
-	t.Parallel()
+		start := time.Now()
+		task, err := service.Create(context.Background(), "titulo", "descricao", models.PriorityHigh, reminderAt)
+		end := time.Now()
 
-	reminderAt := time.Date(2024, 9, 10, 12, 30, 0, 0, time.UTC)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if task == nil || task.ID == "" {
+			t.Fatalf("expected stored task, got %+v", task)
+		}
+		stored, err := service.GetByID(context.Background(), task.ID)
+		if err != nil || stored == nil {
+			t.Fatalf("expected task in the store, got %+v, %v", stored, err)
+		}
+		if task.Title != "titulo" {
+			t.Fatalf("expected title %q, got %q", "titulo", task.Title)
+		}
+		if task.Description != "descricao" {
+			t.Fatalf("expected description %q, got %q", "descricao", task.Description)
+		}
+		if task.Priority != models.PriorityHigh {
+			t.Fatalf("expected priority %q, got %q", models.PriorityHigh, task.Priority)
+		}
+		if !task.ReminderAt.Equal(reminderAt) {
+			t.Fatalf("expected reminderAt %v, got %v", reminderAt, task.ReminderAt)
+		}
+		if task.Done {
+			t.Fatalf("expected done to be false")
+		}
+		if task.CreatedAt.IsZero() || task.UpdatedAt.IsZero() {
+			t.Fatalf("expected CreatedAt/UpdatedAt to be set")
+		}
+		if task.CreatedAt.Before(start) || task.CreatedAt.After(end) {
+			t.Fatalf("expected CreatedAt within test window")
+		}
+		if task.UpdatedAt.Before(start) || task.UpdatedAt.After(end) {
+			t.Fatalf("expected UpdatedAt within test window")
+		}
+		if task.UpdatedAt.Before(task.CreatedAt) {
+			t.Fatalf("expected UpdatedAt to be >= CreatedAt")
+		}
+	})
 
-	tests := []struct {
-		name      string
-		createErr error
-		wantErr   bool
-	}{
-		{
-			name:      "creates task with expected fields",
-			createErr: nil,
-			wantErr:   false,
-		},
-		{
-			name:      "propagates repository error",
-			createErr: errors.New("db failure"),
-			wantErr:   true,
-		},
-	}
+	t.Run("propagates repository error", func(t *testing.T) {
+		t.Parallel()
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
-
-			var captured *models.Task
-			store := &fakeStore{
-				createFn: func(ctx context.Context, task *models.Task) error {
-					captured = task
-					task.ID = "task-1"
-					return tt.createErr
-				},
-				getFn: func(ctx context.Context, id string) (*models.Task, error) {
-					if captured == nil {
-						t.Fatal("expected Create to run before GetByID")
-					}
-					if id != captured.ID {
-						t.Fatalf("expected GetByID id %q, got %q", captured.ID, id)
-					}
-					return captured, nil
-				},
-			}
-			service := NewService(store)
-
-			start := time.Now()
-			task, err := service.Create(context.Background(), "titulo", "descricao", models.PriorityHigh, reminderAt)
-			end := time.Now()
-
-			if tt.wantErr {
-				if err == nil {
-					t.Fatalf("expected error, got nil")
-				}
-				if !errors.Is(err, tt.createErr) {
-					t.Fatalf("expected error %v, got %v", tt.createErr, err)
-				}
-				if task != nil {
-					t.Fatalf("expected nil task, got %+v", task)
-				}
-				return
-			}
+		createErr := errors.New("db failure")
+		service := NewService(failingCreateStore{repository.NewMemoryStore(), createErr})
 
-			if err != nil {
-				t.Fatalf("unexpected error: %v", err)
-			}
-			if task == nil {
-				t.Fatal("expected task, got nil")
-			}
-			if captured == nil {
-				t.Fatal("expected repository Create to receive task")
-			}
-			if task != captured {
-				t.Fatalf("expected returned task to match repository result")
-			}
-			if task.Title != "titulo" {
-				t.Fatalf("expected title %q, got %q", "titulo", task.Title)
-			}
-			if task.Description != "descricao" {
-				t.Fatalf("expected description %q, got %q", "descricao", task.Description)
-			}
-			if task.Priority != models.PriorityHigh {
-				t.Fatalf("expected priority %q, got %q", models.PriorityHigh, task.Priority)
-			}
-			if !task.ReminderAt.Equal(reminderAt) {
-				t.Fatalf("expected reminderAt %v, got %v", reminderAt, task.ReminderAt)
-			}
-			if task.Done {
-				t.Fatalf("expected done to be false")
-			}
-			if task.CreatedAt.IsZero() || task.UpdatedAt.IsZero() {
-				t.Fatalf("expected CreatedAt/UpdatedAt to be set")
-			}
-			if task.CreatedAt.Before(start) || task.CreatedAt.After(end) {
-				t.Fatalf("expected CreatedAt within test window")
-			}
-			if task.UpdatedAt.Before(start) || task.UpdatedAt.After(end) {
-				t.Fatalf("expected UpdatedAt within test window")
-			}
-			if task.UpdatedAt.Before(task.CreatedAt) {
-				t.Fatalf("expected UpdatedAt to be >= CreatedAt")
-			}
-		})
-	}
+		task, err := service.Create(context.Background(), "titulo", "descricao", models.PriorityHigh, reminderAt)
+
+		if !errors.Is(err, createErr) {
+			t.Fatalf("expected error %v, got %v", createErr, err)
+		}
+		if task != nil {
+			t.Fatalf("expected nil task, got %+v", task)
+		}
+	})
 }
 
 func TestServiceCreateWithParent(t *testing.T) {
 	t.Parallel()
 
-	parentID := "parent-1"
-	childID := "child-1"
-
 	t.Run("returns task with parent", func(t *testing.T) {
 		t.Parallel()
 
-		store := &fakeStore{
-			createFn: func(ctx context.Context, task *models.Task) error {
-				task.ID = childID
-				return nil
-			},
-			getFn: func(ctx context.Context, id string) (*models.Task, error) {
-				if id == parentID {
-					return &models.Task{ID: parentID, Title: "Parent"}, nil
-				}
-				if id == childID {
-					return &models.Task{
-						ID:       childID,
-						Title:    "Child",
-						ParentID: &parentID,
-						Parent:   &models.Task{ID: parentID, Title: "Parent"},
-					}, nil
-				}
-				return nil, nil
-			},
+		ctx := context.Background()
+		service := NewService(repository.NewMemoryStore())
+		parent, err := service.CreateTask(ctx, models.Task{Title: "Parent", Priority: models.PriorityLow})
+		if err != nil {
+			t.Fatalf("create parent: %v", err)
 		}
-		service := NewService(store)
 
-		task, err := service.CreateWithParent(context.Background(), "Child", "desc", models.PriorityLow, time.Time{}, &parentID)
+		task, err := service.CreateWithParent(ctx, "Child", "desc", models.PriorityLow, time.Time{}, &parent.ID)
 
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -184,20 +114,16 @@ func TestServiceCreateWithParent(t *testing.T) {
 		if task == nil {
 			t.Fatal("expected task, got nil")
 		}
-		if task.Parent == nil || task.Parent.ID != parentID {
-			t.Fatalf("expected parent %q, got %+v", parentID, task.Parent)
+		if task.Parent == nil || task.Parent.ID != parent.ID {
+			t.Fatalf("expected parent %q, got %+v", parent.ID, task.Parent)
 		}
 	})
 
 	t.Run("parent not found", func(t *testing.T) {
 		t.Parallel()
 
-		store := &fakeStore{
-			getFn: func(ctx context.Context, id string) (*models.Task, error) {
-				return nil, nil
-			},
-		}
-		service := NewService(store)
+		service := NewService(repository.NewMemoryStore())
+		parentID := "parent-1"
 
 		task, err := service.CreateWithParent(context.Background(), "Child", "desc", models.PriorityLow, time.Time{}, &parentID)
 
@@ -216,12 +142,7 @@ func TestServiceListSubtasks(t *testing.T) {
 	t.Run("not found", func(t *testing.T) {
 		t.Parallel()
 
-		store := &fakeStore{
-			getFn: func(ctx context.Context, id string) (*models.Task, error) {
-				return nil, nil
-			},
-		}
-		service := NewService(store)
+		service := NewService(repository.NewMemoryStore())
 
 		tasks, err := service.ListSubtasks(context.Background(), "parent-1")
 
@@ -236,20 +157,19 @@ func TestServiceListSubtasks(t *testing.T) {
 	t.Run("success", func(t *testing.T) {
 		t.Parallel()
 
-		store := &fakeStore{
-			getFn: func(ctx context.Context, id string) (*models.Task, error) {
-				return &models.Task{
-					ID: id,
-					Children: []models.Task{
-						{ID: "child-1"},
-						{ID: "child-2"},
-					},
-				}, nil
-			},
+		ctx := context.Background()
+		service := NewService(repository.NewMemoryStore())
+		parent, err := service.CreateTask(ctx, models.Task{Title: "parent", Priority: models.PriorityLow})
+		if err != nil {
+			t.Fatalf("create parent: %v", err)
+		}
+		for _, title := range []string{"child-1", "child-2"} {
+			if _, err := service.CreateTask(ctx, models.Task{Title: title, Priority: models.PriorityLow, ParentID: &parent.ID}); err != nil {
+				t.Fatalf("create child: %v", err)
+			}
 		}
-		service := NewService(store)
 
-		tasks, err := service.ListSubtasks(context.Background(), "parent-1")
+		tasks, err := service.ListSubtasks(ctx, parent.ID)
 
 		if err != nil {
 			t.Fatalf("unexpected error: %v", err)
@@ -259,3 +179,2005 @@ func TestServiceListSubtasks(t *testing.T) {
 		}
 	})
 }
+
+func TestServiceCompleteRecurring(t *testing.T) {
+	t.Parallel()
+
+	t.Run("spawns next occurrence and keeps history", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		service := NewService(repository.NewMemoryStore())
+		reminderAt := time.Now().Add(-time.Hour).Truncate(time.Second)
+		current, err := service.CreateTask(ctx, models.Task{
+			Title:      "Backup semanal",
+			Priority:   models.PriorityHigh,
+			ReminderAt: reminderAt,
+			Recurrence: "weekly",
+		})
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+
+		completed, err := service.Complete(ctx, current.ID)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if completed == nil || !completed.Done {
+			t.Fatalf("expected completed task, got %+v", completed)
+		}
+		page, err := service.ListPage(ctx, task.ListQuery{Done: new(bool)}, "")
+		if err != nil || len(page.Tasks) != 1 {
+			t.Fatalf("expected next occurrence to be created, got %+v, %v", page, err)
+		}
+		created := page.Tasks[0]
+		if !created.ReminderAt.Equal(reminderAt.AddDate(0, 0, 7)) {
+			t.Fatalf("next reminder = %v, want %v", created.ReminderAt, reminderAt.AddDate(0, 0, 7))
+		}
+		if created.Done || created.Recurrence != "weekly" || created.Title != current.Title {
+			t.Fatalf("unexpected next occurrence: %+v", created)
+		}
+		if created.SeriesID == nil || *created.SeriesID != current.ID {
+			t.Fatalf("series id = %v, want %s", created.SeriesID, current.ID)
+		}
+		archived, err := service.GetByID(ctx, current.ID)
+		if err != nil || archived.Recurrence != "" || archived.SeriesID == nil || *archived.SeriesID != current.ID {
+			t.Fatalf("unexpected archived occurrence: %+v, %v", archived, err)
+		}
+	})
+
+	t.Run("non recurring task does not spawn", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		service := NewService(repository.NewMemoryStore())
+		current, err := service.CreateTask(ctx, models.Task{Title: "A", Priority: models.PriorityLow})
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+
+		if _, err := service.Complete(ctx, current.ID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if page, err := service.ListPage(ctx, task.ListQuery{}, ""); err != nil || len(page.Tasks) != 1 {
+			t.Fatalf("expected only the completed task, got %+v, %v", page, err)
+		}
+	})
+}
+
+func TestServiceCreateInvalidRecurrence(t *testing.T) {
+	t.Parallel()
+
+	service := NewService(repository.NewMemoryStore())
+
+	_, err := service.CreateTask(context.Background(), models.Task{Title: "A", Recurrence: "às vezes"})
+
+	if !errors.Is(err, ErrInvalidRecurrence) {
+		t.Fatalf("expected ErrInvalidRecurrence, got %v", err)
+	}
+}
+
+func TestServiceReminders(t *testing.T) {
+	t.Parallel()
+
+	reminderAt := time.Now().Add(24 * time.Hour).Truncate(time.Second)
+
+	t.Run("create adds a reminder at reminder_at by default", func(t *testing.T) {
+		t.Parallel()
+
+		service := NewService(repository.NewMemoryStore())
+
+		task, err := service.Create(context.Background(), "A", "", models.PriorityLow, reminderAt)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(task.Reminders) != 1 || !task.Reminders[0].FireAt.Equal(reminderAt) || !task.Reminders[0].IsRelative() {
+			t.Fatalf("unexpected reminders: %+v", task.Reminders)
+		}
+	})
+
+	t.Run("relative reminders without anchor are invalid", func(t *testing.T) {
+		t.Parallel()
+
+		service := NewService(repository.NewMemoryStore())
+
+		_, err := service.CreateTask(context.Background(), models.Task{
+			Title:     "A",
+			Reminders: []models.Reminder{{Offset: "-1h"}},
+		})
+
+		if !errors.Is(err, ErrInvalidReminder) {
+			t.Fatalf("expected ErrInvalidReminder, got %v", err)
+		}
+	})
+
+	t.Run("changing reminder_at reschedules relative reminders", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		store := repository.NewMemoryStore()
+		service := NewService(store)
+		oldAnchor := time.Now().Add(-time.Hour).Truncate(time.Second)
+		absolute := oldAnchor.Add(-time.Hour)
+		created, err := service.CreateTask(ctx, models.Task{
+			Title:      "A",
+			Priority:   models.PriorityLow,
+			ReminderAt: oldAnchor,
+			Reminders: []models.Reminder{
+				{Offset: "-1d"},
+				{Offset: "0"},
+				{At: &absolute},
+			},
+		})
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		byOffset := map[string]models.Reminder{}
+		for _, r := range created.Reminders {
+			byOffset[r.Offset] = r
+			if _, err := store.MarkReminderSent(ctx, r.ID, oldAnchor); err != nil {
+				t.Fatalf("mark sent: %v", err)
+			}
+		}
+		if err := store.RecordReminderFailure(ctx, byOffset["0"].ID, models.ChannelList{"ntfy"}, "fora do ar", nil); err != nil {
+			t.Fatalf("record failure: %v", err)
+		}
+
+		if _, err := service.Patch(ctx, created.ID, map[string]any{"reminder_at": reminderAt}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		reminders, err := service.ListReminders(ctx, created.ID)
+		if err != nil {
+			t.Fatalf("list reminders: %v", err)
+		}
+		for _, r := range reminders {
+			switch r.Offset {
+			case "0":
+				if !r.FireAt.Equal(reminderAt) {
+					t.Fatalf("at-time fire_at = %v, want %v", r.FireAt, reminderAt)
+				}
+				if r.DeliveredAt != nil {
+					t.Fatalf("expected at-time reminder to be pending again, got %+v", r)
+				}
+				if r.Attempts != 0 || r.RetryAt != nil || r.LastError != "" || len(r.RetryChannels) != 0 {
+					t.Fatalf("expected earlier delivery failures to be cleared, got %+v", r)
+				}
+			case "-1d":
+				if r.DeliveredAt == nil {
+					t.Fatalf("day-before reminder is still in the past and should stay delivered")
+				}
+			default:
+				if !r.FireAt.Equal(absolute) {
+					t.Fatalf("absolute reminders must not be rescheduled, got %+v", r)
+				}
+			}
+		}
+	})
+}
+
+func TestServiceTrash(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T) (*Service, *models.Task, *models.Task, *models.Task) {
+		t.Helper()
+		service := NewService(repository.NewMemoryStore())
+		parent, err := service.CreateTask(ctx, models.Task{Title: "parent", Priority: models.PriorityLow})
+		if err != nil {
+			t.Fatalf("create parent: %v", err)
+		}
+		child, err := service.CreateTask(ctx, models.Task{Title: "child", Priority: models.PriorityLow, ParentID: &parent.ID})
+		if err != nil {
+			t.Fatalf("create child: %v", err)
+		}
+		grandchild, err := service.CreateTask(ctx, models.Task{Title: "grandchild", Priority: models.PriorityLow, ParentID: &child.ID})
+		if err != nil {
+			t.Fatalf("create grandchild: %v", err)
+		}
+		for _, id := range []string{grandchild.ID, child.ID, parent.ID} {
+			if err := service.Delete(ctx, id); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+		}
+		return service, parent, child, grandchild
+	}
+
+	t.Run("lists deleted children recursively", func(t *testing.T) {
+		service, parent, child, grandchild := setup(t)
+
+		children, err := service.DeletedChildren(ctx, parent.ID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(children) != 2 || children[0].ID != child.ID || children[1].ID != grandchild.ID {
+			t.Fatalf("unexpected deleted children: %+v", children)
+		}
+	})
+
+	t.Run("restores only the task", func(t *testing.T) {
+		service, parent, child, _ := setup(t)
+
+		restored, err := service.Restore(ctx, parent.ID, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(restored.Children) != 0 {
+			t.Fatalf("expected children to stay in trash, got %+v", restored.Children)
+		}
+		trash, _ := service.Trash(ctx)
+		if len(trash) != 2 {
+			t.Fatalf("trash = %d, want 2", len(trash))
+		}
+		if got, _ := service.GetByID(ctx, child.ID); got != nil {
+			t.Fatalf("expected child still deleted, got %+v", got)
+		}
+	})
+
+	t.Run("restores with children", func(t *testing.T) {
+		service, parent, child, grandchild := setup(t)
+
+		restored, err := service.Restore(ctx, parent.ID, true)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(restored.Children) != 1 || restored.Children[0].ID != child.ID {
+			t.Fatalf("expected child restored, got %+v", restored.Children)
+		}
+		if got, _ := service.GetByID(ctx, grandchild.ID); got == nil {
+			t.Fatalf("expected grandchild restored")
+		}
+		if trash, _ := service.Trash(ctx); len(trash) != 0 {
+			t.Fatalf("expected empty trash, got %+v", trash)
+		}
+	})
+
+	t.Run("restore outside trash", func(t *testing.T) {
+		service, parent, _, _ := setup(t)
+		if _, err := service.Restore(ctx, parent.ID, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if _, err := service.Restore(ctx, parent.ID, false); !errors.Is(err, ErrTaskNotInTrash) {
+			t.Fatalf("expected ErrTaskNotInTrash, got %v", err)
+		}
+	})
+
+	t.Run("purge", func(t *testing.T) {
+		service, parent, child, _ := setup(t)
+
+		if err := service.Purge(ctx, parent.ID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := service.Purge(ctx, parent.ID); !errors.Is(err, ErrTaskNotInTrash) {
+			t.Fatalf("expected ErrTaskNotInTrash on second purge, got %v", err)
+		}
+
+		purged, err := service.PurgeOlderThan(ctx, time.Hour)
+		if err != nil || purged != 0 {
+			t.Fatalf("purge older than 1h = %d, %v; want 0", purged, err)
+		}
+		purged, err = service.PurgeOlderThan(ctx, 0)
+		if err != nil || purged != 2 {
+			t.Fatalf("purge older than 0 = %d, %v; want 2", purged, err)
+		}
+		if _, err := service.Restore(ctx, child.ID, false); !errors.Is(err, ErrTaskNotInTrash) {
+			t.Fatalf("expected purged child gone, got %v", err)
+		}
+
+		// O histórico sobrevive ao purge e termina com a entrada dele.
+		for _, id := range []string{parent.ID, child.ID} {
+			history, err := service.History(ctx, id)
+			if err != nil || len(history) == 0 {
+				t.Fatalf("history of %s = %+v, %v", id, history, err)
+			}
+			if last := history[len(history)-1]; last.Action != models.HistoryPurge || last.Field != "deleted_at" {
+				t.Fatalf("last history entry of %s = %+v, want purge", id, last)
+			}
+		}
+	})
+
+	t.Run("restore rolls back on failure", func(t *testing.T) {
+		store := &failingRestoreStore{MemoryStore: repository.NewMemoryStore()}
+		service := NewService(store)
+		parent, _ := service.CreateTask(ctx, models.Task{Title: "parent", Priority: models.PriorityLow})
+		child, _ := service.CreateTask(ctx, models.Task{Title: "child", Priority: models.PriorityLow, ParentID: &parent.ID})
+		for _, id := range []string{child.ID, parent.ID} {
+			if err := service.Delete(ctx, id); err != nil {
+				t.Fatalf("delete: %v", err)
+			}
+		}
+		store.failID = child.ID
+
+		if _, err := service.Restore(ctx, parent.ID, true); err == nil {
+			t.Fatalf("expected restore to fail")
+		}
+		if trash, _ := service.Trash(ctx); len(trash) != 2 {
+			t.Fatalf("trash = %+v, want parent and child still there", trash)
+		}
+		history, _ := service.History(ctx, parent.ID)
+		if last := history[len(history)-1]; last.Action != models.HistoryDelete {
+			t.Fatalf("last history entry = %+v, want the restore rolled back", last)
+		}
+	})
+}
+
+// failingRestoreStore falha ao restaurar a tarefa failID.
+type failingRestoreStore struct {
+	*repository.MemoryStore
+	failID string
+}
+
+func (f *failingRestoreStore) Restore(ctx context.Context, id string) (*models.Task, error) {
+	if id == f.failID {
+		return nil, errors.New("falha ao restaurar")
+	}
+	return f.MemoryStore.Restore(ctx, id)
+}
+
+func TestServiceIfMatch(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+
+	created, err := service.CreateTask(ctx, models.Task{Title: "A", Priority: models.PriorityLow})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if created.Version != 1 {
+		t.Fatalf("version = %d, want 1", created.Version)
+	}
+
+	patched, err := service.PatchIfMatch(ctx, created.ID, 1, map[string]any{"title": "B"})
+	if err != nil {
+		t.Fatalf("patch: %v", err)
+	}
+	if patched.Version != 2 {
+		t.Fatalf("version = %d, want 2", patched.Version)
+	}
+
+	tests := []struct {
+		name string
+		run  func() error
+	}{
+		{name: "patch", run: func() error {
+			_, err := service.PatchIfMatch(ctx, created.ID, 1, map[string]any{"title": "C"})
+			return err
+		}},
+		{name: "complete", run: func() error {
+			_, err := service.CompleteIfMatch(ctx, created.ID, 1)
+			return err
+		}},
+		{name: "delete", run: func() error {
+			return service.DeleteIfMatch(ctx, created.ID, 1)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run("stale "+tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, ErrVersionConflict) {
+				t.Fatalf("err = %v, want ErrVersionConflict", err)
+			}
+		})
+	}
+
+	got, _ := service.GetByID(ctx, created.ID)
+	if got == nil || got.Title != "B" || got.Done || got.Version != 2 {
+		t.Fatalf("stale writes must not apply, got %+v", got)
+	}
+}
+
+func TestServiceHistory(t *testing.T) {
+	cliCtx := task.WithSource(context.Background(), task.SourceCLI)
+	apiCtx := task.WithSource(context.Background(), "api:req-1")
+	service := NewService(repository.NewMemoryStore())
+
+	created, err := service.CreateTask(cliCtx, models.Task{Title: "A", Priority: models.PriorityLow})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := service.Patch(apiCtx, created.ID, map[string]any{"priority": models.PriorityHigh, "title": "A"}); err != nil {
+		t.Fatalf("patch: %v", err)
+	}
+	if _, err := service.Complete(cliCtx, created.ID); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+	if _, err := service.Patch(apiCtx, created.ID, map[string]any{"done": false}); err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	if err := service.Delete(cliCtx, created.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := service.Restore(cliCtx, created.ID, false); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+
+	changes, err := service.History(context.Background(), created.ID)
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+
+	type entry struct {
+		action        models.HistoryAction
+		field         string
+		before, after models.AuditValue
+		source        string
+	}
+	want := []entry{
+		{models.HistoryCreate, "title", "", `"A"`, task.SourceCLI},
+		{models.HistoryCreate, "priority", "", `"low"`, task.SourceCLI},
+		{models.HistoryCreate, "status", "", `"todo"`, task.SourceCLI},
+		{models.HistoryUpdate, "priority", `"low"`, `"high"`, "api:req-1"},
+		{models.HistoryComplete, "status", `"todo"`, `"done"`, task.SourceCLI},
+		{models.HistoryComplete, "done", "false", "true", task.SourceCLI},
+		{models.HistoryComplete, "completed_at", "null", "", task.SourceCLI},
+		{models.HistoryUpdate, "status", `"done"`, `"todo"`, "api:req-1"},
+		{models.HistoryUpdate, "done", "true", "false", "api:req-1"},
+		{models.HistoryUpdate, "completed_at", "", "null", "api:req-1"},
+		{models.HistoryDelete, "deleted_at", "null", "", task.SourceCLI},
+		{models.HistoryRestore, "deleted_at", "", "null", task.SourceCLI},
+	}
+	if len(changes) != len(want) {
+		t.Fatalf("expected %d changes, got %+v", len(want), changes)
+	}
+	for i, w := range want {
+		got := changes[i]
+		// deleted_at e completed_at carregam o horário; só o lado vazio é
+		// comparado.
+		if got.Action != w.action || got.Field != w.field || got.Source != w.source ||
+			(w.before != "" && got.Before != w.before) || (w.after != "" && got.After != w.after) {
+			t.Fatalf("change %d = %+v, want %+v", i, got, w)
+		}
+	}
+
+	t.Run("unknown source", func(t *testing.T) {
+		other, _ := service.CreateTask(context.Background(), models.Task{Title: "B", Priority: models.PriorityLow})
+		changes, _ := service.History(context.Background(), other.ID)
+		if len(changes) == 0 || changes[0].Source != task.SourceUnknown {
+			t.Fatalf("expected source %q, got %+v", task.SourceUnknown, changes)
+		}
+	})
+
+	t.Run("missing task", func(t *testing.T) {
+		if _, err := service.History(context.Background(), "missing"); !errors.Is(err, ErrTaskNotFound) {
+			t.Fatalf("err = %v, want ErrTaskNotFound", err)
+		}
+	})
+}
+
+func TestServiceSearch(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	for _, title := range []string{"Comprar café", "Café com a equipe", "Lavar o carro"} {
+		if _, err := service.CreateTask(ctx, models.Task{Title: title, Priority: models.PriorityLow}); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+	if _, err := service.CreateTask(ctx, models.Task{Title: "Café no escritório", Priority: models.PriorityLow, Tags: []models.Tag{{Name: "trabalho"}}}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		tags    task.TagFilter
+		limit   int
+		want    int
+		wantErr error
+	}{
+		{name: "default limit", query: "café", want: 3},
+		{name: "with tag", query: "café", tags: task.TagFilter{Include: []string{"trabalho"}}, want: 1},
+		{name: "without tag", query: "café", tags: task.TagFilter{Exclude: []string{"trabalho"}}, want: 2},
+		{name: "limit", query: "café", limit: 1, want: 1},
+		{name: "no hits", query: "piscina", want: 0},
+		{name: "blank query", query: "   ", wantErr: ErrInvalidInput},
+		{name: "negative limit", query: "café", limit: -1, wantErr: ErrInvalidInput},
+		{name: "limit above max", query: "café", limit: MaxSearchLimit + 1, wantErr: ErrInvalidInput},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := service.Search(ctx, tt.query, task.SearchFilter{Tags: tt.tags}, tt.limit)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (results == nil || len(results) != tt.want) {
+				t.Fatalf("expected %d results, got %#v", tt.want, results)
+			}
+		})
+	}
+}
+
+func TestServiceListPage(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	for i := range 5 {
+		if _, err := service.CreateTask(ctx, models.Task{Title: fmt.Sprintf("task %d", i), Priority: models.PriorityLow}); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	t.Run("walks pages with cursor", func(t *testing.T) {
+		query := task.ListQuery{Limit: 2}
+		seen := map[string]bool{}
+		cursor := ""
+		pages := 0
+		for {
+			page, err := service.ListPage(ctx, query, cursor)
+			if err != nil {
+				t.Fatalf("list page: %v", err)
+			}
+			pages++
+			for _, tk := range page.Tasks {
+				if seen[tk.ID] {
+					t.Fatalf("task %s repeated across pages", tk.ID)
+				}
+				seen[tk.ID] = true
+			}
+			if page.NextCursor == "" {
+				break
+			}
+			cursor = page.NextCursor
+		}
+		if pages != 3 || len(seen) != 5 {
+			t.Fatalf("expected 5 tasks in 3 pages, got %d in %d", len(seen), pages)
+		}
+	})
+
+	t.Run("exact page has no next cursor", func(t *testing.T) {
+		page, err := service.ListPage(ctx, task.ListQuery{Limit: 5}, "")
+		if err != nil || len(page.Tasks) != 5 || page.NextCursor != "" {
+			t.Fatalf("page = %+v, %v; want 5 tasks and no cursor", page, err)
+		}
+	})
+
+	first, err := service.ListPage(ctx, task.ListQuery{Limit: 1}, "")
+	if err != nil {
+		t.Fatalf("list page: %v", err)
+	}
+	from, to := time.Now(), time.Now().Add(-time.Hour)
+
+	tests := []struct {
+		name    string
+		query   task.ListQuery
+		cursor  string
+		wantErr error
+	}{
+		{name: "garbage cursor", cursor: "???", wantErr: ErrInvalidCursor},
+		{name: "cursor from another sort", query: task.ListQuery{Sort: task.SortPriority}, cursor: first.NextCursor, wantErr: ErrInvalidCursor},
+		{name: "inverted range", query: task.ListQuery{CreatedFrom: &from, CreatedTo: &to}, wantErr: ErrInvalidInput},
+		{name: "limit above max", query: task.ListQuery{Limit: MaxPageSize + 1}, wantErr: ErrInvalidInput},
+		{name: "unknown sort", query: task.ListQuery{Sort: "title"}, wantErr: ErrInvalidSort},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.ListPage(ctx, tt.query, tt.cursor); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestServiceListPageDefaultLimit(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	for i := range DefaultPageSize + 2 {
+		if _, err := service.CreateTask(ctx, models.Task{Title: fmt.Sprintf("task %d", i), Priority: models.PriorityLow}); err != nil {
+			t.Fatalf("create: %v", err)
+		}
+	}
+
+	all, err := service.ListPage(ctx, task.ListQuery{}, "")
+	if err != nil || len(all.Tasks) != DefaultPageSize+2 || all.NextCursor != "" {
+		t.Fatalf("list without paging = %d tasks, cursor %q, %v; want all of them", len(all.Tasks), all.NextCursor, err)
+	}
+
+	first, err := service.ListPage(ctx, task.ListQuery{Limit: 1}, "")
+	if err != nil || first.NextCursor == "" {
+		t.Fatalf("first page = %+v, %v", first, err)
+	}
+	rest, err := service.ListPage(ctx, task.ListQuery{}, first.NextCursor)
+	if err != nil || len(rest.Tasks) != DefaultPageSize || rest.NextCursor == "" {
+		t.Fatalf("page after cursor = %d tasks, cursor %q, %v; want %d and a next page", len(rest.Tasks), rest.NextCursor, err, DefaultPageSize)
+	}
+}
+
+func TestServiceTreeAndReparenting(t *testing.T) {
+	ctx := context.Background()
+
+	// chain cria root -> n1 -> ... -> n(levels-1) e devolve os IDs em ordem.
+	chain := func(t *testing.T, service *Service, levels int) []string {
+		t.Helper()
+		var ids []string
+		var parentID *string
+		for i := range levels {
+			created, err := service.CreateTask(ctx, models.Task{Title: fmt.Sprintf("n%d", i), Priority: models.PriorityLow, ParentID: parentID})
+			if err != nil {
+				t.Fatalf("create n%d: %v", i, err)
+			}
+			ids = append(ids, created.ID)
+			parentID = &created.ID
+		}
+		return ids
+	}
+
+	t.Run("tree and breadcrumbs", func(t *testing.T) {
+		service := NewService(repository.NewMemoryStore())
+		ids := chain(t, service, 4)
+
+		tree, err := service.Tree(ctx, ids[0], 0)
+		if err != nil {
+			t.Fatalf("tree: %v", err)
+		}
+		if got := treeHeight(tree); got != 3 {
+			t.Fatalf("tree height = %d, want 3", got)
+		}
+		if shallow, _ := service.Tree(ctx, ids[0], 1); treeHeight(shallow) != 1 {
+			t.Fatalf("expected depth=1 to load one level")
+		}
+
+		path, err := service.Breadcrumbs(ctx, ids[3])
+		if err != nil {
+			t.Fatalf("breadcrumbs: %v", err)
+		}
+		if len(path) != 3 || path[0].ID != ids[0] || path[2].ID != ids[2] {
+			t.Fatalf("unexpected breadcrumbs %+v", path)
+		}
+
+		if _, err := service.Tree(ctx, "missing", 0); !errors.Is(err, ErrTaskNotFound) {
+			t.Fatalf("err = %v, want ErrTaskNotFound", err)
+		}
+		if _, err := service.Tree(ctx, ids[0], DefaultMaxDepth+1); !errors.Is(err, ErrInvalidInput) {
+			t.Fatalf("err = %v, want ErrInvalidInput", err)
+		}
+	})
+
+	t.Run("rejects cycles", func(t *testing.T) {
+		service := NewService(repository.NewMemoryStore())
+		ids := chain(t, service, 3)
+
+		for _, target := range []string{ids[0], ids[1], ids[2]} {
+			_, err := service.Patch(ctx, target, map[string]any{"parent_id": ids[2]})
+			if target == ids[2] && !errors.Is(err, ErrTaskCycle) {
+				t.Fatalf("self parent err = %v, want ErrTaskCycle", err)
+			}
+			if target != ids[2] && !errors.Is(err, ErrTaskCycle) {
+				t.Fatalf("moving an ancestor below its descendant: err = %v, want ErrTaskCycle", err)
+			}
+		}
+
+		other, _ := service.CreateTask(ctx, models.Task{Title: "other", Priority: models.PriorityLow})
+		if _, err := service.Patch(ctx, ids[1], map[string]any{"parent_id": other.ID}); err != nil {
+			t.Fatalf("moving to an unrelated task should work: %v", err)
+		}
+	})
+
+	t.Run("rejects moves deeper than max depth", func(t *testing.T) {
+		service := NewServiceWithConfig(repository.NewMemoryStore(), Config{MaxDepth: 3})
+		deep := chain(t, service, 3)    // níveis 0..2
+		subtree := chain(t, service, 2) // raiz com um filho
+
+		if _, err := service.CreateTask(ctx, models.Task{Title: "n3", Priority: models.PriorityLow, ParentID: &deep[2]}); err != nil {
+			t.Fatalf("level 3 should fit: %v", err)
+		}
+		if _, err := service.Patch(ctx, subtree[0], map[string]any{"parent_id": deep[2]}); !errors.Is(err, ErrMaxDepthExceeded) {
+			t.Fatalf("err = %v, want ErrMaxDepthExceeded", err)
+		}
+		if _, err := service.Patch(ctx, subtree[0], map[string]any{"parent_id": deep[1]}); err != nil {
+			t.Fatalf("subtree ending at level 3 should fit: %v", err)
+		}
+	})
+}
+
+func TestConfigFromEnv(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    int
+		wantErr bool
+	}{
+		{name: "custom", value: "4", want: 4},
+		{name: "zero", value: "0", wantErr: true},
+		{name: "not a number", value: "fundo", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("TASK_MAX_DEPTH", tt.value)
+			cfg, err := ConfigFromEnv()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && cfg.MaxDepth != tt.want {
+				t.Fatalf("MaxDepth = %d, want %d", cfg.MaxDepth, tt.want)
+			}
+		})
+	}
+}
+
+// failingPatchStore falha ao alterar a tarefa failID.
+type failingPatchStore struct {
+	*repository.MemoryStore
+	failID string
+}
+
+func (f *failingPatchStore) Patch(ctx context.Context, id string, changes map[string]any) (*models.Task, error) {
+	if id == f.failID {
+		return nil, errors.New("falha ao gravar")
+	}
+	return f.MemoryStore.Patch(ctx, id, changes)
+}
+
+func TestServiceCompletionPolicies(t *testing.T) {
+	ctx := context.Background()
+
+	// family cria root -> {a -> a1, b} e devolve o service e os IDs por título.
+	family := func(t *testing.T, cfg Config) (*Service, map[string]string) {
+		t.Helper()
+		cfg.MaxDepth = DefaultMaxDepth
+		service := NewServiceWithConfig(repository.NewMemoryStore(), cfg)
+		ids := map[string]string{}
+		for _, node := range []struct{ title, parent string }{{"root", ""}, {"a", "root"}, {"a1", "a"}, {"b", "root"}} {
+			newTask := models.Task{Title: node.title, Priority: models.PriorityLow}
+			if node.parent != "" {
+				parentID := ids[node.parent]
+				newTask.ParentID = &parentID
+			}
+			created, err := service.CreateTask(ctx, newTask)
+			if err != nil {
+				t.Fatalf("create %s: %v", node.title, err)
+			}
+			ids[node.title] = created.ID
+		}
+		return service, ids
+	}
+	isDone := func(t *testing.T, service *Service, id string) bool {
+		t.Helper()
+		got, err := service.GetByID(ctx, id)
+		if err != nil || got == nil {
+			t.Fatalf("get %s: %v", id, err)
+		}
+		return got.Done
+	}
+
+	t.Run("independent completes only the task", func(t *testing.T) {
+		service, ids := family(t, Config{})
+		completed, err := service.Complete(ctx, ids["root"])
+		if err != nil {
+			t.Fatalf("complete: %v", err)
+		}
+		if completed.Progress == nil || *completed.Progress != (models.Progress{Done: 0, Total: 3}) {
+			t.Fatalf("progress = %+v, want 0/3", completed.Progress)
+		}
+		if isDone(t, service, ids["a1"]) {
+			t.Fatalf("expected subtasks to stay open")
+		}
+	})
+
+	t.Run("block refuses open subtasks", func(t *testing.T) {
+		service, ids := family(t, Config{Completion: CompletionBlock})
+		if _, err := service.Complete(ctx, ids["a"]); !errors.Is(err, ErrOpenSubtasks) {
+			t.Fatalf("err = %v, want ErrOpenSubtasks", err)
+		}
+		if _, err := service.Patch(ctx, ids["a"], map[string]any{"done": true}); !errors.Is(err, ErrOpenSubtasks) {
+			t.Fatalf("patch err = %v, want ErrOpenSubtasks", err)
+		}
+		if _, err := service.Complete(ctx, ids["a1"]); err != nil {
+			t.Fatalf("complete leaf: %v", err)
+		}
+		if _, err := service.Complete(ctx, ids["a"]); err != nil {
+			t.Fatalf("complete once subtasks are done: %v", err)
+		}
+	})
+
+	t.Run("cascade completes every open subtask", func(t *testing.T) {
+		service, ids := family(t, Config{Completion: CompletionCascade})
+		completed, err := service.Complete(ctx, ids["root"])
+		if err != nil {
+			t.Fatalf("complete: %v", err)
+		}
+		for _, title := range []string{"root", "a", "a1", "b"} {
+			if !isDone(t, service, ids[title]) {
+				t.Fatalf("expected %s to be done", title)
+			}
+		}
+		if *completed.Progress != (models.Progress{Done: 3, Total: 3}) {
+			t.Fatalf("progress = %+v, want 3/3", completed.Progress)
+		}
+		history, _ := service.History(ctx, ids["a1"])
+		if len(history) == 0 || history[len(history)-1].Action != models.HistoryComplete {
+			t.Fatalf("expected cascaded completion in history, got %+v", history)
+		}
+	})
+
+	t.Run("cascade checks subtask dependencies first", func(t *testing.T) {
+		service, ids := family(t, Config{Completion: CompletionCascade})
+		external, _ := service.CreateTask(ctx, models.Task{Title: "external", Priority: models.PriorityLow})
+		if _, err := service.AddDependency(ctx, ids["b"], external.ID); err != nil {
+			t.Fatalf("add dependency: %v", err)
+		}
+		if _, err := service.Complete(ctx, ids["root"]); !errors.Is(err, ErrTaskBlocked) {
+			t.Fatalf("err = %v, want ErrTaskBlocked", err)
+		}
+		for _, title := range []string{"root", "a", "a1", "b"} {
+			if isDone(t, service, ids[title]) {
+				t.Fatalf("expected %s to stay open", title)
+			}
+		}
+
+		// Dependências entre tarefas da própria cascata não bloqueiam.
+		if _, err := service.RemoveDependency(ctx, ids["b"], external.ID); err != nil {
+			t.Fatalf("remove dependency: %v", err)
+		}
+		if _, err := service.AddDependency(ctx, ids["a1"], ids["b"]); err != nil {
+			t.Fatalf("add dependency: %v", err)
+		}
+		if _, err := service.Complete(ctx, ids["root"]); err != nil {
+			t.Fatalf("complete: %v", err)
+		}
+	})
+
+	t.Run("cascade is undone when a subtask fails", func(t *testing.T) {
+		store := &failingPatchStore{MemoryStore: repository.NewMemoryStore()}
+		service := NewServiceWithConfig(store, Config{Completion: CompletionCascade, MaxDepth: DefaultMaxDepth})
+		root, _ := service.CreateTask(ctx, models.Task{Title: "root", Priority: models.PriorityLow})
+		a, _ := service.CreateTask(ctx, models.Task{Title: "a", Priority: models.PriorityLow, ParentID: &root.ID})
+		b, _ := service.CreateTask(ctx, models.Task{Title: "b", Priority: models.PriorityLow, ParentID: &root.ID})
+		store.failID = b.ID
+
+		if _, err := service.Complete(ctx, root.ID); err == nil {
+			t.Fatalf("expected the failing subtask to abort the cascade")
+		}
+		for _, id := range []string{root.ID, a.ID, b.ID} {
+			if isDone(t, service, id) {
+				t.Fatalf("expected %s to stay open after the failed cascade", id)
+			}
+		}
+	})
+
+	t.Run("auto-completes finished parents", func(t *testing.T) {
+		service, ids := family(t, Config{AutoCompleteParent: true})
+		if _, err := service.Complete(ctx, ids["a1"]); err != nil {
+			t.Fatalf("complete a1: %v", err)
+		}
+		if !isDone(t, service, ids["a"]) || isDone(t, service, ids["root"]) {
+			t.Fatalf("expected a done and root still open (b is open)")
+		}
+		if _, err := service.Complete(ctx, ids["b"]); err != nil {
+			t.Fatalf("complete b: %v", err)
+		}
+		if !isDone(t, service, ids["root"]) {
+			t.Fatalf("expected root done after its last subtask")
+		}
+	})
+
+	t.Run("progress on reads", func(t *testing.T) {
+		service, ids := family(t, Config{})
+		if _, err := service.Complete(ctx, ids["a1"]); err != nil {
+			t.Fatalf("complete: %v", err)
+		}
+		root, _ := service.GetByID(ctx, ids["root"])
+		if root.Progress == nil || *root.Progress != (models.Progress{Done: 1, Total: 3}) {
+			t.Fatalf("root progress = %+v, want 1/3", root.Progress)
+		}
+		for _, child := range root.Children {
+			if child.ID == ids["a"] && (child.Progress == nil || *child.Progress != (models.Progress{Done: 1, Total: 1})) {
+				t.Fatalf("child progress = %+v, want 1/1", child.Progress)
+			}
+			if child.ID == ids["b"] && child.Progress != nil {
+				t.Fatalf("expected no progress on a leaf, got %+v", child.Progress)
+			}
+		}
+
+		page, err := service.ListPage(ctx, task.ListQuery{RootOnly: true}, "")
+		if err != nil || len(page.Tasks) != 1 || page.Tasks[0].Progress == nil {
+			t.Fatalf("expected listed root with progress, got %+v, %v", page, err)
+		}
+	})
+}
+
+func TestConfigFromEnv_Completion(t *testing.T) {
+	t.Setenv("TASK_COMPLETION_POLICY", "cascade")
+	t.Setenv("TASK_AUTO_COMPLETE_PARENT", "true")
+	cfg, err := ConfigFromEnv()
+	if err != nil {
+		t.Fatalf("config: %v", err)
+	}
+	if cfg.Completion != CompletionCascade || !cfg.AutoCompleteParent {
+		t.Fatalf("unexpected config %+v", cfg)
+	}
+
+	t.Setenv("TASK_COMPLETION_POLICY", "sometimes")
+	if _, err := ConfigFromEnv(); err == nil {
+		t.Fatalf("expected error for unknown policy")
+	}
+}
+
+func TestServiceMove(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	home, _ := service.CreateTask(ctx, models.Task{Title: "home", Priority: models.PriorityLow})
+	work, _ := service.CreateTask(ctx, models.Task{Title: "work", Priority: models.PriorityLow})
+	branch, _ := service.CreateTask(ctx, models.Task{Title: "branch", Priority: models.PriorityLow, ParentID: &home.ID})
+	leaf, _ := service.CreateTask(ctx, models.Task{Title: "leaf", Priority: models.PriorityLow, ParentID: &branch.ID})
+
+	moved, err := service.Move(ctx, branch.ID, &work.ID, 0)
+	if err != nil {
+		t.Fatalf("move: %v", err)
+	}
+	if moved.ParentID == nil || *moved.ParentID != work.ID {
+		t.Fatalf("parent_id = %v, want %s", moved.ParentID, work.ID)
+	}
+	path, _ := service.Breadcrumbs(ctx, leaf.ID)
+	if len(path) != 2 || path[0].ID != work.ID || path[1].ID != branch.ID {
+		t.Fatalf("expected leaf to follow its parent, got %+v", path)
+	}
+
+	root, err := service.Move(ctx, branch.ID, nil, 0)
+	if err != nil {
+		t.Fatalf("move to root: %v", err)
+	}
+	if root.ParentID != nil {
+		t.Fatalf("expected root task, got parent %v", *root.ParentID)
+	}
+
+	if _, err := service.Patch(ctx, leaf.ID, map[string]any{"parent_id": nil}); err != nil {
+		t.Fatalf("detach via patch: %v", err)
+	}
+	if detached, _ := service.GetByID(ctx, leaf.ID); detached.ParentID != nil {
+		t.Fatalf("expected leaf detached, got parent %v", *detached.ParentID)
+	}
+
+	missing := "missing"
+	errs := []struct {
+		name     string
+		id       string
+		parentID *string
+		version  int64
+		want     error
+	}{
+		{name: "missing task", id: "missing", want: ErrTaskNotFound},
+		{name: "missing parent", id: branch.ID, parentID: &missing, want: ErrParentTaskNotFound},
+		{name: "below itself", id: branch.ID, parentID: &branch.ID, want: ErrTaskCycle},
+		{name: "stale version", id: branch.ID, parentID: &work.ID, version: 1, want: ErrVersionConflict},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.Move(ctx, tt.id, tt.parentID, tt.version); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestServiceTags(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	created, err := service.CreateTask(ctx, models.Task{Title: "backup", Priority: models.PriorityLow, Tags: []models.Tag{{Name: "#HomeLab"}, {Name: "homelab"}}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if len(created.Tags) != 1 || created.Tags[0].Name != "homelab" {
+		t.Fatalf("expected normalized, deduplicated tags, got %+v", created.Tags)
+	}
+
+	tagged, err := service.TagTask(ctx, created.ID, []string{"urgente", "homelab"})
+	if err != nil {
+		t.Fatalf("tag task: %v", err)
+	}
+	if len(tagged.Tags) != 2 || tagged.Version != created.Version+1 {
+		t.Fatalf("expected two tags and a new version, got %+v (version %d)", tagged.Tags, tagged.Version)
+	}
+
+	untagged, err := service.UntagTask(ctx, created.ID, "#urgente")
+	if err != nil {
+		t.Fatalf("untag task: %v", err)
+	}
+	if len(untagged.Tags) != 1 || untagged.Tags[0].Name != "homelab" {
+		t.Fatalf("expected only homelab left, got %+v", untagged.Tags)
+	}
+
+	history, err := service.History(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("history: %v", err)
+	}
+	last := history[len(history)-1]
+	if last.Field != "tags" || last.Before != `["homelab","urgente"]` || last.After != `["homelab"]` {
+		t.Fatalf("expected tags change recorded by name, got %+v", last)
+	}
+
+	urgente, err := service.GetTagByName(ctx, "URGENTE")
+	if err != nil {
+		t.Fatalf("get by name: %v", err)
+	}
+	if _, err := service.PatchTag(ctx, urgente.ID, map[string]any{"color": "Red"}); err != nil {
+		t.Fatalf("patch color: %v", err)
+	}
+
+	errs := []struct {
+		name string
+		run  func() error
+		want error
+	}{
+		{"invalid name", func() error { _, err := service.CreateTag(ctx, "com espaço", ""); return err }, ErrInvalidTag},
+		{"invalid color", func() error { _, err := service.CreateTag(ctx, "nova", "rosa"); return err }, ErrInvalidTagColor},
+		{"duplicate", func() error { _, err := service.CreateTag(ctx, "#HOMELAB", ""); return err }, ErrTagExists},
+		{"rename onto another tag", func() error {
+			_, err := service.PatchTag(ctx, urgente.ID, map[string]any{"name": "homelab"})
+			return err
+		}, ErrTagExists},
+		{"missing tag", func() error { _, err := service.GetTagByName(ctx, "nope"); return err }, ErrTagNotFound},
+		{"untag missing tag", func() error { _, err := service.UntagTask(ctx, created.ID, "nope"); return err }, ErrTagNotFound},
+		{"tag missing task", func() error { _, err := service.TagTask(ctx, "missing", []string{"x"}); return err }, ErrTaskNotFound},
+		{"tag without names", func() error { _, err := service.TagTask(ctx, created.ID, nil); return err }, ErrInvalidTag},
+		{"delete missing tag", func() error { return service.DeleteTag(ctx, "missing") }, ErrTagNotFound},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if err := service.DeleteTag(ctx, urgente.ID); err != nil {
+		t.Fatalf("delete tag: %v", err)
+	}
+	tags, err := service.ListTags(ctx)
+	if err != nil || len(tags) != 1 || tags[0].Name != "homelab" {
+		t.Fatalf("list tags = %+v, %v", tags, err)
+	}
+}
+
+func TestServiceRecurrenceKeepsTags(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	created, err := service.CreateTask(ctx, models.Task{Title: "lixo", Priority: models.PriorityLow, Recurrence: "weekly", ReminderAt: time.Now(), Tags: []models.Tag{{Name: "casa"}}})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := service.Complete(ctx, created.ID); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+
+	page, err := service.ListPage(ctx, task.ListQuery{Done: new(bool), Tags: task.TagFilter{Include: []string{"casa"}}}, "")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(page.Tasks) != 1 || page.Tasks[0].ID == created.ID {
+		t.Fatalf("expected next occurrence to keep the tags, got %+v", page.Tasks)
+	}
+}
+
+func TestServiceRecurrenceWithoutReminder(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	created, err := service.CreateTask(ctx, models.Task{Title: "regar", Priority: models.PriorityLow, Recurrence: "daily"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := service.Complete(ctx, created.ID); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+
+	page, err := service.ListPage(ctx, task.ListQuery{Done: new(bool)}, "")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(page.Tasks) != 1 || page.Tasks[0].ID == created.ID {
+		t.Fatalf("expected the next occurrence, got %+v", page.Tasks)
+	}
+	if next := page.Tasks[0]; !next.ReminderAt.IsZero() {
+		t.Fatalf("next reminder_at = %v, want none like the completed task", next.ReminderAt)
+	}
+}
+
+func TestServiceRecurrenceDueWithoutReminder(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	dueAt := time.Now().Add(-24 * time.Hour).Truncate(time.Minute)
+	created, err := service.CreateTask(ctx, models.Task{Title: "relatório", Priority: models.PriorityLow, Recurrence: "weekly", DueAt: &dueAt})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if _, err := service.Complete(ctx, created.ID); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+
+	page, err := service.ListPage(ctx, task.ListQuery{Done: new(bool)}, "")
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(page.Tasks) != 1 {
+		t.Fatalf("expected the next occurrence, got %+v", page.Tasks)
+	}
+	next := page.Tasks[0]
+	if want := dueAt.AddDate(0, 0, 7); next.DueAt == nil || !next.DueAt.Equal(want) {
+		t.Fatalf("next due_at = %v, want %v", next.DueAt, want)
+	}
+	if !next.ReminderAt.IsZero() {
+		t.Fatalf("next reminder_at = %v, want none", next.ReminderAt)
+	}
+}
+
+func TestServiceProjects(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	home, err := service.CreateProject(ctx, models.Project{Name: "  casa ", DefaultPriority: "alta", DefaultReminderOffset: "2H"})
+	if err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+	if home.Name != "casa" || home.DefaultPriority != models.PriorityHigh || home.DefaultReminderOffset != "2h" {
+		t.Fatalf("expected normalized project, got %+v", home)
+	}
+
+	before := time.Now()
+	defaulted, err := service.CreateTask(ctx, models.Task{Title: "louça", ProjectID: &home.ID})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if defaulted.Priority != models.PriorityHigh {
+		t.Fatalf("priority = %s, want the project default", defaulted.Priority)
+	}
+	if defaulted.ReminderAt.Before(before.Add(2*time.Hour)) || defaulted.ReminderAt.After(time.Now().Add(2*time.Hour)) {
+		t.Fatalf("reminder_at = %v, want two hours from creation", defaulted.ReminderAt)
+	}
+	explicit, err := service.CreateTask(ctx, models.Task{Title: "lixo", Priority: models.PriorityLow, ProjectID: &home.ID})
+	if err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if explicit.Priority != models.PriorityLow || !explicit.ReminderAt.After(before) {
+		t.Fatalf("expected explicit priority kept and default reminder, got %+v", explicit)
+	}
+	if _, err := service.Complete(ctx, explicit.ID); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+
+	got, err := service.ResolveProject(ctx, "casa")
+	if err != nil {
+		t.Fatalf("resolve by name: %v", err)
+	}
+	if got.Counts == nil || *got.Counts != (models.ProjectCounts{Total: 2, Open: 1, Done: 1}) {
+		t.Fatalf("counts = %+v, want 1 open and 1 done", got.Counts)
+	}
+	if byID, err := service.ResolveProject(ctx, home.ID); err != nil || byID.ID != home.ID {
+		t.Fatalf("resolve by id = %+v, %v", byID, err)
+	}
+
+	page, err := service.ListProjectTasks(ctx, home.ID, task.ListQuery{Done: new(bool)}, "")
+	if err != nil || len(page.Tasks) != 1 || page.Tasks[0].ID != defaulted.ID {
+		t.Fatalf("list project tasks = %+v, %v", page, err)
+	}
+
+	loose, _ := service.CreateTask(ctx, models.Task{Title: "solta", Priority: models.PriorityLow})
+	if moved, err := service.Patch(ctx, loose.ID, map[string]any{"project_id": home.ID}); err != nil || moved.ProjectID == nil {
+		t.Fatalf("move into project = %+v, %v", moved, err)
+	}
+
+	if _, err := service.PatchProject(ctx, home.ID, map[string]any{"archived": true}); err != nil {
+		t.Fatalf("archive: %v", err)
+	}
+	if active, _ := service.ListProjects(ctx, false); len(active) != 0 {
+		t.Fatalf("expected archived project hidden, got %+v", active)
+	}
+	if all, _ := service.ListProjects(ctx, true); len(all) != 1 || all[0].Counts == nil || all[0].Counts.Total != 3 {
+		t.Fatalf("expected archived project with counts, got %+v", all)
+	}
+
+	errs := []struct {
+		name string
+		run  func() error
+		want error
+	}{
+		{"blank name", func() error { _, err := service.CreateProject(ctx, models.Project{Name: " "}); return err }, ErrInvalidProject},
+		{"invalid priority", func() error {
+			_, err := service.CreateProject(ctx, models.Project{Name: "x", DefaultPriority: "urgente"})
+			return err
+		}, ErrInvalidProject},
+		{"negative reminder", func() error {
+			_, err := service.CreateProject(ctx, models.Project{Name: "x", DefaultReminderOffset: "-1h"})
+			return err
+		}, ErrInvalidProject},
+		{"duplicate", func() error { _, err := service.CreateProject(ctx, models.Project{Name: "casa"}); return err }, ErrProjectExists},
+		{"unknown field", func() error { _, err := service.PatchProject(ctx, home.ID, map[string]any{"id": "x"}); return err }, ErrInvalidProject},
+		{"task in archived project", func() error {
+			_, err := service.CreateTask(ctx, models.Task{Title: "x", Priority: models.PriorityLow, ProjectID: &home.ID})
+			return err
+		}, ErrProjectArchived},
+		{"move into archived project", func() error {
+			_, err := service.Patch(ctx, defaulted.ID, map[string]any{"project_id": home.ID})
+			return err
+		}, ErrProjectArchived},
+		{"task in missing project", func() error {
+			missing := "missing"
+			_, err := service.CreateTask(ctx, models.Task{Title: "x", Priority: models.PriorityLow, ProjectID: &missing})
+			return err
+		}, ErrProjectNotFound},
+		{"resolve missing", func() error { _, err := service.ResolveProject(ctx, "nope"); return err }, ErrProjectNotFound},
+		{"tasks of missing project", func() error {
+			_, err := service.ListProjectTasks(ctx, "00000000-0000-0000-0000-000000000000", task.ListQuery{}, "")
+			return err
+		}, ErrProjectNotFound},
+		{"delete missing", func() error { return service.DeleteProject(ctx, "missing") }, ErrProjectNotFound},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	if err := service.DeleteProject(ctx, home.ID); err != nil {
+		t.Fatalf("delete project: %v", err)
+	}
+	if orphan, _ := service.GetByID(ctx, defaulted.ID); orphan == nil || orphan.ProjectID != nil {
+		t.Fatalf("expected task kept without project, got %+v", orphan)
+	}
+}
+
+func TestServiceDependencies(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	newTask := func(title string) *models.Task {
+		t.Helper()
+		created, err := service.CreateTask(ctx, models.Task{Title: title, Priority: models.PriorityLow})
+		if err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+		return created
+	}
+	design, build, deploy := newTask("design"), newTask("build"), newTask("deploy")
+
+	blocked, err := service.AddDependency(ctx, build.ID, design.ID)
+	if err != nil {
+		t.Fatalf("add dependency: %v", err)
+	}
+	if !blocked.Blocked || fmt.Sprint(blocked.BlockedBy) != fmt.Sprint([]string{design.ID}) || blocked.Version != build.Version+1 {
+		t.Fatalf("expected build blocked by design in a new version, got %+v", blocked)
+	}
+	if again, err := service.AddDependency(ctx, build.ID, design.ID); err != nil || again.Version != blocked.Version {
+		t.Fatalf("add dependency twice = %+v, %v; want no new version", again, err)
+	}
+	if _, err := service.AddDependency(ctx, deploy.ID, build.ID); err != nil {
+		t.Fatalf("add dependency: %v", err)
+	}
+
+	errs := []struct {
+		name string
+		run  func() error
+		want error
+	}{
+		{"self", func() error { _, err := service.AddDependency(ctx, design.ID, design.ID); return err }, ErrDependencyCycle},
+		{"cycle", func() error { _, err := service.AddDependency(ctx, design.ID, deploy.ID); return err }, ErrDependencyCycle},
+		{"missing task", func() error { _, err := service.AddDependency(ctx, "missing", design.ID); return err }, ErrTaskNotFound},
+		{"missing dependency", func() error { _, err := service.AddDependency(ctx, design.ID, "missing"); return err }, ErrDependencyNotFound},
+		{"remove missing", func() error { _, err := service.RemoveDependency(ctx, design.ID, build.ID); return err }, ErrDependencyNotFound},
+		{"complete blocked", func() error { _, err := service.Complete(ctx, build.ID); return err }, ErrTaskBlocked},
+		{"patch done blocked", func() error {
+			_, err := service.Patch(ctx, deploy.ID, map[string]any{"done": true})
+			return err
+		}, ErrTaskBlocked},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.run(); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	deps, err := service.Dependencies(ctx, build.ID)
+	if err != nil || len(deps.DependsOn) != 1 || deps.DependsOn[0].ID != design.ID || len(deps.Blocks) != 1 || !deps.Blocks[0].Blocked {
+		t.Fatalf("dependencies of build = %+v, %v", deps, err)
+	}
+
+	page, err := service.ReadyPage(ctx, task.ListQuery{}, "")
+	if err != nil || len(page.Tasks) != 1 || page.Tasks[0].ID != design.ID {
+		t.Fatalf("ready = %+v, %v; want only design", page, err)
+	}
+
+	if _, err := service.Complete(ctx, design.ID); err != nil {
+		t.Fatalf("complete design: %v", err)
+	}
+	got, _ := service.GetByID(ctx, build.ID)
+	if got.Blocked || len(got.BlockedBy) != 0 {
+		t.Fatalf("expected build unblocked after design, got %+v", got)
+	}
+	if page, _ := service.ReadyPage(ctx, task.ListQuery{}, ""); len(page.Tasks) != 1 || page.Tasks[0].ID != build.ID {
+		t.Fatalf("ready = %+v; want only build", page.Tasks)
+	}
+
+	unblocked, err := service.RemoveDependency(ctx, deploy.ID, build.ID)
+	if err != nil || unblocked.Blocked {
+		t.Fatalf("remove dependency = %+v, %v", unblocked, err)
+	}
+	history, _ := service.History(ctx, deploy.ID)
+	last := history[len(history)-1]
+	if last.Field != "depends_on" || string(last.Before) != `["`+build.ID+`"]` || string(last.After) != `[]` {
+		t.Fatalf("expected depends_on change in history, got %+v", last)
+	}
+}
+
+func TestServiceDependencies_WarnPolicy(t *testing.T) {
+	ctx := context.Background()
+	service := NewServiceWithConfig(repository.NewMemoryStore(), Config{MaxDepth: DefaultMaxDepth, Dependencies: DependencyWarn})
+	first, _ := service.CreateTask(ctx, models.Task{Title: "first", Priority: models.PriorityLow})
+	second, _ := service.CreateTask(ctx, models.Task{Title: "second", Priority: models.PriorityLow})
+	if _, err := service.AddDependency(ctx, second.ID, first.ID); err != nil {
+		t.Fatalf("add dependency: %v", err)
+	}
+
+	completed, err := service.Complete(ctx, second.ID)
+	if err != nil {
+		t.Fatalf("complete with warn policy: %v", err)
+	}
+	if !completed.Done || completed.Blocked || fmt.Sprint(completed.BlockedBy) != fmt.Sprint([]string{first.ID}) {
+		t.Fatalf("expected done task reporting its open blocker, got %+v", completed)
+	}
+}
+
+func TestConfigFromEnv_Dependencies(t *testing.T) {
+	if cfg, err := ConfigFromEnv(); err != nil || cfg.Dependencies != DependencyRefuse {
+		t.Fatalf("default config = %+v, %v; want refuse", cfg, err)
+	}
+
+	t.Setenv("TASK_DEPENDENCY_POLICY", "warn")
+	if cfg, err := ConfigFromEnv(); err != nil || cfg.Dependencies != DependencyWarn {
+		t.Fatalf("config = %+v, %v; want warn", cfg, err)
+	}
+
+	t.Setenv("TASK_DEPENDENCY_POLICY", "ignore")
+	if _, err := ConfigFromEnv(); err == nil {
+		t.Fatalf("expected error for unknown policy")
+	}
+}
+
+func TestServiceDueDates(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	now := time.Now().Truncate(time.Second)
+	day := func(offset int) *time.Time {
+		y, m, d := now.Date()
+		v := time.Date(y, m, d+offset, 0, 0, 0, 0, time.Local)
+		return &v
+	}
+
+	t.Run("computes due states", func(t *testing.T) {
+		tests := []struct {
+			name string
+			task models.Task
+			want [3]bool
+		}{
+			{"yesterday", models.Task{DueAt: day(-1), DueAllDay: true}, [3]bool{true, false, false}},
+			{"today", models.Task{DueAt: day(0), DueAllDay: true}, [3]bool{false, true, false}},
+			{"tomorrow", models.Task{DueAt: day(1), DueAllDay: true}, [3]bool{false, false, true}},
+			{"next week", models.Task{DueAt: day(7), DueAllDay: true}, [3]bool{}},
+			{"no due date", models.Task{}, [3]bool{}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				tt.task.Title = tt.name
+				tt.task.Priority = models.PriorityLow
+				created, err := service.CreateTask(ctx, tt.task)
+				if err != nil {
+					t.Fatalf("create: %v", err)
+				}
+				if got := [3]bool{created.Overdue, created.DueToday, created.DueSoon}; got != tt.want {
+					t.Fatalf("create flags = %v, want %v", got, tt.want)
+				}
+				loaded, err := service.GetByID(ctx, created.ID)
+				if err != nil {
+					t.Fatalf("get: %v", err)
+				}
+				if got := [3]bool{loaded.Overdue, loaded.DueToday, loaded.DueSoon}; got != tt.want {
+					t.Fatalf("get flags = %v, want %v", got, tt.want)
+				}
+			})
+		}
+	})
+
+	t.Run("due reminders carry the computed fields", func(t *testing.T) {
+		created, err := service.CreateTask(ctx, models.Task{
+			Title:      "Pagar boleto",
+			Priority:   models.PriorityHigh,
+			ReminderAt: now.Add(time.Hour),
+			DueAt:      day(0),
+			DueAllDay:  true,
+		})
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+
+		due, err := service.GetDue(ctx, task.DueFilter{Before: now.Add(2 * time.Hour)})
+		if err != nil {
+			t.Fatalf("get due: %v", err)
+		}
+		for _, got := range due {
+			if got.ID == created.ID {
+				if !got.DueToday {
+					t.Fatalf("due task = %+v, want due_today computed", got)
+				}
+				return
+			}
+		}
+		t.Fatalf("expected %s among the due tasks, got %+v", created.ID, due)
+	})
+
+	t.Run("reminders relative to the due date", func(t *testing.T) {
+		reminderAt := now.Add(time.Hour)
+		dueAt := now.Add(72 * time.Hour)
+		created, err := service.CreateTask(ctx, models.Task{
+			Title:      "Declarar IR",
+			Priority:   models.PriorityHigh,
+			ReminderAt: reminderAt,
+			DueAt:      &dueAt,
+			Reminders: []models.Reminder{
+				{Offset: "0"},
+				{Offset: "-1d", Anchor: models.AnchorDue},
+			},
+		})
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		fireAt := func(task *models.Task, anchor string) time.Time {
+			for _, r := range task.Reminders {
+				if r.Anchor == anchor {
+					return r.FireAt
+				}
+			}
+			t.Fatalf("no reminder anchored at %q in %+v", anchor, task.Reminders)
+			return time.Time{}
+		}
+		if got := fireAt(created, models.AnchorDue); !got.Equal(dueAt.Add(-24 * time.Hour)) {
+			t.Fatalf("due reminder fires at %v, want %v", got, dueAt.Add(-24*time.Hour))
+		}
+
+		newDue := dueAt.Add(48 * time.Hour)
+		patched, err := service.Patch(ctx, created.ID, map[string]any{"due_at": newDue})
+		if err != nil {
+			t.Fatalf("patch: %v", err)
+		}
+		if got := fireAt(patched, models.AnchorDue); !got.Equal(newDue.Add(-24 * time.Hour)) {
+			t.Fatalf("due reminder after patch fires at %v, want %v", got, newDue.Add(-24*time.Hour))
+		}
+		if got := fireAt(patched, ""); !got.Equal(reminderAt) {
+			t.Fatalf("reminder_at reminder moved to %v, want %v", got, reminderAt)
+		}
+
+		if _, err := service.CreateTask(ctx, models.Task{
+			Title:     "sem prazo",
+			Priority:  models.PriorityLow,
+			Reminders: []models.Reminder{{Offset: "-1d", Anchor: models.AnchorDue}},
+		}); !errors.Is(err, ErrInvalidReminder) {
+			t.Fatalf("due reminder without due_at: err = %v, want ErrInvalidReminder", err)
+		}
+		if _, err := service.AddReminder(ctx, created.ID, models.Reminder{Offset: "0", Anchor: "whenever"}); !errors.Is(err, ErrInvalidReminder) {
+			t.Fatalf("unknown anchor: err = %v, want ErrInvalidReminder", err)
+		}
+	})
+
+	t.Run("recurrence moves the due date along", func(t *testing.T) {
+		reminderAt := now.Add(-time.Hour)
+		created, err := service.CreateTask(ctx, models.Task{
+			Title:      "Pagar aluguel",
+			Priority:   models.PriorityHigh,
+			ReminderAt: reminderAt,
+			DueAt:      day(2),
+			DueAllDay:  true,
+			Recurrence: "weekly",
+		})
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		if _, err := service.Complete(ctx, created.ID); err != nil {
+			t.Fatalf("complete: %v", err)
+		}
+
+		tasks, err := service.List(ctx)
+		if err != nil {
+			t.Fatalf("list: %v", err)
+		}
+		for _, tk := range tasks {
+			if tk.Title != "Pagar aluguel" || tk.ID == created.ID {
+				continue
+			}
+			if tk.DueAt == nil || !tk.DueAt.Equal(day(2).AddDate(0, 0, 7)) || !tk.DueAllDay {
+				t.Fatalf("next occurrence due = %v (all day %v), want %v", tk.DueAt, tk.DueAllDay, day(2).AddDate(0, 0, 7))
+			}
+			return
+		}
+		t.Fatalf("next occurrence not found")
+	})
+}
+
+func TestConfigFromEnv_DueSoon(t *testing.T) {
+	if cfg, err := ConfigFromEnv(); err != nil || cfg.DueSoon != DefaultDueSoon {
+		t.Fatalf("default config = %+v, %v; want %v", cfg, err, DefaultDueSoon)
+	}
+
+	t.Setenv("TASK_DUE_SOON", "3d")
+	if cfg, err := ConfigFromEnv(); err != nil || cfg.DueSoon != 72*time.Hour {
+		t.Fatalf("config = %+v, %v; want 72h", cfg, err)
+	}
+
+	t.Setenv("TASK_DUE_SOON", "logo")
+	if _, err := ConfigFromEnv(); err == nil {
+		t.Fatalf("expected error for invalid window")
+	}
+}
+
+func TestServicePatchCompletesRecurring(t *testing.T) {
+	ctx := context.Background()
+
+	for _, changes := range []map[string]any{
+		{"status": models.StatusDone},
+		{"done": true, "title": "backup da semana"},
+	} {
+		t.Run(fmt.Sprint(changes), func(t *testing.T) {
+			service := NewService(repository.NewMemoryStore())
+			created, err := service.CreateTask(ctx, models.Task{Title: "backup", Priority: models.PriorityLow, Recurrence: "weekly", ReminderAt: time.Now()})
+			if err != nil {
+				t.Fatalf("create: %v", err)
+			}
+
+			patched, err := service.PatchIfMatch(ctx, created.ID, created.Version, changes)
+			if err != nil || patched.Status != models.StatusDone || patched.Recurrence != "" || patched.CompletedAt == nil {
+				t.Fatalf("patch = %+v, %v; want done and archived in the series", patched, err)
+			}
+			if title, ok := changes["title"]; ok && patched.Title != title {
+				t.Fatalf("title = %q, want %q", patched.Title, title)
+			}
+
+			page, err := service.ListPage(ctx, task.ListQuery{Done: new(bool)}, "")
+			if err != nil || len(page.Tasks) != 1 || page.Tasks[0].Recurrence != "weekly" {
+				t.Fatalf("open tasks = %+v, %v; want the next occurrence", page.Tasks, err)
+			}
+			history, err := service.History(ctx, created.ID)
+			if err != nil || len(history) == 0 || history[len(history)-1].Action != models.HistoryComplete {
+				t.Fatalf("history = %+v, %v; want the completion recorded", history, err)
+			}
+		})
+	}
+}
+
+func TestServiceStatus(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	newTask := func(title string) *models.Task {
+		t.Helper()
+		created, err := service.CreateTask(ctx, models.Task{Title: title, Priority: models.PriorityLow, Done: true})
+		if err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+		return created
+	}
+	doc, call := newTask("doc"), newTask("ligar")
+	if doc.Status != models.StatusTodo || doc.Done {
+		t.Fatalf("expected new task to start as todo, got %+v", doc)
+	}
+
+	started, err := service.SetStatus(ctx, doc.ID, doc.Version, models.StatusInProgress)
+	if err != nil || started.Status != models.StatusInProgress || started.StartedAt == nil || started.Done {
+		t.Fatalf("start = %+v, %v", started, err)
+	}
+	waiting, err := service.SetStatus(ctx, doc.ID, 0, models.StatusWaiting)
+	if err != nil || waiting.Status != models.StatusWaiting || !waiting.StartedAt.Equal(*started.StartedAt) {
+		t.Fatalf("wait = %+v, %v; want started_at kept", waiting, err)
+	}
+	if _, err := service.SetStatus(ctx, doc.ID, started.Version, models.StatusTodo); !errors.Is(err, ErrVersionConflict) {
+		t.Fatalf("stale status err = %v, want conflict", err)
+	}
+
+	done, err := service.SetStatus(ctx, doc.ID, 0, models.StatusDone)
+	if err != nil || done.Status != models.StatusDone || !done.Done || done.CompletedAt == nil {
+		t.Fatalf("done = %+v, %v", done, err)
+	}
+	if _, err := service.SetStatus(ctx, doc.ID, 0, models.StatusCancelled); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("done -> cancelled err = %v, want invalid transition", err)
+	}
+	reopened, err := service.Reopen(ctx, doc.ID, done.Version)
+	if err != nil || reopened.Status != models.StatusTodo || reopened.Done || reopened.CompletedAt != nil {
+		t.Fatalf("reopen = %+v, %v", reopened, err)
+	}
+	if _, err := service.Reopen(ctx, doc.ID, 0); !errors.Is(err, ErrTaskNotClosed) {
+		t.Fatalf("reopen open task err = %v, want ErrTaskNotClosed", err)
+	}
+	if got, err := service.Reopen(ctx, "missing", 0); got != nil || err != nil {
+		t.Fatalf("reopen missing = %+v, %v; want nil, nil", got, err)
+	}
+
+	cancelled, err := service.SetStatus(ctx, call.ID, 0, models.StatusCancelled)
+	if err != nil || cancelled.Status != models.StatusCancelled || cancelled.CancelledAt == nil || cancelled.Done {
+		t.Fatalf("cancel = %+v, %v", cancelled, err)
+	}
+	if _, err := service.Complete(ctx, call.ID); !errors.Is(err, ErrInvalidTransition) {
+		t.Fatalf("complete cancelled err = %v, want invalid transition", err)
+	}
+	if _, err := service.Snooze(ctx, call.ID, time.Now().Add(time.Hour)); !errors.Is(err, ErrTaskCancelled) {
+		t.Fatalf("snooze cancelled err = %v, want ErrTaskCancelled", err)
+	}
+	if _, err := service.SetStatus(ctx, call.ID, 0, "feito"); !errors.Is(err, ErrInvalidStatus) {
+		t.Fatalf("invalid status err = %v", err)
+	}
+
+	// Dependência cancelada não bloqueia.
+	if blocked, err := service.AddDependency(ctx, doc.ID, call.ID); err != nil || blocked.Blocked {
+		t.Fatalf("depend on cancelled = %+v, %v; want not blocked", blocked, err)
+	}
+
+	patches := []struct {
+		name    string
+		changes map[string]any
+		want    models.TaskStatus
+		wantErr error
+	}{
+		{"done true", map[string]any{"done": true}, models.StatusDone, nil},
+		{"done false reopens", map[string]any{"done": false}, models.StatusTodo, nil},
+		{"done false on open task", map[string]any{"done": false}, models.StatusTodo, nil},
+		{"status", map[string]any{"status": models.StatusBlocked}, models.StatusBlocked, nil},
+		{"invalid status", map[string]any{"status": models.TaskStatus("feito")}, "", ErrInvalidStatus},
+	}
+	for _, tt := range patches {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := service.Patch(ctx, doc.ID, tt.changes)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (got.Status != tt.want || got.Done != (tt.want == models.StatusDone)) {
+				t.Fatalf("patch = %+v, want status %s", got, tt.want)
+			}
+		})
+	}
+
+	history, _ := service.History(ctx, call.ID)
+	last := history[len(history)-1]
+	if last.Action != models.HistoryStatus {
+		t.Fatalf("expected status action in history, got %+v", last)
+	}
+}
+
+func TestServiceStatusRollsBack(t *testing.T) {
+	ctx := context.Background()
+	store := &failingHistoryStore{MemoryStore: repository.NewMemoryStore()}
+	service := NewService(store)
+	created, err := service.CreateTask(ctx, models.Task{Title: "doc", Priority: models.PriorityLow})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	store.fail = true
+
+	if _, err := service.SetStatus(ctx, created.ID, 0, models.StatusInProgress); err == nil {
+		t.Fatalf("expected status change to fail with the history")
+	}
+	got, _ := service.GetByID(ctx, created.ID)
+	if got.Status != models.StatusTodo || got.Version != created.Version {
+		t.Fatalf("task = %+v, want status change rolled back", got)
+	}
+}
+
+func TestServiceSnoozeRollsBack(t *testing.T) {
+	ctx := context.Background()
+	store := &failingHistoryStore{MemoryStore: repository.NewMemoryStore()}
+	service := NewService(store)
+	created, err := service.CreateTask(ctx, models.Task{Title: "ligar", Priority: models.PriorityLow})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	store.fail = true
+
+	if _, err := service.Snooze(ctx, created.ID, time.Now().Add(time.Hour)); err == nil {
+		t.Fatalf("expected snooze to fail with the history")
+	}
+	got, _ := service.GetByID(ctx, created.ID)
+	if got.SnoozeCount != 0 || !got.ReminderAt.Equal(created.ReminderAt) || len(got.Reminders) != 0 {
+		t.Fatalf("task = %+v, want snooze rolled back", got)
+	}
+}
+
+// failingHistoryStore falha ao gravar histórico enquanto fail estiver ligado.
+type failingHistoryStore struct {
+	*repository.MemoryStore
+	fail bool
+}
+
+func (f *failingHistoryStore) AppendHistory(ctx context.Context, changes []models.TaskChange) error {
+	if f.fail {
+		return errors.New("falha ao gravar histórico")
+	}
+	return f.MemoryStore.AppendHistory(ctx, changes)
+}
+
+func TestServiceAdvice(t *testing.T) {
+	ctx := context.Background()
+	service := NewService(repository.NewMemoryStore())
+	newTask := func(title string, priority models.Priority) *models.Task {
+		t.Helper()
+		created, err := service.CreateTask(ctx, models.Task{Title: title, Priority: priority})
+		if err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+		return created
+	}
+	high := newTask("alta", models.PriorityHigh)
+	low, err := service.CreateTask(ctx, models.Task{Title: "baixa", Priority: models.PriorityLow, ReminderAt: time.Now().Add(-time.Hour)})
+	if err != nil {
+		t.Fatalf("create baixa: %v", err)
+	}
+	design, build := newTask("design", models.PriorityMedium), newTask("build", models.PriorityHigh)
+	if _, err := service.AddDependency(ctx, build.ID, design.ID); err != nil {
+		t.Fatalf("add dependency: %v", err)
+	}
+	done := newTask("feita", models.PriorityHigh)
+	if _, err := service.Complete(ctx, done.ID); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+
+	advice, err := service.Advice(ctx, 0, "")
+	if err != nil {
+		t.Fatalf("advice: %v", err)
+	}
+	var order []string
+	for _, a := range advice {
+		order = append(order, a.Task.ID)
+	}
+	if fmt.Sprint(order) != fmt.Sprint([]string{high.ID, low.ID, design.ID, build.ID}) {
+		t.Fatalf("order = %v; want alta, baixa, design, build", order)
+	}
+	if last := advice[len(advice)-1]; !last.Task.Blocked || !strings.Contains(last.Explanation, "depende de 1 tarefa(s) em aberto") {
+		t.Fatalf("expected blocked build last with explanation, got %+v", last)
+	}
+
+	if advice, err := service.Advice(ctx, 1, "priority=0,blocked=0"); err != nil || len(advice) != 1 || advice[0].Task.ID != low.ID {
+		t.Fatalf("advice without priority = %+v, %v; want only the task with a late reminder", advice, err)
+	}
+	if _, err := service.Advice(ctx, 0, "urgency=1"); !errors.Is(err, ErrInvalidWeights) {
+		t.Fatalf("invalid weights err = %v", err)
+	}
+	if _, err := service.Advice(ctx, -1, ""); !errors.Is(err, ErrInvalidInput) {
+		t.Fatalf("invalid limit err = %v", err)
+	}
+
+	zero := NewServiceWithConfig(repository.NewMemoryStore(), Config{MaxDepth: DefaultMaxDepth})
+	if zero.AdviceWeights() != task.DefaultAdviceWeights() {
+		t.Fatalf("expected default weights without config, got %+v", zero.AdviceWeights())
+	}
+}
+
+func TestConfigFromEnv_AdviceWeights(t *testing.T) {
+	t.Setenv("TASK_ADVICE_WEIGHTS", "due=6,age=0")
+	cfg, err := ConfigFromEnv()
+	want := task.DefaultAdviceWeights()
+	want.Due, want.Age = 6, 0
+	if err != nil || cfg.Advice != want {
+		t.Fatalf("config = %+v, %v; want %+v", cfg.Advice, err, want)
+	}
+
+	t.Setenv("TASK_ADVICE_WEIGHTS", "due=muito")
+	if _, err := ConfigFromEnv(); err == nil {
+		t.Fatalf("expected error for invalid weights")
+	}
+}
+
+func TestServicePlan(t *testing.T) {
+	ctx := context.Background()
+	config := DefaultConfig()
+	config.WorkHours = task.WorkHours{Start: 9 * time.Hour, End: 12 * time.Hour}
+	service := NewServiceWithConfig(repository.NewMemoryStore(), config)
+
+	now := time.Now()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+	newTask := func(title string, priority models.Priority, estimate int, reminderAt time.Time) *models.Task {
+		t.Helper()
+		created, err := service.CreateTask(ctx, models.Task{Title: title, Priority: priority, EstimateMinutes: estimate, ReminderAt: reminderAt})
+		if err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+		return created
+	}
+	report := newTask("relatório", models.PriorityHigh, 60, time.Time{})
+	call := newTask("ligação", models.PriorityLow, 30, tomorrow.Add(10*time.Hour))
+	mail := newTask("e-mails", models.PriorityMedium, 0, time.Time{})
+	done := newTask("feita", models.PriorityHigh, 0, time.Time{})
+	if _, err := service.Complete(ctx, done.ID); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+
+	plan, err := service.Plan(ctx, tomorrow)
+	if err != nil {
+		t.Fatalf("plan: %v", err)
+	}
+	var got []string
+	for _, b := range plan.Blocks {
+		got = append(got, fmt.Sprintf("%s %s-%s", b.Title, b.Start.Format("15:04"), b.End.Format("15:04")))
+	}
+	want := []string{"relatório 09:00-10:00", "ligação 10:00-10:30", "e-mails 10:30-11:00"}
+	if fmt.Sprint(got) != fmt.Sprint(want) || len(plan.Unscheduled) != 0 || plan.FreeMinutes != 60 {
+		t.Fatalf("plan = %v (unscheduled %+v, free %d); want %v", got, plan.Unscheduled, plan.FreeMinutes, want)
+	}
+	if _, err := service.Plan(ctx, tomorrow.AddDate(0, 0, -2)); !errors.Is(err, ErrInvalidPlanDate) {
+		t.Fatalf("plan yesterday err = %v", err)
+	}
+
+	updated, err := service.AcceptPlan(ctx, []task.PlanSlot{plan.Blocks[0].PlanSlot, plan.Blocks[1].PlanSlot, plan.Blocks[2].PlanSlot})
+	if err != nil || len(updated) != 2 {
+		t.Fatalf("accept = %+v, %v; want report and mail updated", updated, err)
+	}
+	for _, want := range []struct {
+		id string
+		at time.Time
+	}{{report.ID, tomorrow.Add(9 * time.Hour)}, {call.ID, tomorrow.Add(10 * time.Hour)}, {mail.ID, tomorrow.Add(10*time.Hour + 30*time.Minute)}} {
+		got, _ := service.GetByID(ctx, want.id)
+		if !got.ReminderAt.Equal(want.at) {
+			t.Fatalf("reminder of %s = %v, want %v", got.Title, got.ReminderAt, want.at)
+		}
+	}
+
+	errs := []struct {
+		name  string
+		slots []task.PlanSlot
+		want  error
+	}{
+		{"empty", nil, ErrInvalidInput},
+		{"no start", []task.PlanSlot{{TaskID: report.ID}}, ErrInvalidInput},
+		{"missing task", []task.PlanSlot{{TaskID: "missing", Start: tomorrow}}, ErrTaskNotFound},
+		{"closed task", []task.PlanSlot{{TaskID: report.ID, Start: tomorrow}, {TaskID: done.ID, Start: tomorrow}}, ErrTaskAlreadyDone},
+	}
+	for _, tt := range errs {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := service.AcceptPlan(ctx, tt.slots); !errors.Is(err, tt.want) {
+				t.Fatalf("err = %v, want %v", err, tt.want)
+			}
+		})
+	}
+	if got, _ := service.GetByID(ctx, report.ID); !got.ReminderAt.Equal(tomorrow.Add(9 * time.Hour)) {
+		t.Fatalf("failed accept changed the report reminder to %v", got.ReminderAt)
+	}
+
+	if _, err := service.CreateTask(ctx, models.Task{Title: "x", Priority: models.PriorityLow, EstimateMinutes: -5}); !errors.Is(err, ErrInvalidEstimate) {
+		t.Fatalf("negative estimate err = %v", err)
+	}
+	if _, err := service.Patch(ctx, mail.ID, map[string]any{"estimate_minutes": 2000}); !errors.Is(err, ErrInvalidEstimate) {
+		t.Fatalf("huge estimate err = %v", err)
+	}
+	if got, err := service.Patch(ctx, mail.ID, map[string]any{"estimate_minutes": 15}); err != nil || got.EstimateMinutes != 15 {
+		t.Fatalf("patch estimate = %+v, %v", got, err)
+	}
+}
+
+func TestConfigFromEnv_Plan(t *testing.T) {
+	if cfg, err := ConfigFromEnv(); err != nil || cfg.WorkHours != DefaultWorkHours || cfg.Estimate != DefaultEstimate {
+		t.Fatalf("default config = %+v, %v", cfg, err)
+	}
+
+	t.Setenv("TASK_WORK_HOURS", "08:00-17:30")
+	t.Setenv("TASK_DEFAULT_ESTIMATE", "45m")
+	cfg, err := ConfigFromEnv()
+	if err != nil || cfg.WorkHours != (task.WorkHours{Start: 8 * time.Hour, End: 17*time.Hour + 30*time.Minute}) || cfg.Estimate != 45*time.Minute {
+		t.Fatalf("config = %+v, %v", cfg, err)
+	}
+
+	t.Setenv("TASK_WORK_HOURS", "18:00-09:00")
+	if _, err := ConfigFromEnv(); err == nil {
+		t.Fatalf("expected error for inverted work hours")
+	}
+	t.Setenv("TASK_WORK_HOURS", "09:00-18:00")
+	t.Setenv("TASK_DEFAULT_ESTIMATE", "0")
+	if _, err := ConfigFromEnv(); err == nil {
+		t.Fatalf("expected error for zero default estimate")
+	}
+}
+
+func TestServiceTimer(t *testing.T) {
+	ctx := context.Background()
+	store := repository.NewMemoryStore()
+	service := NewService(store)
+
+	parent, _ := service.CreateTask(ctx, models.Task{Title: "parent", Priority: models.PriorityLow})
+	child, _ := service.CreateTask(ctx, models.Task{Title: "child", Priority: models.PriorityLow, ParentID: &parent.ID})
+	other, _ := service.CreateTask(ctx, models.Task{Title: "other", Priority: models.PriorityLow})
+	done, _ := service.CreateTask(ctx, models.Task{Title: "done", Priority: models.PriorityLow})
+	if _, err := service.Complete(ctx, done.ID); err != nil {
+		t.Fatalf("complete: %v", err)
+	}
+
+	if timer, err := service.RunningTimer(ctx); err != nil || timer != nil {
+		t.Fatalf("running with no timer = %+v, %v; want nil", timer, err)
+	}
+	if _, err := service.StopTimer(ctx); !errors.Is(err, ErrNoRunningTimer) {
+		t.Fatalf("stop with no timer err = %v", err)
+	}
+
+	started, err := service.StartTimer(ctx, child.ID, "começando")
+	if err != nil || started.Entry.TaskID != child.ID || started.Stopped != nil {
+		t.Fatalf("start = %+v, %v", started, err)
+	}
+	if started.Task.Status != models.StatusInProgress {
+		t.Fatalf("status after start = %s, want in_progress", started.Task.Status)
+	}
+	if _, err := service.StartTimer(ctx, child.ID, ""); !errors.Is(err, ErrTimerRunning) {
+		t.Fatalf("start twice err = %v", err)
+	}
+	if _, err := service.StartTimer(ctx, done.ID, ""); !errors.Is(err, ErrTaskAlreadyDone) {
+		t.Fatalf("start done err = %v", err)
+	}
+	if _, err := service.StartTimer(ctx, "missing", ""); !errors.Is(err, ErrTaskNotFound) {
+		t.Fatalf("start missing err = %v", err)
+	}
+
+	switched, err := service.StartTimer(ctx, other.ID, "")
+	if err != nil || switched.Stopped == nil || switched.Stopped.ID != started.Entry.ID || switched.Stopped.StoppedAt == nil {
+		t.Fatalf("start other = %+v, %v; want the first timer stopped", switched, err)
+	}
+	running, err := service.RunningTimer(ctx)
+	if err != nil || running == nil || running.Task.ID != other.ID {
+		t.Fatalf("running = %+v, %v; want other", running, err)
+	}
+	stopped, err := service.StopTimer(ctx)
+	if err != nil || stopped.Entry.StoppedAt == nil || stopped.Task.ID != other.ID {
+		t.Fatalf("stop = %+v, %v", stopped, err)
+	}
+
+	now := time.Now()
+	for _, e := range []struct {
+		taskID string
+		ago    time.Duration
+		length time.Duration
+	}{{parent.ID, 3 * time.Hour, time.Hour}, {child.ID, 90 * time.Minute, 30 * time.Minute}} {
+		stop := now.Add(-e.ago + e.length)
+		if err := store.CreateTimeEntry(ctx, &models.TimeEntry{TaskID: e.taskID, StartedAt: now.Add(-e.ago), StoppedAt: &stop}); err != nil {
+			t.Fatalf("create entry: %v", err)
+		}
+	}
+	got, err := service.GetByID(ctx, parent.ID)
+	if err != nil || got.TrackedSeconds < 90*60 || got.TrackedSeconds > 90*60+5 {
+		t.Fatalf("parent tracked = %d, %v; want 1h30 with the subtask", got.TrackedSeconds, err)
+	}
+
+	entries, err := service.TimeEntries(ctx, task.TimeQuery{TaskID: &child.ID})
+	if err != nil || len(entries) != 2 || entries[0].Note != "" || entries[1].Note != "começando" {
+		t.Fatalf("child entries = %+v, %v", entries, err)
+	}
+	missing := "missing"
+	if _, err := service.TimeEntries(ctx, task.TimeQuery{TaskID: &missing}); !errors.Is(err, ErrTaskNotFound) {
+		t.Fatalf("entries of missing task err = %v", err)
+	}
+
+	from := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, -1)
+	sheet, err := service.Timesheet(ctx, from, from.AddDate(0, 0, 2))
+	if err != nil || sheet.Seconds < 90*60 || sheet.Seconds > 90*60+5 {
+		t.Fatalf("timesheet = %+v, %v; want 1h30", sheet, err)
+	}
+	if _, err := service.Timesheet(ctx, from, from); !errors.Is(err, ErrInvalidPeriod) {
+		t.Fatalf("empty period err = %v", err)
+	}
+}
+
+// staleTimerStore não enxerga o cronômetro rodando, como uma leitura feita
+// antes de outro start concorrente gravar o seu.
+type staleTimerStore struct {
+	*repository.MemoryStore
+}
+
+func (s staleTimerStore) RunningTimeEntry(ctx context.Context) (*models.TimeEntry, error) {
+	return nil, nil
+}
+
+func TestServiceTimerConflict(t *testing.T) {
+	ctx := context.Background()
+	store := staleTimerStore{repository.NewMemoryStore()}
+	service := NewService(store)
+
+	first, _ := service.CreateTask(ctx, models.Task{Title: "first", Priority: models.PriorityLow})
+	second, _ := service.CreateTask(ctx, models.Task{Title: "second", Priority: models.PriorityLow})
+	if _, err := service.StartTimer(ctx, first.ID, ""); err != nil {
+		t.Fatalf("start first: %v", err)
+	}
+
+	if _, err := service.StartTimer(ctx, second.ID, ""); !errors.Is(err, ErrTimerConflict) {
+		t.Fatalf("concurrent start err = %v, want ErrTimerConflict", err)
+	}
+	entries, err := service.TimeEntries(ctx, task.TimeQuery{})
+	if err != nil || len(entries) != 1 || entries[0].TaskID != first.ID {
+		t.Fatalf("entries = %+v, %v; want only the first timer", entries, err)
+	}
+}
@@ -0,0 +1,224 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// TimeStore guarda os registros de tempo.
+type TimeStore interface {
+	CreateTimeEntry(ctx context.Context, entry *models.TimeEntry) error
+	StopTimeEntry(ctx context.Context, id string, at time.Time) (bool, error)
+	RunningTimeEntry(ctx context.Context) (*models.TimeEntry, error)
+	ListTimeEntries(ctx context.Context, query task.TimeQuery) ([]models.TimeEntry, error)
+	TrackedTime(ctx context.Context, ids []string, maxDepth int, now time.Time) (map[string]time.Duration, error)
+}
+
+// Timer é um registro de tempo com a sua tarefa. Ao iniciar um cronômetro
+// com outro rodando, Stopped é o que foi parado.
+type Timer struct {
+	Entry          models.TimeEntry  `json:"entry"`
+	Task           models.Task       `json:"task"`
+	ElapsedSeconds int64             `json:"elapsed_seconds"`
+	Stopped        *models.TimeEntry `json:"stopped,omitempty"`
+}
+
+// StartTimer começa a contar o tempo em taskID. Só um cronômetro roda por vez:
+// se outro estiver rodando, ele é parado antes. Tarefa em todo passa para
+// in_progress. Se outro cronômetro começar ao mesmo tempo, devolve
+// ErrTimerConflict e nada muda.
+func (s *Service) StartTimer(ctx context.Context, taskID, note string) (*Timer, error) {
+	var timer *Timer
+	err := s.repo.Atomic(ctx, func(ctx context.Context) (err error) {
+		timer, err = s.startTimer(ctx, taskID, note)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return timer, nil
+}
+
+func (s *Service) startTimer(ctx context.Context, taskID, note string) (*Timer, error) {
+	t, err := s.repo.GetByID(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tarefa: %w", err)
+	}
+	if t == nil {
+		return nil, ErrTaskNotFound
+	}
+	if t.Status.Closed() {
+		return nil, ErrTaskAlreadyDone
+	}
+
+	running, err := s.repo.RunningTimeEntry(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar o cronômetro: %w", err)
+	}
+	if running != nil && running.TaskID == taskID {
+		return nil, ErrTimerRunning
+	}
+
+	now := time.Now()
+	timer := &Timer{}
+	if running != nil {
+		if _, err := s.repo.StopTimeEntry(ctx, running.ID, now); err != nil {
+			return nil, fmt.Errorf("[ ERRO ] Problema ao parar o cronômetro: %w", err)
+		}
+		running.StoppedAt = &now
+		timer.Stopped = running
+	}
+
+	entry := &models.TimeEntry{TaskID: taskID, StartedAt: now, Note: note}
+	if err := s.repo.CreateTimeEntry(ctx, entry); err != nil {
+		if errors.Is(err, ErrTimerConflict) {
+			return nil, ErrTimerConflict
+		}
+		return nil, fmt.Errorf("[ ERRO ] Problema ao iniciar o cronômetro: %w", err)
+	}
+	timer.Entry = *entry
+
+	if t.Status == models.StatusTodo {
+		updated, err := s.transition(ctx, t, 0, models.StatusInProgress)
+		if err != nil {
+			return nil, err
+		}
+		if updated != nil {
+			t = updated
+		}
+	} else if t, err = s.withComputed(ctx, t); err != nil {
+		return nil, err
+	}
+	timer.Task = *t
+	return timer, nil
+}
+
+// StopTimer para o cronômetro rodando.
+func (s *Service) StopTimer(ctx context.Context) (*Timer, error) {
+	running, err := s.repo.RunningTimeEntry(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar o cronômetro: %w", err)
+	}
+	if running == nil {
+		return nil, ErrNoRunningTimer
+	}
+
+	now := time.Now()
+	stopped, err := s.repo.StopTimeEntry(ctx, running.ID, now)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao parar o cronômetro: %w", err)
+	}
+	if !stopped {
+		return nil, ErrNoRunningTimer
+	}
+	running.StoppedAt = &now
+	return s.timer(ctx, running, now)
+}
+
+// RunningTimer devolve o cronômetro rodando, ou nil se não houver.
+func (s *Service) RunningTimer(ctx context.Context) (*Timer, error) {
+	running, err := s.repo.RunningTimeEntry(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar o cronômetro: %w", err)
+	}
+	if running == nil {
+		return nil, nil
+	}
+	return s.timer(ctx, running, time.Now())
+}
+
+func (s *Service) timer(ctx context.Context, entry *models.TimeEntry, now time.Time) (*Timer, error) {
+	t, err := s.repo.GetByID(ctx, entry.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tarefa: %w", err)
+	}
+	if t == nil {
+		return nil, ErrTaskNotFound
+	}
+	if _, err := s.withComputed(ctx, t); err != nil {
+		return nil, err
+	}
+	return &Timer{
+		Entry:          *entry,
+		Task:           *t,
+		ElapsedSeconds: int64(entry.Duration(now) / time.Second),
+	}, nil
+}
+
+// TimeEntries lista os registros de tempo da consulta, dos mais antigos para
+// os mais novos.
+func (s *Service) TimeEntries(ctx context.Context, query task.TimeQuery) ([]models.TimeEntry, error) {
+	if query.From != nil && query.To != nil && !query.From.Before(*query.To) {
+		return nil, ErrInvalidPeriod
+	}
+	if query.TaskID != nil {
+		t, err := s.repo.GetByID(ctx, *query.TaskID)
+		if err != nil {
+			return nil, fmt.Errorf("[ ERRO ] Problema ao buscar tarefa: %w", err)
+		}
+		if t == nil {
+			return nil, ErrTaskNotFound
+		}
+	}
+
+	entries, err := s.repo.ListTimeEntries(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao listar os registros de tempo: %w", err)
+	}
+	if entries == nil {
+		entries = []models.TimeEntry{}
+	}
+	return entries, nil
+}
+
+// Timesheet monta a folha de horas de [from, to), por dia e projeto.
+func (s *Service) Timesheet(ctx context.Context, from, to time.Time) (*task.Timesheet, error) {
+	if !from.Before(to) {
+		return nil, ErrInvalidPeriod
+	}
+	entries, err := s.repo.ListTimeEntries(ctx, task.TimeQuery{From: &from, To: &to})
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao listar os registros de tempo: %w", err)
+	}
+	projects, err := s.repo.ListProjects(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao listar os projetos: %w", err)
+	}
+
+	names := make(map[string]string, len(projects))
+	for _, p := range projects {
+		names[p.ID] = p.Name
+	}
+	sheet := task.BuildTimesheet(entries, names, from, to, time.Now())
+	return &sheet, nil
+}
+
+// attachTracked preenche o tempo registrado em cada tarefa, somando o das
+// subtarefas.
+func (s *Service) attachTracked(ctx context.Context, now time.Time, tasks ...*models.Task) error {
+	ids := make([]string, 0, len(tasks))
+	for _, t := range tasks {
+		if t != nil {
+			ids = append(ids, t.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	tracked, err := s.repo.TrackedTime(ctx, ids, s.config.MaxDepth, now)
+	if err != nil {
+		return fmt.Errorf("[ ERRO ] Problema ao somar o tempo registrado: %w", err)
+	}
+	for _, t := range tasks {
+		if t != nil {
+			t.TrackedSeconds = int64(tracked[t.ID] / time.Second)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// TrashStore cuida das tarefas na lixeira.
+type TrashStore interface {
+	ListDeleted(ctx context.Context) ([]models.Task, error)
+	Restore(ctx context.Context, id string) (*models.Task, error)
+	Purge(ctx context.Context, id string) (bool, error)
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+func (s *Service) Trash(ctx context.Context) ([]models.Task, error) {
+	tasks, err := s.repo.ListDeleted(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao listar a lixeira: %w", err)
+	}
+	if tasks == nil {
+		return []models.Task{}, nil
+	}
+	return tasks, nil
+}
+
+// DeletedChildren devolve as subtarefas (em qualquer nível) de id que também
+// estão na lixeira.
+func (s *Service) DeletedChildren(ctx context.Context, id string) ([]models.Task, error) {
+	trash, err := s.Trash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return trashedChildren(trash, id), nil
+}
+
+func trashedChildren(trash []models.Task, id string) []models.Task {
+	byParent := map[string][]models.Task{}
+	for _, t := range trash {
+		if t.ParentID != nil {
+			byParent[*t.ParentID] = append(byParent[*t.ParentID], t)
+		}
+	}
+
+	var children []models.Task
+	queue := []string{id}
+	for len(queue) > 0 {
+		parentID := queue[0]
+		queue = queue[1:]
+		for _, child := range byParent[parentID] {
+			children = append(children, child)
+			queue = append(queue, child.ID)
+		}
+	}
+	return children
+}
+
+// Restore tira a tarefa da lixeira e, com withChildren, também as subtarefas
+// apagadas dela.
+func (s *Service) Restore(ctx context.Context, id string, withChildren bool) (*models.Task, error) {
+	var restored *models.Task
+	err := s.repo.Atomic(ctx, func(ctx context.Context) (err error) {
+		restored, err = s.restore(ctx, id, withChildren)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return restored, nil
+}
+
+func (s *Service) restore(ctx context.Context, id string, withChildren bool) (*models.Task, error) {
+	trash, err := s.Trash(ctx)
+	if err != nil {
+		return nil, err
+	}
+	deletedAt := make(map[string]time.Time, len(trash))
+	for _, t := range trash {
+		deletedAt[t.ID] = t.DeletedAt.Time
+	}
+
+	var children []models.Task
+	if withChildren {
+		children = trashedChildren(trash, id)
+	}
+
+	restored, err := s.repo.Restore(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao restaurar tarefa: %w", err)
+	}
+	if restored == nil {
+		return nil, ErrTaskNotInTrash
+	}
+	if err := s.recordRestore(ctx, id, deletedAt[id]); err != nil {
+		return nil, err
+	}
+
+	if len(children) == 0 {
+		return restored, nil
+	}
+	for _, child := range children {
+		if _, err := s.repo.Restore(ctx, child.ID); err != nil {
+			return nil, fmt.Errorf("[ ERRO ] Problema ao restaurar subtarefa %s: %w", child.ID, err)
+		}
+		if err := s.recordRestore(ctx, child.ID, deletedAt[child.ID]); err != nil {
+			return nil, err
+		}
+	}
+	return s.reload(ctx, id)
+}
+
+func (s *Service) recordRestore(ctx context.Context, id string, deletedAt time.Time) error {
+	return s.recordDeletion(ctx, models.HistoryRestore, id, &deletedAt, nil)
+}
+
+// Purge apaga de vez uma tarefa da lixeira. O histórico dela fica, com a
+// entrada do purge no fim.
+func (s *Service) Purge(ctx context.Context, id string) error {
+	return s.repo.Atomic(ctx, func(ctx context.Context) error {
+		trash, err := s.Trash(ctx)
+		if err != nil {
+			return err
+		}
+
+		purged, err := s.repo.Purge(ctx, id)
+		if err != nil {
+			return fmt.Errorf("[ ERRO ] Problema ao apagar tarefa da lixeira: %w", err)
+		}
+		if !purged {
+			return ErrTaskNotInTrash
+		}
+		for _, t := range trash {
+			if t.ID == id {
+				return s.recordPurge(ctx, t)
+			}
+		}
+		return nil
+	})
+}
+
+// PurgeOlderThan esvazia da lixeira o que foi apagado há mais de age.
+func (s *Service) PurgeOlderThan(ctx context.Context, age time.Duration) (int64, error) {
+	if age < 0 {
+		return 0, ErrInvalidInput
+	}
+
+	var purged int64
+	err := s.repo.Atomic(ctx, func(ctx context.Context) error {
+		trash, err := s.Trash(ctx)
+		if err != nil {
+			return err
+		}
+
+		before := time.Now().Add(-age)
+		purged, err = s.repo.PurgeDeletedBefore(ctx, before)
+		if err != nil {
+			return fmt.Errorf("[ ERRO ] Problema ao esvaziar a lixeira: %w", err)
+		}
+		for _, t := range trash {
+			if t.DeletedAt.Time.Before(before) {
+				if err := s.recordPurge(ctx, t); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return purged, nil
+}
+
+func (s *Service) recordPurge(ctx context.Context, t models.Task) error {
+	deletedAt := t.DeletedAt.Time
+	return s.recordDeletion(ctx, models.HistoryPurge, t.ID, &deletedAt, nil)
+}
@@ -0,0 +1,122 @@
+package api
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// TreeStore percorre a hierarquia de subtarefas.
+type TreeStore interface {
+	GetTree(ctx context.Context, id string, maxDepth int) (*models.Task, error)
+	Ancestors(ctx context.Context, id string) ([]models.Task, error)
+	Progress(ctx context.Context, ids []string, maxDepth int) (map[string]models.Progress, error)
+}
+
+// Tree devolve a tarefa com as subtarefas aninhadas até depth níveis. depth 0
+// usa a profundidade máxima configurada.
+func (s *Service) Tree(ctx context.Context, id string, depth int) (*models.Task, error) {
+	if depth == 0 {
+		depth = s.config.MaxDepth
+	}
+	if depth < 0 || depth > s.config.MaxDepth {
+		return nil, ErrInvalidInput
+	}
+
+	tree, err := s.repo.GetTree(ctx, id, depth)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao carregar subtarefas: %w", err)
+	}
+	if tree == nil {
+		return nil, ErrTaskNotFound
+	}
+	if err := s.attachComputed(ctx, treeRefs(tree)...); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// Breadcrumbs devolve o caminho da raiz até o pai direto da tarefa.
+func (s *Service) Breadcrumbs(ctx context.Context, id string) ([]models.Task, error) {
+	path, err := s.repo.Ancestors(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao carregar caminho da tarefa: %w", err)
+	}
+	if path == nil {
+		return []models.Task{}, nil
+	}
+	return path, nil
+}
+
+// checkPlacement valida colocar a tarefa currentID (vazio numa criação) abaixo
+// de parentID: o pai não pode ser uma das subtarefas dela, e a subtarefa mais
+// funda não pode passar da profundidade máxima.
+func (s *Service) checkPlacement(ctx context.Context, parentID, currentID string) error {
+	ancestors, err := s.repo.Ancestors(ctx, parentID)
+	if err != nil {
+		return fmt.Errorf("[ ERRO ] Problema ao validar tarefa pai: %w", err)
+	}
+
+	// A raiz está no nível 0; a tarefa movida fica um nível abaixo do pai.
+	depth := len(ancestors) + 1
+	if currentID != "" {
+		for _, ancestor := range ancestors {
+			if ancestor.ID == currentID {
+				return ErrTaskCycle
+			}
+		}
+
+		subtree, err := s.repo.GetTree(ctx, currentID, s.config.MaxDepth)
+		if err != nil {
+			return fmt.Errorf("[ ERRO ] Problema ao validar subtarefas: %w", err)
+		}
+		depth += treeHeight(subtree)
+	}
+
+	if depth > s.config.MaxDepth {
+		return ErrMaxDepthExceeded
+	}
+	return nil
+}
+
+func treeHeight(t *models.Task) int {
+	if t == nil {
+		return 0
+	}
+	height := 0
+	for i := range t.Children {
+		height = max(height, treeHeight(&t.Children[i])+1)
+	}
+	return height
+}
+
+// Move coloca a tarefa, com todas as subtarefas, abaixo de parentID; nil
+// torna a tarefa raiz. As subtarefas acompanham porque só o parent_id da
+// tarefa muda, numa única escrita condicional à versão lida (ou à version
+// informada, se maior que zero).
+func (s *Service) Move(ctx context.Context, id string, parentID *string, version int64) (*models.Task, error) {
+	current, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("[ ERRO ] Problema ao mover tarefa: %w", err)
+	}
+	if current == nil {
+		return nil, ErrTaskNotFound
+	}
+	if version == 0 {
+		version = current.Version
+	}
+
+	changes := map[string]any{"parent_id": nil}
+	if parentID != nil {
+		changes["parent_id"] = *parentID
+	}
+	moved, err := s.PatchIfMatch(ctx, id, version, changes)
+	if err != nil {
+		return nil, err
+	}
+	if moved == nil {
+		return nil, ErrTaskNotFound
+	}
+	return moved, nil
+}
@@ -0,0 +1,29 @@
+package task
+
+import "github.com/andre-felipe-wonsik-alves/internal/models"
+
+// DependencyCycle diz se fazer taskID depender de dependsOnID fecharia um
+// ciclo no grafo edges, isto é, se dependsOnID já depende (direta ou
+// indiretamente) de taskID. Uma tarefa depender de si mesma também é ciclo.
+func DependencyCycle(edges []models.TaskDependency, taskID, dependsOnID string) bool {
+	dependsOn := map[string][]string{}
+	for _, e := range edges {
+		dependsOn[e.TaskID] = append(dependsOn[e.TaskID], e.DependsOnID)
+	}
+
+	seen := map[string]bool{}
+	stack := []string{dependsOnID}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if id == taskID {
+			return true
+		}
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+		stack = append(stack, dependsOn[id]...)
+	}
+	return false
+}
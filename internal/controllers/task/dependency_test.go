@@ -0,0 +1,38 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+func TestDependencyCycle(t *testing.T) {
+	// a depende de b, b depende de c; d depende de b.
+	edges := []models.TaskDependency{
+		{TaskID: "a", DependsOnID: "b"},
+		{TaskID: "b", DependsOnID: "c"},
+		{TaskID: "d", DependsOnID: "b"},
+	}
+
+	tests := []struct {
+		name        string
+		taskID      string
+		dependsOnID string
+		want        bool
+	}{
+		{name: "self", taskID: "a", dependsOnID: "a", want: true},
+		{name: "direct back edge", taskID: "b", dependsOnID: "a", want: true},
+		{name: "transitive back edge", taskID: "c", dependsOnID: "a", want: true},
+		{name: "shared dependency", taskID: "a", dependsOnID: "d", want: false},
+		{name: "new task", taskID: "e", dependsOnID: "a", want: false},
+		{name: "existing edge", taskID: "a", dependsOnID: "c", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DependencyCycle(edges, tt.taskID, tt.dependsOnID); got != tt.want {
+				t.Fatalf("DependencyCycle(%s -> %s) = %v, want %v", tt.taskID, tt.dependsOnID, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package task
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+var ErrInvalidDue = errors.New("prazo inválido")
+
+// NoDueDate é onde as tarefas sem prazo entram na ordenação por due_at: depois
+// de todas as datadas na ordem crescente.
+var NoDueDate = time.Date(9999, 12, 31, 0, 0, 0, 0, time.UTC)
+
+// ParseDue lê o prazo de uma tarefa. Só a data ("2006-01-02" ou "02/01/2006")
+// vira um prazo de dia inteiro, guardado como a meia-noite em loc; com horário
+// ("02/01/2006 15:04" ou RFC 3339) o prazo é aquele instante.
+func ParseDue(input string, loc *time.Location) (time.Time, bool, error) {
+	s := strings.TrimSpace(input)
+	for _, layout := range []string{time.DateOnly, "02/01/2006"} {
+		if t, err := time.ParseInLocation(layout, s, loc); err == nil {
+			return t, true, nil
+		}
+	}
+	if t, err := time.ParseInLocation("02/01/2006 15:04", s, loc); err == nil {
+		return t, false, nil
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, false, nil
+	}
+	return time.Time{}, false, ErrInvalidDue
+}
+
+// DueDeadline é o instante em que a tarefa passa a estar atrasada. Um prazo de
+// dia inteiro vale até o fim do dia.
+func DueDeadline(t models.Task, loc *time.Location) (time.Time, bool) {
+	if t.DueAt == nil {
+		return time.Time{}, false
+	}
+	if !t.DueAllDay {
+		return *t.DueAt, true
+	}
+	y, m, d := t.DueAt.In(loc).Date()
+	return time.Date(y, m, d+1, 0, 0, 0, 0, loc), true
+}
+
+// DueState é a situação do prazo de uma tarefa em aberto; a concluída ou
+// cancelada não tem situação. No máximo um dos campos fica ligado.
+type DueState struct {
+	Overdue bool
+	Today   bool
+	Soon    bool
+}
+
+// DueStateOf calcula a situação do prazo em now. Vencendo em até soon (e não
+// hoje), a tarefa está "em breve"; soon 0 desliga esse aviso.
+func DueStateOf(t models.Task, now time.Time, soon time.Duration) DueState {
+	deadline, ok := DueDeadline(t, now.Location())
+	if !ok || t.Status.Closed() {
+		return DueState{}
+	}
+	if !now.Before(deadline) {
+		return DueState{Overdue: true}
+	}
+	if sameDay(t.DueAt.In(now.Location()), now) {
+		return DueState{Today: true}
+	}
+	return DueState{Soon: soon > 0 && deadline.Sub(now) <= soon}
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.Date()
+	by, bm, bd := b.Date()
+	return ay == by && am == bm && ad == bd
+}
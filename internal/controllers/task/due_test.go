@@ -0,0 +1,85 @@
+package task
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+func TestParseDue(t *testing.T) {
+	loc := time.FixedZone("BRT", -3*60*60)
+
+	tests := []struct {
+		input      string
+		want       time.Time
+		wantAllDay bool
+		wantErr    bool
+	}{
+		{input: "2025-06-10", want: time.Date(2025, 6, 10, 0, 0, 0, 0, loc), wantAllDay: true},
+		{input: "10/06/2025", want: time.Date(2025, 6, 10, 0, 0, 0, 0, loc), wantAllDay: true},
+		{input: "10/06/2025 18:30", want: time.Date(2025, 6, 10, 18, 30, 0, 0, loc)},
+		{input: "2025-06-10T18:30:00Z", want: time.Date(2025, 6, 10, 18, 30, 0, 0, time.UTC)},
+		{input: "", wantErr: true},
+		{input: "amanhã", wantErr: true},
+		{input: "31/02/2025", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, allDay, err := ParseDue(tt.input, loc)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidDue) {
+					t.Fatalf("err = %v, want ErrInvalidDue", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !got.Equal(tt.want) || allDay != tt.wantAllDay {
+				t.Fatalf("ParseDue = %v, %v; want %v, %v", got, allDay, tt.want, tt.wantAllDay)
+			}
+		})
+	}
+}
+
+func TestDueStateOf(t *testing.T) {
+	loc := time.FixedZone("BRT", -3*60*60)
+	now := time.Date(2025, 6, 10, 14, 0, 0, 0, loc)
+	at := func(day, hour int) *time.Time {
+		v := time.Date(2025, 6, day, hour, 0, 0, 0, loc)
+		return &v
+	}
+
+	tests := []struct {
+		name string
+		task models.Task
+		want DueState
+	}{
+		{name: "sem prazo", task: models.Task{}},
+		{name: "horário já passou", task: models.Task{DueAt: at(10, 9)}, want: DueState{Overdue: true}},
+		{name: "mais tarde hoje", task: models.Task{DueAt: at(10, 18)}, want: DueState{Today: true}},
+		{name: "dia inteiro hoje", task: models.Task{DueAt: at(10, 0), DueAllDay: true}, want: DueState{Today: true}},
+		{name: "dia inteiro ontem", task: models.Task{DueAt: at(9, 0), DueAllDay: true}, want: DueState{Overdue: true}},
+		{name: "amanhã", task: models.Task{DueAt: at(11, 9)}, want: DueState{Soon: true}},
+		{name: "dia inteiro amanhã", task: models.Task{DueAt: at(11, 0), DueAllDay: true}, want: DueState{Soon: true}},
+		{name: "dia inteiro depois de amanhã", task: models.Task{DueAt: at(12, 0), DueAllDay: true}},
+		{name: "semana que vem", task: models.Task{DueAt: at(17, 9)}},
+		{name: "concluída", task: models.Task{DueAt: at(9, 9), Status: models.StatusDone, Done: true}},
+		{name: "cancelada", task: models.Task{DueAt: at(9, 9), Status: models.StatusCancelled}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := DueStateOf(tt.task, now, 48*time.Hour); got != tt.want {
+				t.Fatalf("DueStateOf = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if got := DueStateOf(models.Task{DueAt: at(11, 9)}, now, 0); got != (DueState{}) {
+		t.Fatalf("soon = 0 should disable due_soon, got %+v", got)
+	}
+}
@@ -0,0 +1,14 @@
+package task
+
+import (
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+type DueFilter struct {
+	Before      time.Time
+	After       *time.Time
+	IncludeDone bool
+	Priority    *models.Priority
+}
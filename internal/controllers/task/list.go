@@ -0,0 +1,192 @@
+package task
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+var (
+	ErrInvalidSort   = errors.New("ordenação inválida")
+	ErrInvalidCursor = errors.New("cursor inválido")
+)
+
+type SortKey string
+
+const (
+	SortCreatedAt  SortKey = "created_at"
+	SortPriority   SortKey = "priority"
+	SortReminderAt SortKey = "reminder_at"
+	SortDueAt      SortKey = "due_at"
+)
+
+// ListQuery filtra, ordena e pagina a listagem de tarefas. O valor zero lista
+// tudo, das criadas mais recentemente para as mais antigas. Os intervalos
+// incluem o início (From) e excluem o fim (To). O filtro de tags olha t.Tags,
+// que precisa estar carregado. Ready deixa só as tarefas em aberto sem
+// dependências em aberto; as dependências quem confere é o Store. Statuses
+// deixa só as tarefas em um dos status e IDs, quando não é nil, só essas
+// tarefas.
+type ListQuery struct {
+	IDs          []string
+	Done         *bool
+	Statuses     []models.TaskStatus
+	Priority     *models.Priority
+	ParentID     *string
+	ProjectID    *string
+	RootOnly     bool
+	Ready        bool
+	ReminderFrom *time.Time
+	ReminderTo   *time.Time
+	CreatedFrom  *time.Time
+	CreatedTo    *time.Time
+	Tags         TagFilter
+
+	Sort      SortKey
+	Ascending bool
+
+	// Limit 0 é sem limite. After continua a partir da última tarefa da
+	// página anterior.
+	Limit int
+	After *Cursor
+}
+
+// ParseSort lê a ordenação no formato da API: a chave, com "-" na frente
+// para decrescente (ex.: "-priority").
+func ParseSort(spec string) (SortKey, bool, error) {
+	ascending := !strings.HasPrefix(spec, "-")
+	key := SortKey(strings.TrimPrefix(spec, "-"))
+	switch key {
+	case SortCreatedAt, SortPriority, SortReminderAt, SortDueAt:
+		return key, ascending, nil
+	}
+	return "", false, ErrInvalidSort
+}
+
+func (q ListQuery) SortKey() SortKey {
+	if q.Sort == "" {
+		return SortCreatedAt
+	}
+	return q.Sort
+}
+
+// SortSpec é a ordenação no formato aceito por ParseSort.
+func (q ListQuery) SortSpec() string {
+	if q.Ascending {
+		return string(q.SortKey())
+	}
+	return "-" + string(q.SortKey())
+}
+
+// PriorityRank ordena prioridades pela importância, não pelo nome.
+func PriorityRank(p models.Priority) int {
+	switch p {
+	case models.PriorityHigh:
+		return 3
+	case models.PriorityMedium:
+		return 2
+	case models.PriorityLow:
+		return 1
+	}
+	return 0
+}
+
+// Matches diz se t passa pelos filtros (sem olhar o cursor).
+func (q ListQuery) Matches(t models.Task) bool {
+	switch {
+	case q.IDs != nil && !slices.Contains(q.IDs, t.ID),
+		q.Done != nil && t.Done != *q.Done,
+		q.Priority != nil && t.Priority != *q.Priority,
+		q.ParentID != nil && (t.ParentID == nil || *t.ParentID != *q.ParentID),
+		q.ProjectID != nil && (t.ProjectID == nil || *t.ProjectID != *q.ProjectID),
+		q.RootOnly && t.ParentID != nil,
+		len(q.Statuses) > 0 && !slices.Contains(q.Statuses, t.Status),
+		q.Ready && t.Status.Closed(),
+		!inRange(t.ReminderAt, q.ReminderFrom, q.ReminderTo),
+		!inRange(t.CreatedAt, q.CreatedFrom, q.CreatedTo),
+		!q.Tags.Matches(t.Tags):
+		return false
+	}
+	return true
+}
+
+func inRange(at time.Time, from, to *time.Time) bool {
+	return (from == nil || !at.Before(*from)) && (to == nil || at.Before(*to))
+}
+
+// Less é a ordem total da listagem: a chave escolhida e, no empate, o ID.
+func (q ListQuery) Less(a, b models.Task) bool {
+	return q.before(q.CursorFor(a), q.CursorFor(b))
+}
+
+// AfterCursor diz se t vem depois do cursor na ordem da listagem.
+func (q ListQuery) AfterCursor(t models.Task) bool {
+	return q.After == nil || q.before(*q.After, q.CursorFor(t))
+}
+
+func (q ListQuery) before(a, b Cursor) bool {
+	cmp := 0
+	switch q.SortKey() {
+	case SortPriority:
+		cmp = a.Rank - b.Rank
+	default:
+		cmp = a.At.Compare(b.At)
+	}
+	if cmp == 0 {
+		cmp = strings.Compare(a.ID, b.ID)
+	}
+	if q.Ascending {
+		return cmp < 0
+	}
+	return cmp > 0
+}
+
+// Cursor é a posição de uma tarefa na ordem da listagem.
+type Cursor struct {
+	Sort string    `json:"s"`
+	Rank int       `json:"r,omitempty"`
+	At   time.Time `json:"t"`
+	ID   string    `json:"id"`
+}
+
+// CursorFor é a posição de t na ordem de q.
+func (q ListQuery) CursorFor(t models.Task) Cursor {
+	c := Cursor{Sort: q.SortSpec(), ID: t.ID}
+	switch q.SortKey() {
+	case SortPriority:
+		c.Rank = PriorityRank(t.Priority)
+	case SortReminderAt:
+		c.At = t.ReminderAt
+	case SortDueAt:
+		c.At = NoDueDate
+		if t.DueAt != nil {
+			c.At = *t.DueAt
+		}
+	default:
+		c.At = t.CreatedAt
+	}
+	return c
+}
+
+// Encode gera o cursor opaco devolvido para o cliente.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func DecodeCursor(s string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+	var c Cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.ID == "" {
+		return Cursor{}, ErrInvalidCursor
+	}
+	return c, nil
+}
@@ -0,0 +1,92 @@
+package task
+
+import (
+	"testing"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+func TestParseSort(t *testing.T) {
+	tests := []struct {
+		spec          string
+		wantKey       SortKey
+		wantAscending bool
+		wantErr       bool
+	}{
+		{spec: "created_at", wantKey: SortCreatedAt, wantAscending: true},
+		{spec: "-priority", wantKey: SortPriority},
+		{spec: "reminder_at", wantKey: SortReminderAt, wantAscending: true},
+		{spec: "-due_at", wantKey: SortDueAt},
+		{spec: "title", wantErr: true},
+		{spec: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			key, ascending, err := ParseSort(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && (key != tt.wantKey || ascending != tt.wantAscending) {
+				t.Fatalf("ParseSort = %s, %v; want %s, %v", key, ascending, tt.wantKey, tt.wantAscending)
+			}
+		})
+	}
+}
+
+func TestListQueryLess(t *testing.T) {
+	now := time.Now()
+	low := models.Task{ID: "a", Priority: models.PriorityLow, CreatedAt: now}
+	high := models.Task{ID: "b", Priority: models.PriorityHigh, CreatedAt: now.Add(-time.Hour)}
+	medium := models.Task{ID: "c", Priority: models.PriorityMedium, CreatedAt: now}
+
+	byPriority := ListQuery{Sort: SortPriority}
+	if !byPriority.Less(high, medium) || !byPriority.Less(medium, low) {
+		t.Fatalf("expected high < medium < low when sorting by -priority")
+	}
+
+	newest := ListQuery{}
+	if !newest.Less(medium, low) {
+		t.Fatalf("expected ID tie-break (desc) on equal created_at")
+	}
+	if !newest.Less(low, high) {
+		t.Fatalf("expected newest first by default")
+	}
+}
+
+func TestListQueryLess_DueAt(t *testing.T) {
+	due := time.Now()
+	soon := models.Task{ID: "a", DueAt: &due}
+	later := models.Task{ID: "b", DueAt: ptrTime(due.Add(time.Hour))}
+	undated := models.Task{ID: "c"}
+
+	byDue := ListQuery{Sort: SortDueAt, Ascending: true}
+	if !byDue.Less(soon, later) || !byDue.Less(later, undated) {
+		t.Fatalf("expected soon < later < undated when sorting by due_at")
+	}
+}
+
+func ptrTime(v time.Time) *time.Time {
+	return &v
+}
+
+func TestCursorRoundTrip(t *testing.T) {
+	q := ListQuery{Sort: SortReminderAt, Ascending: true}
+	tk := models.Task{ID: "task-1", ReminderAt: time.Date(2025, 6, 1, 12, 0, 0, 123, time.UTC)}
+
+	encoded := q.CursorFor(tk).Encode()
+	decoded, err := DecodeCursor(encoded)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if decoded.Sort != "reminder_at" || decoded.ID != "task-1" || !decoded.At.Equal(tk.ReminderAt) {
+		t.Fatalf("unexpected cursor %+v", decoded)
+	}
+
+	for _, bad := range []string{"%%%", "bm90LWpzb24", "e30"} {
+		if _, err := DecodeCursor(bad); err != ErrInvalidCursor {
+			t.Fatalf("DecodeCursor(%q) err = %v, want ErrInvalidCursor", bad, err)
+		}
+	}
+}
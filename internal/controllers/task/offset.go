@@ -0,0 +1,38 @@
+package task
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidOffset = errors.New("deslocamento de lembrete inválido")
+
+// ParseOffset interpreta o deslocamento de um lembrete relativo. Além das
+// durações do Go ("-1h30m"), aceita dias e semanas ("-1d", "-2w") e "0".
+func ParseOffset(input string) (time.Duration, error) {
+	s := strings.ToLower(strings.TrimSpace(input))
+	if s == "" {
+		return 0, ErrInvalidOffset
+	}
+	if s == "0" {
+		return 0, nil
+	}
+
+	if d, err := time.ParseDuration(s); err == nil {
+		return d, nil
+	}
+
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, ok := strings.CutSuffix(s, suffix); ok {
+			v, err := strconv.Atoi(n)
+			if err != nil {
+				return 0, ErrInvalidOffset
+			}
+			return time.Duration(v) * unit, nil
+		}
+	}
+
+	return 0, ErrInvalidOffset
+}
@@ -0,0 +1,43 @@
+package task
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseOffset(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		input   string
+		want    time.Duration
+		wantErr bool
+	}{
+		{input: "0", want: 0},
+		{input: "-1h", want: -time.Hour},
+		{input: "-1h30m", want: -90 * time.Minute},
+		{input: "-1d", want: -24 * time.Hour},
+		{input: "-2w", want: -14 * 24 * time.Hour},
+		{input: "15m", want: 15 * time.Minute},
+		{input: "", wantErr: true},
+		{input: "ontem", wantErr: true},
+		{input: "-xd", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseOffset(tt.input)
+		if tt.wantErr {
+			if !errors.Is(err, ErrInvalidOffset) {
+				t.Fatalf("ParseOffset(%q) error = %v, want %v", tt.input, err, ErrInvalidOffset)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("ParseOffset(%q) unexpected error: %v", tt.input, err)
+		}
+		if got != tt.want {
+			t.Fatalf("ParseOffset(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,270 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+var (
+	ErrInvalidEstimate  = errors.New("estimativa inválida")
+	ErrInvalidWorkHours = errors.New("horário de trabalho inválido")
+	ErrInvalidPlanDate  = errors.New("data do plano inválida")
+)
+
+// MaxEstimateMinutes é a maior estimativa aceita: um dia.
+const MaxEstimateMinutes = 24 * 60
+
+// planStep é a grade em que o plano começa quando o dia já está em andamento.
+const planStep = 5 * time.Minute
+
+// ParseEstimate lê quanto a tarefa deve levar, em minutos. Aceita durações
+// ("45m", "1h30m") ou só os minutos ("90").
+func ParseEstimate(input string) (int, error) {
+	s := strings.ToLower(strings.TrimSpace(input))
+	minutes, err := strconv.Atoi(s)
+	if err != nil {
+		d, err := time.ParseDuration(s)
+		if err != nil || d%time.Minute != 0 {
+			return 0, ErrInvalidEstimate
+		}
+		minutes = int(d / time.Minute)
+	}
+	if minutes < 0 || minutes > MaxEstimateMinutes {
+		return 0, ErrInvalidEstimate
+	}
+	return minutes, nil
+}
+
+// WorkHours é o horário de trabalho, em tempo desde a meia-noite.
+type WorkHours struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// ParseWorkHours lê o horário no formato "09:00-18:00".
+func ParseWorkHours(input string) (WorkHours, error) {
+	from, to, ok := strings.Cut(strings.TrimSpace(input), "-")
+	if !ok {
+		return WorkHours{}, ErrInvalidWorkHours
+	}
+	start, err := clockOffset(from)
+	if err != nil {
+		return WorkHours{}, err
+	}
+	end, err := clockOffset(to)
+	if err != nil {
+		return WorkHours{}, err
+	}
+	if end <= start {
+		return WorkHours{}, ErrInvalidWorkHours
+	}
+	return WorkHours{Start: start, End: end}, nil
+}
+
+func clockOffset(input string) (time.Duration, error) {
+	s := strings.TrimSpace(input)
+	if s == "24:00" {
+		return 24 * time.Hour, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, ErrInvalidWorkHours
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func (w WorkHours) String() string {
+	clock := func(d time.Duration) string {
+		return fmt.Sprintf("%02d:%02d", int(d/time.Hour), int(d%time.Hour/time.Minute))
+	}
+	return clock(w.Start) + "-" + clock(w.End)
+}
+
+// ParsePlanDate lê o dia a planejar: vazio ou "hoje"/"today", "amanhã"/
+// "tomorrow", "2006-01-02" ou "02/01/2006". Devolve a meia-noite do dia no
+// fuso de now; dias que já passaram não podem ser planejados.
+func ParsePlanDate(input string, now time.Time) (time.Time, error) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	s := strings.ToLower(strings.TrimSpace(input))
+	switch s {
+	case "", "today", "hoje":
+		return today, nil
+	case "tomorrow", "amanhã", "amanha":
+		return today.AddDate(0, 0, 1), nil
+	}
+	for _, layout := range []string{time.DateOnly, "02/01/2006"} {
+		if day, err := time.ParseInLocation(layout, s, now.Location()); err == nil {
+			if day.Before(today) {
+				return time.Time{}, ErrInvalidPlanDate
+			}
+			return day, nil
+		}
+	}
+	return time.Time{}, ErrInvalidPlanDate
+}
+
+// PlanSlot é o horário escolhido para uma tarefa; aceitar o plano leva o
+// lembrete da tarefa para Start.
+type PlanSlot struct {
+	TaskID string    `json:"task_id" example:"8f3edff7-f3fe-4ab1-a60a-f35efcdfbf70"`
+	Start  time.Time `json:"start" example:"2025-06-10T09:00:00-03:00"`
+}
+
+// PlanBlock é um bloco da agenda. Fixed é o bloco preso ao lembrete da
+// tarefa; os demais o planner encaixou no tempo livre.
+type PlanBlock struct {
+	PlanSlot
+	End    time.Time `json:"end"`
+	Title  string    `json:"title"`
+	Fixed  bool      `json:"fixed"`
+	Reason string    `json:"reason"`
+}
+
+func (b PlanBlock) Minutes() int {
+	return int(b.End.Sub(b.Start) / time.Minute)
+}
+
+// PlanSkip é uma tarefa em aberto que ficou fora da agenda.
+type PlanSkip struct {
+	TaskID string `json:"task_id"`
+	Title  string `json:"title"`
+	Reason string `json:"reason"`
+}
+
+// Plan é a agenda de um dia, de Start a End (o horário de trabalho, ou o que
+// resta dele).
+type Plan struct {
+	Date        string      `json:"date" example:"2025-06-10"`
+	Start       time.Time   `json:"start"`
+	End         time.Time   `json:"end"`
+	Blocks      []PlanBlock `json:"blocks"`
+	Unscheduled []PlanSkip  `json:"unscheduled"`
+	FreeMinutes int         `json:"free_minutes"`
+}
+
+// BuildPlan monta a agenda de day no horário hours. Tarefas com lembrete
+// ainda por vir no dia ficam presas a ele (e de fora, se o lembrete cai fora
+// do horário); as outras entram, na ordem do ranking, no primeiro espaço
+// livre em que cabem. Sem estimativa, a tarefa
+// leva fallback. Tarefas bloqueadas ou aguardando alguém ficam de fora.
+func BuildPlan(ranked []Advice, day time.Time, hours WorkHours, now time.Time, fallback time.Duration) Plan {
+	midnight := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	start, end := midnight.Add(hours.Start), midnight.Add(hours.End)
+	if now.After(start) {
+		start = now.Truncate(planStep)
+		if start.Before(now) {
+			start = start.Add(planStep)
+		}
+	}
+	plan := Plan{Date: midnight.Format(time.DateOnly), Start: start, End: end, Blocks: []PlanBlock{}, Unscheduled: []PlanSkip{}}
+	if !start.Before(end) {
+		plan.Start = end
+		for _, a := range ranked {
+			plan.skip(a.Task, "o horário de trabalho do dia já acabou")
+		}
+		return plan
+	}
+
+	estimate := func(t models.Task) time.Duration {
+		if t.EstimateMinutes > 0 {
+			return time.Duration(t.EstimateMinutes) * time.Minute
+		}
+		return fallback
+	}
+
+	var flexible []Advice
+	for _, a := range ranked {
+		t := a.Task
+		switch {
+		case t.Blocked:
+			plan.skip(t, "depende de tarefas em aberto")
+		case t.Status == models.StatusBlocked:
+			plan.skip(t, "marcada como bloqueada")
+		case t.Status == models.StatusWaiting:
+			plan.skip(t, "aguardando outra pessoa")
+		case !t.ReminderAt.Before(start) && t.ReminderAt.Before(end):
+			plan.Blocks = append(plan.Blocks, PlanBlock{
+				PlanSlot: PlanSlot{TaskID: t.ID, Start: t.ReminderAt.In(day.Location())},
+				End:      minTime(t.ReminderAt.Add(estimate(t)), end).In(day.Location()),
+				Title:    t.Title,
+				Fixed:    true,
+				Reason:   "no horário do lembrete",
+			})
+		case !t.ReminderAt.Before(start) && sameDay(t.ReminderAt.In(day.Location()), midnight):
+			plan.skip(t, "lembrete às "+t.ReminderAt.In(day.Location()).Format("15:04")+", fora do horário de trabalho")
+		default:
+			flexible = append(flexible, a)
+		}
+	}
+	plan.sortBlocks()
+
+	for _, a := range flexible {
+		d := estimate(a.Task)
+		at, ok := plan.firstGap(d)
+		if !ok {
+			plan.skip(a.Task, fmt.Sprintf("não há %d minutos livres no dia", int(d/time.Minute)))
+			continue
+		}
+		plan.Blocks = append(plan.Blocks, PlanBlock{
+			PlanSlot: PlanSlot{TaskID: a.Task.ID, Start: at},
+			End:      at.Add(d),
+			Title:    a.Task.Title,
+			Reason:   a.Explanation,
+		})
+		plan.sortBlocks()
+	}
+
+	busy, cursor := time.Duration(0), plan.Start
+	for _, b := range plan.Blocks {
+		from := maxTime(b.Start, cursor)
+		if b.End.After(from) {
+			busy += b.End.Sub(from)
+			cursor = b.End
+		}
+	}
+	plan.FreeMinutes = int((plan.End.Sub(plan.Start) - busy) / time.Minute)
+	return plan
+}
+
+// firstGap é o início do primeiro espaço livre de pelo menos d.
+func (p *Plan) firstGap(d time.Duration) (time.Time, bool) {
+	cursor := p.Start
+	for _, b := range p.Blocks {
+		if b.Start.Sub(cursor) >= d {
+			return cursor, true
+		}
+		cursor = maxTime(cursor, b.End)
+	}
+	return cursor, p.End.Sub(cursor) >= d
+}
+
+func (p *Plan) sortBlocks() {
+	slices.SortStableFunc(p.Blocks, func(a, b PlanBlock) int {
+		return a.Start.Compare(b.Start)
+	})
+}
+
+func (p *Plan) skip(t models.Task, reason string) {
+	p.Unscheduled = append(p.Unscheduled, PlanSkip{TaskID: t.ID, Title: t.Title, Reason: reason})
+}
+
+func minTime(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+func maxTime(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
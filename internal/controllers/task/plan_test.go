@@ -0,0 +1,180 @@
+package task
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+func TestParseEstimate(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{input: "45m", want: 45},
+		{input: "1h30m", want: 90},
+		{input: "90", want: 90},
+		{input: "0", want: 0},
+		{input: "", wantErr: true},
+		{input: "30s", wantErr: true},
+		{input: "-10m", wantErr: true},
+		{input: "25h", wantErr: true},
+		{input: "muito", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseEstimate(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidEstimate) {
+					t.Fatalf("err = %v, want ErrInvalidEstimate", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("got %d, %v; want %d", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseWorkHours(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    WorkHours
+		wantErr bool
+	}{
+		{input: "09:00-18:00", want: WorkHours{Start: 9 * time.Hour, End: 18 * time.Hour}},
+		{input: " 08:30 - 24:00 ", want: WorkHours{Start: 8*time.Hour + 30*time.Minute, End: 24 * time.Hour}},
+		{input: "18:00-09:00", wantErr: true},
+		{input: "09:00", wantErr: true},
+		{input: "9h-18h", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseWorkHours(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidWorkHours) {
+					t.Fatalf("err = %v, want ErrInvalidWorkHours", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("got %+v, %v; want %+v", got, err, tt.want)
+			}
+			if again, _ := ParseWorkHours(got.String()); again != got {
+				t.Fatalf("String() = %q does not round-trip", got.String())
+			}
+		})
+	}
+}
+
+func TestParsePlanDate(t *testing.T) {
+	loc := time.FixedZone("BRT", -3*60*60)
+	now := time.Date(2025, 6, 10, 14, 0, 0, 0, loc)
+	today := time.Date(2025, 6, 10, 0, 0, 0, 0, loc)
+
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{input: "", want: today},
+		{input: "hoje", want: today},
+		{input: "amanhã", want: today.AddDate(0, 0, 1)},
+		{input: "2025-06-12", want: today.AddDate(0, 0, 2)},
+		{input: "12/06/2025", want: today.AddDate(0, 0, 2)},
+		{input: "09/06/2025", wantErr: true},
+		{input: "depois", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParsePlanDate(tt.input, now)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidPlanDate) {
+					t.Fatalf("err = %v, want ErrInvalidPlanDate", err)
+				}
+				return
+			}
+			if err != nil || !got.Equal(tt.want) {
+				t.Fatalf("got %v, %v; want %v", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildPlan(t *testing.T) {
+	day := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	at := func(h, m int) time.Time { return day.Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute) }
+	hours := WorkHours{Start: 9 * time.Hour, End: 12 * time.Hour}
+	advice := func(tasks ...models.Task) []Advice {
+		ranked := make([]Advice, len(tasks))
+		for i, t := range tasks {
+			ranked[i] = Advice{Rank: i + 1, Task: t, Explanation: "sugestão"}
+		}
+		return ranked
+	}
+
+	ranked := advice(
+		models.Task{ID: "report", Title: "Relatório", EstimateMinutes: 90},
+		models.Task{ID: "call", Title: "Ligação", ReminderAt: at(10, 0), EstimateMinutes: 30},
+		models.Task{ID: "mail", Title: "E-mails"},
+		models.Task{ID: "blocked", Title: "Deploy", Blocked: true},
+		models.Task{ID: "waiting", Title: "Orçamento", Status: models.StatusWaiting},
+		models.Task{ID: "big", Title: "Migração", EstimateMinutes: 120},
+		models.Task{ID: "evening", Title: "Academia", ReminderAt: at(19, 0)},
+	)
+
+	plan := BuildPlan(ranked, day, hours, at(7, 0), 30*time.Minute)
+	if plan.Date != "2025-06-10" || !plan.Start.Equal(at(9, 0)) || !plan.End.Equal(at(12, 0)) {
+		t.Fatalf("unexpected window: %+v", plan)
+	}
+
+	want := []struct {
+		id         string
+		start, end time.Time
+		fixed      bool
+	}{
+		{"mail", at(9, 0), at(9, 30), false},
+		{"call", at(10, 0), at(10, 30), true},
+		{"report", at(10, 30), at(12, 0), false},
+	}
+	if len(plan.Blocks) != len(want) {
+		t.Fatalf("blocks = %+v", plan.Blocks)
+	}
+	for i, w := range want {
+		b := plan.Blocks[i]
+		if b.TaskID != w.id || !b.Start.Equal(w.start) || !b.End.Equal(w.end) || b.Fixed != w.fixed {
+			t.Fatalf("block %d = %+v, want %+v", i, b, w)
+		}
+	}
+
+	skipped := map[string]string{}
+	for _, s := range plan.Unscheduled {
+		skipped[s.TaskID] = s.Reason
+	}
+	for _, id := range []string{"blocked", "waiting", "big", "evening"} {
+		if skipped[id] == "" {
+			t.Fatalf("expected %s unscheduled, got %+v", id, plan.Unscheduled)
+		}
+	}
+	if plan.FreeMinutes != 30 {
+		t.Fatalf("free = %d, want 30", plan.FreeMinutes)
+	}
+
+	late := BuildPlan(ranked, day, hours, at(11, 2), 30*time.Minute)
+	// O lembrete das 10:00 já passou: a ligação entra no primeiro espaço livre.
+	if !late.Start.Equal(at(11, 5)) || len(late.Blocks) != 1 || late.Blocks[0].TaskID != "call" || late.Blocks[0].Fixed {
+		t.Fatalf("plan started at 11:02 = %+v", late)
+	}
+
+	over := BuildPlan(ranked, day, hours, at(13, 0), 30*time.Minute)
+	if len(over.Blocks) != 0 || len(over.Unscheduled) != len(ranked) || over.FreeMinutes != 0 {
+		t.Fatalf("plan after hours = %+v", over)
+	}
+}
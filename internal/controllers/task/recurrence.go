@@ -0,0 +1,369 @@
+package task
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidRecurrence = errors.New("recorrência inválida")
+
+type Frequency string
+
+const (
+	FrequencyDaily   Frequency = "DAILY"
+	FrequencyWeekly  Frequency = "WEEKLY"
+	FrequencyMonthly Frequency = "MONTHLY"
+	FrequencyYearly  Frequency = "YEARLY"
+)
+
+// Recurrence calcula a próxima ocorrência estritamente depois de after.
+type Recurrence interface {
+	Next(after time.Time) time.Time
+}
+
+// ParseRecurrence aceita os atalhos (daily, semanal, mensal...), um
+// subconjunto de RRULE (FREQ, INTERVAL, BYDAY, BYMONTHDAY) ou uma expressão
+// cron de 5 campos, opcionalmente prefixada por "cron:".
+func ParseRecurrence(input string) (Recurrence, error) {
+	s := strings.TrimSpace(input)
+	if s == "" {
+		return nil, ErrInvalidRecurrence
+	}
+
+	switch strings.ToLower(s) {
+	case "daily", "diaria", "diária", "diariamente":
+		return Rule{Frequency: FrequencyDaily, Interval: 1}, nil
+	case "weekly", "semanal", "semanalmente":
+		return Rule{Frequency: FrequencyWeekly, Interval: 1}, nil
+	case "weekdays", "dias-uteis", "dias úteis":
+		return Rule{Frequency: FrequencyWeekly, Interval: 1, ByDay: []time.Weekday{
+			time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday,
+		}}, nil
+	case "monthly", "mensal", "mensalmente":
+		return Rule{Frequency: FrequencyMonthly, Interval: 1}, nil
+	case "yearly", "anual", "anualmente":
+		return Rule{Frequency: FrequencyYearly, Interval: 1}, nil
+	}
+
+	if rest, ok := strings.CutPrefix(strings.ToLower(s), "cron:"); ok {
+		return parseCron(strings.TrimSpace(rest))
+	}
+	if strings.Contains(strings.ToUpper(s), "FREQ=") {
+		return parseRRule(s)
+	}
+	if len(strings.Fields(s)) == 5 {
+		return parseCron(s)
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrInvalidRecurrence, input)
+}
+
+// AnchorRecurrence lê a regra de input e, se ela for mensal sem BYMONTHDAY,
+// fixa o dia do mês no de base. Devolve também o texto da regra a gravar na
+// próxima ocorrência, para que um mês curto não arraste a série de vez
+// (31/01 → 28/02 → 31/03, e não 28/03).
+func AnchorRecurrence(input string, base time.Time) (Recurrence, string, error) {
+	rule, err := ParseRecurrence(input)
+	if err != nil {
+		return nil, "", err
+	}
+	r, ok := rule.(Rule)
+	if !ok || r.Frequency != FrequencyMonthly || r.ByMonthDay != 0 || base.IsZero() {
+		return rule, input, nil
+	}
+	r.ByMonthDay = base.Day()
+	return r, r.String(), nil
+}
+
+// NextOccurrence avança a regra a partir de base até passar de now, para que
+// uma tarefa concluída com atraso não gere ocorrências já vencidas.
+func NextOccurrence(rule Recurrence, base, now time.Time) time.Time {
+	if base.IsZero() {
+		base = now
+	}
+	next := rule.Next(base)
+	for i := 0; !next.After(now) && !next.IsZero() && i < 10000; i++ {
+		next = rule.Next(next)
+	}
+	return next
+}
+
+type Rule struct {
+	Frequency  Frequency
+	Interval   int
+	ByDay      []time.Weekday
+	ByMonthDay int
+}
+
+var weekdayCodes = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+func parseRRule(input string) (Rule, error) {
+	s := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(input)), "RRULE:")
+	rule := Rule{Interval: 1}
+
+	for _, part := range strings.Split(s, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return Rule{}, fmt.Errorf("%w: %q", ErrInvalidRecurrence, part)
+		}
+
+		switch key {
+		case "FREQ":
+			switch Frequency(value) {
+			case FrequencyDaily, FrequencyWeekly, FrequencyMonthly, FrequencyYearly:
+				rule.Frequency = Frequency(value)
+			default:
+				return Rule{}, fmt.Errorf("%w: FREQ=%s", ErrInvalidRecurrence, value)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 {
+				return Rule{}, fmt.Errorf("%w: INTERVAL=%s", ErrInvalidRecurrence, value)
+			}
+			rule.Interval = n
+		case "BYDAY":
+			for _, code := range strings.Split(value, ",") {
+				day, ok := weekdayCodes[code]
+				if !ok {
+					return Rule{}, fmt.Errorf("%w: BYDAY=%s", ErrInvalidRecurrence, code)
+				}
+				rule.ByDay = append(rule.ByDay, day)
+			}
+		case "BYMONTHDAY":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 1 || n > 31 {
+				return Rule{}, fmt.Errorf("%w: BYMONTHDAY=%s", ErrInvalidRecurrence, value)
+			}
+			rule.ByMonthDay = n
+		default:
+			return Rule{}, fmt.Errorf("%w: %s não suportado", ErrInvalidRecurrence, key)
+		}
+	}
+
+	if rule.Frequency == "" {
+		return Rule{}, fmt.Errorf("%w: FREQ é obrigatório", ErrInvalidRecurrence)
+	}
+	return rule, nil
+}
+
+// String escreve a regra como RRULE.
+func (r Rule) String() string {
+	parts := []string{"FREQ=" + string(r.Frequency), "INTERVAL=" + strconv.Itoa(max(r.Interval, 1))}
+	if len(r.ByDay) > 0 {
+		codes := make([]string, 0, len(r.ByDay))
+		for _, day := range r.ByDay {
+			for code, d := range weekdayCodes {
+				if d == day {
+					codes = append(codes, code)
+				}
+			}
+		}
+		parts = append(parts, "BYDAY="+strings.Join(codes, ","))
+	}
+	if r.ByMonthDay > 0 {
+		parts = append(parts, "BYMONTHDAY="+strconv.Itoa(r.ByMonthDay))
+	}
+	return strings.Join(parts, ";")
+}
+
+func (r Rule) Next(after time.Time) time.Time {
+	interval := r.Interval
+	if interval < 1 {
+		interval = 1
+	}
+
+	switch r.Frequency {
+	case FrequencyDaily:
+		return after.AddDate(0, 0, interval)
+	case FrequencyWeekly:
+		if len(r.ByDay) == 0 {
+			return after.AddDate(0, 0, 7*interval)
+		}
+		for d := 1; d <= 7*interval+7; d++ {
+			candidate := after.AddDate(0, 0, d)
+			if r.hasDay(candidate.Weekday()) && weeksBetween(after, candidate)%interval == 0 {
+				return candidate
+			}
+		}
+		return time.Time{}
+	case FrequencyMonthly:
+		day := r.ByMonthDay
+		if day == 0 {
+			day = after.Day()
+		}
+		if candidate := dayOfMonth(after, 0, day); candidate.After(after) {
+			return candidate
+		}
+		return dayOfMonth(after, interval, day)
+	case FrequencyYearly:
+		return after.AddDate(interval, 0, 0)
+	default:
+		return time.Time{}
+	}
+}
+
+func (r Rule) hasDay(day time.Weekday) bool {
+	for _, d := range r.ByDay {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
+
+func weeksBetween(a, b time.Time) int {
+	startOfWeek := func(t time.Time) time.Time {
+		offset := (int(t.Weekday()) + 6) % 7
+		y, m, d := t.AddDate(0, 0, -offset).Date()
+		return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	}
+	return int(startOfWeek(b).Sub(startOfWeek(a)).Hours() / (24 * 7))
+}
+
+// dayOfMonth devolve o dia informado monthsAhead meses depois de t, limitado
+// ao último dia do mês (31 em fevereiro vira 28/29).
+func dayOfMonth(t time.Time, monthsAhead, day int) time.Time {
+	first := time.Date(t.Year(), t.Month()+time.Month(monthsAhead), 1, t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	last := first.AddDate(0, 1, -1).Day()
+	if day > last {
+		day = last
+	}
+	return first.AddDate(0, 0, day-1)
+}
+
+type CronSchedule struct {
+	minutes  uint64
+	hours    uint64
+	days     uint64
+	months   uint64
+	weekdays uint64
+	anyDay   bool
+	anyWeek  bool
+}
+
+func parseCron(input string) (CronSchedule, error) {
+	fields := strings.Fields(input)
+	if len(fields) != 5 {
+		return CronSchedule{}, fmt.Errorf("%w: cron precisa de 5 campos", ErrInvalidRecurrence)
+	}
+
+	var c CronSchedule
+	var err error
+	if c.minutes, err = parseCronField(fields[0], 0, 59); err != nil {
+		return CronSchedule{}, err
+	}
+	if c.hours, err = parseCronField(fields[1], 0, 23); err != nil {
+		return CronSchedule{}, err
+	}
+	if c.days, err = parseCronField(fields[2], 1, 31); err != nil {
+		return CronSchedule{}, err
+	}
+	if c.months, err = parseCronField(fields[3], 1, 12); err != nil {
+		return CronSchedule{}, err
+	}
+	if c.weekdays, err = parseCronField(fields[4], 0, 7); err != nil {
+		return CronSchedule{}, err
+	}
+	if c.weekdays&(1<<7) != 0 {
+		c.weekdays |= 1
+	}
+	c.anyDay = fields[2] == "*"
+	c.anyWeek = fields[4] == "*"
+
+	return c, nil
+}
+
+func parseCronField(field string, min, max int) (uint64, error) {
+	var bits uint64
+
+	for _, part := range strings.Split(field, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(part, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("%w: passo %q", ErrInvalidRecurrence, part)
+			}
+			step = n
+		}
+
+		lo, hi := min, max
+		if rangePart != "*" {
+			from, to, isRange := strings.Cut(rangePart, "-")
+			n, err := strconv.Atoi(from)
+			if err != nil {
+				return 0, fmt.Errorf("%w: campo %q", ErrInvalidRecurrence, part)
+			}
+			lo, hi = n, n
+			if isRange {
+				if hi, err = strconv.Atoi(to); err != nil {
+					return 0, fmt.Errorf("%w: campo %q", ErrInvalidRecurrence, part)
+				}
+			} else if hasStep {
+				hi = max
+			}
+		}
+		if lo < min || hi > max || lo > hi {
+			return 0, fmt.Errorf("%w: campo %q fora do intervalo %d-%d", ErrInvalidRecurrence, part, min, max)
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << uint(v)
+		}
+	}
+
+	return bits, nil
+}
+
+func (c CronSchedule) Next(after time.Time) time.Time {
+	t := after.Truncate(time.Minute).Add(time.Minute)
+	limit := t.AddDate(5, 0, 0)
+
+	for t.Before(limit) {
+		if !has(c.months, int(t.Month())) {
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !c.matchDay(t) {
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(c.hours, t.Hour()) {
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, t.Location())
+			continue
+		}
+		if !has(c.minutes, t.Minute()) {
+			t = t.Add(time.Minute)
+			continue
+		}
+		return t
+	}
+
+	return time.Time{}
+}
+
+// matchDay segue a regra do cron: quando dia do mês e dia da semana são
+// restritos, basta um deles casar.
+func (c CronSchedule) matchDay(t time.Time) bool {
+	dom := has(c.days, t.Day())
+	dow := has(c.weekdays, int(t.Weekday()))
+	if c.anyDay || c.anyWeek {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+func has(bits uint64, v int) bool {
+	return bits&(1<<uint(v)) != 0
+}
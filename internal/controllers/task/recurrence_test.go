@@ -0,0 +1,138 @@
+package task
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseRecurrenceNext(t *testing.T) {
+	t.Parallel()
+
+	// Quarta-feira, 15/01/2025 09:00
+	base := time.Date(2025, 1, 15, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		input string
+		after time.Time
+		want  time.Time
+	}{
+		{name: "daily alias", input: "daily", after: base, want: base.AddDate(0, 0, 1)},
+		{name: "pt-br semanal", input: "semanal", after: base, want: base.AddDate(0, 0, 7)},
+		{name: "monthly keeps day", input: "mensal", after: base, want: time.Date(2025, 2, 15, 9, 0, 0, 0, time.UTC)},
+		{name: "monthly clamps to month end", input: "monthly", after: time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC), want: time.Date(2025, 2, 28, 9, 0, 0, 0, time.UTC)},
+		{name: "yearly", input: "anual", after: base, want: time.Date(2026, 1, 15, 9, 0, 0, 0, time.UTC)},
+		{name: "weekdays skips weekend", input: "weekdays", after: time.Date(2025, 1, 17, 9, 0, 0, 0, time.UTC), want: time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC)},
+		{name: "rrule interval", input: "FREQ=DAILY;INTERVAL=3", after: base, want: base.AddDate(0, 0, 3)},
+		{name: "rrule byday", input: "RRULE:FREQ=WEEKLY;BYDAY=MO,FR", after: base, want: time.Date(2025, 1, 17, 9, 0, 0, 0, time.UTC)},
+		{name: "rrule biweekly byday", input: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO", after: base, want: time.Date(2025, 1, 27, 9, 0, 0, 0, time.UTC)},
+		{name: "rrule bymonthday later this month", input: "FREQ=MONTHLY;BYMONTHDAY=20", after: base, want: time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC)},
+		{name: "rrule bymonthday next month", input: "FREQ=MONTHLY;BYMONTHDAY=10", after: base, want: time.Date(2025, 2, 10, 9, 0, 0, 0, time.UTC)},
+		{name: "cron every monday 08:30", input: "30 8 * * 1", after: base, want: time.Date(2025, 1, 20, 8, 30, 0, 0, time.UTC)},
+		{name: "cron prefixed with step", input: "cron: */15 * * * *", after: base.Add(time.Minute), want: base.Add(15 * time.Minute)},
+		{name: "cron first day of month", input: "0 0 1 * *", after: base, want: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule, err := ParseRecurrence(tt.input)
+			if err != nil {
+				t.Fatalf("ParseRecurrence(%q) unexpected error: %v", tt.input, err)
+			}
+			if got := rule.Next(tt.after); !got.Equal(tt.want) {
+				t.Fatalf("Next(%v) = %v, want %v", tt.after, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseRecurrenceInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, input := range []string{"", "sometimes", "FREQ=HOURLY", "FREQ=DAILY;INTERVAL=0", "FREQ=WEEKLY;BYDAY=XX", "61 * * * *", "INTERVAL=2"} {
+		if _, err := ParseRecurrence(input); !errors.Is(err, ErrInvalidRecurrence) {
+			t.Fatalf("ParseRecurrence(%q) error = %v, want %v", input, err, ErrInvalidRecurrence)
+		}
+	}
+}
+
+func TestNextOccurrenceSkipsPast(t *testing.T) {
+	t.Parallel()
+
+	rule, _ := ParseRecurrence("daily")
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	got := NextOccurrence(rule, base, now)
+	want := time.Date(2025, 1, 11, 9, 0, 0, 0, time.UTC)
+	if !got.Equal(want) {
+		t.Fatalf("NextOccurrence = %v, want %v", got, want)
+	}
+}
+
+func TestAnchorRecurrence(t *testing.T) {
+	t.Parallel()
+
+	jan31 := time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		input    string
+		base     time.Time
+		wantText string
+		want     []time.Time
+	}{
+		{
+			name:     "monthly keeps the anchor after a short month",
+			input:    "mensal",
+			base:     jan31,
+			wantText: "FREQ=MONTHLY;INTERVAL=1;BYMONTHDAY=31",
+			want: []time.Time{
+				time.Date(2025, 2, 28, 9, 0, 0, 0, time.UTC),
+				time.Date(2025, 3, 31, 9, 0, 0, 0, time.UTC),
+				time.Date(2025, 4, 30, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:     "explicit bymonthday is kept",
+			input:    "FREQ=MONTHLY;BYMONTHDAY=10",
+			base:     jan31,
+			wantText: "FREQ=MONTHLY;BYMONTHDAY=10",
+			want:     []time.Time{time.Date(2025, 2, 10, 9, 0, 0, 0, time.UTC)},
+		},
+		{
+			name:     "weekly is untouched",
+			input:    "weekly",
+			base:     jan31,
+			wantText: "weekly",
+			want:     []time.Time{jan31.AddDate(0, 0, 7)},
+		},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			rule, text, err := AnchorRecurrence(tt.input, tt.base)
+			if err != nil || text != tt.wantText {
+				t.Fatalf("AnchorRecurrence(%q) = %q, %v; want %q", tt.input, text, err, tt.wantText)
+			}
+			after := tt.base
+			for _, want := range tt.want {
+				if after = rule.Next(after); !after.Equal(want) {
+					t.Fatalf("Next = %v, want %v", after, want)
+				}
+			}
+
+			again, _ := ParseRecurrence(text)
+			if got := again.Next(tt.want[0]); len(tt.want) > 1 && !got.Equal(tt.want[1]) {
+				t.Fatalf("stored rule Next(%v) = %v, want %v", tt.want[0], got, tt.want[1])
+			}
+		})
+	}
+}
@@ -3,9 +3,13 @@ package repository
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
 	"github.com/andre-felipe-wonsik-alves/internal/models"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type DBStore struct {
@@ -16,15 +20,41 @@ func NewDBStore(db *gorm.DB) *DBStore {
 	return &DBStore{db: db}
 }
 
+type txKey struct{}
+
+// Atomic roda fn numa transação: tudo o que fn faz no Store com o ctx que
+// recebe é desfeito se ela devolver erro. Dentro de outra transação, só
+// participa dela.
+func (s *DBStore) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return fn(ctx)
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
+
+// conn é a conexão do ctx: a transação aberta por Atomic, se houver.
+func (s *DBStore) conn(ctx context.Context) *gorm.DB {
+	if tx, ok := ctx.Value(txKey{}).(*gorm.DB); ok {
+		return tx.WithContext(ctx)
+	}
+	return s.db.WithContext(ctx)
+}
+
 func (s *DBStore) Create(ctx context.Context, t *models.Task) error {
-	return s.db.WithContext(ctx).Create(t).Error
+	return s.conn(ctx).Create(t).Error
 }
 
 func (s *DBStore) GetByID(ctx context.Context, id string) (*models.Task, error) {
 	var t models.Task
-	err := s.db.WithContext(ctx).
+	err := s.conn(ctx).
 		Preload("Parent").
 		Preload("Children").
+		Preload("Reminders", func(db *gorm.DB) *gorm.DB {
+			return db.Order("fire_at asc")
+		}).
+		Preload("Tags", preloadTags).
 		First(&t, "id = ?", id).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, nil
@@ -35,18 +65,100 @@ func (s *DBStore) GetByID(ctx context.Context, id string) (*models.Task, error)
 	return &t, nil
 }
 
-func (s *DBStore) List(ctx context.Context) ([]models.Task, error) {
-	var tasks []models.Task
-	err := s.db.WithContext(ctx).
+// priorityRankSQL espelha task.PriorityRank para ordenar no banco.
+const priorityRankSQL = "CASE priority WHEN 'high' THEN 3 WHEN 'medium' THEN 2 WHEN 'low' THEN 1 ELSE 0 END"
+
+func (s *DBStore) List(ctx context.Context, q task.ListQuery) ([]models.Task, error) {
+	query := s.conn(ctx).
 		Preload("Parent").
 		Preload("Children").
-		Order("created_at desc").
-		Find(&tasks).Error
+		Preload("Tags", preloadTags)
+
+	if q.IDs != nil {
+		query = query.Where("id IN ?", q.IDs)
+	}
+	if q.Done != nil {
+		query = query.Where("done = ?", *q.Done)
+	}
+	if len(q.Statuses) > 0 {
+		query = query.Where("status IN ?", q.Statuses)
+	}
+	if q.Priority != nil {
+		query = query.Where("priority = ?", *q.Priority)
+	}
+	if q.ParentID != nil {
+		query = query.Where("parent_id = ?", *q.ParentID)
+	}
+	if q.ProjectID != nil {
+		query = query.Where("project_id = ?", *q.ProjectID)
+	}
+	if q.RootOnly {
+		query = query.Where("parent_id IS NULL")
+	}
+	if q.Ready {
+		query = whereReady(query)
+	}
+	query = whereRange(query, "reminder_at", q.ReminderFrom, q.ReminderTo)
+	query = whereRange(query, "created_at", q.CreatedFrom, q.CreatedTo)
+	if condition, args := tagFilterSQL("tasks.id", q.Tags); condition != "" {
+		query = query.Where(condition, args...)
+	}
+
+	// Sem prazo conta como task.NoDueDate, para a ordem bater com a do cursor.
+	column, columnArgs := "created_at", []any{}
+	switch q.SortKey() {
+	case task.SortPriority:
+		column = priorityRankSQL
+	case task.SortReminderAt:
+		column = "reminder_at"
+	case task.SortDueAt:
+		column, columnArgs = "COALESCE(due_at, ?)", []any{task.NoDueDate}
+	}
+	direction, op := "DESC", "<"
+	if q.Ascending {
+		direction, op = "ASC", ">"
+	}
+
+	// Paginação por keyset: continua depois de (chave, id) do cursor.
+	if q.After != nil {
+		var value any = q.After.At
+		if q.SortKey() == task.SortPriority {
+			value = q.After.Rank
+		}
+		args := append(append([]any{}, columnArgs...), value)
+		args = append(append(args, columnArgs...), value, q.After.ID)
+		query = query.Where(
+			fmt.Sprintf("((%[1]s %[2]s ?) OR (%[1]s = ? AND id %[2]s ?))", column, op),
+			args...,
+		)
+	}
+
+	query = query.Order(clause.OrderBy{Expression: clause.Expr{
+		SQL:                fmt.Sprintf("%s %s, id %s", column, direction, direction),
+		Vars:               columnArgs,
+		WithoutParentheses: true,
+	}})
+	if q.Limit > 0 {
+		query = query.Limit(q.Limit)
+	}
+
+	var tasks []models.Task
+	err := query.Find(&tasks).Error
 	return tasks, err
 }
 
+func whereRange(query *gorm.DB, column string, from, to *time.Time) *gorm.DB {
+	if from != nil {
+		query = query.Where(column+" >= ?", *from)
+	}
+	if to != nil {
+		query = query.Where(column+" < ?", *to)
+	}
+	return query
+}
+
 func (s *DBStore) Patch(ctx context.Context, id string, changes map[string]any) (*models.Task, error) {
-	tx := s.db.WithContext(ctx).Model(&models.Task{}).Where("id = ?", id).Updates(changes)
+	tx := s.conn(ctx).Model(&models.Task{}).Where("id = ?", id).Updates(nextVersion(changes))
 	if tx.Error != nil {
 		return nil, tx.Error
 	}
@@ -56,7 +168,75 @@ func (s *DBStore) Patch(ctx context.Context, id string, changes map[string]any)
 	return s.GetByID(ctx, id)
 }
 
+// PatchIfVersion só aplica changes se a tarefa ainda estiver na versão
+// informada; caso contrário devolve task.ErrVersionConflict.
+func (s *DBStore) PatchIfVersion(ctx context.Context, id string, version int64, changes map[string]any) (*models.Task, error) {
+	tx := s.conn(ctx).
+		Model(&models.Task{}).
+		Where("id = ? AND version = ?", id, version).
+		Updates(nextVersion(changes))
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return nil, s.versionMiss(ctx, id)
+	}
+	return s.GetByID(ctx, id)
+}
+
 func (s *DBStore) Delete(ctx context.Context, id string) error {
-	tx := s.db.WithContext(ctx).Delete(&models.Task{}, "id = ?", id)
+	tx := s.conn(ctx).Delete(&models.Task{}, "id = ?", id)
 	return tx.Error
 }
+
+func (s *DBStore) DeleteIfVersion(ctx context.Context, id string, version int64) error {
+	tx := s.conn(ctx).Delete(&models.Task{}, "id = ? AND version = ?", id, version)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return s.versionMiss(ctx, id)
+	}
+	return nil
+}
+
+// versionMiss distingue, depois de um update condicional sem linhas
+// afetadas, tarefa inexistente (nil) de versão desatualizada.
+func (s *DBStore) versionMiss(ctx context.Context, id string) error {
+	var count int64
+	if err := s.conn(ctx).Model(&models.Task{}).Where("id = ?", id).Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return nil
+	}
+	return task.ErrVersionConflict
+}
+
+func nextVersion(changes map[string]any) map[string]any {
+	updates := make(map[string]any, len(changes)+1)
+	for column, value := range changes {
+		updates[column] = value
+	}
+	updates["version"] = gorm.Expr("version + 1")
+	return updates
+}
+
+func (s *DBStore) ListDue(ctx context.Context, filter task.DueFilter) ([]models.Task, error) {
+	query := s.conn(ctx).
+		Where("reminder_at > ? AND reminder_at <= ?", time.Time{}, filter.Before)
+
+	if filter.After != nil {
+		query = query.Where("reminder_at >= ?", *filter.After)
+	}
+	if !filter.IncludeDone {
+		query = query.Where("status NOT IN ?", models.ClosedStatuses)
+	}
+	if filter.Priority != nil {
+		query = query.Where("priority = ?", *filter.Priority)
+	}
+
+	var tasks []models.Task
+	err := query.Order("reminder_at asc").Find(&tasks).Error
+	return tasks, err
+}
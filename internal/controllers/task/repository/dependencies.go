@@ -0,0 +1,187 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// openBlockerSQL acha as dependências em aberto e fora da lixeira da tarefa
+// na coluna de ID column.
+const openBlockerSQL = "SELECT 1 FROM task_dependencies d JOIN tasks b ON b.id = d.depends_on_id " +
+	"WHERE d.task_id = %s AND b.status NOT IN ? AND b.deleted_at IS NULL"
+
+// whereReady deixa só as tarefas em aberto sem dependências em aberto.
+func whereReady(query *gorm.DB) *gorm.DB {
+	return query.
+		Where("status NOT IN ?", models.ClosedStatuses).
+		Where("NOT EXISTS ("+fmt.Sprintf(openBlockerSQL, "tasks.id")+")", models.ClosedStatuses)
+}
+
+// AddDependency grava a dependência; gravar de novo não faz nada.
+func (s *DBStore) AddDependency(ctx context.Context, dep *models.TaskDependency) error {
+	return s.conn(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(dep).Error
+}
+
+func (s *DBStore) RemoveDependency(ctx context.Context, taskID, dependsOnID string) (bool, error) {
+	tx := s.conn(ctx).Delete(&models.TaskDependency{}, "task_id = ? AND depends_on_id = ?", taskID, dependsOnID)
+	return tx.RowsAffected > 0, tx.Error
+}
+
+// dependencyOrder é a ordem de criação das arestas, com desempate estável.
+const dependencyOrder = "created_at asc, task_id asc, depends_on_id asc"
+
+// DependenciesOf devolve as arestas que saem de id ou chegam nela, incluindo
+// as de tarefas na lixeira: elas voltam junto quando a tarefa é restaurada.
+func (s *DBStore) DependenciesOf(ctx context.Context, id string) ([]models.TaskDependency, error) {
+	var deps []models.TaskDependency
+	err := s.conn(ctx).
+		Where("task_id = ? OR depends_on_id = ?", id, id).
+		Order(dependencyOrder).
+		Find(&deps).Error
+	return deps, err
+}
+
+// ReachableDependencies devolve só as arestas alcançáveis a partir de fromID
+// seguindo depends_on, o bastante para procurar ciclos.
+func (s *DBStore) ReachableDependencies(ctx context.Context, fromID string) ([]models.TaskDependency, error) {
+	var deps []models.TaskDependency
+	err := s.conn(ctx).Raw(`
+		WITH RECURSIVE reach AS (
+			SELECT task_id, depends_on_id, created_at FROM task_dependencies WHERE task_id = @from
+			UNION
+			SELECT d.task_id, d.depends_on_id, d.created_at
+			FROM task_dependencies d JOIN reach ON d.task_id = reach.depends_on_id
+		)
+		SELECT task_id, depends_on_id, created_at FROM reach
+		ORDER BY `+dependencyOrder,
+		map[string]any{"from": fromID},
+	).Scan(&deps).Error
+	return deps, err
+}
+
+// OpenBlockers devolve, para cada tarefa de ids, os IDs das dependências em
+// aberto e fora da lixeira. Tarefas sem nenhuma ficam fora do mapa.
+func (s *DBStore) OpenBlockers(ctx context.Context, ids []string) (map[string][]string, error) {
+	blockers := map[string][]string{}
+	if len(ids) == 0 {
+		return blockers, nil
+	}
+
+	var deps []models.TaskDependency
+	err := s.conn(ctx).
+		Table("task_dependencies d").
+		Select("d.task_id, d.depends_on_id").
+		Joins("JOIN tasks b ON b.id = d.depends_on_id").
+		Where("d.task_id IN ? AND b.status NOT IN ? AND b.deleted_at IS NULL", ids, models.ClosedStatuses).
+		Order("d.depends_on_id asc").
+		Scan(&deps).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, dep := range deps {
+		blockers[dep.TaskID] = append(blockers[dep.TaskID], dep.DependsOnID)
+	}
+	return blockers, nil
+}
+
+func (s *MemoryStore) AddDependency(ctx context.Context, dep *models.TaskDependency) error {
+	defer s.lock(ctx)()
+
+	for _, id := range []string{dep.TaskID, dep.DependsOnID} {
+		if _, ok := s.tasks[id]; !ok {
+			return ErrMemoryTaskNotFound
+		}
+	}
+	for _, existing := range s.dependencies {
+		if existing.TaskID == dep.TaskID && existing.DependsOnID == dep.DependsOnID {
+			return nil
+		}
+	}
+	if dep.CreatedAt.IsZero() {
+		dep.CreatedAt = s.now()
+	}
+	s.dependencies = append(s.dependencies, *dep)
+	return nil
+}
+
+func (s *MemoryStore) RemoveDependency(ctx context.Context, taskID, dependsOnID string) (bool, error) {
+	defer s.lock(ctx)()
+
+	for i, dep := range s.dependencies {
+		if dep.TaskID == taskID && dep.DependsOnID == dependsOnID {
+			s.dependencies = append(s.dependencies[:i], s.dependencies[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (s *MemoryStore) DependenciesOf(ctx context.Context, id string) ([]models.TaskDependency, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var deps []models.TaskDependency
+	for _, dep := range s.dependencies {
+		if dep.TaskID == id || dep.DependsOnID == id {
+			deps = append(deps, dep)
+		}
+	}
+	return deps, nil
+}
+
+func (s *MemoryStore) ReachableDependencies(ctx context.Context, fromID string) ([]models.TaskDependency, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var deps []models.TaskDependency
+	seen := map[string]bool{fromID: true}
+	queue := []string{fromID}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, dep := range s.dependencies {
+			if dep.TaskID != id {
+				continue
+			}
+			deps = append(deps, dep)
+			if !seen[dep.DependsOnID] {
+				seen[dep.DependsOnID] = true
+				queue = append(queue, dep.DependsOnID)
+			}
+		}
+	}
+	return deps, nil
+}
+
+func (s *MemoryStore) OpenBlockers(ctx context.Context, ids []string) (map[string][]string, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	blockers := map[string][]string{}
+	for _, id := range ids {
+		if open := s.openBlockersOf(id); len(open) > 0 {
+			blockers[id] = open
+		}
+	}
+	return blockers, nil
+}
+
+// openBlockersOf assume o mutex já travado.
+func (s *MemoryStore) openBlockersOf(taskID string) []string {
+	var open []string
+	for _, dep := range s.dependencies {
+		if dep.TaskID != taskID {
+			continue
+		}
+		if blocker, ok := s.live(dep.DependsOnID); ok && !blocker.task.Status.Closed() {
+			open = append(open, dep.DependsOnID)
+		}
+	}
+	sort.Strings(open)
+	return open
+}
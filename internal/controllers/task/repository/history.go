@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// AppendHistory grava as mudanças de uma operação de uma vez só.
+func (s *DBStore) AppendHistory(ctx context.Context, changes []models.TaskChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+	return s.conn(ctx).Create(&changes).Error
+}
+
+// ListHistory devolve o histórico da tarefa na ordem em que foi gravado,
+// inclusive de tarefas apagadas de vez.
+func (s *DBStore) ListHistory(ctx context.Context, taskID string) ([]models.TaskChange, error) {
+	var changes []models.TaskChange
+	err := s.conn(ctx).
+		Where("task_id = ?", taskID).
+		Order("id").
+		Find(&changes).Error
+	return changes, err
+}
@@ -0,0 +1,699 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var ErrMemoryTaskNotFound = errors.New("tarefa não existe no store em memória")
+
+// MemoryStore guarda tudo em mapas protegidos por mutex, com a mesma
+// semântica do DBStore (soft delete, preload de pai/filhos, nil quando não
+// encontra). Serve para testes e para rodar sem banco.
+type MemoryStore struct {
+	// tx é segurado por Atomic do começo ao fim e por toda escrita feita fora
+	// de uma transação, então um rollback nunca apaga a escrita de outro.
+	tx           sync.Mutex
+	mu           sync.RWMutex
+	seq          int
+	tasks        map[string]*memoryTask
+	reminders    map[string]*models.Reminder
+	history      []models.TaskChange
+	tags         map[string]*models.Tag
+	taskTags     map[string]map[string]bool
+	projects     map[string]*models.Project
+	dependencies []models.TaskDependency
+	timeEntries  map[string]*models.TimeEntry
+	now          func() time.Time
+}
+
+type memoryTask struct {
+	task models.Task
+	seq  int
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		tasks:       map[string]*memoryTask{},
+		reminders:   map[string]*models.Reminder{},
+		tags:        map[string]*models.Tag{},
+		taskTags:    map[string]map[string]bool{},
+		projects:    map[string]*models.Project{},
+		timeEntries: map[string]*models.TimeEntry{},
+		now:         time.Now,
+	}
+}
+
+type memoryTxKey struct{}
+
+// Atomic roda fn e, se ela devolver erro, volta o Store ao estado de antes.
+// Escritas de fora da transação esperam ela terminar.
+func (s *MemoryStore) Atomic(ctx context.Context, fn func(ctx context.Context) error) error {
+	if ctx.Value(memoryTxKey{}) != nil {
+		return fn(ctx)
+	}
+
+	s.tx.Lock()
+	defer s.tx.Unlock()
+
+	s.mu.RLock()
+	saved := s.snapshot()
+	s.mu.RUnlock()
+
+	if err := fn(context.WithValue(ctx, memoryTxKey{}, true)); err != nil {
+		s.mu.Lock()
+		s.restore(saved)
+		s.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// lock segura o lock de escrita; fora de uma transação, espera também as
+// transações em andamento.
+func (s *MemoryStore) lock(ctx context.Context) (unlock func()) {
+	if ctx.Value(memoryTxKey{}) != nil {
+		s.mu.Lock()
+		return s.mu.Unlock
+	}
+	s.tx.Lock()
+	s.mu.Lock()
+	return func() {
+		s.mu.Unlock()
+		s.tx.Unlock()
+	}
+}
+
+// snapshot copia o estado do Store; quem chama segura o lock.
+func (s *MemoryStore) snapshot() *MemoryStore {
+	copyMap := func(src map[string]bool) map[string]bool {
+		dst := make(map[string]bool, len(src))
+		for k, v := range src {
+			dst[k] = v
+		}
+		return dst
+	}
+
+	saved := &MemoryStore{
+		seq:          s.seq,
+		tasks:        make(map[string]*memoryTask, len(s.tasks)),
+		reminders:    make(map[string]*models.Reminder, len(s.reminders)),
+		history:      append([]models.TaskChange(nil), s.history...),
+		tags:         make(map[string]*models.Tag, len(s.tags)),
+		taskTags:     make(map[string]map[string]bool, len(s.taskTags)),
+		projects:     make(map[string]*models.Project, len(s.projects)),
+		dependencies: append([]models.TaskDependency(nil), s.dependencies...),
+		timeEntries:  make(map[string]*models.TimeEntry, len(s.timeEntries)),
+	}
+	for id, stored := range s.tasks {
+		saved.tasks[id] = &memoryTask{task: cloneTask(stored.task), seq: stored.seq}
+	}
+	for id, r := range s.reminders {
+		clone := *r
+		saved.reminders[id] = &clone
+	}
+	for id, tag := range s.tags {
+		clone := *tag
+		saved.tags[id] = &clone
+	}
+	for id, tags := range s.taskTags {
+		saved.taskTags[id] = copyMap(tags)
+	}
+	for id, p := range s.projects {
+		clone := *p
+		saved.projects[id] = &clone
+	}
+	for id, e := range s.timeEntries {
+		clone := cloneTimeEntry(*e)
+		saved.timeEntries[id] = &clone
+	}
+	return saved
+}
+
+// restore volta ao estado salvo por snapshot; quem chama segura o lock.
+func (s *MemoryStore) restore(saved *MemoryStore) {
+	s.seq = saved.seq
+	s.tasks = saved.tasks
+	s.reminders = saved.reminders
+	s.history = saved.history
+	s.tags = saved.tags
+	s.taskTags = saved.taskTags
+	s.projects = saved.projects
+	s.dependencies = saved.dependencies
+	s.timeEntries = saved.timeEntries
+}
+
+var (
+	schemaCache    sync.Map
+	taskSchema     = mustParseSchema(&models.Task{})
+	reminderSchema = mustParseSchema(&models.Reminder{})
+	tagSchema      = mustParseSchema(&models.Tag{})
+	projectSchema  = mustParseSchema(&models.Project{})
+)
+
+func mustParseSchema(model any) *schema.Schema {
+	s, err := schema.Parse(model, &schemaCache, schema.NamingStrategy{})
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// applyChanges aplica um mapa coluna→valor, como o Updates do gorm recebe.
+func applyChanges(ctx context.Context, s *schema.Schema, dest any, changes map[string]any) error {
+	value := reflect.ValueOf(dest).Elem()
+	for column, change := range changes {
+		field := s.LookUpField(column)
+		if field == nil {
+			return fmt.Errorf("coluna desconhecida: %s", column)
+		}
+		if err := field.Set(ctx, value, change); err != nil {
+			return fmt.Errorf("coluna %s: %w", column, err)
+		}
+	}
+	return nil
+}
+
+func (s *MemoryStore) Create(ctx context.Context, t *models.Task) error {
+	defer s.lock(ctx)()
+
+	if t.ID == "" {
+		t.ID = uuid.NewString()
+	}
+	if _, exists := s.tasks[t.ID]; exists {
+		return fmt.Errorf("tarefa %s já existe", t.ID)
+	}
+	if t.ParentID != nil {
+		if _, ok := s.tasks[*t.ParentID]; !ok {
+			return ErrMemoryTaskNotFound
+		}
+	}
+	if t.ProjectID != nil {
+		if _, ok := s.projects[*t.ProjectID]; !ok {
+			return fmt.Errorf("projeto %s não existe", *t.ProjectID)
+		}
+	}
+
+	now := s.now()
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = now
+	}
+	if t.UpdatedAt.IsZero() {
+		t.UpdatedAt = now
+	}
+	if t.Version == 0 {
+		t.Version = 1
+	}
+	t.SyncStatus()
+	for i := range t.Reminders {
+		r := &t.Reminders[i]
+		r.TaskID = t.ID
+		s.insertReminder(r, now)
+	}
+
+	s.seq++
+	s.tasks[t.ID] = &memoryTask{task: cloneTask(*t), seq: s.seq}
+	return nil
+}
+
+func (s *MemoryStore) GetByID(ctx context.Context, id string) (*models.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.get(id), nil
+}
+
+func (s *MemoryStore) List(ctx context.Context, q task.ListQuery) ([]models.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var live []*memoryTask
+	for _, stored := range s.liveTasks() {
+		candidate := stored.task
+		candidate.Tags = s.tagsOf(candidate.ID)
+		if q.Ready && len(s.openBlockersOf(candidate.ID)) > 0 {
+			continue
+		}
+		if q.Matches(candidate) && q.AfterCursor(candidate) {
+			live = append(live, stored)
+		}
+	}
+	sort.SliceStable(live, func(i, j int) bool {
+		return q.Less(live[i].task, live[j].task)
+	})
+	if q.Limit > 0 && len(live) > q.Limit {
+		live = live[:q.Limit]
+	}
+
+	tasks := make([]models.Task, 0, len(live))
+	for _, stored := range live {
+		tasks = append(tasks, s.withRelations(stored))
+	}
+	return tasks, nil
+}
+
+func (s *MemoryStore) Patch(ctx context.Context, id string, changes map[string]any) (*models.Task, error) {
+	defer s.lock(ctx)()
+
+	return s.patch(ctx, id, nil, changes)
+}
+
+func (s *MemoryStore) PatchIfVersion(ctx context.Context, id string, version int64, changes map[string]any) (*models.Task, error) {
+	defer s.lock(ctx)()
+
+	return s.patch(ctx, id, &version, changes)
+}
+
+func (s *MemoryStore) Delete(ctx context.Context, id string) error {
+	defer s.lock(ctx)()
+
+	if stored, ok := s.live(id); ok {
+		stored.task.DeletedAt.Time = s.now()
+		stored.task.DeletedAt.Valid = true
+	}
+	return nil
+}
+
+func (s *MemoryStore) DeleteIfVersion(ctx context.Context, id string, version int64) error {
+	defer s.lock(ctx)()
+
+	stored, ok := s.live(id)
+	if !ok {
+		return nil
+	}
+	if stored.task.Version != version {
+		return task.ErrVersionConflict
+	}
+	stored.task.DeletedAt.Time = s.now()
+	stored.task.DeletedAt.Valid = true
+	return nil
+}
+
+func (s *MemoryStore) ListDue(ctx context.Context, filter task.DueFilter) ([]models.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var tasks []models.Task
+	for _, stored := range s.liveTasks() {
+		t := stored.task
+		if t.ReminderAt.IsZero() || t.ReminderAt.After(filter.Before) {
+			continue
+		}
+		if filter.After != nil && t.ReminderAt.Before(*filter.After) {
+			continue
+		}
+		if !filter.IncludeDone && t.Status.Closed() {
+			continue
+		}
+		if filter.Priority != nil && t.Priority != *filter.Priority {
+			continue
+		}
+		tasks = append(tasks, cloneTask(t))
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].ReminderAt.Before(tasks[j].ReminderAt)
+	})
+	return tasks, nil
+}
+
+func (s *MemoryStore) ListReminders(ctx context.Context, taskID string) ([]models.Reminder, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.remindersOf(taskID), nil
+}
+
+func (s *MemoryStore) GetReminder(ctx context.Context, taskID, id string) (*models.Reminder, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	r, ok := s.reminders[id]
+	if !ok || r.TaskID != taskID {
+		return nil, nil
+	}
+	clone := cloneReminder(*r)
+	return &clone, nil
+}
+
+func (s *MemoryStore) CreateReminder(ctx context.Context, r *models.Reminder) error {
+	defer s.lock(ctx)()
+
+	if _, ok := s.tasks[r.TaskID]; !ok {
+		return ErrMemoryTaskNotFound
+	}
+	s.insertReminder(r, s.now())
+	return nil
+}
+
+func (s *MemoryStore) PatchReminder(ctx context.Context, id string, changes map[string]any) (*models.Reminder, error) {
+	defer s.lock(ctx)()
+
+	stored, ok := s.reminders[id]
+	if !ok {
+		return nil, nil
+	}
+
+	updated := cloneReminder(*stored)
+	if err := applyChanges(ctx, reminderSchema, &updated, changes); err != nil {
+		return nil, err
+	}
+	if _, ok := changes["updated_at"]; !ok {
+		updated.UpdatedAt = s.now()
+	}
+	s.reminders[id] = &updated
+
+	clone := cloneReminder(updated)
+	return &clone, nil
+}
+
+func (s *MemoryStore) DeleteReminder(ctx context.Context, id string) error {
+	defer s.lock(ctx)()
+
+	delete(s.reminders, id)
+	return nil
+}
+
+func (s *MemoryStore) PendingReminders(ctx context.Context, now time.Time) ([]models.Reminder, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var reminders []models.Reminder
+	for _, r := range s.reminders {
+		if r.DeliveredAt != nil || r.NextAttempt().After(now) {
+			continue
+		}
+		stored, ok := s.live(r.TaskID)
+		if !ok || stored.task.Status.Closed() {
+			continue
+		}
+		clone := cloneReminder(*r)
+		t := cloneTask(stored.task)
+		clone.Task = &t
+		reminders = append(reminders, clone)
+	}
+	sortReminders(reminders)
+	return reminders, nil
+}
+
+func (s *MemoryStore) MarkReminderSent(ctx context.Context, id string, sentAt time.Time) (bool, error) {
+	defer s.lock(ctx)()
+
+	r, ok := s.reminders[id]
+	if !ok || r.DeliveredAt != nil {
+		return false, nil
+	}
+	delivered := sentAt
+	r.DeliveredAt = &delivered
+	r.UpdatedAt = s.now()
+	return true, nil
+}
+
+func (s *MemoryStore) RecordReminderFailure(ctx context.Context, id string, channels models.ChannelList, reason string, retryAt *time.Time) error {
+	defer s.lock(ctx)()
+
+	r, ok := s.reminders[id]
+	if !ok {
+		return nil
+	}
+	r.Attempts++
+	r.RetryChannels = slices.Clone(channels)
+	r.LastError = reason
+	r.RetryAt = nil
+	if retryAt != nil {
+		at := *retryAt
+		r.RetryAt = &at
+		r.DeliveredAt = nil
+	}
+	r.UpdatedAt = s.now()
+	return nil
+}
+
+func (s *MemoryStore) ListDeleted(ctx context.Context) ([]models.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var tasks []models.Task
+	for _, stored := range s.tasks {
+		if stored.task.DeletedAt.Valid {
+			tasks = append(tasks, cloneTask(stored.task))
+		}
+	}
+	sort.SliceStable(tasks, func(i, j int) bool {
+		return tasks[i].DeletedAt.Time.After(tasks[j].DeletedAt.Time)
+	})
+	return tasks, nil
+}
+
+func (s *MemoryStore) Restore(ctx context.Context, id string) (*models.Task, error) {
+	defer s.lock(ctx)()
+
+	stored, ok := s.tasks[id]
+	if !ok || !stored.task.DeletedAt.Valid {
+		return nil, nil
+	}
+	stored.task.DeletedAt = gorm.DeletedAt{}
+	stored.task.UpdatedAt = s.now()
+	return s.get(id), nil
+}
+
+func (s *MemoryStore) Purge(ctx context.Context, id string) (bool, error) {
+	defer s.lock(ctx)()
+
+	stored, ok := s.tasks[id]
+	if !ok || !stored.task.DeletedAt.Valid {
+		return false, nil
+	}
+	s.purge(id)
+	return true, nil
+}
+
+func (s *MemoryStore) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	defer s.lock(ctx)()
+
+	var purged int64
+	for id, stored := range s.tasks {
+		if stored.task.DeletedAt.Valid && stored.task.DeletedAt.Time.Before(before) {
+			s.purge(id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+func (s *MemoryStore) AppendHistory(ctx context.Context, changes []models.TaskChange) error {
+	defer s.lock(ctx)()
+
+	for i := range changes {
+		changes[i].ID = uint(len(s.history) + 1)
+		if changes[i].CreatedAt.IsZero() {
+			changes[i].CreatedAt = s.now()
+		}
+		s.history = append(s.history, changes[i])
+	}
+	return nil
+}
+
+func (s *MemoryStore) ListHistory(ctx context.Context, taskID string) ([]models.TaskChange, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var changes []models.TaskChange
+	for _, change := range s.history {
+		if change.TaskID == taskID {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+// As funções abaixo assumem o mutex já travado.
+
+// purge imita as FKs do banco: lembretes, tags, dependências e registros de
+// tempo em cascata, filhos sem pai.
+func (s *MemoryStore) purge(id string) {
+	delete(s.tasks, id)
+	delete(s.taskTags, id)
+	kept := s.dependencies[:0]
+	for _, dep := range s.dependencies {
+		if dep.TaskID != id && dep.DependsOnID != id {
+			kept = append(kept, dep)
+		}
+	}
+	s.dependencies = kept
+	for rid, r := range s.reminders {
+		if r.TaskID == id {
+			delete(s.reminders, rid)
+		}
+	}
+	for eid, e := range s.timeEntries {
+		if e.TaskID == id {
+			delete(s.timeEntries, eid)
+		}
+	}
+	for _, stored := range s.tasks {
+		if stored.task.ParentID != nil && *stored.task.ParentID == id {
+			stored.task.ParentID = nil
+		}
+	}
+}
+
+func (s *MemoryStore) patch(ctx context.Context, id string, version *int64, changes map[string]any) (*models.Task, error) {
+	stored, ok := s.live(id)
+	if !ok {
+		return nil, nil
+	}
+	if version != nil && stored.task.Version != *version {
+		return nil, task.ErrVersionConflict
+	}
+
+	updated := cloneTask(stored.task)
+	if err := applyChanges(ctx, taskSchema, &updated, changes); err != nil {
+		return nil, err
+	}
+	if _, ok := changes["updated_at"]; !ok {
+		updated.UpdatedAt = s.now()
+	}
+	updated.Version = stored.task.Version + 1
+	stored.task = updated
+	return s.get(id), nil
+}
+
+func (s *MemoryStore) get(id string) *models.Task {
+	stored, ok := s.live(id)
+	if !ok {
+		return nil
+	}
+	t := s.withRelations(stored)
+	t.Reminders = s.remindersOf(id)
+	return &t
+}
+
+func (s *MemoryStore) live(id string) (*memoryTask, bool) {
+	stored, ok := s.tasks[id]
+	if !ok || stored.task.DeletedAt.Valid {
+		return nil, false
+	}
+	return stored, true
+}
+
+func (s *MemoryStore) liveTasks() []*memoryTask {
+	live := make([]*memoryTask, 0, len(s.tasks))
+	for _, stored := range s.tasks {
+		if !stored.task.DeletedAt.Valid {
+			live = append(live, stored)
+		}
+	}
+	sort.Slice(live, func(i, j int) bool { return live[i].seq < live[j].seq })
+	return live
+}
+
+func (s *MemoryStore) withRelations(stored *memoryTask) models.Task {
+	t := cloneTask(stored.task)
+	if t.ParentID != nil {
+		if parent, ok := s.live(*t.ParentID); ok {
+			p := cloneTask(parent.task)
+			t.Parent = &p
+		}
+	}
+	for _, child := range s.liveTasks() {
+		if child.task.ParentID != nil && *child.task.ParentID == t.ID {
+			t.Children = append(t.Children, cloneTask(child.task))
+		}
+	}
+	t.Tags = s.tagsOf(t.ID)
+	return t
+}
+
+func (s *MemoryStore) remindersOf(taskID string) []models.Reminder {
+	var reminders []models.Reminder
+	for _, r := range s.reminders {
+		if r.TaskID == taskID {
+			reminders = append(reminders, cloneReminder(*r))
+		}
+	}
+	sortReminders(reminders)
+	return reminders
+}
+
+func (s *MemoryStore) insertReminder(r *models.Reminder, now time.Time) {
+	if r.ID == "" {
+		r.ID = uuid.NewString()
+	}
+	if r.CreatedAt.IsZero() {
+		r.CreatedAt = now
+	}
+	if r.UpdatedAt.IsZero() {
+		r.UpdatedAt = now
+	}
+	stored := cloneReminder(*r)
+	s.reminders[r.ID] = &stored
+}
+
+func sortReminders(reminders []models.Reminder) {
+	sort.Slice(reminders, func(i, j int) bool {
+		if !reminders[i].FireAt.Equal(reminders[j].FireAt) {
+			return reminders[i].FireAt.Before(reminders[j].FireAt)
+		}
+		return reminders[i].ID < reminders[j].ID
+	})
+}
+
+func cloneTask(t models.Task) models.Task {
+	if t.Channels != nil {
+		t.Channels = append(models.ChannelList(nil), t.Channels...)
+	}
+	if t.ParentID != nil {
+		parentID := *t.ParentID
+		t.ParentID = &parentID
+	}
+	if t.SeriesID != nil {
+		seriesID := *t.SeriesID
+		t.SeriesID = &seriesID
+	}
+	if t.ProjectID != nil {
+		projectID := *t.ProjectID
+		t.ProjectID = &projectID
+	}
+	for _, at := range []**time.Time{&t.DueAt, &t.StartedAt, &t.CompletedAt, &t.CancelledAt} {
+		if *at != nil {
+			v := **at
+			*at = &v
+		}
+	}
+	t.Parent = nil
+	t.Children = nil
+	t.Reminders = nil
+	t.Tags = nil
+	t.Progress = nil
+	return t
+}
+
+func cloneReminder(r models.Reminder) models.Reminder {
+	if r.At != nil {
+		at := *r.At
+		r.At = &at
+	}
+	if r.DeliveredAt != nil {
+		delivered := *r.DeliveredAt
+		r.DeliveredAt = &delivered
+	}
+	if r.RetryAt != nil {
+		retryAt := *r.RetryAt
+		r.RetryAt = &retryAt
+	}
+	r.RetryChannels = slices.Clone(r.RetryChannels)
+	r.Task = nil
+	return r
+}
@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task/repository/storetest"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+func TestMemoryStore_Conformance(t *testing.T) {
+	t.Parallel()
+
+	storetest.Run(t, func(t *testing.T) api.Store {
+		return NewMemoryStore()
+	})
+}
+
+func TestMemoryStore_Concurrent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryStore()
+	parent := &models.Task{Title: "parent", Priority: models.PriorityLow}
+	if err := store.Create(ctx, parent); err != nil {
+		t.Fatalf("create parent: %v", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			child := &models.Task{Title: fmt.Sprintf("child %d", i), Priority: models.PriorityLow, ParentID: &parent.ID}
+			if err := store.Create(ctx, child); err != nil {
+				t.Errorf("create: %v", err)
+				return
+			}
+			if _, err := store.Patch(ctx, child.ID, map[string]any{"done": true}); err != nil {
+				t.Errorf("patch: %v", err)
+			}
+			if _, err := store.List(ctx, task.ListQuery{}); err != nil {
+				t.Errorf("list: %v", err)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	got, err := store.GetByID(ctx, parent.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if len(got.Children) != 20 {
+		t.Fatalf("children = %d, want 20", len(got.Children))
+	}
+}
+
+func TestMemoryStore_AtomicKeepsConcurrentWrites(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryStore()
+	errRollback := errors.New("rollback")
+	started, release := make(chan struct{}), make(chan struct{})
+
+	txDone := make(chan error, 1)
+	go func() {
+		txDone <- store.Atomic(ctx, func(ctx context.Context) error {
+			if err := store.Create(ctx, &models.Task{Title: "na transação", Priority: models.PriorityLow}); err != nil {
+				return err
+			}
+			close(started)
+			<-release
+			return errRollback
+		})
+	}()
+	<-started
+
+	outside := &models.Task{Title: "fora da transação", Priority: models.PriorityLow}
+	writeDone := make(chan error, 1)
+	go func() { writeDone <- store.Create(ctx, outside) }()
+
+	// Dá tempo para a escrita de fora acontecer durante a transação, se o
+	// Store deixar.
+	select {
+	case err := <-writeDone:
+		writeDone <- err
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+
+	if err := <-txDone; !errors.Is(err, errRollback) {
+		t.Fatalf("atomic error = %v, want %v", err, errRollback)
+	}
+	if err := <-writeDone; err != nil {
+		t.Fatalf("create outside: %v", err)
+	}
+
+	tasks, err := store.List(ctx, task.ListQuery{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != outside.ID {
+		t.Fatalf("tasks = %+v, want only the one written outside the transaction", tasks)
+	}
+}
+
+func TestMemoryStore_ReturnsCopies(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := NewMemoryStore()
+	created := &models.Task{Title: "original", Priority: models.PriorityLow, Channels: models.ChannelList{"ntfy"}}
+	if err := store.Create(ctx, created); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	created.Title = "changed by caller"
+
+	got, _ := store.GetByID(ctx, created.ID)
+	got.Channels[0] = "email"
+
+	again, _ := store.GetByID(ctx, created.ID)
+	if again.Title != "original" || again.Channels[0] != "ntfy" {
+		t.Fatalf("store state leaked to caller: %+v", again)
+	}
+}
@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task/repository/storetest"
+	"github.com/andre-felipe-wonsik-alves/internal/database"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// Roda só com TEST_POSTGRES_DSN definido, ex.: o banco do docker-compose.
+// As tabelas são truncadas antes de cada subteste.
+func TestDBStore_Conformance(t *testing.T) {
+	dsn := os.Getenv("TEST_POSTGRES_DSN")
+	if dsn == "" {
+		t.Skip("TEST_POSTGRES_DSN não definido")
+	}
+
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("connect: %v", err)
+	}
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	storetest.Run(t, func(t *testing.T) api.Store {
+		if err := db.Exec("TRUNCATE tasks, reminders, task_history, tags, task_tags, projects, task_dependencies CASCADE").Error; err != nil {
+			t.Fatalf("truncate: %v", err)
+		}
+		return NewDBStore(db)
+	})
+}
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+func (s *DBStore) ListProjects(ctx context.Context, includeArchived bool) ([]models.Project, error) {
+	query := s.conn(ctx).Order("name asc")
+	if !includeArchived {
+		query = query.Where("archived = ?", false)
+	}
+	var projects []models.Project
+	err := query.Find(&projects).Error
+	return projects, err
+}
+
+func (s *DBStore) GetProject(ctx context.Context, id string) (*models.Project, error) {
+	return s.firstProject(ctx, "id = ?", id)
+}
+
+func (s *DBStore) GetProjectByName(ctx context.Context, name string) (*models.Project, error) {
+	return s.firstProject(ctx, "name = ?", name)
+}
+
+func (s *DBStore) firstProject(ctx context.Context, query string, arg any) (*models.Project, error) {
+	var project models.Project
+	err := s.conn(ctx).First(&project, query, arg).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &project, nil
+}
+
+func (s *DBStore) CreateProject(ctx context.Context, project *models.Project) error {
+	return s.conn(ctx).Create(project).Error
+}
+
+func (s *DBStore) PatchProject(ctx context.Context, id string, changes map[string]any) (*models.Project, error) {
+	tx := s.conn(ctx).Model(&models.Project{}).Where("id = ?", id).Updates(changes)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return nil, nil
+	}
+	return s.GetProject(ctx, id)
+}
+
+// DeleteProject apaga o projeto; as tarefas dele ficam sem projeto (ON
+// DELETE SET NULL).
+func (s *DBStore) DeleteProject(ctx context.Context, id string) (bool, error) {
+	tx := s.conn(ctx).Delete(&models.Project{}, "id = ?", id)
+	return tx.RowsAffected > 0, tx.Error
+}
+
+// ProjectCounts conta as tarefas fora da lixeira de cada projeto em ids.
+// Projeto sem tarefas fica fora do mapa.
+func (s *DBStore) ProjectCounts(ctx context.Context, ids []string) (map[string]models.ProjectCounts, error) {
+	counts := map[string]models.ProjectCounts{}
+	if len(ids) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		ProjectID string
+		Total     int
+		Done      int
+		Cancelled int
+	}
+	err := s.conn(ctx).
+		Model(&models.Task{}).
+		Select("project_id, COUNT(*) AS total, "+
+			"SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS done, "+
+			"SUM(CASE WHEN status = ? THEN 1 ELSE 0 END) AS cancelled", models.StatusDone, models.StatusCancelled).
+		Where("project_id IN ?", ids).
+		Group("project_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		counts[row.ProjectID] = models.ProjectCounts{
+			Total:     row.Total,
+			Open:      row.Total - row.Done - row.Cancelled,
+			Done:      row.Done,
+			Cancelled: row.Cancelled,
+		}
+	}
+	return counts, nil
+}
+
+func (s *MemoryStore) ListProjects(ctx context.Context, includeArchived bool) ([]models.Project, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	projects := make([]models.Project, 0, len(s.projects))
+	for _, project := range s.projects {
+		if includeArchived || !project.Archived {
+			projects = append(projects, *project)
+		}
+	}
+	sort.Slice(projects, func(i, j int) bool { return projects[i].Name < projects[j].Name })
+	return projects, nil
+}
+
+func (s *MemoryStore) GetProject(ctx context.Context, id string) (*models.Project, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if project, ok := s.projects[id]; ok {
+		clone := *project
+		return &clone, nil
+	}
+	return nil, nil
+}
+
+func (s *MemoryStore) GetProjectByName(ctx context.Context, name string) (*models.Project, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, project := range s.projects {
+		if project.Name == name {
+			clone := *project
+			return &clone, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *MemoryStore) CreateProject(ctx context.Context, project *models.Project) error {
+	defer s.lock(ctx)()
+
+	for _, existing := range s.projects {
+		if existing.Name == project.Name {
+			return fmt.Errorf("projeto %s já existe", project.Name)
+		}
+	}
+	if project.ID == "" {
+		project.ID = uuid.NewString()
+	}
+	now := s.now()
+	if project.CreatedAt.IsZero() {
+		project.CreatedAt = now
+	}
+	if project.UpdatedAt.IsZero() {
+		project.UpdatedAt = now
+	}
+	stored := *project
+	stored.Counts = nil
+	s.projects[project.ID] = &stored
+	return nil
+}
+
+func (s *MemoryStore) PatchProject(ctx context.Context, id string, changes map[string]any) (*models.Project, error) {
+	defer s.lock(ctx)()
+
+	stored, ok := s.projects[id]
+	if !ok {
+		return nil, nil
+	}
+	updated := *stored
+	if err := applyChanges(ctx, projectSchema, &updated, changes); err != nil {
+		return nil, err
+	}
+	for _, existing := range s.projects {
+		if existing.ID != id && existing.Name == updated.Name {
+			return nil, fmt.Errorf("projeto %s já existe", updated.Name)
+		}
+	}
+	if _, ok := changes["updated_at"]; !ok {
+		updated.UpdatedAt = s.now()
+	}
+	s.projects[id] = &updated
+
+	clone := updated
+	return &clone, nil
+}
+
+func (s *MemoryStore) DeleteProject(ctx context.Context, id string) (bool, error) {
+	defer s.lock(ctx)()
+
+	if _, ok := s.projects[id]; !ok {
+		return false, nil
+	}
+	delete(s.projects, id)
+	for _, stored := range s.tasks {
+		if stored.task.ProjectID != nil && *stored.task.ProjectID == id {
+			stored.task.ProjectID = nil
+		}
+	}
+	return true, nil
+}
+
+func (s *MemoryStore) ProjectCounts(ctx context.Context, ids []string) (map[string]models.ProjectCounts, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	wanted := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	counts := map[string]models.ProjectCounts{}
+	for _, stored := range s.liveTasks() {
+		t := stored.task
+		if t.ProjectID == nil || !wanted[*t.ProjectID] {
+			continue
+		}
+		c := counts[*t.ProjectID]
+		c.Total++
+		switch t.Status {
+		case models.StatusDone:
+			c.Done++
+		case models.StatusCancelled:
+			c.Cancelled++
+		default:
+			c.Open++
+		}
+		counts[*t.ProjectID] = c
+	}
+	return counts, nil
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"gorm.io/gorm"
+)
+
+func (s *DBStore) ListReminders(ctx context.Context, taskID string) ([]models.Reminder, error) {
+	var reminders []models.Reminder
+	err := s.conn(ctx).
+		Where("task_id = ?", taskID).
+		Order("fire_at asc").
+		Find(&reminders).Error
+	return reminders, err
+}
+
+func (s *DBStore) GetReminder(ctx context.Context, taskID, id string) (*models.Reminder, error) {
+	var r models.Reminder
+	err := s.conn(ctx).First(&r, "id = ? AND task_id = ?", id, taskID).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (s *DBStore) CreateReminder(ctx context.Context, r *models.Reminder) error {
+	return s.conn(ctx).Create(r).Error
+}
+
+func (s *DBStore) PatchReminder(ctx context.Context, id string, changes map[string]any) (*models.Reminder, error) {
+	tx := s.conn(ctx).Model(&models.Reminder{}).Where("id = ?", id).Updates(changes)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return nil, nil
+	}
+
+	var r models.Reminder
+	if err := s.conn(ctx).First(&r, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+func (s *DBStore) DeleteReminder(ctx context.Context, id string) error {
+	return s.conn(ctx).Delete(&models.Reminder{}, "id = ?", id).Error
+}
+
+// PendingReminders devolve os lembretes vencidos e ainda não entregues de
+// tarefas abertas, com a tarefa carregada.
+func (s *DBStore) PendingReminders(ctx context.Context, now time.Time) ([]models.Reminder, error) {
+	openTasks := s.conn(ctx).Model(&models.Task{}).Select("id").Where("status NOT IN ?", models.ClosedStatuses)
+
+	var reminders []models.Reminder
+	err := s.conn(ctx).
+		Preload("Task").
+		Where("delivered_at IS NULL AND COALESCE(retry_at, fire_at) <= ?", now).
+		Where("task_id IN (?)", openTasks).
+		Order("fire_at asc").
+		Find(&reminders).Error
+	return reminders, err
+}
+
+func (s *DBStore) MarkReminderSent(ctx context.Context, id string, sentAt time.Time) (bool, error) {
+	tx := s.conn(ctx).
+		Model(&models.Reminder{}).
+		Where("id = ? AND delivered_at IS NULL", id).
+		Update("delivered_at", sentAt)
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	return tx.RowsAffected > 0, nil
+}
+
+// RecordReminderFailure conta a tentativa que falhou nos canais informados.
+// Com retryAt, o lembrete volta a ficar pendente nesse horário só para esses
+// canais; sem ele, fica como entregue e a falha só é registrada.
+func (s *DBStore) RecordReminderFailure(ctx context.Context, id string, channels models.ChannelList, reason string, retryAt *time.Time) error {
+	changes := map[string]any{
+		"attempts":       gorm.Expr("attempts + 1"),
+		"retry_channels": channels,
+		"last_error":     reason,
+		"retry_at":       retryAt,
+		"updated_at":     time.Now(),
+	}
+	if retryAt != nil {
+		changes["delivered_at"] = nil
+	}
+	return s.conn(ctx).Model(&models.Reminder{}).Where("id = ?", id).Updates(changes).Error
+}
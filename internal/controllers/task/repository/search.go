@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// headlineOptions são as opções do ts_headline, com os delimitadores que o
+// task.EscapeHeadline troca por <mark> depois de escapar o texto.
+var headlineOptions = "StartSel=" + task.HeadlineStart + ", StopSel=" + task.HeadlineStop + ", MaxWords=20, MinWords=5"
+
+// Search usa o search_vector (tsvector com GIN) em português e inglês. O
+// snippet vem do ts_headline e passa pelo task.EscapeHeadline, como o dos
+// outros Stores.
+func (s *DBStore) Search(ctx context.Context, query string, filter task.SearchFilter, limit int) ([]task.SearchResult, error) {
+	var hits []struct {
+		ID      string
+		Rank    float64
+		Snippet string
+	}
+	args := []any{task.HeadlineStart + task.HeadlineStop, headlineOptions, query, query}
+	var conditions string
+	if filter.ProjectID != nil {
+		conditions += " AND t.project_id = ?"
+		args = append(args, *filter.ProjectID)
+	}
+	if tagCondition, tagArgs := tagFilterSQL("t.id", filter.Tags); tagCondition != "" {
+		conditions += " AND " + tagCondition
+		args = append(args, tagArgs...)
+	}
+	args = append(args, limit)
+
+	err := s.conn(ctx).Raw(`
+		SELECT t.id,
+		       ts_rank(t.search_vector, q.query) AS rank,
+		       ts_headline('portuguese', translate(t.title || ' ' || coalesce(t.description, ''), ?, ''), q.query, ?) AS snippet
+		FROM tasks t,
+		     (SELECT websearch_to_tsquery('portuguese', ?) || websearch_to_tsquery('english', ?) AS query) q
+		WHERE t.deleted_at IS NULL AND t.search_vector @@ q.query`+conditions+`
+		ORDER BY rank DESC, t.created_at DESC
+		LIMIT ?`,
+		args...,
+	).Scan(&hits).Error
+	if err != nil || len(hits) == 0 {
+		return []task.SearchResult{}, err
+	}
+
+	ids := make([]string, 0, len(hits))
+	for _, hit := range hits {
+		ids = append(ids, hit.ID)
+	}
+	var tasks []models.Task
+	err = s.conn(ctx).
+		Preload("Parent").
+		Preload("Children").
+		Preload("Tags", preloadTags).
+		Where("id IN ?", ids).
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[string]models.Task, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+	}
+
+	results := make([]task.SearchResult, 0, len(hits))
+	for _, hit := range hits {
+		if t, ok := byID[hit.ID]; ok {
+			results = append(results, task.SearchResult{Task: t, Rank: hit.Rank, Snippet: task.EscapeHeadline(hit.Snippet)})
+		}
+	}
+	return results, nil
+}
+
+// Search no SQLite filtra em Go com task.MatchTask: o contrato é o mesmo do
+// Postgres, só a relevância é mais simples (sem stemming).
+func (s *SQLiteStore) Search(ctx context.Context, query string, filter task.SearchFilter, limit int) ([]task.SearchResult, error) {
+	tasks, err := s.List(ctx, task.ListQuery{Tags: filter.Tags, ProjectID: filter.ProjectID})
+	if err != nil {
+		return nil, err
+	}
+	return matchTasks(tasks, query, limit), nil
+}
+
+func (s *MemoryStore) Search(ctx context.Context, query string, filter task.SearchFilter, limit int) ([]task.SearchResult, error) {
+	tasks, err := s.List(ctx, task.ListQuery{Tags: filter.Tags, ProjectID: filter.ProjectID})
+	if err != nil {
+		return nil, err
+	}
+	return matchTasks(tasks, query, limit), nil
+}
+
+// matchTasks ordena como a busca do Postgres: relevância e, no empate, as
+// mais recentes primeiro.
+func matchTasks(tasks []models.Task, query string, limit int) []task.SearchResult {
+	terms := task.SearchTerms(query)
+	results := []task.SearchResult{}
+	for _, t := range tasks {
+		if result, ok := task.MatchTask(t, terms); ok {
+			results = append(results, result)
+		}
+	}
+
+	sort.SliceStable(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return results[i].CreatedAt.After(results[j].CreatedAt)
+	})
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
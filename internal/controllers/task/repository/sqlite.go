@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// SQLiteStore é o DBStore para o modo offline: o SQLite não tem
+// gen_random_uuid(), então os IDs são gerados aqui antes do INSERT.
+type SQLiteStore struct {
+	*DBStore
+}
+
+func NewSQLiteStore(db *gorm.DB) *SQLiteStore {
+	return &SQLiteStore{DBStore: NewDBStore(db)}
+}
+
+func (s *SQLiteStore) Create(ctx context.Context, t *models.Task) error {
+	if t.ID == "" {
+		t.ID = uuid.NewString()
+	}
+	for i := range t.Reminders {
+		if t.Reminders[i].ID == "" {
+			t.Reminders[i].ID = uuid.NewString()
+		}
+	}
+	return s.DBStore.Create(ctx, t)
+}
+
+func (s *SQLiteStore) CreateReminder(ctx context.Context, r *models.Reminder) error {
+	if r.ID == "" {
+		r.ID = uuid.NewString()
+	}
+	return s.DBStore.CreateReminder(ctx, r)
+}
+
+func (s *SQLiteStore) CreateTag(ctx context.Context, tag *models.Tag) error {
+	if tag.ID == "" {
+		tag.ID = uuid.NewString()
+	}
+	return s.DBStore.CreateTag(ctx, tag)
+}
+
+func (s *SQLiteStore) CreateProject(ctx context.Context, project *models.Project) error {
+	if project.ID == "" {
+		project.ID = uuid.NewString()
+	}
+	return s.DBStore.CreateProject(ctx, project)
+}
+
+func (s *SQLiteStore) CreateTimeEntry(ctx context.Context, e *models.TimeEntry) error {
+	if e.ID == "" {
+		e.ID = uuid.NewString()
+	}
+	return s.DBStore.CreateTimeEntry(ctx, e)
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task/repository/storetest"
+	"github.com/andre-felipe-wonsik-alves/internal/database"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func newSQLiteStore(t *testing.T) *SQLiteStore {
+	t.Helper()
+
+	db, err := database.OpenSQLite(":memory:", &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	t.Cleanup(func() {
+		if sqlDB, err := db.DB(); err == nil {
+			sqlDB.Close()
+		}
+	})
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("load migrations: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+	return NewSQLiteStore(db)
+}
+
+func TestSQLiteStore_Conformance(t *testing.T) {
+	t.Parallel()
+
+	storetest.Run(t, func(t *testing.T) api.Store {
+		return newSQLiteStore(t)
+	})
+}
+
+func TestSQLiteStore_TimesAcrossZones(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := newSQLiteStore(t)
+
+	base := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	saoPaulo := time.FixedZone("BRT", -3*60*60)
+
+	// 10:00 em São Paulo é 13:00 UTC: depois de base, apesar do relógio local menor.
+	late := &models.Task{Title: "late", Priority: models.PriorityLow, ReminderAt: base.Add(time.Hour).In(saoPaulo)}
+	early := &models.Task{Title: "early", Priority: models.PriorityLow, ReminderAt: base.Add(-time.Hour)}
+	for _, tk := range []*models.Task{late, early} {
+		if err := store.Create(ctx, tk); err != nil {
+			t.Fatalf("create %s: %v", tk.Title, err)
+		}
+	}
+
+	due, err := store.ListDue(ctx, task.DueFilter{Before: base})
+	if err != nil {
+		t.Fatalf("list due: %v", err)
+	}
+	if len(due) != 1 || due[0].ID != early.ID {
+		t.Fatalf("expected only early task due, got %+v", due)
+	}
+
+	got, err := store.GetByID(ctx, late.ID)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if !got.ReminderAt.Equal(late.ReminderAt) {
+		t.Fatalf("reminder_at = %v, want %v", got.ReminderAt, late.ReminderAt)
+	}
+}
@@ -0,0 +1,1577 @@
+// Package storetest tem a suíte de conformidade que toda implementação de
+// api.Store (DBStore, SQLiteStore, MemoryStore) precisa passar.
+package storetest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task/api"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// Factory devolve um Store vazio. É chamada uma vez por subteste.
+type Factory func(t *testing.T) api.Store
+
+const missingID = "00000000-0000-0000-0000-000000000000"
+
+// Run executa a suíte contra os Stores criados por newStore.
+func Run(t *testing.T, newStore Factory) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		run  func(t *testing.T, store api.Store)
+	}{
+		{"create and get", testCreateAndGet},
+		{"not found", testNotFound},
+		{"children loading", testChildrenLoading},
+		{"list", testList},
+		{"list query", testListQuery},
+		{"list pagination", testListPagination},
+		{"patch", testPatch},
+		{"soft delete", testSoftDelete},
+		{"list due", testListDue},
+		{"reminders", testReminders},
+		{"pending reminders", testPendingReminders},
+		{"versioning", testVersioning},
+		{"trash", testTrash},
+		{"purge", testPurge},
+		{"history", testHistory},
+		{"search", testSearch},
+		{"tree", testTree},
+		{"progress", testProgress},
+		{"progress skips cancelled", testProgressSkipsCancelled},
+		{"tags", testTags},
+		{"projects", testProjects},
+		{"dependencies", testDependencies},
+		{"status", testStatus},
+		{"time entries", testTimeEntries},
+		{"atomic", testAtomic},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.run(t, newStore(t))
+		})
+	}
+}
+
+func create(t *testing.T, store api.Store, task *models.Task) *models.Task {
+	t.Helper()
+	if task.Priority == "" {
+		task.Priority = models.PriorityMedium
+	}
+	if err := store.Create(context.Background(), task); err != nil {
+		t.Fatalf("create %q: %v", task.Title, err)
+	}
+	if task.ID == "" {
+		t.Fatalf("create %q: expected generated id", task.Title)
+	}
+	return task
+}
+
+func get(t *testing.T, store api.Store, id string) *models.Task {
+	t.Helper()
+	got, err := store.GetByID(context.Background(), id)
+	if err != nil {
+		t.Fatalf("get %s: %v", id, err)
+	}
+	return got
+}
+
+func testCreateAndGet(t *testing.T, store api.Store) {
+	reminderAt := time.Now().Add(time.Hour).Truncate(time.Second)
+	created := create(t, store, &models.Task{
+		Title:           "Atualizar o servidor",
+		Description:     "apt upgrade",
+		Priority:        models.PriorityHigh,
+		ReminderAt:      reminderAt,
+		Channels:        models.ChannelList{"ntfy", "email"},
+		Recurrence:      "weekly",
+		DueAt:           ptr(reminderAt.Add(24 * time.Hour)),
+		DueAllDay:       true,
+		EstimateMinutes: 45,
+		Reminders:       []models.Reminder{{Offset: "0", FireAt: reminderAt}, {Offset: "-1h", Anchor: models.AnchorDue, FireAt: reminderAt.Add(23 * time.Hour)}},
+	})
+
+	got := get(t, store, created.ID)
+	if got == nil {
+		t.Fatalf("expected task %s", created.ID)
+	}
+	if got.Title != created.Title || got.Description != created.Description || got.Priority != models.PriorityHigh {
+		t.Fatalf("unexpected task: %+v", got)
+	}
+	if !got.ReminderAt.Equal(reminderAt) {
+		t.Fatalf("reminder_at = %v, want %v", got.ReminderAt, reminderAt)
+	}
+	if len(got.Channels) != 2 || got.Channels[0] != "ntfy" || got.Recurrence != "weekly" {
+		t.Fatalf("unexpected channels/recurrence: %+v", got)
+	}
+	if got.CreatedAt.IsZero() || got.Done {
+		t.Fatalf("expected created_at set and done=false, got %+v", got)
+	}
+	if got.DueAt == nil || !got.DueAt.Equal(reminderAt.Add(24*time.Hour)) || !got.DueAllDay {
+		t.Fatalf("due_at = %v (all day %v), want %v", got.DueAt, got.DueAllDay, reminderAt.Add(24*time.Hour))
+	}
+	if got.EstimateMinutes != 45 {
+		t.Fatalf("estimate_minutes = %d, want 45", got.EstimateMinutes)
+	}
+	if len(got.Reminders) != 2 || got.Reminders[0].ID == "" || got.Reminders[0].TaskID != created.ID {
+		t.Fatalf("expected nested reminders, got %+v", got.Reminders)
+	}
+	anchors := map[string]bool{}
+	for _, r := range got.Reminders {
+		anchors[r.Anchor] = true
+	}
+	if !anchors[""] || !anchors[models.AnchorDue] {
+		t.Fatalf("expected reminder anchors to round-trip, got %+v", got.Reminders)
+	}
+}
+
+func testNotFound(t *testing.T, store api.Store) {
+	ctx := context.Background()
+
+	if got := get(t, store, missingID); got != nil {
+		t.Fatalf("get missing = %+v, want nil", got)
+	}
+	patched, err := store.Patch(ctx, missingID, map[string]any{"done": true})
+	if err != nil || patched != nil {
+		t.Fatalf("patch missing = %+v, %v; want nil, nil", patched, err)
+	}
+	if err := store.Delete(ctx, missingID); err != nil {
+		t.Fatalf("delete missing: %v", err)
+	}
+	reminder, err := store.GetReminder(ctx, missingID, missingID)
+	if err != nil || reminder != nil {
+		t.Fatalf("get missing reminder = %+v, %v; want nil, nil", reminder, err)
+	}
+	patchedReminder, err := store.PatchReminder(ctx, missingID, map[string]any{"channel": "ntfy"})
+	if err != nil || patchedReminder != nil {
+		t.Fatalf("patch missing reminder = %+v, %v; want nil, nil", patchedReminder, err)
+	}
+	claimed, err := store.MarkReminderSent(ctx, missingID, time.Now())
+	if err != nil || claimed {
+		t.Fatalf("claim missing reminder = %v, %v; want false, nil", claimed, err)
+	}
+}
+
+func testChildrenLoading(t *testing.T, store api.Store) {
+	parent := create(t, store, &models.Task{Title: "Homelab"})
+	first := create(t, store, &models.Task{Title: "Trocar disco", ParentID: &parent.ID})
+	second := create(t, store, &models.Task{Title: "Backup", ParentID: &parent.ID})
+	create(t, store, &models.Task{Title: "Outra"})
+
+	got := get(t, store, parent.ID)
+	if got == nil || len(got.Children) != 2 {
+		t.Fatalf("expected two children, got %+v", got)
+	}
+	ids := map[string]bool{got.Children[0].ID: true, got.Children[1].ID: true}
+	if !ids[first.ID] || !ids[second.ID] {
+		t.Fatalf("unexpected children: %+v", got.Children)
+	}
+	if got.Parent != nil {
+		t.Fatalf("root task should have no parent, got %+v", got.Parent)
+	}
+
+	child := get(t, store, first.ID)
+	if child.ParentID == nil || *child.ParentID != parent.ID {
+		t.Fatalf("parent_id = %v, want %s", child.ParentID, parent.ID)
+	}
+	if child.Parent == nil || child.Parent.ID != parent.ID || child.Parent.Title != "Homelab" {
+		t.Fatalf("expected parent loaded, got %+v", child.Parent)
+	}
+}
+
+func testList(t *testing.T, store api.Store) {
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	older := create(t, store, &models.Task{Title: "older", CreatedAt: base})
+	newer := create(t, store, &models.Task{Title: "newer", CreatedAt: base.Add(time.Minute)})
+	child := create(t, store, &models.Task{Title: "child", ParentID: &older.ID, CreatedAt: base.Add(-time.Minute)})
+
+	tasks, err := store.List(context.Background(), task.ListQuery{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(tasks) != 3 {
+		t.Fatalf("list = %d tasks, want 3", len(tasks))
+	}
+	if tasks[0].ID != newer.ID || tasks[1].ID != older.ID || tasks[2].ID != child.ID {
+		t.Fatalf("expected newest first, got %s, %s, %s", tasks[0].Title, tasks[1].Title, tasks[2].Title)
+	}
+	if len(tasks[1].Children) != 1 || tasks[2].Parent == nil {
+		t.Fatalf("expected relations loaded on list, got %+v", tasks)
+	}
+}
+
+func testListQuery(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	parent := create(t, store, &models.Task{Title: "parent", Priority: models.PriorityLow, CreatedAt: base, ReminderAt: base.Add(2 * time.Hour), DueAt: ptr(base.Add(24 * time.Hour))})
+	urgent := create(t, store, &models.Task{Title: "urgent", Priority: models.PriorityHigh, CreatedAt: base.Add(time.Minute), ReminderAt: base.Add(time.Hour), DueAt: ptr(base.Add(48 * time.Hour))})
+	child := create(t, store, &models.Task{Title: "child", Priority: models.PriorityMedium, ParentID: &parent.ID, CreatedAt: base.Add(2 * time.Minute)})
+	done := create(t, store, &models.Task{Title: "done", Priority: models.PriorityHigh, CreatedAt: base.Add(3 * time.Minute)})
+	if _, err := store.Patch(ctx, done.ID, map[string]any{"status": models.StatusDone, "done": true}); err != nil {
+		t.Fatalf("patch: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		query task.ListQuery
+		want  []string
+	}{
+		{"default newest first", task.ListQuery{}, []string{done.ID, child.ID, urgent.ID, parent.ID}},
+		{"done", task.ListQuery{Done: ptr(true)}, []string{done.ID}},
+		{"not done", task.ListQuery{Done: ptr(false)}, []string{child.ID, urgent.ID, parent.ID}},
+		{"priority", task.ListQuery{Priority: ptr(models.PriorityHigh)}, []string{done.ID, urgent.ID}},
+		{"parent", task.ListQuery{ParentID: &parent.ID}, []string{child.ID}},
+		{"ids", task.ListQuery{IDs: []string{parent.ID, child.ID, missingID}}, []string{child.ID, parent.ID}},
+		{"no ids", task.ListQuery{IDs: []string{}}, []string{}},
+		{"root only", task.ListQuery{RootOnly: true}, []string{done.ID, urgent.ID, parent.ID}},
+		{"reminder range", task.ListQuery{ReminderFrom: ptr(base.Add(time.Hour)), ReminderTo: ptr(base.Add(2 * time.Hour))}, []string{urgent.ID}},
+		{"created range", task.ListQuery{CreatedFrom: ptr(base.Add(time.Minute)), CreatedTo: ptr(base.Add(3 * time.Minute))}, []string{child.ID, urgent.ID}},
+		{"oldest first", task.ListQuery{Ascending: true}, []string{parent.ID, urgent.ID, child.ID, done.ID}},
+		{"reminder ascending", task.ListQuery{Sort: task.SortReminderAt, Ascending: true, Done: ptr(false), RootOnly: true}, []string{urgent.ID, parent.ID}},
+		{"due ascending, undated last", task.ListQuery{Sort: task.SortDueAt, Ascending: true, Done: ptr(false)}, []string{parent.ID, urgent.ID, child.ID}},
+		{"due descending", task.ListQuery{Sort: task.SortDueAt, Done: ptr(false), RootOnly: true}, []string{urgent.ID, parent.ID}},
+		{"limit", task.ListQuery{Limit: 2}, []string{done.ID, child.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := store.List(ctx, tt.query)
+			if err != nil {
+				t.Fatalf("list: %v", err)
+			}
+			got := make([]string, 0, len(tasks))
+			for _, tk := range tasks {
+				got = append(got, tk.Title)
+			}
+			if len(tasks) != len(tt.want) {
+				t.Fatalf("list = %v, want %d tasks", got, len(tt.want))
+			}
+			for i := range tt.want {
+				if tasks[i].ID != tt.want[i] {
+					t.Fatalf("list = %v, wrong order at %d", got, i)
+				}
+			}
+		})
+	}
+
+	// Prioridade ordena por importância, não pelo nome.
+	tasks, err := store.List(ctx, task.ListQuery{Sort: task.SortPriority, Done: ptr(false)})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(tasks) != 3 || tasks[0].ID != urgent.ID || tasks[1].ID != child.ID || tasks[2].ID != parent.ID {
+		t.Fatalf("expected high, medium, low; got %+v", tasks)
+	}
+}
+
+func testListPagination(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	priorities := []models.Priority{models.PriorityLow, models.PriorityMedium, models.PriorityHigh}
+	for i := range 7 {
+		// Dois a dois com o mesmo created_at, para exercitar o desempate por ID.
+		tk := &models.Task{
+			Title:      fmt.Sprintf("task %d", i),
+			Priority:   priorities[i%3],
+			CreatedAt:  base.Add(time.Duration(i/2) * time.Minute),
+			ReminderAt: base.Add(time.Duration(i%4) * time.Hour),
+		}
+		if i%3 != 0 {
+			tk.DueAt = ptr(base.Add(time.Duration(i%2) * 24 * time.Hour))
+		}
+		create(t, store, tk)
+	}
+
+	for _, spec := range []string{"-created_at", "created_at", "-priority", "priority", "reminder_at", "-reminder_at", "due_at", "-due_at"} {
+		t.Run(spec, func(t *testing.T) {
+			key, ascending, err := task.ParseSort(spec)
+			if err != nil {
+				t.Fatalf("parse sort: %v", err)
+			}
+			query := task.ListQuery{Sort: key, Ascending: ascending}
+
+			all, err := store.List(ctx, query)
+			if err != nil {
+				t.Fatalf("list: %v", err)
+			}
+
+			var paged []models.Task
+			query.Limit = 3
+			for range len(all) {
+				page, err := store.List(ctx, query)
+				if err != nil {
+					t.Fatalf("list page: %v", err)
+				}
+				paged = append(paged, page...)
+				if len(page) < query.Limit {
+					break
+				}
+				cursor := query.CursorFor(page[len(page)-1])
+				query.After = &cursor
+			}
+
+			if len(paged) != len(all) {
+				t.Fatalf("paged %d tasks, want %d", len(paged), len(all))
+			}
+			for i := range all {
+				if paged[i].ID != all[i].ID {
+					t.Fatalf("page order differs from full list at %d", i)
+				}
+				if i > 0 && query.Less(all[i], all[i-1]) {
+					t.Fatalf("full list out of order at %d", i)
+				}
+			}
+		})
+	}
+}
+
+func testPatch(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	parent := create(t, store, &models.Task{Title: "parent"})
+	created := create(t, store, &models.Task{Title: "draft", Priority: models.PriorityLow})
+
+	reminderAt := time.Now().Add(2 * time.Hour).Truncate(time.Second)
+	patched, err := store.Patch(ctx, created.ID, map[string]any{
+		"title":        "final",
+		"done":         true,
+		"priority":     models.PriorityHigh,
+		"reminder_at":  reminderAt,
+		"parent_id":    parent.ID,
+		"channels":     models.ChannelList{"gotify"},
+		"snooze_count": 2,
+	})
+	if err != nil {
+		t.Fatalf("patch: %v", err)
+	}
+	if patched == nil {
+		t.Fatalf("expected patched task")
+	}
+	if patched.Title != "final" || !patched.Done || patched.Priority != models.PriorityHigh || patched.SnoozeCount != 2 {
+		t.Fatalf("unexpected patched task: %+v", patched)
+	}
+	if !patched.ReminderAt.Equal(reminderAt) {
+		t.Fatalf("reminder_at = %v, want %v", patched.ReminderAt, reminderAt)
+	}
+	if patched.ParentID == nil || *patched.ParentID != parent.ID || patched.Parent == nil {
+		t.Fatalf("expected parent set and loaded, got %+v", patched)
+	}
+	if len(patched.Channels) != 1 || patched.Channels[0] != "gotify" {
+		t.Fatalf("channels = %v, want [gotify]", patched.Channels)
+	}
+
+	got := get(t, store, created.ID)
+	if got.Title != "final" || !got.Done {
+		t.Fatalf("patch not persisted: %+v", got)
+	}
+	if got := get(t, store, parent.ID); len(got.Children) != 1 {
+		t.Fatalf("expected parent to see the moved child, got %+v", got.Children)
+	}
+}
+
+func testSoftDelete(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	parent := create(t, store, &models.Task{Title: "parent"})
+	child := create(t, store, &models.Task{Title: "child", ParentID: &parent.ID})
+	kept := create(t, store, &models.Task{Title: "kept", ParentID: &parent.ID})
+
+	if err := store.Delete(ctx, child.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	if got := get(t, store, child.ID); got != nil {
+		t.Fatalf("deleted task still visible: %+v", got)
+	}
+	patched, err := store.Patch(ctx, child.ID, map[string]any{"title": "ghost"})
+	if err != nil || patched != nil {
+		t.Fatalf("patch deleted = %+v, %v; want nil, nil", patched, err)
+	}
+
+	got := get(t, store, parent.ID)
+	if len(got.Children) != 1 || got.Children[0].ID != kept.ID {
+		t.Fatalf("deleted child still loaded: %+v", got.Children)
+	}
+
+	tasks, err := store.List(ctx, task.ListQuery{})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	for _, tk := range tasks {
+		if tk.ID == child.ID {
+			t.Fatalf("deleted task listed")
+		}
+	}
+
+	if err := store.Delete(ctx, parent.ID); err != nil {
+		t.Fatalf("delete parent: %v", err)
+	}
+	if got := get(t, store, kept.ID); got == nil || got.Parent != nil {
+		t.Fatalf("expected child without loaded parent after parent delete, got %+v", got)
+	}
+}
+
+func testListDue(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	high := models.PriorityHigh
+
+	past := create(t, store, &models.Task{Title: "past", ReminderAt: now.Add(-2 * time.Hour)})
+	recent := create(t, store, &models.Task{Title: "recent", ReminderAt: now.Add(-time.Minute), Priority: high})
+	create(t, store, &models.Task{Title: "future", ReminderAt: now.Add(time.Hour)})
+	create(t, store, &models.Task{Title: "no reminder"})
+	done := create(t, store, &models.Task{Title: "done", ReminderAt: now.Add(-time.Hour), Done: true})
+
+	tests := []struct {
+		name   string
+		filter task.DueFilter
+		want   []string
+	}{
+		{"open before now", task.DueFilter{Before: now}, []string{past.ID, recent.ID}},
+		{"include done", task.DueFilter{Before: now, IncludeDone: true}, []string{past.ID, done.ID, recent.ID}},
+		{"after window", task.DueFilter{Before: now, After: ptr(now.Add(-30 * time.Minute))}, []string{recent.ID}},
+		{"priority", task.DueFilter{Before: now, Priority: &high}, []string{recent.ID}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasks, err := store.ListDue(ctx, tt.filter)
+			if err != nil {
+				t.Fatalf("list due: %v", err)
+			}
+			if len(tasks) != len(tt.want) {
+				t.Fatalf("got %d tasks, want %d: %+v", len(tasks), len(tt.want), tasks)
+			}
+			for i, id := range tt.want {
+				if tasks[i].ID != id {
+					t.Fatalf("position %d = %s, want %s", i, tasks[i].Title, id)
+				}
+			}
+		})
+	}
+}
+
+func testReminders(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	owner := create(t, store, &models.Task{Title: "owner"})
+	other := create(t, store, &models.Task{Title: "other"})
+
+	late := &models.Reminder{TaskID: owner.ID, Offset: "0", FireAt: now.Add(time.Hour)}
+	early := &models.Reminder{TaskID: owner.ID, At: ptr(now.Add(time.Minute)), FireAt: now.Add(time.Minute), Channel: "email"}
+	for _, r := range []*models.Reminder{late, early} {
+		if err := store.CreateReminder(ctx, r); err != nil {
+			t.Fatalf("create reminder: %v", err)
+		}
+		if r.ID == "" {
+			t.Fatalf("expected generated reminder id")
+		}
+	}
+
+	reminders, err := store.ListReminders(ctx, owner.ID)
+	if err != nil {
+		t.Fatalf("list reminders: %v", err)
+	}
+	if len(reminders) != 2 || reminders[0].ID != early.ID || reminders[1].ID != late.ID {
+		t.Fatalf("expected reminders ordered by fire_at, got %+v", reminders)
+	}
+	if reminders[0].At == nil || !reminders[0].At.Equal(*early.At) || reminders[0].Channel != "email" {
+		t.Fatalf("unexpected absolute reminder: %+v", reminders[0])
+	}
+
+	if got, err := store.GetReminder(ctx, owner.ID, late.ID); err != nil || got == nil || got.Offset != "0" {
+		t.Fatalf("get reminder = %+v, %v", got, err)
+	}
+	if got, err := store.GetReminder(ctx, other.ID, late.ID); err != nil || got != nil {
+		t.Fatalf("reminder of another task = %+v, %v; want nil, nil", got, err)
+	}
+
+	fireAt := now.Add(3 * time.Hour)
+	patched, err := store.PatchReminder(ctx, late.ID, map[string]any{"fire_at": fireAt, "channel": "ntfy"})
+	if err != nil || patched == nil {
+		t.Fatalf("patch reminder = %+v, %v", patched, err)
+	}
+	if !patched.FireAt.Equal(fireAt) || patched.Channel != "ntfy" {
+		t.Fatalf("unexpected patched reminder: %+v", patched)
+	}
+
+	if err := store.DeleteReminder(ctx, early.ID); err != nil {
+		t.Fatalf("delete reminder: %v", err)
+	}
+	reminders, err = store.ListReminders(ctx, owner.ID)
+	if err != nil || len(reminders) != 1 || reminders[0].ID != late.ID {
+		t.Fatalf("after delete = %+v, %v", reminders, err)
+	}
+
+	if got := get(t, store, owner.ID); len(got.Reminders) != 1 {
+		t.Fatalf("expected reminders loaded on get, got %+v", got.Reminders)
+	}
+}
+
+func testPendingReminders(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+
+	open := create(t, store, &models.Task{Title: "open"})
+	done := create(t, store, &models.Task{Title: "done", Done: true})
+	deleted := create(t, store, &models.Task{Title: "deleted"})
+
+	due := &models.Reminder{TaskID: open.ID, Offset: "0", FireAt: now.Add(-time.Minute)}
+	reminders := []*models.Reminder{
+		due,
+		{TaskID: open.ID, Offset: "1h", FireAt: now.Add(time.Hour)},
+		{TaskID: done.ID, Offset: "0", FireAt: now.Add(-time.Minute)},
+		{TaskID: deleted.ID, Offset: "0", FireAt: now.Add(-time.Minute)},
+	}
+	for _, r := range reminders {
+		if err := store.CreateReminder(ctx, r); err != nil {
+			t.Fatalf("create reminder: %v", err)
+		}
+	}
+	if err := store.Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	pending, err := store.PendingReminders(ctx, now)
+	if err != nil {
+		t.Fatalf("pending: %v", err)
+	}
+	if len(pending) != 1 || pending[0].ID != due.ID {
+		t.Fatalf("expected only the due reminder of the open task, got %+v", pending)
+	}
+	if pending[0].Task == nil || pending[0].Task.ID != open.ID {
+		t.Fatalf("expected task loaded, got %+v", pending[0].Task)
+	}
+
+	claimed, err := store.MarkReminderSent(ctx, due.ID, now)
+	if err != nil || !claimed {
+		t.Fatalf("first claim = %v, %v; want true", claimed, err)
+	}
+	claimed, err = store.MarkReminderSent(ctx, due.ID, now)
+	if err != nil || claimed {
+		t.Fatalf("second claim = %v, %v; want false", claimed, err)
+	}
+
+	pending, err = store.PendingReminders(ctx, now)
+	if err != nil || len(pending) != 0 {
+		t.Fatalf("after claim = %+v, %v; want none", pending, err)
+	}
+
+	retryAt := now.Add(time.Minute)
+	if err := store.RecordReminderFailure(ctx, due.ID, models.ChannelList{"ntfy"}, "ntfy: fora do ar", &retryAt); err != nil {
+		t.Fatalf("record failure: %v", err)
+	}
+	if pending, err = store.PendingReminders(ctx, now); err != nil || len(pending) != 0 {
+		t.Fatalf("before retry = %+v, %v; want none", pending, err)
+	}
+	pending, err = store.PendingReminders(ctx, retryAt)
+	if err != nil || len(pending) != 1 || pending[0].ID != due.ID {
+		t.Fatalf("at retry = %+v, %v; want the failed reminder", pending, err)
+	}
+	if r := pending[0]; r.Attempts != 1 || len(r.RetryChannels) != 1 || r.RetryChannels[0] != "ntfy" || r.LastError != "ntfy: fora do ar" {
+		t.Fatalf("failure not recorded: %+v", r)
+	}
+
+	if claimed, err := store.MarkReminderSent(ctx, due.ID, retryAt); err != nil || !claimed {
+		t.Fatalf("claim retry = %v, %v; want true", claimed, err)
+	}
+	if err := store.RecordReminderFailure(ctx, due.ID, models.ChannelList{"ntfy"}, "desistiu", nil); err != nil {
+		t.Fatalf("record final failure: %v", err)
+	}
+	if pending, err = store.PendingReminders(ctx, retryAt.Add(time.Minute)); err != nil || len(pending) != 0 {
+		t.Fatalf("after giving up = %+v, %v; want none", pending, err)
+	}
+}
+
+func testVersioning(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	created := create(t, store, &models.Task{Title: "v1"})
+
+	got := get(t, store, created.ID)
+	if got.Version != 1 {
+		t.Fatalf("version after create = %d, want 1", got.Version)
+	}
+
+	patched, err := store.Patch(ctx, created.ID, map[string]any{"title": "v2"})
+	if err != nil || patched == nil || patched.Version != 2 {
+		t.Fatalf("patch = %+v, %v; want version 2", patched, err)
+	}
+
+	patched, err = store.PatchIfVersion(ctx, created.ID, 2, map[string]any{"title": "v3"})
+	if err != nil || patched == nil || patched.Version != 3 || patched.Title != "v3" {
+		t.Fatalf("patch if version = %+v, %v; want version 3", patched, err)
+	}
+
+	stale, err := store.PatchIfVersion(ctx, created.ID, 2, map[string]any{"title": "stale"})
+	if !errors.Is(err, task.ErrVersionConflict) || stale != nil {
+		t.Fatalf("stale patch = %+v, %v; want ErrVersionConflict", stale, err)
+	}
+	if got := get(t, store, created.ID); got.Title != "v3" || got.Version != 3 {
+		t.Fatalf("stale patch applied: %+v", got)
+	}
+
+	missing, err := store.PatchIfVersion(ctx, missingID, 1, map[string]any{"title": "ghost"})
+	if err != nil || missing != nil {
+		t.Fatalf("patch missing = %+v, %v; want nil, nil", missing, err)
+	}
+
+	if err := store.DeleteIfVersion(ctx, created.ID, 1); !errors.Is(err, task.ErrVersionConflict) {
+		t.Fatalf("stale delete = %v, want ErrVersionConflict", err)
+	}
+	if got := get(t, store, created.ID); got == nil {
+		t.Fatalf("stale delete removed the task")
+	}
+	if err := store.DeleteIfVersion(ctx, created.ID, 3); err != nil {
+		t.Fatalf("delete if version: %v", err)
+	}
+	if got := get(t, store, created.ID); got != nil {
+		t.Fatalf("task not deleted: %+v", got)
+	}
+	if err := store.DeleteIfVersion(ctx, missingID, 1); err != nil {
+		t.Fatalf("delete missing = %v, want nil", err)
+	}
+}
+
+func trashIDs(t *testing.T, store api.Store) map[string]bool {
+	t.Helper()
+	deleted, err := store.ListDeleted(context.Background())
+	if err != nil {
+		t.Fatalf("list deleted: %v", err)
+	}
+	ids := map[string]bool{}
+	for _, tk := range deleted {
+		if !tk.DeletedAt.Valid {
+			t.Fatalf("trash entry without deleted_at: %+v", tk)
+		}
+		ids[tk.ID] = true
+	}
+	return ids
+}
+
+func testTrash(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	parent := create(t, store, &models.Task{Title: "parent"})
+	child := create(t, store, &models.Task{Title: "child", ParentID: &parent.ID})
+	live := create(t, store, &models.Task{Title: "live"})
+
+	if ids := trashIDs(t, store); len(ids) != 0 {
+		t.Fatalf("expected empty trash, got %v", ids)
+	}
+
+	for _, id := range []string{child.ID, parent.ID} {
+		if err := store.Delete(ctx, id); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+	}
+	ids := trashIDs(t, store)
+	if len(ids) != 2 || !ids[parent.ID] || !ids[child.ID] || ids[live.ID] {
+		t.Fatalf("unexpected trash: %v", ids)
+	}
+
+	restored, err := store.Restore(ctx, parent.ID)
+	if err != nil || restored == nil {
+		t.Fatalf("restore = %+v, %v", restored, err)
+	}
+	if restored.DeletedAt.Valid || len(restored.Children) != 0 {
+		t.Fatalf("expected restored parent without the deleted child, got %+v", restored)
+	}
+	if got := get(t, store, parent.ID); got == nil {
+		t.Fatalf("restored task not visible")
+	}
+
+	if restored, err := store.Restore(ctx, live.ID); err != nil || restored != nil {
+		t.Fatalf("restore live task = %+v, %v; want nil, nil", restored, err)
+	}
+	if restored, err := store.Restore(ctx, missingID); err != nil || restored != nil {
+		t.Fatalf("restore missing = %+v, %v; want nil, nil", restored, err)
+	}
+
+	if _, err := store.Restore(ctx, child.ID); err != nil {
+		t.Fatalf("restore child: %v", err)
+	}
+	if got := get(t, store, parent.ID); len(got.Children) != 1 {
+		t.Fatalf("expected restored child loaded, got %+v", got.Children)
+	}
+}
+
+func testPurge(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	now := time.Now()
+	parent := create(t, store, &models.Task{Title: "parent"})
+	child := create(t, store, &models.Task{Title: "child", ParentID: &parent.ID})
+	old := create(t, store, &models.Task{Title: "old"})
+	live := create(t, store, &models.Task{Title: "live"})
+	if err := store.CreateReminder(ctx, &models.Reminder{TaskID: parent.ID, Offset: "0", FireAt: now}); err != nil {
+		t.Fatalf("create reminder: %v", err)
+	}
+
+	if purged, err := store.Purge(ctx, live.ID); err != nil || purged {
+		t.Fatalf("purge live task = %v, %v; want false, nil", purged, err)
+	}
+	if got := get(t, store, live.ID); got == nil {
+		t.Fatalf("live task purged")
+	}
+
+	for _, id := range []string{parent.ID, old.ID} {
+		if err := store.Delete(ctx, id); err != nil {
+			t.Fatalf("delete: %v", err)
+		}
+	}
+
+	purged, err := store.Purge(ctx, parent.ID)
+	if err != nil || !purged {
+		t.Fatalf("purge = %v, %v; want true, nil", purged, err)
+	}
+	if ids := trashIDs(t, store); ids[parent.ID] {
+		t.Fatalf("purged task still in trash")
+	}
+	if reminders, err := store.ListReminders(ctx, parent.ID); err != nil || len(reminders) != 0 {
+		t.Fatalf("reminders of purged task = %+v, %v; want none", reminders, err)
+	}
+	got := get(t, store, child.ID)
+	if got == nil || got.ParentID != nil {
+		t.Fatalf("expected child detached from purged parent, got %+v", got)
+	}
+
+	if n, err := store.PurgeDeletedBefore(ctx, now.Add(-time.Hour)); err != nil || n != 0 {
+		t.Fatalf("purge before an hour ago = %d, %v; want 0", n, err)
+	}
+	if n, err := store.PurgeDeletedBefore(ctx, time.Now().Add(time.Minute)); err != nil || n != 1 {
+		t.Fatalf("purge before now = %d, %v; want 1", n, err)
+	}
+	if ids := trashIDs(t, store); len(ids) != 0 {
+		t.Fatalf("expected empty trash, got %v", ids)
+	}
+}
+
+func testHistory(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	tk := create(t, store, &models.Task{Title: "audited"})
+	other := create(t, store, &models.Task{Title: "other"})
+	at := time.Date(2025, 6, 1, 12, 0, 0, 0, time.FixedZone("BRT", -3*60*60))
+
+	batches := [][]models.TaskChange{
+		{
+			{TaskID: tk.ID, Action: models.HistoryCreate, Field: "title", After: `"audited"`, Source: "cli", CreatedAt: at},
+			{TaskID: tk.ID, Action: models.HistoryCreate, Field: "priority", After: `"medium"`, Source: "cli", CreatedAt: at},
+		},
+		{
+			{TaskID: other.ID, Action: models.HistoryCreate, Field: "title", After: `"other"`, Source: "cli", CreatedAt: at},
+		},
+		{
+			{TaskID: tk.ID, Action: models.HistoryUpdate, Field: "priority", Before: `"medium"`, After: `"high"`, Source: "api:req-1", CreatedAt: at.Add(time.Minute)},
+		},
+	}
+	for _, batch := range batches {
+		if err := store.AppendHistory(ctx, batch); err != nil {
+			t.Fatalf("append history: %v", err)
+		}
+	}
+
+	if err := store.Delete(ctx, tk.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if _, err := store.Purge(ctx, tk.ID); err != nil {
+		t.Fatalf("purge: %v", err)
+	}
+
+	changes, err := store.ListHistory(ctx, tk.ID)
+	if err != nil {
+		t.Fatalf("list history: %v", err)
+	}
+	if len(changes) != 3 {
+		t.Fatalf("expected 3 changes kept after purge, got %+v", changes)
+	}
+	wantFields := []string{"title", "priority", "priority"}
+	for i, change := range changes {
+		if change.ID == 0 || change.Field != wantFields[i] {
+			t.Fatalf("change %d = %+v, want field %s with id", i, change, wantFields[i])
+		}
+	}
+	last := changes[2]
+	if last.Before != `"medium"` || last.After != `"high"` || last.Source != "api:req-1" || !last.CreatedAt.Equal(at.Add(time.Minute)) {
+		t.Fatalf("unexpected change %+v", last)
+	}
+	if changes[0].Before != "" {
+		t.Fatalf("expected empty before on create, got %q", changes[0].Before)
+	}
+
+	if changes, err := store.ListHistory(ctx, missingID); err != nil || len(changes) != 0 {
+		t.Fatalf("history of missing task = %+v, %v; want none", changes, err)
+	}
+}
+
+func testSearch(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	inTitle := create(t, store, &models.Task{Title: "Comprar café", Description: "Passar no mercado"})
+	inDescription := create(t, store, &models.Task{Title: "Reunião de equipe", Description: "Levar café para a reunião"})
+	deleted := create(t, store, &models.Task{Title: "Café antigo"})
+	create(t, store, &models.Task{Title: "Trocar lâmpada"})
+	if err := store.Delete(ctx, deleted.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	results, err := store.Search(ctx, "café", task.SearchFilter{}, 10)
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results, got %+v", results)
+	}
+	if results[0].ID != inTitle.ID || results[1].ID != inDescription.ID {
+		t.Fatalf("expected title hit ranked first, got %s, %s", results[0].Title, results[1].Title)
+	}
+	for _, r := range results {
+		if r.Rank <= 0 || !strings.Contains(r.Snippet, task.HighlightStart) {
+			t.Fatalf("expected rank and highlighted snippet, got %+v", r)
+		}
+	}
+
+	if results, err := store.Search(ctx, "café", task.SearchFilter{}, 1); err != nil || len(results) != 1 {
+		t.Fatalf("search with limit = %+v, %v; want 1 result", results, err)
+	}
+	if results, err := store.Search(ctx, "xyzzy", task.SearchFilter{}, 10); err != nil || results == nil || len(results) != 0 {
+		t.Fatalf("search without hits = %#v, %v; want empty slice", results, err)
+	}
+
+	// O snippet só tem as tags dos marcadores; o resto vem escapado.
+	create(t, store, &models.Task{Title: "Consertar portão", Description: "<script>alert(1)</script> & trocar a fechadura"})
+	results, err = store.Search(ctx, "fechadura", task.SearchFilter{}, 10)
+	if err != nil || len(results) != 1 {
+		t.Fatalf("search fechadura = %+v, %v; want 1 result", results, err)
+	}
+	snippet := results[0].Snippet
+	if strings.Contains(snippet, "<script>") || !strings.Contains(snippet, "&lt;script&gt;") || !strings.Contains(snippet, "&amp;") {
+		t.Fatalf("snippet %q should escape the task text", snippet)
+	}
+	if !strings.Contains(snippet, task.HighlightStart+"fechadura"+task.HighlightStop) {
+		t.Fatalf("snippet %q should highlight the term", snippet)
+	}
+}
+
+func testTree(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	base := time.Now().Add(-time.Hour).Truncate(time.Second)
+	root := create(t, store, &models.Task{Title: "root", CreatedAt: base})
+	a := create(t, store, &models.Task{Title: "a", ParentID: &root.ID, CreatedAt: base.Add(time.Minute)})
+	b := create(t, store, &models.Task{Title: "b", ParentID: &root.ID, CreatedAt: base.Add(2 * time.Minute)})
+	a1 := create(t, store, &models.Task{Title: "a1", ParentID: &a.ID, CreatedAt: base.Add(3 * time.Minute)})
+	a1x := create(t, store, &models.Task{Title: "a1x", ParentID: &a1.ID, CreatedAt: base.Add(4 * time.Minute)})
+	gone := create(t, store, &models.Task{Title: "gone", ParentID: &root.ID, CreatedAt: base.Add(5 * time.Minute)})
+	if err := store.Delete(ctx, gone.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	tree, err := store.GetTree(ctx, root.ID, 10)
+	if err != nil {
+		t.Fatalf("get tree: %v", err)
+	}
+	if tree == nil || len(tree.Children) != 2 || tree.Children[0].ID != a.ID || tree.Children[1].ID != b.ID {
+		t.Fatalf("expected root with children a, b; got %+v", tree)
+	}
+	branch := tree.Children[0]
+	if len(branch.Children) != 1 || branch.Children[0].ID != a1.ID ||
+		len(branch.Children[0].Children) != 1 || branch.Children[0].Children[0].ID != a1x.ID {
+		t.Fatalf("expected a -> a1 -> a1x, got %+v", branch)
+	}
+
+	shallow, err := store.GetTree(ctx, root.ID, 1)
+	if err != nil {
+		t.Fatalf("get shallow tree: %v", err)
+	}
+	if len(shallow.Children) != 2 || len(shallow.Children[0].Children) != 0 {
+		t.Fatalf("expected only one level, got %+v", shallow)
+	}
+	if missing, err := store.GetTree(ctx, missingID, 10); err != nil || missing != nil {
+		t.Fatalf("tree of missing task = %+v, %v; want nil", missing, err)
+	}
+
+	path, err := store.Ancestors(ctx, a1x.ID)
+	if err != nil {
+		t.Fatalf("ancestors: %v", err)
+	}
+	if len(path) != 3 || path[0].ID != root.ID || path[1].ID != a.ID || path[2].ID != a1.ID {
+		t.Fatalf("expected root, a, a1; got %+v", path)
+	}
+	if path, err := store.Ancestors(ctx, root.ID); err != nil || len(path) != 0 {
+		t.Fatalf("ancestors of root = %+v, %v; want none", path, err)
+	}
+
+	// Ciclos vindos de dados antigos não podem travar as consultas.
+	x := create(t, store, &models.Task{Title: "x"})
+	y := create(t, store, &models.Task{Title: "y", ParentID: &x.ID})
+	if _, err := store.Patch(ctx, x.ID, map[string]any{"parent_id": y.ID}); err != nil {
+		t.Fatalf("patch: %v", err)
+	}
+	if tree, err := store.GetTree(ctx, x.ID, 10); err != nil || tree == nil || len(tree.Children) != 1 || len(tree.Children[0].Children) != 0 {
+		t.Fatalf("cyclic tree = %+v, %v", tree, err)
+	}
+	if path, err := store.Ancestors(ctx, x.ID); err != nil || len(path) != 1 || path[0].ID != y.ID {
+		t.Fatalf("cyclic ancestors = %+v, %v", path, err)
+	}
+
+	// parent_id nil desvincula a tarefa, levando as subtarefas junto.
+	detached, err := store.Patch(ctx, a.ID, map[string]any{"parent_id": nil})
+	if err != nil {
+		t.Fatalf("detach: %v", err)
+	}
+	if detached == nil || detached.ParentID != nil {
+		t.Fatalf("expected a detached, got %+v", detached)
+	}
+	if path, err := store.Ancestors(ctx, a1x.ID); err != nil || len(path) != 2 || path[0].ID != a.ID {
+		t.Fatalf("ancestors after detach = %+v, %v", path, err)
+	}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
+
+func testProgress(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	root := create(t, store, &models.Task{Title: "root"})
+	a := create(t, store, &models.Task{Title: "a", ParentID: &root.ID, Done: true})
+	create(t, store, &models.Task{Title: "b", ParentID: &root.ID})
+	create(t, store, &models.Task{Title: "a1", ParentID: &a.ID, Done: true})
+	create(t, store, &models.Task{Title: "a2", ParentID: &a.ID})
+	gone := create(t, store, &models.Task{Title: "gone", ParentID: &root.ID})
+	if err := store.Delete(ctx, gone.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	leaf := create(t, store, &models.Task{Title: "leaf"})
+
+	progress, err := store.Progress(ctx, []string{root.ID, a.ID, leaf.ID, missingID}, 10)
+	if err != nil {
+		t.Fatalf("progress: %v", err)
+	}
+	if got := progress[root.ID]; got != (models.Progress{Done: 2, Total: 4}) {
+		t.Fatalf("root progress = %+v, want 2/4", got)
+	}
+	if got := progress[a.ID]; got != (models.Progress{Done: 1, Total: 2}) {
+		t.Fatalf("a progress = %+v, want 1/2", got)
+	}
+	if _, ok := progress[leaf.ID]; ok {
+		t.Fatalf("expected no progress for a task without subtasks")
+	}
+	if _, ok := progress[missingID]; ok {
+		t.Fatalf("expected no progress for a missing task")
+	}
+
+	shallow, err := store.Progress(ctx, []string{root.ID}, 1)
+	if err != nil {
+		t.Fatalf("shallow progress: %v", err)
+	}
+	if got := shallow[root.ID]; got != (models.Progress{Done: 1, Total: 2}) {
+		t.Fatalf("progress one level deep = %+v, want 1/2", got)
+	}
+
+	if none, err := store.Progress(ctx, nil, 10); err != nil || len(none) != 0 {
+		t.Fatalf("progress of no tasks = %+v, %v", none, err)
+	}
+}
+
+func testProgressSkipsCancelled(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	root := create(t, store, &models.Task{Title: "root"})
+	create(t, store, &models.Task{Title: "feita", ParentID: &root.ID, Status: models.StatusDone})
+	create(t, store, &models.Task{Title: "aberta", ParentID: &root.ID})
+	cancelled := create(t, store, &models.Task{Title: "cancelada", ParentID: &root.ID, Status: models.StatusCancelled})
+	create(t, store, &models.Task{Title: "abaixo da cancelada", ParentID: &cancelled.ID, Status: models.StatusDone})
+	onlyCancelled := create(t, store, &models.Task{Title: "só canceladas"})
+	create(t, store, &models.Task{Title: "cancelada", ParentID: &onlyCancelled.ID, Status: models.StatusCancelled})
+
+	progress, err := store.Progress(ctx, []string{root.ID, onlyCancelled.ID}, 10)
+	if err != nil {
+		t.Fatalf("progress: %v", err)
+	}
+	if got := progress[root.ID]; got != (models.Progress{Done: 2, Total: 3}) {
+		t.Fatalf("root progress = %+v, want 2/3 without the cancelled subtask", got)
+	}
+	if got, ok := progress[onlyCancelled.ID]; ok {
+		t.Fatalf("progress with only cancelled subtasks = %+v, want none", got)
+	}
+}
+
+func createTag(t *testing.T, store api.Store, name string) *models.Tag {
+	t.Helper()
+	tag := &models.Tag{Name: name}
+	if err := store.CreateTag(context.Background(), tag); err != nil {
+		t.Fatalf("create tag %q: %v", name, err)
+	}
+	if tag.ID == "" {
+		t.Fatalf("create tag %q: expected generated id", name)
+	}
+	return tag
+}
+
+func tagNames(tags []models.Tag) string {
+	names := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		names = append(names, tag.Name)
+	}
+	return strings.Join(names, ",")
+}
+
+// sameIDs compara os IDs ignorando a ordem.
+func sameIDs(got, want []string) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	seen := make(map[string]bool, len(got))
+	for _, id := range got {
+		seen[id] = true
+	}
+	for _, id := range want {
+		if !seen[id] {
+			return false
+		}
+	}
+	return true
+}
+
+func testTags(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	work := createTag(t, store, "work")
+	home := createTag(t, store, "home")
+	both := create(t, store, &models.Task{Title: "café no escritório"})
+	onlyWork := create(t, store, &models.Task{Title: "café com a equipe"})
+	untagged := create(t, store, &models.Task{Title: "café em casa"})
+
+	attach := []struct{ task, tag string }{
+		{both.ID, work.ID}, {both.ID, home.ID}, {onlyWork.ID, work.ID}, {onlyWork.ID, work.ID},
+	}
+	for _, a := range attach {
+		if err := store.AttachTag(ctx, a.task, a.tag); err != nil {
+			t.Fatalf("attach: %v", err)
+		}
+	}
+
+	if got := get(t, store, both.ID); tagNames(got.Tags) != "home,work" {
+		t.Fatalf("tags = %q, want home,work", tagNames(got.Tags))
+	}
+	if got := get(t, store, onlyWork.ID); tagNames(got.Tags) != "work" {
+		t.Fatalf("attaching twice: tags = %q, want work", tagNames(got.Tags))
+	}
+
+	if tags, err := store.ListTags(ctx); err != nil || tagNames(tags) != "home,work" {
+		t.Fatalf("list tags = %q, %v", tagNames(tags), err)
+	}
+	if tag, err := store.GetTagByName(ctx, "work"); err != nil || tag == nil || tag.ID != work.ID {
+		t.Fatalf("get by name = %+v, %v", tag, err)
+	}
+	if tag, err := store.GetTagByName(ctx, "nope"); err != nil || tag != nil {
+		t.Fatalf("get missing by name = %+v, %v; want nil, nil", tag, err)
+	}
+	if tag, err := store.GetTag(ctx, missingID); err != nil || tag != nil {
+		t.Fatalf("get missing = %+v, %v; want nil, nil", tag, err)
+	}
+
+	filters := []struct {
+		name   string
+		filter task.TagFilter
+		want   []string
+	}{
+		{"include", task.TagFilter{Include: []string{"work"}}, []string{both.ID, onlyWork.ID}},
+		{"include all", task.TagFilter{Include: []string{"work", "home"}}, []string{both.ID}},
+		{"exclude", task.TagFilter{Exclude: []string{"home"}}, []string{onlyWork.ID, untagged.ID}},
+		{"include and exclude", task.TagFilter{Include: []string{"work"}, Exclude: []string{"home"}}, []string{onlyWork.ID}},
+		{"unknown tag", task.TagFilter{Include: []string{"nope"}}, nil},
+	}
+	for _, f := range filters {
+		t.Run("list "+f.name, func(t *testing.T) {
+			tasks, err := store.List(ctx, task.ListQuery{Tags: f.filter})
+			if err != nil {
+				t.Fatalf("list: %v", err)
+			}
+			var ids []string
+			for _, tk := range tasks {
+				ids = append(ids, tk.ID)
+			}
+			if !sameIDs(ids, f.want) {
+				t.Fatalf("list = %v, want %v", ids, f.want)
+			}
+		})
+		t.Run("search "+f.name, func(t *testing.T) {
+			results, err := store.Search(ctx, "café", task.SearchFilter{Tags: f.filter}, 10)
+			if err != nil {
+				t.Fatalf("search: %v", err)
+			}
+			var ids []string
+			for _, r := range results {
+				ids = append(ids, r.ID)
+			}
+			if !sameIDs(ids, f.want) {
+				t.Fatalf("search = %v, want %v", ids, f.want)
+			}
+		})
+	}
+
+	detached, err := store.DetachTag(ctx, both.ID, home.ID)
+	if err != nil || !detached {
+		t.Fatalf("detach = %v, %v; want true, nil", detached, err)
+	}
+	if detached, err := store.DetachTag(ctx, both.ID, home.ID); err != nil || detached {
+		t.Fatalf("detach twice = %v, %v; want false, nil", detached, err)
+	}
+	if got := get(t, store, both.ID); tagNames(got.Tags) != "work" {
+		t.Fatalf("tags after detach = %q, want work", tagNames(got.Tags))
+	}
+
+	renamed, err := store.PatchTag(ctx, work.ID, map[string]any{"name": "job", "color": "blue"})
+	if err != nil || renamed == nil || renamed.Name != "job" || renamed.Color != "blue" {
+		t.Fatalf("patch tag = %+v, %v", renamed, err)
+	}
+	if got := get(t, store, onlyWork.ID); tagNames(got.Tags) != "job" {
+		t.Fatalf("tags after rename = %q, want job", tagNames(got.Tags))
+	}
+	if tag, err := store.PatchTag(ctx, missingID, map[string]any{"color": "red"}); err != nil || tag != nil {
+		t.Fatalf("patch missing tag = %+v, %v; want nil, nil", tag, err)
+	}
+
+	deleted, err := store.DeleteTag(ctx, work.ID)
+	if err != nil || !deleted {
+		t.Fatalf("delete tag = %v, %v; want true, nil", deleted, err)
+	}
+	if deleted, err := store.DeleteTag(ctx, work.ID); err != nil || deleted {
+		t.Fatalf("delete tag twice = %v, %v; want false, nil", deleted, err)
+	}
+	if got := get(t, store, both.ID); len(got.Tags) != 0 {
+		t.Fatalf("expected deleted tag gone from task, got %q", tagNames(got.Tags))
+	}
+
+	if err := store.AttachTag(ctx, untagged.ID, home.ID); err != nil {
+		t.Fatalf("attach: %v", err)
+	}
+	if err := store.Delete(ctx, untagged.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if purged, err := store.Purge(ctx, untagged.ID); err != nil || !purged {
+		t.Fatalf("purge = %v, %v", purged, err)
+	}
+	if tasks, err := store.List(ctx, task.ListQuery{Tags: task.TagFilter{Include: []string{"home"}}}); err != nil || len(tasks) != 0 {
+		t.Fatalf("purged task still tagged: %+v, %v", tasks, err)
+	}
+}
+
+func testProjects(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	home := &models.Project{Name: "casa", DefaultPriority: models.PriorityLow, DefaultReminderOffset: "1d"}
+	work := &models.Project{Name: "trabalho"}
+	old := &models.Project{Name: "antigo", Archived: true}
+	for _, p := range []*models.Project{home, work, old} {
+		if err := store.CreateProject(ctx, p); err != nil {
+			t.Fatalf("create project %s: %v", p.Name, err)
+		}
+		if p.ID == "" {
+			t.Fatalf("create project %s: expected generated id", p.Name)
+		}
+	}
+
+	got, err := store.GetProject(ctx, home.ID)
+	if err != nil || got == nil || got.DefaultPriority != models.PriorityLow || got.DefaultReminderOffset != "1d" {
+		t.Fatalf("get project = %+v, %v", got, err)
+	}
+	if got, err := store.GetProjectByName(ctx, "trabalho"); err != nil || got == nil || got.ID != work.ID {
+		t.Fatalf("get project by name = %+v, %v", got, err)
+	}
+	if got, err := store.GetProject(ctx, missingID); err != nil || got != nil {
+		t.Fatalf("get missing project = %+v, %v; want nil, nil", got, err)
+	}
+
+	active, err := store.ListProjects(ctx, false)
+	if err != nil || len(active) != 2 || active[0].ID != home.ID || active[1].ID != work.ID {
+		t.Fatalf("list active projects = %+v, %v", active, err)
+	}
+	if all, err := store.ListProjects(ctx, true); err != nil || len(all) != 3 || all[0].ID != old.ID {
+		t.Fatalf("list all projects = %+v, %v", all, err)
+	}
+
+	dishes := create(t, store, &models.Task{Title: "louça", ProjectID: &home.ID})
+	create(t, store, &models.Task{Title: "lixo", ProjectID: &home.ID, Done: true})
+	gone := create(t, store, &models.Task{Title: "apagada", ProjectID: &home.ID})
+	report := create(t, store, &models.Task{Title: "relatório", ProjectID: &work.ID})
+	loose := create(t, store, &models.Task{Title: "solta"})
+	if err := store.Delete(ctx, gone.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+
+	counts, err := store.ProjectCounts(ctx, []string{home.ID, work.ID, old.ID})
+	if err != nil {
+		t.Fatalf("project counts: %v", err)
+	}
+	if got := counts[home.ID]; got != (models.ProjectCounts{Total: 2, Open: 1, Done: 1}) {
+		t.Fatalf("home counts = %+v, want 2 total, 1 open, 1 done", got)
+	}
+	if got := counts[work.ID]; got != (models.ProjectCounts{Total: 1, Open: 1}) {
+		t.Fatalf("work counts = %+v, want 1 open", got)
+	}
+	if _, ok := counts[old.ID]; ok {
+		t.Fatalf("expected no counts for a project without tasks")
+	}
+
+	tasks, err := store.List(ctx, task.ListQuery{ProjectID: &home.ID, Done: ptr(false)})
+	if err != nil || len(tasks) != 1 || tasks[0].ID != dishes.ID || tasks[0].ProjectID == nil || *tasks[0].ProjectID != home.ID {
+		t.Fatalf("list project tasks = %+v, %v", tasks, err)
+	}
+	results, err := store.Search(ctx, "relatório", task.SearchFilter{ProjectID: &home.ID}, 10)
+	if err != nil || len(results) != 0 {
+		t.Fatalf("search in another project = %+v, %v", results, err)
+	}
+	if results, err := store.Search(ctx, "relatório", task.SearchFilter{ProjectID: &work.ID}, 10); err != nil || len(results) != 1 {
+		t.Fatalf("search in project = %+v, %v", results, err)
+	}
+
+	moved, err := store.Patch(ctx, loose.ID, map[string]any{"project_id": work.ID})
+	if err != nil || moved.ProjectID == nil || *moved.ProjectID != work.ID {
+		t.Fatalf("move to project = %+v, %v", moved, err)
+	}
+	if detached, err := store.Patch(ctx, loose.ID, map[string]any{"project_id": nil}); err != nil || detached.ProjectID != nil {
+		t.Fatalf("detach from project = %+v, %v", detached, err)
+	}
+
+	patched, err := store.PatchProject(ctx, work.ID, map[string]any{"name": "emprego", "archived": true})
+	if err != nil || patched == nil || patched.Name != "emprego" || !patched.Archived {
+		t.Fatalf("patch project = %+v, %v", patched, err)
+	}
+	if got, err := store.PatchProject(ctx, missingID, map[string]any{"archived": true}); err != nil || got != nil {
+		t.Fatalf("patch missing project = %+v, %v; want nil, nil", got, err)
+	}
+
+	deleted, err := store.DeleteProject(ctx, work.ID)
+	if err != nil || !deleted {
+		t.Fatalf("delete project = %v, %v; want true, nil", deleted, err)
+	}
+	if deleted, err := store.DeleteProject(ctx, work.ID); err != nil || deleted {
+		t.Fatalf("delete project twice = %v, %v; want false, nil", deleted, err)
+	}
+	if got := get(t, store, report.ID); got == nil || got.ProjectID != nil {
+		t.Fatalf("expected task to survive without project, got %+v", got)
+	}
+}
+
+func testDependencies(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	design := create(t, store, &models.Task{Title: "design"})
+	build := create(t, store, &models.Task{Title: "build"})
+	deploy := create(t, store, &models.Task{Title: "deploy"})
+	docs := create(t, store, &models.Task{Title: "docs"})
+
+	// deploy depende de build e de docs; build depende de design.
+	edges := []models.TaskDependency{
+		{TaskID: build.ID, DependsOnID: design.ID},
+		{TaskID: deploy.ID, DependsOnID: build.ID},
+		{TaskID: deploy.ID, DependsOnID: docs.ID},
+	}
+	for i := range edges {
+		edges[i].CreatedAt = time.Now().Add(time.Duration(i) * time.Second)
+		if err := store.AddDependency(ctx, &edges[i]); err != nil {
+			t.Fatalf("add dependency %d: %v", i, err)
+		}
+	}
+	if err := store.AddDependency(ctx, &models.TaskDependency{TaskID: build.ID, DependsOnID: design.ID, CreatedAt: time.Now()}); err != nil {
+		t.Fatalf("add dependency twice: %v", err)
+	}
+
+	listed, err := store.DependenciesOf(ctx, build.ID)
+	if err != nil || len(listed) != 2 || listed[0].DependsOnID != design.ID || listed[1].TaskID != deploy.ID {
+		t.Fatalf("dependencies of build = %+v, %v", listed, err)
+	}
+	reachable := []struct {
+		from string
+		want int
+	}{
+		{deploy.ID, 3},
+		{build.ID, 1},
+		{design.ID, 0},
+	}
+	for _, tt := range reachable {
+		got, err := store.ReachableDependencies(ctx, tt.from)
+		if err != nil || len(got) != tt.want {
+			t.Fatalf("reachable from %s = %+v, %v; want %d edges", tt.from, got, err, tt.want)
+		}
+	}
+
+	readyIDs := func() []string {
+		t.Helper()
+		tasks, err := store.List(ctx, task.ListQuery{Ready: true})
+		if err != nil {
+			t.Fatalf("list ready: %v", err)
+		}
+		ids := make([]string, 0, len(tasks))
+		for _, tk := range tasks {
+			ids = append(ids, tk.ID)
+		}
+		return ids
+	}
+	if got := readyIDs(); !sameIDs(got, []string{design.ID, docs.ID}) {
+		t.Fatalf("ready = %v, want design and docs", got)
+	}
+
+	blockers, err := store.OpenBlockers(ctx, []string{build.ID, deploy.ID, design.ID})
+	if err != nil {
+		t.Fatalf("open blockers: %v", err)
+	}
+	if !sameIDs(blockers[deploy.ID], []string{build.ID, docs.ID}) || !sameIDs(blockers[build.ID], []string{design.ID}) {
+		t.Fatalf("open blockers = %+v", blockers)
+	}
+	if _, ok := blockers[design.ID]; ok {
+		t.Fatalf("expected no blockers for design, got %v", blockers[design.ID])
+	}
+
+	// Dependência concluída ou na lixeira deixa de bloquear.
+	if _, err := store.Patch(ctx, design.ID, map[string]any{"status": models.StatusDone, "done": true}); err != nil {
+		t.Fatalf("complete design: %v", err)
+	}
+	if err := store.Delete(ctx, docs.ID); err != nil {
+		t.Fatalf("delete docs: %v", err)
+	}
+	if got := readyIDs(); !sameIDs(got, []string{build.ID}) {
+		t.Fatalf("ready = %v, want only build", got)
+	}
+	if blockers, err := store.OpenBlockers(ctx, []string{deploy.ID}); err != nil || !sameIDs(blockers[deploy.ID], []string{build.ID}) {
+		t.Fatalf("open blockers of deploy = %+v, %v", blockers, err)
+	}
+
+	removed, err := store.RemoveDependency(ctx, deploy.ID, build.ID)
+	if err != nil || !removed {
+		t.Fatalf("remove dependency = %v, %v; want true, nil", removed, err)
+	}
+	if removed, err := store.RemoveDependency(ctx, deploy.ID, build.ID); err != nil || removed {
+		t.Fatalf("remove dependency twice = %v, %v; want false, nil", removed, err)
+	}
+
+	// Apagar de vez leva as dependências junto.
+	if _, err := store.Purge(ctx, docs.ID); err != nil {
+		t.Fatalf("purge docs: %v", err)
+	}
+	if listed, err := store.DependenciesOf(ctx, deploy.ID); err != nil || len(listed) != 0 {
+		t.Fatalf("dependencies of deploy after purge = %+v, %v", listed, err)
+	}
+	listed, err = store.DependenciesOf(ctx, build.ID)
+	if err != nil || len(listed) != 1 || listed[0].DependsOnID != design.ID {
+		t.Fatalf("dependencies of build after purge = %+v, %v", listed, err)
+	}
+}
+
+func testStatus(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	project := &models.Project{Name: "casa"}
+	if err := store.CreateProject(ctx, project); err != nil {
+		t.Fatalf("create project: %v", err)
+	}
+
+	todo := create(t, store, &models.Task{Title: "todo", ProjectID: &project.ID, ReminderAt: now.Add(-time.Hour)})
+	doing := create(t, store, &models.Task{Title: "doing", ProjectID: &project.ID, Status: models.StatusInProgress, StartedAt: ptr(now)})
+	cancelled := create(t, store, &models.Task{Title: "cancelled", ProjectID: &project.ID, ReminderAt: now.Add(-time.Hour), Reminders: []models.Reminder{{Offset: "0", FireAt: now.Add(-time.Hour)}}})
+	finished := create(t, store, &models.Task{Title: "finished", Done: true})
+
+	if got := get(t, store, todo.ID); got.Status != models.StatusTodo || got.Done {
+		t.Fatalf("new task status = %q (done %v), want todo", got.Status, got.Done)
+	}
+	if got := get(t, store, finished.ID); got.Status != models.StatusDone || !got.Done {
+		t.Fatalf("task created done has status %q, want done", got.Status)
+	}
+	if got := get(t, store, doing.ID); got.Status != models.StatusInProgress || got.StartedAt == nil || !got.StartedAt.Equal(now) {
+		t.Fatalf("in progress task = %+v", got)
+	}
+
+	patched, err := store.Patch(ctx, cancelled.ID, map[string]any{"status": models.StatusCancelled, "done": false, "cancelled_at": now})
+	if err != nil || patched == nil || patched.Status != models.StatusCancelled || patched.CancelledAt == nil || !patched.CancelledAt.Equal(now) {
+		t.Fatalf("cancel = %+v, %v", patched, err)
+	}
+
+	tasks, err := store.List(ctx, task.ListQuery{Statuses: []models.TaskStatus{models.StatusInProgress, models.StatusCancelled}, Sort: task.SortCreatedAt, Ascending: true})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if len(tasks) != 2 || tasks[0].ID != doing.ID || tasks[1].ID != cancelled.ID {
+		t.Fatalf("list by status = %+v, want doing and cancelled", tasks)
+	}
+
+	// Cancelada conta como fechada: não vence, não dispara lembrete e não
+	// aparece como aberta no projeto.
+	due, err := store.ListDue(ctx, task.DueFilter{Before: now})
+	if err != nil {
+		t.Fatalf("list due: %v", err)
+	}
+	if len(due) != 1 || due[0].ID != todo.ID {
+		t.Fatalf("due = %+v, want only todo", due)
+	}
+	pending, err := store.PendingReminders(ctx, now)
+	if err != nil {
+		t.Fatalf("pending reminders: %v", err)
+	}
+	for _, r := range pending {
+		if r.TaskID == cancelled.ID {
+			t.Fatalf("cancelled task reminder is still pending: %+v", r)
+		}
+	}
+	counts, err := store.ProjectCounts(ctx, []string{project.ID})
+	if err != nil {
+		t.Fatalf("project counts: %v", err)
+	}
+	if got := counts[project.ID]; got != (models.ProjectCounts{Total: 3, Open: 2, Cancelled: 1}) {
+		t.Fatalf("counts = %+v, want 3 total, 2 open, 1 cancelled", got)
+	}
+}
+
+func createTimeEntry(t *testing.T, store api.Store, taskID string, start time.Time, d time.Duration) *models.TimeEntry {
+	t.Helper()
+	entry := &models.TimeEntry{TaskID: taskID, StartedAt: start}
+	if d > 0 {
+		stop := start.Add(d)
+		entry.StoppedAt = &stop
+	}
+	if err := store.CreateTimeEntry(context.Background(), entry); err != nil {
+		t.Fatalf("create time entry: %v", err)
+	}
+	if entry.ID == "" {
+		t.Fatalf("create time entry: expected generated id")
+	}
+	return entry
+}
+
+func testTimeEntries(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	root := create(t, store, &models.Task{Title: "root"})
+	child := create(t, store, &models.Task{Title: "child", ParentID: &root.ID})
+	grandchild := create(t, store, &models.Task{Title: "grandchild", ParentID: &child.ID})
+	other := create(t, store, &models.Task{Title: "other"})
+
+	if running, err := store.RunningTimeEntry(ctx); err != nil || running != nil {
+		t.Fatalf("running with no entries = %+v, %v; want nil", running, err)
+	}
+
+	createTimeEntry(t, store, root.ID, now.Add(-5*time.Hour), time.Hour)
+	createTimeEntry(t, store, child.ID, now.Add(-3*time.Hour), 30*time.Minute)
+	createTimeEntry(t, store, grandchild.ID, now.Add(-2*time.Hour), 15*time.Minute)
+	running := createTimeEntry(t, store, other.ID, now.Add(-20*time.Minute), 0)
+
+	got, err := store.RunningTimeEntry(ctx)
+	if err != nil || got == nil || got.ID != running.ID || got.StoppedAt != nil {
+		t.Fatalf("running = %+v, %v; want %s", got, err, running.ID)
+	}
+	second := &models.TimeEntry{TaskID: root.ID, StartedAt: now}
+	if err := store.CreateTimeEntry(ctx, second); !errors.Is(err, task.ErrTimerConflict) {
+		t.Fatalf("second running entry = %v, want ErrTimerConflict", err)
+	}
+
+	all, err := store.ListTimeEntries(ctx, task.TimeQuery{})
+	if err != nil || len(all) != 4 {
+		t.Fatalf("list all = %d entries, %v; want 4", len(all), err)
+	}
+	if all[0].TaskID != root.ID || all[3].TaskID != other.ID {
+		t.Fatalf("entries not ordered by start: %+v", all)
+	}
+	if all[1].Task == nil || all[1].Task.Title != "child" {
+		t.Fatalf("expected entry task loaded, got %+v", all[1].Task)
+	}
+
+	from, to := now.Add(-160*time.Minute), now.Add(-time.Hour)
+	window, err := store.ListTimeEntries(ctx, task.TimeQuery{From: &from, To: &to})
+	if err != nil || len(window) != 2 || window[0].TaskID != child.ID || window[1].TaskID != grandchild.ID {
+		t.Fatalf("entries crossing the window = %+v, %v; want child and grandchild", window, err)
+	}
+	recent := now.Add(-time.Minute)
+	if open, err := store.ListTimeEntries(ctx, task.TimeQuery{From: &recent}); err != nil || len(open) != 1 || open[0].ID != running.ID {
+		t.Fatalf("entries after a minute ago = %+v, %v; want the running one", open, err)
+	}
+	if mine, err := store.ListTimeEntries(ctx, task.TimeQuery{TaskID: &child.ID}); err != nil || len(mine) != 1 {
+		t.Fatalf("entries of child = %+v, %v; want 1", mine, err)
+	}
+
+	tracked, err := store.TrackedTime(ctx, []string{root.ID, child.ID, other.ID, missingID}, 10, now)
+	if err != nil {
+		t.Fatalf("tracked time: %v", err)
+	}
+	if got := tracked[root.ID]; got != time.Hour+45*time.Minute {
+		t.Fatalf("root tracked = %v, want 1h45m", got)
+	}
+	if got := tracked[child.ID]; got != 45*time.Minute {
+		t.Fatalf("child tracked = %v, want 45m", got)
+	}
+	if got := tracked[other.ID]; got != 20*time.Minute {
+		t.Fatalf("running tracked = %v, want 20m", got)
+	}
+	if _, ok := tracked[missingID]; ok {
+		t.Fatalf("expected no tracked time for a missing task")
+	}
+	shallow, err := store.TrackedTime(ctx, []string{root.ID}, 1, now)
+	if err != nil || shallow[root.ID] != time.Hour+30*time.Minute {
+		t.Fatalf("tracked one level deep = %v, %v; want 1h30m", shallow[root.ID], err)
+	}
+
+	if stopped, err := store.StopTimeEntry(ctx, running.ID, now); err != nil || !stopped {
+		t.Fatalf("stop = %v, %v; want true, nil", stopped, err)
+	}
+	if stopped, err := store.StopTimeEntry(ctx, running.ID, now); err != nil || stopped {
+		t.Fatalf("stop twice = %v, %v; want false, nil", stopped, err)
+	}
+	if got, err := store.RunningTimeEntry(ctx); err != nil || got != nil {
+		t.Fatalf("running after stop = %+v, %v; want nil", got, err)
+	}
+
+	if err := store.Delete(ctx, other.ID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if purged, err := store.Purge(ctx, other.ID); err != nil || !purged {
+		t.Fatalf("purge = %v, %v; want true, nil", purged, err)
+	}
+	if left, err := store.ListTimeEntries(ctx, task.TimeQuery{TaskID: &other.ID}); err != nil || len(left) != 0 {
+		t.Fatalf("entries of purged task = %+v, %v; want none", left, err)
+	}
+}
+
+func testAtomic(t *testing.T, store api.Store) {
+	ctx := context.Background()
+	kept := create(t, store, &models.Task{Title: "kept"})
+	errRollback := errors.New("rollback")
+
+	var createdID string
+	err := store.Atomic(ctx, func(ctx context.Context) error {
+		created := &models.Task{Title: "rolled back", Priority: models.PriorityLow}
+		if err := store.Create(ctx, created); err != nil {
+			return err
+		}
+		createdID = created.ID
+		if _, err := store.Patch(ctx, kept.ID, map[string]any{"title": "changed"}); err != nil {
+			return err
+		}
+		// Atomic aninhado participa da transação de fora.
+		return store.Atomic(ctx, func(ctx context.Context) error {
+			if _, err := store.Patch(ctx, created.ID, map[string]any{"title": "nested"}); err != nil {
+				return err
+			}
+			return errRollback
+		})
+	})
+	if !errors.Is(err, errRollback) {
+		t.Fatalf("atomic err = %v, want the error from fn", err)
+	}
+	if got := get(t, store, createdID); got != nil {
+		t.Fatalf("task created in a rolled back transaction: %+v", got)
+	}
+	if got := get(t, store, kept.ID); got == nil || got.Title != "kept" {
+		t.Fatalf("patch not rolled back: %+v", got)
+	}
+
+	err = store.Atomic(ctx, func(ctx context.Context) error {
+		_, err := store.Patch(ctx, kept.ID, map[string]any{"title": "committed"})
+		return err
+	})
+	if err != nil {
+		t.Fatalf("atomic: %v", err)
+	}
+	if got := get(t, store, kept.ID); got == nil || got.Title != "committed" {
+		t.Fatalf("patch not committed: %+v", got)
+	}
+}
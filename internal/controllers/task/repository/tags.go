@@ -0,0 +1,236 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// preloadTags carrega as tags das tarefas em ordem alfabética.
+func preloadTags(db *gorm.DB) *gorm.DB {
+	return db.Order("tags.name asc")
+}
+
+// tagFilterSQL traduz f em condições sobre a coluna de ID de tarefa column.
+// Devolve "" quando não há filtro.
+func tagFilterSQL(column string, f task.TagFilter) (string, []any) {
+	const hasTag = "SELECT 1 FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE tt.task_id = "
+
+	var conditions []string
+	var args []any
+	for _, name := range f.Include {
+		conditions = append(conditions, "EXISTS ("+hasTag+column+" AND g.name = ?)")
+		args = append(args, name)
+	}
+	if len(f.Exclude) > 0 {
+		conditions = append(conditions, "NOT EXISTS ("+hasTag+column+" AND g.name IN ?)")
+		args = append(args, f.Exclude)
+	}
+	return strings.Join(conditions, " AND "), args
+}
+
+func (s *DBStore) ListTags(ctx context.Context) ([]models.Tag, error) {
+	var tags []models.Tag
+	err := s.conn(ctx).Order("name asc").Find(&tags).Error
+	return tags, err
+}
+
+func (s *DBStore) GetTag(ctx context.Context, id string) (*models.Tag, error) {
+	return s.firstTag(ctx, "id = ?", id)
+}
+
+func (s *DBStore) GetTagByName(ctx context.Context, name string) (*models.Tag, error) {
+	return s.firstTag(ctx, "name = ?", name)
+}
+
+func (s *DBStore) firstTag(ctx context.Context, query string, arg any) (*models.Tag, error) {
+	var tag models.Tag
+	err := s.conn(ctx).First(&tag, query, arg).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &tag, nil
+}
+
+func (s *DBStore) CreateTag(ctx context.Context, tag *models.Tag) error {
+	return s.conn(ctx).Create(tag).Error
+}
+
+func (s *DBStore) PatchTag(ctx context.Context, id string, changes map[string]any) (*models.Tag, error) {
+	tx := s.conn(ctx).Model(&models.Tag{}).Where("id = ?", id).Updates(changes)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return nil, nil
+	}
+	return s.GetTag(ctx, id)
+}
+
+// DeleteTag apaga a tag; os vínculos com tarefas vão junto (ON DELETE CASCADE).
+func (s *DBStore) DeleteTag(ctx context.Context, id string) (bool, error) {
+	tx := s.conn(ctx).Delete(&models.Tag{}, "id = ?", id)
+	return tx.RowsAffected > 0, tx.Error
+}
+
+// AttachTag vincula a tag à tarefa; vincular de novo não faz nada.
+func (s *DBStore) AttachTag(ctx context.Context, taskID, tagID string) error {
+	return s.conn(ctx).Exec(
+		"INSERT INTO task_tags (task_id, tag_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+		taskID, tagID,
+	).Error
+}
+
+func (s *DBStore) DetachTag(ctx context.Context, taskID, tagID string) (bool, error) {
+	tx := s.conn(ctx).Exec("DELETE FROM task_tags WHERE task_id = ? AND tag_id = ?", taskID, tagID)
+	return tx.RowsAffected > 0, tx.Error
+}
+
+func (s *MemoryStore) ListTags(ctx context.Context) ([]models.Tag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	tags := make([]models.Tag, 0, len(s.tags))
+	for _, tag := range s.tags {
+		tags = append(tags, *tag)
+	}
+	sortTags(tags)
+	return tags, nil
+}
+
+func (s *MemoryStore) GetTag(ctx context.Context, id string) (*models.Tag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if tag, ok := s.tags[id]; ok {
+		clone := *tag
+		return &clone, nil
+	}
+	return nil, nil
+}
+
+func (s *MemoryStore) GetTagByName(ctx context.Context, name string) (*models.Tag, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, tag := range s.tags {
+		if tag.Name == name {
+			clone := *tag
+			return &clone, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *MemoryStore) CreateTag(ctx context.Context, tag *models.Tag) error {
+	defer s.lock(ctx)()
+
+	for _, existing := range s.tags {
+		if existing.Name == tag.Name {
+			return fmt.Errorf("tag %s já existe", tag.Name)
+		}
+	}
+	if tag.ID == "" {
+		tag.ID = uuid.NewString()
+	}
+	now := s.now()
+	if tag.CreatedAt.IsZero() {
+		tag.CreatedAt = now
+	}
+	if tag.UpdatedAt.IsZero() {
+		tag.UpdatedAt = now
+	}
+	stored := *tag
+	s.tags[tag.ID] = &stored
+	return nil
+}
+
+func (s *MemoryStore) PatchTag(ctx context.Context, id string, changes map[string]any) (*models.Tag, error) {
+	defer s.lock(ctx)()
+
+	stored, ok := s.tags[id]
+	if !ok {
+		return nil, nil
+	}
+	updated := *stored
+	if err := applyChanges(ctx, tagSchema, &updated, changes); err != nil {
+		return nil, err
+	}
+	for _, existing := range s.tags {
+		if existing.ID != id && existing.Name == updated.Name {
+			return nil, fmt.Errorf("tag %s já existe", updated.Name)
+		}
+	}
+	if _, ok := changes["updated_at"]; !ok {
+		updated.UpdatedAt = s.now()
+	}
+	s.tags[id] = &updated
+
+	clone := updated
+	return &clone, nil
+}
+
+func (s *MemoryStore) DeleteTag(ctx context.Context, id string) (bool, error) {
+	defer s.lock(ctx)()
+
+	if _, ok := s.tags[id]; !ok {
+		return false, nil
+	}
+	delete(s.tags, id)
+	for _, tagIDs := range s.taskTags {
+		delete(tagIDs, id)
+	}
+	return true, nil
+}
+
+func (s *MemoryStore) AttachTag(ctx context.Context, taskID, tagID string) error {
+	defer s.lock(ctx)()
+
+	if _, ok := s.tasks[taskID]; !ok {
+		return ErrMemoryTaskNotFound
+	}
+	if _, ok := s.tags[tagID]; !ok {
+		return fmt.Errorf("tag %s não existe", tagID)
+	}
+	if s.taskTags[taskID] == nil {
+		s.taskTags[taskID] = map[string]bool{}
+	}
+	s.taskTags[taskID][tagID] = true
+	return nil
+}
+
+func (s *MemoryStore) DetachTag(ctx context.Context, taskID, tagID string) (bool, error) {
+	defer s.lock(ctx)()
+
+	if !s.taskTags[taskID][tagID] {
+		return false, nil
+	}
+	delete(s.taskTags[taskID], tagID)
+	return true, nil
+}
+
+// tagsOf assume o mutex já travado.
+func (s *MemoryStore) tagsOf(taskID string) []models.Tag {
+	var tags []models.Tag
+	for tagID := range s.taskTags[taskID] {
+		if tag, ok := s.tags[tagID]; ok {
+			tags = append(tags, *tag)
+		}
+	}
+	sortTags(tags)
+	return tags
+}
+
+func sortTags(tags []models.Tag) {
+	sort.Slice(tags, func(i, j int) bool { return tags[i].Name < tags[j].Name })
+}
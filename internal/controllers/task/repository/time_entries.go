@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/controllers/task"
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// CreateTimeEntry grava o registro; um segundo cronômetro rodando esbarra no
+// índice idx_time_entries_running e devolve task.ErrTimerConflict.
+func (s *DBStore) CreateTimeEntry(ctx context.Context, e *models.TimeEntry) error {
+	tx := s.conn(ctx).Omit("Task").Clauses(clause.OnConflict{DoNothing: true}).Create(e)
+	if tx.Error != nil {
+		return tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return task.ErrTimerConflict
+	}
+	return nil
+}
+
+// StopTimeEntry para o cronômetro id em at; devolve false se ele já estava
+// parado ou não existe.
+func (s *DBStore) StopTimeEntry(ctx context.Context, id string, at time.Time) (bool, error) {
+	tx := s.conn(ctx).
+		Model(&models.TimeEntry{}).
+		Where("id = ? AND stopped_at IS NULL", id).
+		Updates(map[string]any{"stopped_at": at, "updated_at": time.Now()})
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	return tx.RowsAffected > 0, nil
+}
+
+// RunningTimeEntry devolve o cronômetro rodando, ou nil.
+func (s *DBStore) RunningTimeEntry(ctx context.Context) (*models.TimeEntry, error) {
+	var e models.TimeEntry
+	err := s.conn(ctx).
+		Where("stopped_at IS NULL").
+		Order("started_at desc").
+		First(&e).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// ListTimeEntries devolve os registros da consulta, dos mais antigos para os
+// mais novos, com a tarefa carregada (mesmo se estiver na lixeira).
+func (s *DBStore) ListTimeEntries(ctx context.Context, q task.TimeQuery) ([]models.TimeEntry, error) {
+	query := s.conn(ctx).
+		Preload("Task", func(db *gorm.DB) *gorm.DB { return db.Unscoped() })
+	if q.TaskID != nil {
+		query = query.Where("task_id = ?", *q.TaskID)
+	}
+	if q.From != nil {
+		query = query.Where("stopped_at IS NULL OR stopped_at > ?", *q.From)
+	}
+	if q.To != nil {
+		query = query.Where("started_at < ?", *q.To)
+	}
+
+	var entries []models.TimeEntry
+	err := query.Order("started_at asc").Find(&entries).Error
+	return entries, err
+}
+
+type trackedRow struct {
+	RootID string
+	ID     string
+}
+
+// TrackedTime soma, para cada tarefa de ids, o tempo registrado nela e nas
+// subtarefas até maxDepth níveis abaixo; o cronômetro rodando conta até now.
+// Tarefas sem tempo ficam fora do mapa.
+func (s *DBStore) TrackedTime(ctx context.Context, ids []string, maxDepth int, now time.Time) (map[string]time.Duration, error) {
+	tracked := map[string]time.Duration{}
+	if len(ids) == 0 {
+		return tracked, nil
+	}
+
+	var rows []trackedRow
+	err := s.conn(ctx).Raw(`
+		WITH RECURSIVE sub AS (
+			SELECT id AS root_id, id, 0 AS depth FROM tasks WHERE id IN @ids AND deleted_at IS NULL
+			UNION ALL
+			SELECT sub.root_id, t.id, sub.depth + 1
+			FROM tasks t JOIN sub ON t.parent_id = sub.id
+			WHERE t.deleted_at IS NULL AND sub.depth < @depth
+		)
+		SELECT DISTINCT root_id, id FROM sub`,
+		map[string]any{"ids": ids, "depth": maxDepth},
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	roots := map[string][]string{}
+	taskIDs := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if _, ok := roots[row.ID]; !ok {
+			taskIDs = append(taskIDs, row.ID)
+		}
+		roots[row.ID] = append(roots[row.ID], row.RootID)
+	}
+
+	var entries []models.TimeEntry
+	if err := s.conn(ctx).Where("task_id IN ?", taskIDs).Find(&entries).Error; err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		for _, root := range roots[e.TaskID] {
+			tracked[root] += e.Duration(now)
+		}
+	}
+	return tracked, nil
+}
+
+func (s *MemoryStore) CreateTimeEntry(ctx context.Context, e *models.TimeEntry) error {
+	defer s.lock(ctx)()
+
+	if _, ok := s.tasks[e.TaskID]; !ok {
+		return ErrMemoryTaskNotFound
+	}
+	if e.Running() {
+		for _, other := range s.timeEntries {
+			if other.Running() {
+				return task.ErrTimerConflict
+			}
+		}
+	}
+	if e.ID == "" {
+		e.ID = uuid.NewString()
+	}
+	now := s.now()
+	if e.CreatedAt.IsZero() {
+		e.CreatedAt = now
+	}
+	if e.UpdatedAt.IsZero() {
+		e.UpdatedAt = now
+	}
+	stored := cloneTimeEntry(*e)
+	stored.Task = nil
+	s.timeEntries[e.ID] = &stored
+	return nil
+}
+
+func (s *MemoryStore) StopTimeEntry(ctx context.Context, id string, at time.Time) (bool, error) {
+	defer s.lock(ctx)()
+
+	e, ok := s.timeEntries[id]
+	if !ok || e.StoppedAt != nil {
+		return false, nil
+	}
+	e.StoppedAt = &at
+	e.UpdatedAt = s.now()
+	return true, nil
+}
+
+func (s *MemoryStore) RunningTimeEntry(ctx context.Context) (*models.TimeEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var running *models.TimeEntry
+	for _, e := range s.timeEntries {
+		if e.Running() && (running == nil || e.StartedAt.After(running.StartedAt)) {
+			running = e
+		}
+	}
+	if running == nil {
+		return nil, nil
+	}
+	clone := cloneTimeEntry(*running)
+	return &clone, nil
+}
+
+func (s *MemoryStore) ListTimeEntries(ctx context.Context, q task.TimeQuery) ([]models.TimeEntry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := s.now()
+	var entries []models.TimeEntry
+	for _, e := range s.timeEntries {
+		if !q.Matches(*e, now) {
+			continue
+		}
+		clone := cloneTimeEntry(*e)
+		if stored, ok := s.tasks[e.TaskID]; ok {
+			t := cloneTask(stored.task)
+			clone.Task = &t
+		}
+		entries = append(entries, clone)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if !entries[i].StartedAt.Equal(entries[j].StartedAt) {
+			return entries[i].StartedAt.Before(entries[j].StartedAt)
+		}
+		return entries[i].ID < entries[j].ID
+	})
+	return entries, nil
+}
+
+func (s *MemoryStore) TrackedTime(ctx context.Context, ids []string, maxDepth int, now time.Time) (map[string]time.Duration, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	byParent := map[string][]string{}
+	for _, stored := range s.liveTasks() {
+		if stored.task.ParentID != nil {
+			byParent[*stored.task.ParentID] = append(byParent[*stored.task.ParentID], stored.task.ID)
+		}
+	}
+	byTask := map[string]time.Duration{}
+	for _, e := range s.timeEntries {
+		byTask[e.TaskID] += e.Duration(now)
+	}
+
+	tracked := map[string]time.Duration{}
+	for _, id := range ids {
+		if _, ok := s.live(id); !ok {
+			continue
+		}
+
+		total := byTask[id]
+		seen := map[string]bool{id: true}
+		level := []string{id}
+		for depth := 0; depth < maxDepth && len(level) > 0; depth++ {
+			var next []string
+			for _, parentID := range level {
+				for _, childID := range byParent[parentID] {
+					if seen[childID] {
+						continue
+					}
+					seen[childID] = true
+					total += byTask[childID]
+					next = append(next, childID)
+				}
+			}
+			level = next
+		}
+		if total > 0 {
+			tracked[id] = total
+		}
+	}
+	return tracked, nil
+}
+
+func cloneTimeEntry(e models.TimeEntry) models.TimeEntry {
+	if e.StoppedAt != nil {
+		stoppedAt := *e.StoppedAt
+		e.StoppedAt = &stoppedAt
+	}
+	return e
+}
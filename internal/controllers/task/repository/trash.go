@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// ListDeleted devolve as tarefas na lixeira, das apagadas mais recentemente
+// para as mais antigas.
+func (s *DBStore) ListDeleted(ctx context.Context) ([]models.Task, error) {
+	var tasks []models.Task
+	err := s.conn(ctx).
+		Unscoped().
+		Where("deleted_at IS NOT NULL").
+		Order("deleted_at desc").
+		Find(&tasks).Error
+	return tasks, err
+}
+
+func (s *DBStore) Restore(ctx context.Context, id string) (*models.Task, error) {
+	tx := s.conn(ctx).
+		Unscoped().
+		Model(&models.Task{}).
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Update("deleted_at", nil)
+	if tx.Error != nil {
+		return nil, tx.Error
+	}
+	if tx.RowsAffected == 0 {
+		return nil, nil
+	}
+	return s.GetByID(ctx, id)
+}
+
+// Purge apaga de vez uma tarefa que está na lixeira. Os lembretes vão junto
+// (ON DELETE CASCADE) e os filhos ficam sem pai (ON DELETE SET NULL).
+func (s *DBStore) Purge(ctx context.Context, id string) (bool, error) {
+	tx := s.conn(ctx).
+		Unscoped().
+		Where("id = ? AND deleted_at IS NOT NULL", id).
+		Delete(&models.Task{})
+	if tx.Error != nil {
+		return false, tx.Error
+	}
+	return tx.RowsAffected > 0, nil
+}
+
+func (s *DBStore) PurgeDeletedBefore(ctx context.Context, before time.Time) (int64, error) {
+	tx := s.conn(ctx).
+		Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+		Delete(&models.Task{})
+	return tx.RowsAffected, tx.Error
+}
@@ -0,0 +1,246 @@
+package repository
+
+import (
+	"context"
+	"sort"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// maxAncestors limita a subida pela árvore, para dados antigos com ciclo não
+// travarem a consulta.
+const maxAncestors = 1000
+
+// GetTree carrega a tarefa com as subtarefas aninhadas até maxDepth níveis:
+// uma consulta recursiva acha os IDs e outra carrega as tarefas com as tags.
+func (s *DBStore) GetTree(ctx context.Context, id string, maxDepth int) (*models.Task, error) {
+	tree := s.conn(ctx).Raw(`
+		WITH RECURSIVE tree AS (
+			SELECT id, 0 AS depth FROM tasks WHERE id = @id AND deleted_at IS NULL
+			UNION ALL
+			SELECT t.id, tree.depth + 1
+			FROM tasks t JOIN tree ON t.parent_id = tree.id
+			WHERE t.deleted_at IS NULL AND tree.depth < @depth
+		)
+		SELECT id FROM tree`,
+		map[string]any{"id": id, "depth": maxDepth},
+	)
+
+	var tasks []models.Task
+	err := s.conn(ctx).
+		Preload("Tags", preloadTags).
+		Where("id IN (?)", tree).
+		Find(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return buildTree(id, tasks, maxDepth), nil
+}
+
+// Ancestors devolve os ancestrais vivos da tarefa, da raiz até o pai direto.
+func (s *DBStore) Ancestors(ctx context.Context, id string) ([]models.Task, error) {
+	var tasks []models.Task
+	err := s.conn(ctx).Raw(`
+		WITH RECURSIVE path AS (
+			SELECT p.id, p.parent_id, 1 AS depth
+			FROM tasks c JOIN tasks p ON p.id = c.parent_id
+			WHERE c.id = @id AND p.deleted_at IS NULL
+			UNION ALL
+			SELECT p.id, p.parent_id, path.depth + 1
+			FROM path JOIN tasks p ON p.id = path.parent_id
+			WHERE p.deleted_at IS NULL AND path.depth < @limit
+		)
+		SELECT tasks.* FROM tasks JOIN path ON tasks.id = path.id
+		ORDER BY path.depth DESC`,
+		map[string]any{"id": id, "limit": maxAncestors},
+	).Scan(&tasks).Error
+	if err != nil {
+		return nil, err
+	}
+	return dedupeAncestors(id, tasks), nil
+}
+
+func (s *MemoryStore) GetTree(ctx context.Context, id string, maxDepth int) (*models.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var tasks []models.Task
+	for _, stored := range s.liveTasks() {
+		t := cloneTask(stored.task)
+		t.Tags = s.tagsOf(t.ID)
+		tasks = append(tasks, t)
+	}
+	return buildTree(id, tasks, maxDepth), nil
+}
+
+func (s *MemoryStore) Ancestors(ctx context.Context, id string) ([]models.Task, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	current, ok := s.live(id)
+	if !ok {
+		return nil, nil
+	}
+
+	var path []models.Task
+	seen := map[string]bool{id: true}
+	for parentID := current.task.ParentID; parentID != nil && !seen[*parentID]; {
+		parent, ok := s.live(*parentID)
+		if !ok {
+			break
+		}
+		seen[parent.task.ID] = true
+		path = append([]models.Task{cloneTask(parent.task)}, path...)
+		parentID = parent.task.ParentID
+	}
+	return path, nil
+}
+
+// buildTree monta a árvore de rootID a partir de tarefas soltas, até maxDepth
+// níveis. Filhos saem na ordem de criação.
+func buildTree(rootID string, tasks []models.Task, maxDepth int) *models.Task {
+	byID := make(map[string]models.Task, len(tasks))
+	byParent := map[string][]string{}
+	for _, t := range tasks {
+		byID[t.ID] = t
+		if t.ParentID != nil {
+			byParent[*t.ParentID] = append(byParent[*t.ParentID], t.ID)
+		}
+	}
+	root, ok := byID[rootID]
+	if !ok {
+		return nil
+	}
+
+	visited := map[string]bool{}
+	var attach func(node *models.Task, depth int)
+	attach = func(node *models.Task, depth int) {
+		visited[node.ID] = true
+		node.Parent = nil
+		node.Children = nil
+		if depth >= maxDepth {
+			return
+		}
+
+		childIDs := byParent[node.ID]
+		sort.Slice(childIDs, func(i, j int) bool {
+			a, b := byID[childIDs[i]], byID[childIDs[j]]
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+			return a.ID < b.ID
+		})
+		for _, childID := range childIDs {
+			if visited[childID] {
+				continue
+			}
+			child := byID[childID]
+			attach(&child, depth+1)
+			node.Children = append(node.Children, child)
+		}
+	}
+	attach(&root, 0)
+	return &root
+}
+
+// dedupeAncestors corta o caminho no primeiro ID repetido ou na própria
+// tarefa (dados com ciclo).
+func dedupeAncestors(id string, path []models.Task) []models.Task {
+	seen := map[string]bool{id: true}
+	for i := len(path) - 1; i >= 0; i-- {
+		if seen[path[i].ID] {
+			return path[i+1:]
+		}
+		seen[path[i].ID] = true
+	}
+	return path
+}
+
+type progressRow struct {
+	RootID string
+	Done   int
+	Total  int
+}
+
+// Progress conta, para cada tarefa de ids, as subtarefas vivas e as concluídas
+// até maxDepth níveis abaixo dela. Subtarefas canceladas não entram no total,
+// como no fechamento automático do pai. Tarefas sem subtarefas ficam fora do mapa.
+func (s *DBStore) Progress(ctx context.Context, ids []string, maxDepth int) (map[string]models.Progress, error) {
+	if len(ids) == 0 {
+		return map[string]models.Progress{}, nil
+	}
+
+	var rows []progressRow
+	err := s.conn(ctx).Raw(`
+		WITH RECURSIVE sub AS (
+			SELECT id AS root_id, id, 0 AS depth FROM tasks WHERE id IN @ids AND deleted_at IS NULL
+			UNION ALL
+			SELECT sub.root_id, t.id, sub.depth + 1
+			FROM tasks t JOIN sub ON t.parent_id = sub.id
+			WHERE t.deleted_at IS NULL AND sub.depth < @depth
+		)
+		SELECT sub.root_id,
+			COUNT(DISTINCT sub.id) AS total,
+			COUNT(DISTINCT CASE WHEN t.done THEN sub.id END) AS done
+		FROM sub JOIN tasks t ON t.id = sub.id
+		WHERE sub.id <> sub.root_id AND t.status <> @cancelled
+		GROUP BY sub.root_id`,
+		map[string]any{"ids": ids, "depth": maxDepth, "cancelled": models.StatusCancelled},
+	).Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+
+	progress := make(map[string]models.Progress, len(rows))
+	for _, row := range rows {
+		progress[row.RootID] = models.Progress{Done: row.Done, Total: row.Total}
+	}
+	return progress, nil
+}
+
+func (s *MemoryStore) Progress(ctx context.Context, ids []string, maxDepth int) (map[string]models.Progress, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	byParent := map[string][]models.Task{}
+	for _, stored := range s.liveTasks() {
+		if stored.task.ParentID != nil {
+			byParent[*stored.task.ParentID] = append(byParent[*stored.task.ParentID], stored.task)
+		}
+	}
+
+	progress := map[string]models.Progress{}
+	for _, id := range ids {
+		if _, ok := s.live(id); !ok {
+			continue
+		}
+
+		var counted models.Progress
+		seen := map[string]bool{id: true}
+		level := []string{id}
+		for depth := 0; depth < maxDepth && len(level) > 0; depth++ {
+			var next []string
+			for _, parentID := range level {
+				for _, child := range byParent[parentID] {
+					if seen[child.ID] {
+						continue
+					}
+					seen[child.ID] = true
+					next = append(next, child.ID)
+					if child.Status == models.StatusCancelled {
+						continue
+					}
+					counted.Total++
+					if child.Done {
+						counted.Done++
+					}
+				}
+			}
+			level = next
+		}
+		if counted.Total > 0 {
+			progress[id] = counted
+		}
+	}
+	return progress, nil
+}
@@ -0,0 +1,155 @@
+package task
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+// Marcadores dos termos encontrados no snippet. O resto do snippet vem com o
+// HTML escapado, então os marcadores são as únicas tags dele.
+const (
+	HighlightStart = "<mark>"
+	HighlightStop  = "</mark>"
+)
+
+// HeadlineStart e HeadlineStop são os delimitadores que o ts_headline põe em
+// volta dos acertos. São caracteres de uso privado, que o banco tira do texto
+// antes, para EscapeHeadline só trocar pelos marcadores depois de escapar.
+const (
+	HeadlineStart = "\uE000"
+	HeadlineStop  = "\uE001"
+)
+
+var headlineMarks = strings.NewReplacer(HeadlineStart, HighlightStart, HeadlineStop, HighlightStop)
+
+// EscapeHeadline escapa o HTML do snippet do ts_headline e troca os
+// delimitadores pelos marcadores.
+func EscapeHeadline(snippet string) string {
+	return headlineMarks.Replace(html.EscapeString(snippet))
+}
+
+// snippetWords é o tamanho máximo do trecho devolvido em volta do acerto.
+const snippetWords = 20
+
+// SearchResult é uma tarefa encontrada pela busca textual, com a relevância
+// e um trecho do texto, com o HTML escapado e os termos destacados.
+type SearchResult struct {
+	models.Task
+	Rank    float64 `json:"rank"`
+	Snippet string  `json:"snippet"`
+}
+
+// SearchFilter restringe a busca textual, com a mesma semântica dos filtros
+// equivalentes da ListQuery.
+type SearchFilter struct {
+	Tags      TagFilter
+	ProjectID *string
+}
+
+var accentFolder = strings.NewReplacer(
+	"á", "a", "à", "a", "â", "a", "ã", "a", "ä", "a",
+	"é", "e", "è", "e", "ê", "e", "ë", "e",
+	"í", "i", "ì", "i", "î", "i", "ï", "i",
+	"ó", "o", "ò", "o", "ô", "o", "õ", "o", "ö", "o",
+	"ú", "u", "ù", "u", "û", "u", "ü", "u",
+	"ç", "c", "ñ", "n",
+)
+
+func foldWord(word string) string {
+	word = strings.TrimFunc(word, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return accentFolder.Replace(strings.ToLower(word))
+}
+
+// SearchTerms quebra a busca em termos normalizados (minúsculas, sem acento).
+func SearchTerms(query string) []string {
+	var terms []string
+	for _, word := range strings.Fields(query) {
+		if term := foldWord(word); term != "" {
+			terms = append(terms, term)
+		}
+	}
+	return terms
+}
+
+// MatchTask é a busca simplificada usada fora do Postgres: todos os termos
+// precisam aparecer como início de alguma palavra do título ou da descrição.
+// Acertos no título valem mais, como o peso A do tsvector.
+func MatchTask(t models.Task, terms []string) (SearchResult, bool) {
+	if len(terms) == 0 {
+		return SearchResult{}, false
+	}
+
+	titleWords := strings.Fields(t.Title)
+	descriptionWords := strings.Fields(t.Description)
+
+	var rank float64
+	for _, term := range terms {
+		titleHits := countHits(titleWords, []string{term})
+		descriptionHits := countHits(descriptionWords, []string{term})
+		if titleHits+descriptionHits == 0 {
+			return SearchResult{}, false
+		}
+		rank += float64(titleHits) + 0.4*float64(descriptionHits)
+	}
+	rank /= float64(len(terms))
+
+	return SearchResult{
+		Task:    t,
+		Rank:    rank,
+		Snippet: Highlight(append(titleWords, descriptionWords...), terms),
+	}, true
+}
+
+func countHits(words, terms []string) int {
+	hits := 0
+	for _, word := range words {
+		if matchesAny(word, terms) {
+			hits++
+		}
+	}
+	return hits
+}
+
+func matchesAny(word string, terms []string) bool {
+	folded := foldWord(word)
+	if folded == "" {
+		return false
+	}
+	for _, term := range terms {
+		if strings.HasPrefix(folded, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// Highlight monta o snippet: até snippetWords palavras a partir de pouco antes
+// do primeiro acerto, com o HTML escapado e os acertos entre HighlightStart e
+// HighlightStop.
+func Highlight(words, terms []string) string {
+	first := 0
+	for i, word := range words {
+		if matchesAny(word, terms) {
+			first = i
+			break
+		}
+	}
+
+	start := max(first-snippetWords/4, 0)
+	end := min(start+snippetWords, len(words))
+
+	parts := make([]string, 0, end-start)
+	for _, word := range words[start:end] {
+		escaped := html.EscapeString(word)
+		if matchesAny(word, terms) {
+			escaped = HighlightStart + escaped + HighlightStop
+		}
+		parts = append(parts, escaped)
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,68 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+func TestSearchTerms(t *testing.T) {
+	got := SearchTerms("  Reunião,  CAFÉ! ")
+	want := []string{"reuniao", "cafe"}
+	if len(got) != len(want) {
+		t.Fatalf("SearchTerms = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("SearchTerms = %v, want %v", got, want)
+		}
+	}
+}
+
+func TestMatchTask(t *testing.T) {
+	tk := models.Task{Title: "Comprar café", Description: "Passar no mercado e comprar pão"}
+
+	tests := []struct {
+		name        string
+		query       string
+		task        *models.Task
+		wantOK      bool
+		wantSnippet string
+	}{
+		{name: "accent insensitive", query: "cafe", wantOK: true, wantSnippet: "Comprar <mark>café</mark> Passar no mercado e comprar pão"},
+		{name: "prefix", query: "merc", wantOK: true, wantSnippet: "Comprar café Passar no <mark>mercado</mark> e comprar pão"},
+		{name: "all terms", query: "comprar pão", wantOK: true, wantSnippet: "<mark>Comprar</mark> café Passar no mercado e <mark>comprar</mark> <mark>pão</mark>"},
+		{name: "missing term", query: "café leite", wantOK: false},
+		{name: "escapes html", query: "pao", task: &models.Task{Title: "café <b>&", Description: "pão"}, wantOK: true, wantSnippet: "café &lt;b&gt;&amp; <mark>pão</mark>"},
+		{name: "empty", query: "  ", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			subject := tk
+			if tt.task != nil {
+				subject = *tt.task
+			}
+			got, ok := MatchTask(subject, SearchTerms(tt.query))
+			if ok != tt.wantOK {
+				t.Fatalf("ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && got.Snippet != tt.wantSnippet {
+				t.Fatalf("snippet = %q, want %q", got.Snippet, tt.wantSnippet)
+			}
+		})
+	}
+
+	title, _ := MatchTask(models.Task{Title: "café"}, SearchTerms("café"))
+	description, _ := MatchTask(models.Task{Title: "x", Description: "café"}, SearchTerms("café"))
+	if title.Rank <= description.Rank {
+		t.Fatalf("title hit rank %v should beat description hit rank %v", title.Rank, description.Rank)
+	}
+}
+
+func TestEscapeHeadline(t *testing.T) {
+	got := EscapeHeadline("a <b> & " + HeadlineStart + "c\"d" + HeadlineStop)
+	if want := "a &lt;b&gt; &amp; <mark>c&#34;d</mark>"; got != want {
+		t.Fatalf("EscapeHeadline = %q, want %q", got, want)
+	}
+}
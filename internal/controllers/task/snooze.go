@@ -0,0 +1,83 @@
+package task
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSnooze = errors.New("adiamento inválido")
+
+const defaultSnoozeHour = 9
+
+// ParseSnooze converte a entrada do usuário no novo horário do lembrete. Aceita
+// durações ("10m", "1h30m", "2d"), "amanhã"/"tomorrow" com hora opcional,
+// "hoje"/"today" com hora, só a hora ("18:30") ou a data completa
+// ("02/01/2006 15:04").
+func ParseSnooze(input string, now time.Time) (time.Time, error) {
+	s := strings.ToLower(strings.TrimSpace(input))
+	if s == "" {
+		return time.Time{}, ErrInvalidSnooze
+	}
+
+	if d, err := time.ParseDuration(s); err == nil {
+		if d <= 0 {
+			return time.Time{}, ErrInvalidSnooze
+		}
+		return now.Add(d), nil
+	}
+
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return now.AddDate(0, 0, n), nil
+		}
+	}
+
+	if t, err := time.ParseInLocation("02/01/2006 15:04", s, now.Location()); err == nil {
+		return t, nil
+	}
+
+	day, clock, _ := strings.Cut(s, " ")
+	switch day {
+	case "tomorrow", "amanhã", "amanha":
+		return atClock(now.AddDate(0, 0, 1), clock, defaultSnoozeHour)
+	case "today", "hoje":
+		if clock == "" {
+			return time.Time{}, ErrInvalidSnooze
+		}
+		return atClock(now, clock, 0)
+	}
+
+	if clock == "" {
+		t, err := atClock(now, day, 0)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if !t.After(now) {
+			t = t.AddDate(0, 0, 1)
+		}
+		return t, nil
+	}
+
+	return time.Time{}, ErrInvalidSnooze
+}
+
+func atClock(day time.Time, clock string, defaultHour int) (time.Time, error) {
+	hour, minute := defaultHour, 0
+
+	if clock != "" {
+		t, err := time.Parse("15:04", clock)
+		if err != nil {
+			h, err := strconv.Atoi(strings.TrimSuffix(clock, "h"))
+			if err != nil || h < 0 || h > 23 {
+				return time.Time{}, ErrInvalidSnooze
+			}
+			t = time.Date(0, 1, 1, h, 0, 0, 0, time.UTC)
+		}
+		hour, minute = t.Hour(), t.Minute()
+	}
+
+	y, m, d := day.Date()
+	return time.Date(y, m, d, hour, minute, 0, 0, day.Location()), nil
+}
@@ -0,0 +1,56 @@
+package task
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseSnooze(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2025, 3, 10, 14, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    time.Time
+		wantErr bool
+	}{
+		{name: "minutes", input: "10m", want: now.Add(10 * time.Minute)},
+		{name: "hours and minutes", input: "1h30m", want: now.Add(90 * time.Minute)},
+		{name: "days", input: "2d", want: now.AddDate(0, 0, 2)},
+		{name: "tomorrow defaults to 9:00", input: "tomorrow", want: time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC)},
+		{name: "tomorrow with time", input: "tomorrow 9:00", want: time.Date(2025, 3, 11, 9, 0, 0, 0, time.UTC)},
+		{name: "pt-br amanhã with hour", input: "Amanhã 8h", want: time.Date(2025, 3, 11, 8, 0, 0, 0, time.UTC)},
+		{name: "today with time", input: "hoje 18:30", want: time.Date(2025, 3, 10, 18, 30, 0, 0, time.UTC)},
+		{name: "clock later today", input: "16:00", want: time.Date(2025, 3, 10, 16, 0, 0, 0, time.UTC)},
+		{name: "clock already passed rolls to tomorrow", input: "08:00", want: time.Date(2025, 3, 11, 8, 0, 0, 0, time.UTC)},
+		{name: "absolute date", input: "12/03/2025 10:15", want: time.Date(2025, 3, 12, 10, 15, 0, 0, time.UTC)},
+		{name: "negative duration", input: "-10m", wantErr: true},
+		{name: "today without time", input: "hoje", wantErr: true},
+		{name: "garbage", input: "daqui a pouco", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseSnooze(tt.input, now)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidSnooze) {
+					t.Fatalf("ParseSnooze(%q) error = %v, want %v", tt.input, err, ErrInvalidSnooze)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSnooze(%q) unexpected error: %v", tt.input, err)
+			}
+			if !got.Equal(tt.want) {
+				t.Fatalf("ParseSnooze(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
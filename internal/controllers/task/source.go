@@ -0,0 +1,51 @@
+package task
+
+import (
+	"context"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	SourceCLI     = "cli"
+	SourceUnknown = "desconhecida"
+	// MaxSourceLength é o tamanho da coluna task_history.source.
+	MaxSourceLength = 100
+)
+
+type sourceKey struct{}
+
+// WithSource marca ctx com a origem das alterações feitas com ele (a CLI ou
+// o ID da requisição da API), registrada no histórico das tarefas.
+func WithSource(ctx context.Context, source string) context.Context {
+	return context.WithValue(ctx, sourceKey{}, source)
+}
+
+func SourceFrom(ctx context.Context) string {
+	if source, ok := ctx.Value(sourceKey{}).(string); ok && source != "" {
+		return source
+	}
+	return SourceUnknown
+}
+
+// APISource é a origem de uma requisição da API. O ID vem do cabeçalho
+// X-Request-Id do cliente, então perde os caracteres de controle e é cortado
+// para caber na coluna.
+func APISource(reqID string) string {
+	reqID = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == utf8.RuneError {
+			return -1
+		}
+		return r
+	}, reqID)
+	if reqID == "" {
+		return "api"
+	}
+	source := "api:" + reqID
+	for len(source) > MaxSourceLength {
+		_, size := utf8.DecodeLastRuneInString(source)
+		source = source[:len(source)-size]
+	}
+	return source
+}
@@ -0,0 +1,57 @@
+package task
+
+import (
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+var (
+	ErrInvalidStatus     = errors.New("status inválido")
+	ErrInvalidTransition = errors.New("transição de status não permitida")
+)
+
+// transitions diz para onde cada status pode ir. Fechada, a tarefa só volta
+// para todo (reabrir).
+var transitions = map[models.TaskStatus][]models.TaskStatus{
+	models.StatusTodo:       {models.StatusInProgress, models.StatusBlocked, models.StatusWaiting, models.StatusDone, models.StatusCancelled},
+	models.StatusInProgress: {models.StatusTodo, models.StatusBlocked, models.StatusWaiting, models.StatusDone, models.StatusCancelled},
+	models.StatusBlocked:    {models.StatusTodo, models.StatusInProgress, models.StatusWaiting, models.StatusDone, models.StatusCancelled},
+	models.StatusWaiting:    {models.StatusTodo, models.StatusInProgress, models.StatusBlocked, models.StatusDone, models.StatusCancelled},
+	models.StatusDone:       {models.StatusTodo},
+	models.StatusCancelled:  {models.StatusTodo},
+}
+
+func ParseStatus(input string) (models.TaskStatus, error) {
+	s := strings.ToLower(strings.TrimSpace(input))
+	s = strings.NewReplacer("-", "_", " ", "_").Replace(s)
+
+	switch s {
+	case "todo", "pendente", "a_fazer":
+		return models.StatusTodo, nil
+	case "in_progress", "doing", "em_andamento", "andamento":
+		return models.StatusInProgress, nil
+	case "blocked", "bloqueada":
+		return models.StatusBlocked, nil
+	case "waiting", "aguardando":
+		return models.StatusWaiting, nil
+	case "done", "concluida", "concluída":
+		return models.StatusDone, nil
+	case "cancelled", "canceled", "cancelada":
+		return models.StatusCancelled, nil
+	}
+	return "", ErrInvalidStatus
+}
+
+func ValidStatus(status models.TaskStatus) bool {
+	_, ok := transitions[status]
+	return ok
+}
+
+// CanTransition diz se a tarefa pode ir de from para to. Ficar no mesmo
+// status é sempre permitido.
+func CanTransition(from, to models.TaskStatus) bool {
+	return from == to || slices.Contains(transitions[from], to)
+}
@@ -0,0 +1,66 @@
+package task
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+func TestParseStatus(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    models.TaskStatus
+		wantErr bool
+	}{
+		{input: "todo", want: models.StatusTodo},
+		{input: "in-progress", want: models.StatusInProgress},
+		{input: "Em andamento", want: models.StatusInProgress},
+		{input: "aguardando", want: models.StatusWaiting},
+		{input: "bloqueada", want: models.StatusBlocked},
+		{input: "concluída", want: models.StatusDone},
+		{input: "canceled", want: models.StatusCancelled},
+		{input: "", wantErr: true},
+		{input: "talvez", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseStatus(tt.input)
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidStatus) {
+					t.Fatalf("err = %v, want ErrInvalidStatus", err)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("ParseStatus(%q) = %q, %v; want %q", tt.input, got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCanTransition(t *testing.T) {
+	tests := []struct {
+		from, to models.TaskStatus
+		want     bool
+	}{
+		{models.StatusTodo, models.StatusInProgress, true},
+		{models.StatusInProgress, models.StatusWaiting, true},
+		{models.StatusWaiting, models.StatusDone, true},
+		{models.StatusBlocked, models.StatusCancelled, true},
+		{models.StatusDone, models.StatusTodo, true},
+		{models.StatusCancelled, models.StatusTodo, true},
+		{models.StatusDone, models.StatusDone, true},
+		{models.StatusDone, models.StatusInProgress, false},
+		{models.StatusDone, models.StatusCancelled, false},
+		{models.StatusCancelled, models.StatusDone, false},
+		{models.StatusTodo, "archived", false},
+	}
+
+	for _, tt := range tests {
+		if got := CanTransition(tt.from, tt.to); got != tt.want {
+			t.Errorf("CanTransition(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+}
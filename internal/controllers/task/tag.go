@@ -0,0 +1,128 @@
+package task
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+var (
+	ErrInvalidTag      = errors.New("tag inválida: use letras, números, - ou _, sem - no início (até 50 caracteres)")
+	ErrInvalidTagColor = errors.New("cor de tag inválida")
+)
+
+const maxTagLength = 50
+
+// TagColors são as cores aceitas para uma tag: as cores ANSI básicas que a
+// CLI sabe mostrar. Vazio usa a cor padrão do terminal.
+var TagColors = []string{"red", "green", "yellow", "blue", "magenta", "cyan", "white", "gray"}
+
+// NormalizeTag devolve o nome canônico da tag: sem o "#" e em minúsculas.
+func NormalizeTag(name string) (string, error) {
+	name = strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "#"))
+	if name == "" || utf8.RuneCountInString(name) > maxTagLength {
+		return "", ErrInvalidTag
+	}
+	for i, r := range name {
+		if !isTagRune(r, i == 0) {
+			return "", ErrInvalidTag
+		}
+	}
+	return name, nil
+}
+
+// isTagRune não aceita "-" no começo: no filtro de tags ele quer dizer
+// exclusão, e "-urgente" não teria como ser filtrada.
+func isTagRune(r rune, first bool) bool {
+	if r == '-' {
+		return !first
+	}
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_'
+}
+
+func ValidTagColor(color string) bool {
+	if color == "" {
+		return true
+	}
+	for _, c := range TagColors {
+		if c == color {
+			return true
+		}
+	}
+	return false
+}
+
+// ExtractTags tira as palavras "#tag" do texto e devolve o texto limpo e os
+// nomes normalizados, sem repetição. "#" sozinho ou seguido de algo que não
+// forma uma tag válida fica no texto.
+func ExtractTags(text string) (string, []string) {
+	var kept, tags []string
+	seen := map[string]bool{}
+	for _, word := range strings.Fields(text) {
+		if !strings.HasPrefix(word, "#") {
+			kept = append(kept, word)
+			continue
+		}
+		name, err := NormalizeTag(word)
+		if err != nil {
+			kept = append(kept, word)
+			continue
+		}
+		if !seen[name] {
+			seen[name] = true
+			tags = append(tags, name)
+		}
+	}
+	return strings.Join(kept, " "), tags
+}
+
+// TagFilter filtra por nome de tag: a tarefa precisa ter todas as de Include
+// e nenhuma das de Exclude.
+type TagFilter struct {
+	Include []string
+	Exclude []string
+}
+
+// ParseTagFilter lê os valores no formato da API (tag=homelab&tag=-urgente):
+// "-" na frente exclui a tag.
+func ParseTagFilter(values []string) (TagFilter, error) {
+	var f TagFilter
+	for _, value := range values {
+		exclude := strings.HasPrefix(value, "-")
+		name, err := NormalizeTag(strings.TrimPrefix(value, "-"))
+		if err != nil {
+			return TagFilter{}, err
+		}
+		if exclude {
+			f.Exclude = append(f.Exclude, name)
+		} else {
+			f.Include = append(f.Include, name)
+		}
+	}
+	return f, nil
+}
+
+func (f TagFilter) IsZero() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+func (f TagFilter) Matches(tags []models.Tag) bool {
+	names := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		names[tag.Name] = true
+	}
+	for _, name := range f.Include {
+		if !names[name] {
+			return false
+		}
+	}
+	for _, name := range f.Exclude {
+		if names[name] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,41 @@
+package task
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizeTag(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		want    string
+		wantErr error
+	}{
+		{name: "lowercase without hash", input: " #HomeLab ", want: "homelab"},
+		{name: "inner dash and underscore", input: "casa-nova_2", want: "casa-nova_2"},
+		{name: "accents", input: "Reunião", want: "reunião"},
+		{name: "leading dash", input: "-urgente", wantErr: ErrInvalidTag},
+		{name: "leading dash after hash", input: "#-urgente", wantErr: ErrInvalidTag},
+		{name: "space", input: "casa nova", wantErr: ErrInvalidTag},
+		{name: "empty", input: "#", wantErr: ErrInvalidTag},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeTag(tt.input)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Fatalf("NormalizeTag(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseTagFilter_DoubleDash(t *testing.T) {
+	if _, err := ParseTagFilter([]string{"--urgente"}); !errors.Is(err, ErrInvalidTag) {
+		t.Fatalf("err = %v, want ErrInvalidTag", err)
+	}
+}
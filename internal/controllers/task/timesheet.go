@@ -0,0 +1,189 @@
+package task
+
+import (
+	"cmp"
+	"encoding/csv"
+	"errors"
+	"io"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+var (
+	ErrInvalidPeriod = errors.New("período inválido")
+	// ErrTimerConflict indica que outro cronômetro já está rodando.
+	ErrTimerConflict = errors.New("outro cronômetro já está rodando")
+)
+
+// TimeQuery filtra os registros de tempo. O intervalo pega os registros que
+// cruzam [From, To); o que ainda roda vale até agora. O valor zero lista tudo.
+type TimeQuery struct {
+	TaskID *string
+	From   *time.Time
+	To     *time.Time
+}
+
+// Matches diz se e entra na consulta, contando o que roda até now.
+func (q TimeQuery) Matches(e models.TimeEntry, now time.Time) bool {
+	if q.TaskID != nil && e.TaskID != *q.TaskID {
+		return false
+	}
+	end := now
+	if e.StoppedAt != nil {
+		end = *e.StoppedAt
+	}
+	if q.From != nil && !end.After(*q.From) {
+		return false
+	}
+	return q.To == nil || e.StartedAt.Before(*q.To)
+}
+
+// ParsePeriod lê o período da folha de horas: os dias from e to, inclusive,
+// em "2006-01-02" ou "02/01/2006". Devolve o início de from e o fim de to
+// (a meia-noite seguinte) em loc.
+func ParsePeriod(from, to string, loc *time.Location) (time.Time, time.Time, error) {
+	start, err := parseDay(from, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidPeriod
+	}
+	end, err := parseDay(to, loc)
+	if err != nil || end.Before(start) {
+		return time.Time{}, time.Time{}, ErrInvalidPeriod
+	}
+	return start, end.AddDate(0, 0, 1), nil
+}
+
+func parseDay(input string, loc *time.Location) (time.Time, error) {
+	for _, layout := range []string{time.DateOnly, "02/01/2006"} {
+		if day, err := time.ParseInLocation(layout, input, loc); err == nil {
+			return day, nil
+		}
+	}
+	return time.Time{}, ErrInvalidPeriod
+}
+
+// TimesheetTask é o tempo de uma tarefa num dia.
+type TimesheetTask struct {
+	TaskID  string `json:"task_id"`
+	Title   string `json:"title"`
+	Seconds int64  `json:"seconds"`
+}
+
+// TimesheetProject é o tempo de um projeto num dia; ProjectID vazio junta as
+// tarefas sem projeto.
+type TimesheetProject struct {
+	ProjectID string          `json:"project_id,omitempty"`
+	Project   string          `json:"project"`
+	Seconds   int64           `json:"seconds"`
+	Tasks     []TimesheetTask `json:"tasks"`
+}
+
+type TimesheetDay struct {
+	Date     string             `json:"date" example:"2025-06-10"`
+	Seconds  int64              `json:"seconds"`
+	Projects []TimesheetProject `json:"projects"`
+}
+
+// Timesheet é a folha de horas de From a To (inclusive), por dia e projeto.
+type Timesheet struct {
+	From    string         `json:"from" example:"2025-06-01"`
+	To      string         `json:"to" example:"2025-06-30"`
+	Seconds int64          `json:"seconds"`
+	Days    []TimesheetDay `json:"days"`
+}
+
+// NoProject é o nome do grupo das tarefas sem projeto na folha de horas.
+const NoProject = "sem projeto"
+
+// BuildTimesheet soma os registros em [from, to) por dia (no fuso de from),
+// projeto e tarefa. Registros que passam da meia-noite são divididos entre os
+// dias; o que ainda roda conta até now. Os registros precisam da tarefa
+// carregada; projects dá o nome de cada projeto.
+func BuildTimesheet(entries []models.TimeEntry, projects map[string]string, from, to, now time.Time) Timesheet {
+	type key struct{ day, project, task string }
+	totals := map[key]int64{}
+	titles := map[string]string{}
+
+	loc := from.Location()
+	for _, e := range entries {
+		projectID := ""
+		if e.Task != nil {
+			titles[e.TaskID] = e.Task.Title
+			if e.Task.ProjectID != nil {
+				projectID = *e.Task.ProjectID
+			}
+		}
+
+		start := maxTime(e.StartedAt.In(loc), from)
+		end := minTime(e.StartedAt.Add(e.Duration(now)).In(loc), to)
+		for start.Before(end) {
+			midnight := time.Date(start.Year(), start.Month(), start.Day()+1, 0, 0, 0, 0, loc)
+			stop := minTime(midnight, end)
+			totals[key{start.Format(time.DateOnly), projectID, e.TaskID}] += int64(stop.Sub(start) / time.Second)
+			start = stop
+		}
+	}
+
+	sheet := Timesheet{From: from.Format(time.DateOnly), To: to.AddDate(0, 0, -1).Format(time.DateOnly), Days: []TimesheetDay{}}
+	days := map[string]*TimesheetDay{}
+	for k, seconds := range totals {
+		if seconds == 0 {
+			continue
+		}
+		day, ok := days[k.day]
+		if !ok {
+			day = &TimesheetDay{Date: k.day}
+			days[k.day] = day
+		}
+		i := slices.IndexFunc(day.Projects, func(p TimesheetProject) bool { return p.ProjectID == k.project })
+		if i < 0 {
+			name := NoProject
+			if k.project != "" {
+				name = cmp.Or(projects[k.project], k.project)
+			}
+			day.Projects = append(day.Projects, TimesheetProject{ProjectID: k.project, Project: name})
+			i = len(day.Projects) - 1
+		}
+		project := &day.Projects[i]
+		project.Tasks = append(project.Tasks, TimesheetTask{TaskID: k.task, Title: titles[k.task], Seconds: seconds})
+		project.Seconds += seconds
+		day.Seconds += seconds
+		sheet.Seconds += seconds
+	}
+
+	for _, day := range days {
+		slices.SortFunc(day.Projects, func(a, b TimesheetProject) int { return cmp.Compare(a.Project, b.Project) })
+		for _, p := range day.Projects {
+			slices.SortFunc(p.Tasks, func(a, b TimesheetTask) int {
+				return cmp.Or(cmp.Compare(a.Title, b.Title), cmp.Compare(a.TaskID, b.TaskID))
+			})
+		}
+		sheet.Days = append(sheet.Days, *day)
+	}
+	slices.SortFunc(sheet.Days, func(a, b TimesheetDay) int { return cmp.Compare(a.Date, b.Date) })
+	return sheet
+}
+
+// WriteCSV escreve a folha de horas com uma linha por dia, projeto e tarefa e
+// as horas em decimal (ex.: 1.25).
+func (t Timesheet) WriteCSV(w io.Writer) error {
+	out := csv.NewWriter(w)
+	if err := out.Write([]string{"date", "project", "task_id", "task", "hours"}); err != nil {
+		return err
+	}
+	for _, day := range t.Days {
+		for _, p := range day.Projects {
+			for _, task := range p.Tasks {
+				hours := strconv.FormatFloat(float64(task.Seconds)/3600, 'f', 2, 64)
+				if err := out.Write([]string{day.Date, p.Project, task.TaskID, task.Title, hours}); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	out.Flush()
+	return out.Error()
+}
@@ -0,0 +1,109 @@
+package task
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/andre-felipe-wonsik-alves/internal/models"
+)
+
+func TestParsePeriod(t *testing.T) {
+	loc := time.FixedZone("BRT", -3*60*60)
+
+	from, to, err := ParsePeriod("2025-06-01", "30/06/2025", loc)
+	if err != nil || !from.Equal(time.Date(2025, 6, 1, 0, 0, 0, 0, loc)) || !to.Equal(time.Date(2025, 7, 1, 0, 0, 0, 0, loc)) {
+		t.Fatalf("got %v - %v, %v", from, to, err)
+	}
+	for _, tt := range [][2]string{{"2025-06-10", "2025-06-09"}, {"ontem", "2025-06-09"}, {"2025-06-01", ""}} {
+		if _, _, err := ParsePeriod(tt[0], tt[1], loc); !errors.Is(err, ErrInvalidPeriod) {
+			t.Fatalf("ParsePeriod(%q, %q) err = %v, want ErrInvalidPeriod", tt[0], tt[1], err)
+		}
+	}
+}
+
+func TestTimeQueryMatches(t *testing.T) {
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	at := func(h int) *time.Time { t := now.Add(time.Duration(h) * time.Hour); return &t }
+	taskID := "a"
+	stopped := models.TimeEntry{TaskID: "a", StartedAt: *at(-5), StoppedAt: at(-4)}
+	running := models.TimeEntry{TaskID: "b", StartedAt: *at(-1)}
+
+	tests := []struct {
+		name  string
+		query TimeQuery
+		entry models.TimeEntry
+		want  bool
+	}{
+		{"zero value", TimeQuery{}, stopped, true},
+		{"task", TimeQuery{TaskID: &taskID}, running, false},
+		{"ended before from", TimeQuery{From: at(-4)}, stopped, false},
+		{"crosses from", TimeQuery{From: at(-5)}, stopped, true},
+		{"starts at to", TimeQuery{To: at(-5)}, stopped, false},
+		{"running counts until now", TimeQuery{From: at(-1)}, running, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.query.Matches(tt.entry, now); got != tt.want {
+				t.Fatalf("Matches = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildTimesheet(t *testing.T) {
+	from := time.Date(2025, 6, 9, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 2)
+	now := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	at := func(day, h, m int) time.Time {
+		return from.AddDate(0, 0, day).Add(time.Duration(h)*time.Hour + time.Duration(m)*time.Minute)
+	}
+	stop := func(tm time.Time) *time.Time { return &tm }
+	homelab := "p1"
+	server := &models.Task{Title: "Servidor", ProjectID: &homelab}
+	mail := &models.Task{Title: "E-mails"}
+
+	entries := []models.TimeEntry{
+		{TaskID: "server", Task: server, StartedAt: at(0, 9, 0), StoppedAt: stop(at(0, 10, 30))},
+		{TaskID: "server", Task: server, StartedAt: at(0, 23, 0), StoppedAt: stop(at(1, 1, 0))},
+		{TaskID: "mail", Task: mail, StartedAt: at(0, 14, 0), StoppedAt: stop(at(0, 14, 15))},
+		{TaskID: "mail", Task: mail, StartedAt: at(-1, 23, 30), StoppedAt: stop(at(0, 0, 30))},
+		{TaskID: "server", Task: server, StartedAt: at(1, 11, 0)},
+	}
+
+	sheet := BuildTimesheet(entries, map[string]string{homelab: "homelab"}, from, to, now)
+	if sheet.From != "2025-06-09" || sheet.To != "2025-06-10" || len(sheet.Days) != 2 {
+		t.Fatalf("unexpected sheet: %+v", sheet)
+	}
+
+	first := sheet.Days[0]
+	if first.Date != "2025-06-09" || first.Seconds != (150+45)*60 || len(first.Projects) != 2 {
+		t.Fatalf("first day = %+v", first)
+	}
+	if p := first.Projects[0]; p.Project != "homelab" || p.Seconds != 150*60 || len(p.Tasks) != 1 {
+		t.Fatalf("homelab on first day = %+v", p)
+	}
+	if p := first.Projects[1]; p.Project != NoProject || p.ProjectID != "" || p.Seconds != 45*60 {
+		t.Fatalf("no project on first day = %+v", p)
+	}
+	// 1h da virada do dia mais 1h do cronômetro que ainda roda.
+	if second := sheet.Days[1]; second.Seconds != 2*60*60 {
+		t.Fatalf("second day = %+v", second)
+	}
+	if sheet.Seconds != first.Seconds+sheet.Days[1].Seconds {
+		t.Fatalf("total = %d", sheet.Seconds)
+	}
+
+	var out bytes.Buffer
+	if err := sheet.WriteCSV(&out); err != nil {
+		t.Fatalf("csv: %v", err)
+	}
+	want := "date,project,task_id,task,hours\n" +
+		"2025-06-09,homelab,server,Servidor,2.50\n" +
+		"2025-06-09,sem projeto,mail,E-mails,0.75\n" +
+		"2025-06-10,homelab,server,Servidor,2.00\n"
+	if out.String() != want {
+		t.Fatalf("csv =\n%s\nwant\n%s", out.String(), want)
+	}
+}
@@ -0,0 +1,7 @@
+package task
+
+import "errors"
+
+// ErrVersionConflict indica que a tarefa mudou depois da versão que o
+// cliente leu (controle de concorrência otimista).
+var ErrVersionConflict = errors.New("a tarefa foi alterada por outra operação")
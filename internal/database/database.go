@@ -1,6 +1,7 @@
 package database
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,19 +11,18 @@ import (
 	"gorm.io/gorm/logger"
 )
 
+const (
+	DriverPostgres = "postgres"
+	DriverSQLite   = "sqlite"
+)
+
+var ErrUnknownDriver = errors.New("DB_DRIVER desconhecido (use postgres ou sqlite)")
+
 func Connect() (*gorm.DB, error) {
-	host := env.GetEnv("DB_HOST", "localhost")
-	port := env.GetEnv("DB_PORT", "5432")
-	user := env.GetEnv("DB_USER", "app_user")
-	password := env.GetEnv("DB_PASSWORD", "app_password")
-	databaseName := env.GetEnv("DB_NAME", "app_db")
+	driver := env.GetEnv("DB_DRIVER", DriverPostgres)
+	dsn := env.GetEnv("DB_DSN", "")
 	dev := env.GetEnv("DEVELOPMENT", "true")
 
-	dsn := fmt.Sprintf(
-		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable TimeZone=UTC",
-		host, port, user, password, databaseName,
-	)
-
 	logLevel := logger.Warn
 
 	if dev == "true" {
@@ -33,6 +33,21 @@ func Connect() (*gorm.DB, error) {
 		Logger: logger.Default.LogMode(logLevel),
 	}
 
+	switch driver {
+	case DriverPostgres:
+		return connectPostgres(dsn, gormCfg)
+	case DriverSQLite:
+		return OpenSQLite(dsn, gormCfg)
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownDriver, driver)
+	}
+}
+
+func connectPostgres(dsn string, gormCfg *gorm.Config) (*gorm.DB, error) {
+	if dsn == "" {
+		dsn = postgresDSN()
+	}
+
 	db, err := gorm.Open(postgres.Open(dsn), gormCfg)
 
 	if err != nil {
@@ -49,3 +64,16 @@ func Connect() (*gorm.DB, error) {
 
 	return db, nil
 }
+
+func postgresDSN() string {
+	host := env.GetEnv("DB_HOST", "localhost")
+	port := env.GetEnv("DB_PORT", "5432")
+	user := env.GetEnv("DB_USER", "app_user")
+	password := env.GetEnv("DB_PASSWORD", "app_password")
+	databaseName := env.GetEnv("DB_NAME", "app_db")
+
+	return fmt.Sprintf(
+		"host=%s port=%s user=%s password=%s dbname=%s sslmode=disable TimeZone=UTC",
+		host, port, user, password, databaseName,
+	)
+}
@@ -1,13 +1,301 @@
 package database
 
 import (
-	"github.com/andre-felipe-wonsik-alves/internal/models"
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	"gorm.io/gorm"
 )
 
-func AutoMigrate(db *gorm.DB) error {
-	if err := db.Exec("CREATE EXTENSION IF NOT EXISTS pgcrypto;").Error; err != nil {
+//go:embed migrations
+var migrationsFS embed.FS
+
+const schemaTable = "schema_migrations"
+
+var (
+	ErrSchemaOutdated   = errors.New("schema do banco desatualizado, rode `advisor-go migrate up`")
+	ErrUnknownMigration = errors.New("o banco tem migrations que este binário não conhece")
+	ErrInvalidMigration = errors.New("migration inválida")
+)
+
+var migrationFile = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+}
+
+type appliedMigration struct {
+	Version   int64
+	Name      string
+	AppliedAt time.Time
+}
+
+// LoadMigrations lê os pares NNNN_nome.up.sql / NNNN_nome.down.sql de fsys,
+// ordenados pela versão.
+func LoadMigrations(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		match := migrationFile.FindStringSubmatch(entry.Name())
+		if match == nil {
+			return nil, fmt.Errorf("%w: nome %q fora do padrão NNNN_nome.up|down.sql", ErrInvalidMigration, entry.Name())
+		}
+
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		content, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if m.Name != match[2] {
+			return nil, fmt.Errorf("%w: versão %d com nomes diferentes", ErrInvalidMigration, version)
+		}
+		if match[3] == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if strings.TrimSpace(m.Up) == "" {
+			return nil, fmt.Errorf("%w: versão %d sem arquivo up", ErrInvalidMigration, m.Version)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration
+}
+
+// NewMigrator carrega as migrations embutidas do dialeto do banco.
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	sub, err := fs.Sub(migrationsFS, "migrations/"+db.Dialector.Name())
+	if err != nil {
+		return nil, err
+	}
+	migrations, err := LoadMigrations(sub)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+func (m *Migrator) ensureSchemaTable(ctx context.Context) error {
+	return m.db.WithContext(ctx).Exec(`CREATE TABLE IF NOT EXISTS ` + schemaTable + ` (
+		version    BIGINT PRIMARY KEY,
+		name       VARCHAR(255) NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	)`).Error
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int64]appliedMigration, error) {
+	if err := m.ensureSchemaTable(ctx); err != nil {
+		return nil, err
+	}
+
+	var rows []appliedMigration
+	if err := m.db.WithContext(ctx).Table(schemaTable).Order("version").Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[int64]appliedMigration, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+func (m *Migrator) Status(ctx context.Context) ([]MigrationStatus, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	status := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		s := MigrationStatus{Migration: migration}
+		if row, ok := applied[migration.Version]; ok {
+			appliedAt := row.AppliedAt
+			s.AppliedAt = &appliedAt
+		}
+		status = append(status, s)
+	}
+	return status, nil
+}
+
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	known := map[int64]bool{}
+	var pending []Migration
+	for _, migration := range m.migrations {
+		known[migration.Version] = true
+		if _, ok := applied[migration.Version]; !ok {
+			pending = append(pending, migration)
+		}
+	}
+	for version := range applied {
+		if !known[version] {
+			return nil, fmt.Errorf("%w: versão %d", ErrUnknownMigration, version)
+		}
+	}
+	return pending, nil
+}
+
+// EnsureUpToDate falha se houver migrations pendentes; a aplicação não altera
+// o schema sozinha na inicialização.
+func (m *Migrator) EnsureUpToDate(ctx context.Context) error {
+	pending, err := m.Pending(ctx)
+	if err != nil {
 		return err
 	}
-	return db.AutoMigrate(&models.Task{})
+	if len(pending) > 0 {
+		return fmt.Errorf("%w (%d pendente(s), a partir da %04d_%s)", ErrSchemaOutdated, len(pending), pending[0].Version, pending[0].Name)
+	}
+	return nil
+}
+
+// Up aplica todas as migrations pendentes, cada uma na própria transação.
+func (m *Migrator) Up(ctx context.Context) ([]Migration, error) {
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range pending {
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return err
+			}
+			return tx.Exec(
+				"INSERT INTO "+schemaTable+" (version, name, applied_at) VALUES (?, ?, ?)",
+				migration.Version, migration.Name, time.Now().UTC(),
+			).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration)
+	}
+	return done, nil
+}
+
+// Down desfaz as últimas steps migrations aplicadas, da mais nova para a mais
+// antiga.
+func (m *Migrator) Down(ctx context.Context, steps int) ([]Migration, error) {
+	status, err := m.Status(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(status) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := status[i]
+		if migration.AppliedAt == nil {
+			continue
+		}
+		if strings.TrimSpace(migration.Down) == "" {
+			return done, fmt.Errorf("%w: %04d_%s não tem arquivo down", ErrInvalidMigration, migration.Version, migration.Name)
+		}
+
+		err := m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+			if err := tx.Exec(migration.Down).Error; err != nil {
+				return err
+			}
+			return tx.Exec("DELETE FROM "+schemaTable+" WHERE version = ?", migration.Version).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %04d_%s: %w", migration.Version, migration.Name, err)
+		}
+		done = append(done, migration.Migration)
+	}
+	return done, nil
+}
+
+// CreateMigration gera os arquivos up/down vazios da próxima versão em cada
+// subdiretório de dialeto de dir e devolve os caminhos criados.
+func CreateMigration(dir, name string) ([]string, error) {
+	name = strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, fmt.Errorf("%w: nome vazio", ErrInvalidMigration)
+	}
+
+	dialects, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var next int64
+	for _, dialect := range dialects {
+		if !dialect.IsDir() {
+			continue
+		}
+		migrations, err := LoadMigrations(os.DirFS(filepath.Join(dir, dialect.Name())))
+		if err != nil {
+			return nil, err
+		}
+		if n := len(migrations); n > 0 && migrations[n-1].Version > next {
+			next = migrations[n-1].Version
+		}
+	}
+	next++
+
+	var created []string
+	for _, dialect := range dialects {
+		if !dialect.IsDir() {
+			continue
+		}
+		for _, direction := range []string{"up", "down"} {
+			path := filepath.Join(dir, dialect.Name(), fmt.Sprintf("%04d_%s.%s.sql", next, name, direction))
+			header := fmt.Sprintf("-- %04d_%s (%s)\n", next, name, direction)
+			if err := os.WriteFile(path, []byte(header), 0o644); err != nil {
+				return created, err
+			}
+			created = append(created, path)
+		}
+	}
+	return created, nil
 }
@@ -0,0 +1,150 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	t.Parallel()
+
+	t.Run("pairs and sorts by version", func(t *testing.T) {
+		t.Parallel()
+
+		fsys := fstest.MapFS{
+			"0002_add_tags.up.sql":       {Data: []byte("ALTER TABLE tasks ADD COLUMN tags TEXT;")},
+			"0002_add_tags.down.sql":     {Data: []byte("ALTER TABLE tasks DROP COLUMN tags;")},
+			"0001_create_tasks.up.sql":   {Data: []byte("CREATE TABLE tasks (id TEXT);")},
+			"0001_create_tasks.down.sql": {Data: []byte("DROP TABLE tasks;")},
+		}
+
+		migrations, err := LoadMigrations(fsys)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(migrations) != 2 {
+			t.Fatalf("migrations = %d, want 2", len(migrations))
+		}
+		if migrations[0].Version != 1 || migrations[0].Name != "create_tasks" || migrations[1].Version != 2 {
+			t.Fatalf("unexpected order: %+v", migrations)
+		}
+		if migrations[1].Down == "" {
+			t.Fatalf("expected down script for version 2")
+		}
+	})
+
+	t.Run("rejects invalid files", func(t *testing.T) {
+		t.Parallel()
+
+		for name, fsys := range map[string]fstest.MapFS{
+			"bad name":      {"create_tasks.sql": {Data: []byte("SELECT 1;")}},
+			"missing up":    {"0001_create_tasks.down.sql": {Data: []byte("DROP TABLE tasks;")}},
+			"name mismatch": {"0001_a.up.sql": {Data: []byte("SELECT 1;")}, "0001_b.down.sql": {Data: []byte("SELECT 1;")}},
+		} {
+			if _, err := LoadMigrations(fsys); !errors.Is(err, ErrInvalidMigration) {
+				t.Fatalf("%s: expected ErrInvalidMigration, got %v", name, err)
+			}
+		}
+	})
+}
+
+func TestEmbeddedMigrations(t *testing.T) {
+	t.Parallel()
+
+	dialects, err := fs.ReadDir(migrationsFS, "migrations")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, dialect := range dialects {
+		sub, _ := fs.Sub(migrationsFS, "migrations/"+dialect.Name())
+		migrations, err := LoadMigrations(sub)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", dialect.Name(), err)
+		}
+		for i, m := range migrations {
+			if m.Version != int64(i+1) {
+				t.Fatalf("%s: version %d out of sequence at position %d", dialect.Name(), m.Version, i)
+			}
+			if m.Down == "" {
+				t.Fatalf("%s: %04d_%s has no down script", dialect.Name(), m.Version, m.Name)
+			}
+		}
+	}
+}
+
+func TestCreateMigration(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	postgres := filepath.Join(dir, "postgres")
+	if err := os.MkdirAll(postgres, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(postgres, "0001_create_tasks.up.sql"), []byte("SELECT 1;"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := CreateMigration(dir, "Add Tags!")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{
+		filepath.Join(postgres, "0002_add_tags.up.sql"),
+		filepath.Join(postgres, "0002_add_tags.down.sql"),
+	}
+	if len(created) != len(want) || created[0] != want[0] || created[1] != want[1] {
+		t.Fatalf("created = %v, want %v", created, want)
+	}
+	for _, path := range want {
+		if _, err := os.Stat(path); err != nil {
+			t.Fatalf("expected %s to exist: %v", path, err)
+		}
+	}
+}
+
+func TestMigratorSQLite(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	db, err := OpenSQLite(":memory:", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := migrator.EnsureUpToDate(ctx); !errors.Is(err, ErrSchemaOutdated) {
+		t.Fatalf("EnsureUpToDate on empty db = %v, want ErrSchemaOutdated", err)
+	}
+
+	applied, err := migrator.Up(ctx)
+	if err != nil {
+		t.Fatalf("up: %v", err)
+	}
+	if len(applied) != len(migrator.migrations) {
+		t.Fatalf("applied = %d, want %d", len(applied), len(migrator.migrations))
+	}
+	if err := migrator.EnsureUpToDate(ctx); err != nil {
+		t.Fatalf("EnsureUpToDate after up: %v", err)
+	}
+
+	reverted, err := migrator.Down(ctx, len(applied))
+	if err != nil {
+		t.Fatalf("down: %v", err)
+	}
+	if len(reverted) != len(applied) {
+		t.Fatalf("reverted = %d, want %d", len(reverted), len(applied))
+	}
+	if _, err := migrator.Up(ctx); err != nil {
+		t.Fatalf("up after down: %v", err)
+	}
+}
@@ -0,0 +1,19 @@
+CREATE EXTENSION IF NOT EXISTS pgcrypto;
+
+CREATE TABLE IF NOT EXISTS tasks (
+    id          UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    title       TEXT NOT NULL,
+    description TEXT,
+    priority    VARCHAR(10) NOT NULL,
+    reminder_at TIMESTAMPTZ,
+    done        BOOLEAN DEFAULT FALSE,
+    parent_id   UUID,
+    created_at  TIMESTAMPTZ,
+    updated_at  TIMESTAMPTZ,
+    deleted_at  TIMESTAMPTZ,
+    CONSTRAINT fk_tasks_children FOREIGN KEY (parent_id) REFERENCES tasks (id)
+        ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks (parent_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks (deleted_at);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_tasks_series_id;
+DROP INDEX IF EXISTS idx_tasks_reminder_at;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS snooze_count;
+ALTER TABLE tasks DROP COLUMN IF EXISTS series_id;
+ALTER TABLE tasks DROP COLUMN IF EXISTS recurrence;
+ALTER TABLE tasks DROP COLUMN IF EXISTS channels;
@@ -0,0 +1,7 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS channels VARCHAR(255);
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS recurrence VARCHAR(255);
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS series_id UUID;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS snooze_count BIGINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_reminder_at ON tasks (reminder_at);
+CREATE INDEX IF NOT EXISTS idx_tasks_series_id ON tasks (series_id);
@@ -0,0 +1,10 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS reminder_sent_at TIMESTAMPTZ;
+
+UPDATE tasks t
+SET reminder_sent_at = r.delivered_at
+FROM reminders r
+WHERE r.task_id = t.id
+  AND r.fire_at = t.reminder_at
+  AND r.delivered_at IS NOT NULL;
+
+DROP TABLE IF EXISTS reminders;
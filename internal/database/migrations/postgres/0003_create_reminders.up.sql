@@ -0,0 +1,27 @@
+CREATE TABLE IF NOT EXISTS reminders (
+    id           UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id      UUID NOT NULL,
+    at           TIMESTAMPTZ,
+    fire_offset  VARCHAR(32),
+    channel      VARCHAR(20),
+    fire_at      TIMESTAMPTZ NOT NULL,
+    delivered_at TIMESTAMPTZ,
+    created_at   TIMESTAMPTZ,
+    updated_at   TIMESTAMPTZ,
+    CONSTRAINT fk_tasks_reminders FOREIGN KEY (task_id) REFERENCES tasks (id)
+        ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminders_task_id ON reminders (task_id);
+CREATE INDEX IF NOT EXISTS idx_reminders_fire_at ON reminders (fire_at);
+
+-- Bancos criados pelo AutoMigrate podem ter (ou não) a coluna reminder_sent_at.
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS reminder_sent_at TIMESTAMPTZ;
+
+INSERT INTO reminders (task_id, fire_offset, fire_at, delivered_at, created_at, updated_at)
+SELECT t.id, '0', t.reminder_at, t.reminder_sent_at, now(), now()
+FROM tasks t
+WHERE t.reminder_at > '0001-01-02'
+  AND NOT EXISTS (SELECT 1 FROM reminders r WHERE r.task_id = t.id);
+
+ALTER TABLE tasks DROP COLUMN reminder_sent_at;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS tags (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    name       VARCHAR(50) NOT NULL,
+    color      VARCHAR(20),
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tags_name ON tags (name);
+
+CREATE TABLE IF NOT EXISTS task_tags (
+    task_id UUID NOT NULL,
+    tag_id  UUID NOT NULL,
+    PRIMARY KEY (task_id, tag_id),
+    CONSTRAINT fk_task_tags_task FOREIGN KEY (task_id) REFERENCES tasks (id)
+        ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT fk_task_tags_tag FOREIGN KEY (tag_id) REFERENCES tags (id)
+        ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_tags_tag_id ON task_tags (tag_id);
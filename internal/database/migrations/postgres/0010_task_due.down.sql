@@ -0,0 +1,6 @@
+ALTER TABLE reminders DROP COLUMN IF EXISTS anchor;
+
+DROP INDEX IF EXISTS idx_tasks_due_at;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_all_day;
+ALTER TABLE tasks DROP COLUMN IF EXISTS due_at;
@@ -0,0 +1,6 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_at TIMESTAMPTZ;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS due_all_day BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_due_at ON tasks (due_at);
+
+ALTER TABLE reminders ADD COLUMN IF NOT EXISTS anchor VARCHAR(10);
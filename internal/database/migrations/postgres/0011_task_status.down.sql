@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_tasks_status;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS cancelled_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS completed_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS started_at;
+ALTER TABLE tasks DROP COLUMN IF EXISTS status;
@@ -0,0 +1,8 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS status VARCHAR(16) NOT NULL DEFAULT 'todo';
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS started_at TIMESTAMPTZ;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS completed_at TIMESTAMPTZ;
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS cancelled_at TIMESTAMPTZ;
+
+UPDATE tasks SET status = 'done', completed_at = updated_at WHERE done;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks (status);
@@ -0,0 +1 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS estimate_minutes INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS time_entries (
+    id         UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id    UUID NOT NULL,
+    started_at TIMESTAMPTZ NOT NULL,
+    stopped_at TIMESTAMPTZ,
+    note       TEXT,
+    created_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ,
+    CONSTRAINT fk_tasks_time_entries FOREIGN KEY (task_id) REFERENCES tasks (id)
+        ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_time_entries_task_id ON time_entries (task_id);
+CREATE INDEX IF NOT EXISTS idx_time_entries_started_at ON time_entries (started_at);
+CREATE INDEX IF NOT EXISTS idx_time_entries_stopped_at ON time_entries (stopped_at);
@@ -0,0 +1,4 @@
+ALTER TABLE reminders DROP COLUMN IF EXISTS last_error;
+ALTER TABLE reminders DROP COLUMN IF EXISTS retry_channels;
+ALTER TABLE reminders DROP COLUMN IF EXISTS retry_at;
+ALTER TABLE reminders DROP COLUMN IF EXISTS attempts;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS tasks (
+    id          TEXT PRIMARY KEY,
+    title       TEXT NOT NULL,
+    description TEXT,
+    priority    VARCHAR(10) NOT NULL,
+    reminder_at DATETIME,
+    done        BOOLEAN DEFAULT FALSE,
+    parent_id   TEXT,
+    created_at  DATETIME,
+    updated_at  DATETIME,
+    deleted_at  DATETIME,
+    CONSTRAINT fk_tasks_children FOREIGN KEY (parent_id) REFERENCES tasks (id)
+        ON UPDATE CASCADE ON DELETE SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_parent_id ON tasks (parent_id);
+CREATE INDEX IF NOT EXISTS idx_tasks_deleted_at ON tasks (deleted_at);
@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_tasks_series_id;
+DROP INDEX IF EXISTS idx_tasks_reminder_at;
+
+ALTER TABLE tasks DROP COLUMN snooze_count;
+ALTER TABLE tasks DROP COLUMN series_id;
+ALTER TABLE tasks DROP COLUMN recurrence;
+ALTER TABLE tasks DROP COLUMN channels;
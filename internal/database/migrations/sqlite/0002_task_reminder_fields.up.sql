@@ -0,0 +1,7 @@
+ALTER TABLE tasks ADD COLUMN channels VARCHAR(255);
+ALTER TABLE tasks ADD COLUMN recurrence VARCHAR(255);
+ALTER TABLE tasks ADD COLUMN series_id TEXT;
+ALTER TABLE tasks ADD COLUMN snooze_count INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_reminder_at ON tasks (reminder_at);
+CREATE INDEX IF NOT EXISTS idx_tasks_series_id ON tasks (series_id);
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS reminders (
+    id           TEXT PRIMARY KEY,
+    task_id      TEXT NOT NULL,
+    at           DATETIME,
+    fire_offset  VARCHAR(32),
+    channel      VARCHAR(20),
+    fire_at      DATETIME NOT NULL,
+    delivered_at DATETIME,
+    created_at   DATETIME,
+    updated_at   DATETIME,
+    CONSTRAINT fk_tasks_reminders FOREIGN KEY (task_id) REFERENCES tasks (id)
+        ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reminders_task_id ON reminders (task_id);
+CREATE INDEX IF NOT EXISTS idx_reminders_fire_at ON reminders (fire_at);
+
+-- Mesmo formato de UUID v4 que o Go gera, para as linhas criadas aqui.
+INSERT INTO reminders (id, task_id, fire_offset, fire_at, created_at, updated_at)
+SELECT lower(
+           hex(randomblob(4)) || '-' || hex(randomblob(2)) || '-4' ||
+           substr(hex(randomblob(2)), 2) || '-' ||
+           substr('89ab', 1 + (abs(random()) % 4), 1) ||
+           substr(hex(randomblob(2)), 2) || '-' || hex(randomblob(6))
+       ),
+       t.id, '0', t.reminder_at, datetime('now'), datetime('now')
+FROM tasks t
+WHERE t.reminder_at > '0001-01-02'
+  AND NOT EXISTS (SELECT 1 FROM reminders r WHERE r.task_id = t.id);
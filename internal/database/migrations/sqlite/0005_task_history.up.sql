@@ -0,0 +1,13 @@
+-- Histórico append-only: sem FK para tasks, para sobreviver ao purge.
+CREATE TABLE IF NOT EXISTS task_history (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    task_id      TEXT NOT NULL,
+    action       VARCHAR(20) NOT NULL,
+    field        VARCHAR(64) NOT NULL,
+    before_value TEXT,
+    after_value  TEXT,
+    source       VARCHAR(100) NOT NULL,
+    created_at   DATETIME NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_history_task_id ON task_history (task_id, id);
@@ -0,0 +1,3 @@
+-- O driver do SQLite não tem FTS5; a busca é feita em Go (SQLiteStore.Search).
+-- Migration mantida para a numeração seguir igual à do Postgres.
+SELECT 1;
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS projects (
+    id                      TEXT PRIMARY KEY,
+    name                    VARCHAR(100) NOT NULL,
+    description             TEXT,
+    archived                BOOLEAN NOT NULL DEFAULT FALSE,
+    default_priority        VARCHAR(10),
+    default_reminder_offset VARCHAR(32),
+    created_at              DATETIME,
+    updated_at              DATETIME
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_projects_name ON projects (name);
+
+ALTER TABLE tasks ADD COLUMN project_id TEXT
+    CONSTRAINT fk_tasks_project REFERENCES projects (id) ON UPDATE CASCADE ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_project_id ON tasks (project_id);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS task_dependencies (
+    task_id       TEXT NOT NULL,
+    depends_on_id TEXT NOT NULL,
+    created_at    DATETIME,
+    PRIMARY KEY (task_id, depends_on_id),
+    CONSTRAINT fk_task_dependencies_task FOREIGN KEY (task_id) REFERENCES tasks (id)
+        ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT fk_task_dependencies_depends_on FOREIGN KEY (depends_on_id) REFERENCES tasks (id)
+        ON UPDATE CASCADE ON DELETE CASCADE,
+    CONSTRAINT chk_task_dependencies_self CHECK (task_id <> depends_on_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_task_dependencies_depends_on_id ON task_dependencies (depends_on_id);
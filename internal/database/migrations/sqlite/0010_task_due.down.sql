@@ -0,0 +1,6 @@
+ALTER TABLE reminders DROP COLUMN anchor;
+
+DROP INDEX IF EXISTS idx_tasks_due_at;
+
+ALTER TABLE tasks DROP COLUMN due_all_day;
+ALTER TABLE tasks DROP COLUMN due_at;
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_tasks_status;
+
+ALTER TABLE tasks DROP COLUMN cancelled_at;
+ALTER TABLE tasks DROP COLUMN completed_at;
+ALTER TABLE tasks DROP COLUMN started_at;
+ALTER TABLE tasks DROP COLUMN status;
@@ -0,0 +1,8 @@
+ALTER TABLE tasks ADD COLUMN status VARCHAR(16) NOT NULL DEFAULT 'todo';
+ALTER TABLE tasks ADD COLUMN started_at DATETIME;
+ALTER TABLE tasks ADD COLUMN completed_at DATETIME;
+ALTER TABLE tasks ADD COLUMN cancelled_at DATETIME;
+
+UPDATE tasks SET status = 'done', completed_at = updated_at WHERE done;
+
+CREATE INDEX IF NOT EXISTS idx_tasks_status ON tasks (status);
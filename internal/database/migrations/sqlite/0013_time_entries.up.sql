@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS time_entries (
+    id         TEXT PRIMARY KEY,
+    task_id    TEXT NOT NULL,
+    started_at DATETIME NOT NULL,
+    stopped_at DATETIME,
+    note       TEXT,
+    created_at DATETIME,
+    updated_at DATETIME,
+    CONSTRAINT fk_tasks_time_entries FOREIGN KEY (task_id) REFERENCES tasks (id)
+        ON UPDATE CASCADE ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_time_entries_task_id ON time_entries (task_id);
+CREATE INDEX IF NOT EXISTS idx_time_entries_started_at ON time_entries (started_at);
+CREATE INDEX IF NOT EXISTS idx_time_entries_stopped_at ON time_entries (stopped_at);
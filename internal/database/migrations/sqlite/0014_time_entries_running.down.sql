@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_time_entries_running;
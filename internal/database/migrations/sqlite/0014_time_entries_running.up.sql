@@ -0,0 +1,2 @@
+-- Só um cronômetro roda por vez: todas as linhas abertas têm a mesma chave.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_time_entries_running ON time_entries ((stopped_at IS NULL)) WHERE stopped_at IS NULL;
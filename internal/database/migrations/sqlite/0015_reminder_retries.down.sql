@@ -0,0 +1,4 @@
+ALTER TABLE reminders DROP COLUMN last_error;
+ALTER TABLE reminders DROP COLUMN retry_channels;
+ALTER TABLE reminders DROP COLUMN retry_at;
+ALTER TABLE reminders DROP COLUMN attempts;
@@ -0,0 +1,6 @@
+-- Falhas de entrega: o lembrete volta a ficar pendente em retry_at, só para
+-- os canais que falharam.
+ALTER TABLE reminders ADD COLUMN attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE reminders ADD COLUMN retry_at DATETIME;
+ALTER TABLE reminders ADD COLUMN retry_channels VARCHAR(255);
+ALTER TABLE reminders ADD COLUMN last_error TEXT;
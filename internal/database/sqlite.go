@@ -0,0 +1,166 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const sqliteDefaultFile = "advisor.db"
+
+// DefaultSQLitePath é o arquivo usado quando DB_DRIVER=sqlite e DB_DSN está vazio.
+func DefaultSQLitePath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return sqliteDefaultFile
+	}
+	return filepath.Join(home, ".advisor-go", sqliteDefaultFile)
+}
+
+// OpenSQLite abre o banco SQLite em dsn (um caminho de arquivo ou ":memory:").
+func OpenSQLite(dsn string, cfg *gorm.Config) (*gorm.DB, error) {
+	if dsn == "" {
+		dsn = DefaultSQLitePath()
+	}
+	if err := ensureSQLiteDir(dsn); err != nil {
+		return nil, err
+	}
+
+	sqlDB, err := sql.Open(sqlite.DriverName, sqliteDSN(dsn))
+	if err != nil {
+		return nil, err
+	}
+	// Uso pessoal, um único escritor: uma conexão evita "database is locked"
+	// e mantém o mesmo banco quando o DSN é ":memory:".
+	sqlDB.SetMaxOpenConns(1)
+
+	if cfg == nil {
+		cfg = &gorm.Config{}
+	}
+	cfg.NowFunc = func() time.Time { return time.Now().UTC() }
+
+	db, err := gorm.Open(sqlite.New(sqlite.Config{Conn: &utcPool{sqlDB}}), cfg)
+	if err != nil {
+		sqlDB.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+func ensureSQLiteDir(dsn string) error {
+	path := strings.TrimPrefix(dsn, "file:")
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	if path == "" || strings.Contains(path, ":memory:") {
+		return nil
+	}
+	dir := filepath.Dir(path)
+	if dir == "." {
+		return nil
+	}
+	return os.MkdirAll(dir, 0o755)
+}
+
+func sqliteDSN(dsn string) string {
+	params := []string{"_foreign_keys=on", "_busy_timeout=5000"}
+	sep := "?"
+	if strings.Contains(dsn, "?") {
+		sep = "&"
+	}
+	for _, param := range params {
+		key := param[:strings.IndexByte(param, '=')+1]
+		if strings.Contains(dsn, key) {
+			continue
+		}
+		dsn += sep + param
+		sep = "&"
+	}
+	return dsn
+}
+
+// O driver do SQLite grava time.Time como texto no fuso do valor, então
+// comparações entre datas só funcionam se tudo chegar ao banco em UTC.
+// utcPool normaliza os argumentos de toda query antes de repassá-los.
+type utcPool struct {
+	db *sql.DB
+}
+
+func (p *utcPool) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return p.db.PrepareContext(ctx, query)
+}
+
+func (p *utcPool) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return p.db.ExecContext(ctx, query, toUTC(args)...)
+}
+
+func (p *utcPool) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return p.db.QueryContext(ctx, query, toUTC(args)...)
+}
+
+func (p *utcPool) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return p.db.QueryRowContext(ctx, query, toUTC(args)...)
+}
+
+func (p *utcPool) BeginTx(ctx context.Context, opts *sql.TxOptions) (gorm.ConnPool, error) {
+	tx, err := p.db.BeginTx(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &utcTx{tx}, nil
+}
+
+func (p *utcPool) GetDBConn() (*sql.DB, error) {
+	return p.db, nil
+}
+
+type utcTx struct {
+	tx *sql.Tx
+}
+
+func (t *utcTx) PrepareContext(ctx context.Context, query string) (*sql.Stmt, error) {
+	return t.tx.PrepareContext(ctx, query)
+}
+
+func (t *utcTx) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return t.tx.ExecContext(ctx, query, toUTC(args)...)
+}
+
+func (t *utcTx) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	return t.tx.QueryContext(ctx, query, toUTC(args)...)
+}
+
+func (t *utcTx) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	return t.tx.QueryRowContext(ctx, query, toUTC(args)...)
+}
+
+func (t *utcTx) Commit() error   { return t.tx.Commit() }
+func (t *utcTx) Rollback() error { return t.tx.Rollback() }
+
+func toUTC(args []any) []any {
+	for i, arg := range args {
+		switch v := arg.(type) {
+		case time.Time:
+			args[i] = v.UTC()
+		case *time.Time:
+			if v != nil {
+				args[i] = v.UTC()
+			}
+		case gorm.DeletedAt:
+			if v.Valid {
+				args[i] = v.Time.UTC()
+			}
+		case sql.NullTime:
+			if v.Valid {
+				args[i] = v.Time.UTC()
+			}
+		}
+	}
+	return args
+}
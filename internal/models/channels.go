@@ -0,0 +1,38 @@
+package models
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// ChannelList guarda os canais de notificação de uma tarefa como texto
+// separado por vírgulas no banco e como array no JSON.
+type ChannelList []string
+
+func (c ChannelList) Value() (driver.Value, error) {
+	return strings.Join(c, ","), nil
+}
+
+func (c *ChannelList) Scan(value any) error {
+	var raw string
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case string:
+		raw = v
+	case []byte:
+		raw = string(v)
+	default:
+		return fmt.Errorf("tipo incompatível para ChannelList: %T", value)
+	}
+
+	*c = nil
+	for _, name := range strings.Split(raw, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			*c = append(*c, name)
+		}
+	}
+	return nil
+}
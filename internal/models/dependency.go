@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// TaskDependency diz que TaskID só pode começar depois que DependsOnID for
+// concluída. As dependências formam um grafo sem ciclos entre tarefas de
+// quaisquer árvores.
+type TaskDependency struct {
+	TaskID      string    `gorm:"type:uuid;primaryKey" json:"task_id"`
+	DependsOnID string    `gorm:"type:uuid;primaryKey" json:"depends_on_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
@@ -0,0 +1,54 @@
+package models
+
+import "time"
+
+type HistoryAction string
+
+const (
+	HistoryCreate   HistoryAction = "create"
+	HistoryUpdate   HistoryAction = "update"
+	HistoryComplete HistoryAction = "complete"
+	HistorySnooze   HistoryAction = "snooze"
+	HistoryDelete   HistoryAction = "delete"
+	HistoryRestore  HistoryAction = "restore"
+	HistoryPurge    HistoryAction = "purge"
+	HistoryStatus   HistoryAction = "status"
+	HistoryReopen   HistoryAction = "reopen"
+)
+
+// TaskChange é uma linha do histórico de auditoria: o valor de um campo da
+// tarefa antes e depois de uma operação, com a origem e o horário.
+type TaskChange struct {
+	ID        uint          `gorm:"primaryKey" json:"id"`
+	TaskID    string        `gorm:"type:uuid;not null;index" json:"task_id"`
+	Action    HistoryAction `gorm:"type:varchar(20);not null" json:"action"`
+	Field     string        `gorm:"type:varchar(64);not null" json:"field"`
+	Before    AuditValue    `gorm:"column:before_value;type:text" json:"before" swaggertype:"object"`
+	After     AuditValue    `gorm:"column:after_value;type:text" json:"after" swaggertype:"object"`
+	Source    string        `gorm:"type:varchar(100);not null" json:"source"`
+	CreatedAt time.Time     `gorm:"not null" json:"created_at"`
+}
+
+func (TaskChange) TableName() string {
+	return "task_history"
+}
+
+// AuditValue guarda um valor já codificado em JSON. Vazio significa que o
+// campo não existia (ex.: antes da criação) e vira null na resposta.
+type AuditValue string
+
+func (v AuditValue) MarshalJSON() ([]byte, error) {
+	if v == "" {
+		return []byte("null"), nil
+	}
+	return []byte(v), nil
+}
+
+func (v *AuditValue) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		*v = ""
+		return nil
+	}
+	*v = AuditValue(data)
+	return nil
+}
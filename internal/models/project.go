@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Project agrupa tarefas num quadro separado. DefaultPriority e
+// DefaultReminderOffset (ex.: "1d", relativo à criação) preenchem as tarefas
+// novas do projeto que chegam sem prioridade ou sem lembrete. Projeto
+// arquivado não recebe tarefas novas.
+type Project struct {
+	ID                    string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Name                  string         `gorm:"type:varchar(100);not null;uniqueIndex" json:"name" example:"homelab"`
+	Description           string         `gorm:"type:text" json:"description"`
+	Archived              bool           `gorm:"not null;default:false" json:"archived"`
+	DefaultPriority       Priority       `gorm:"type:varchar(10)" json:"default_priority,omitempty" example:"medium"`
+	DefaultReminderOffset string         `gorm:"type:varchar(32)" json:"default_reminder_offset,omitempty" example:"1d"`
+	Counts                *ProjectCounts `gorm:"-" json:"counts,omitempty"`
+	CreatedAt             time.Time      `json:"created_at"`
+	UpdatedAt             time.Time      `json:"updated_at"`
+}
+
+// ProjectCounts conta as tarefas do projeto que não estão na lixeira. Não é
+// gravado: o Service calcula ao devolver o projeto.
+type ProjectCounts struct {
+	Total     int `json:"total"`
+	Open      int `json:"open"`
+	Done      int `json:"done"`
+	Cancelled int `json:"cancelled"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Reminder é um disparo de notificação de uma tarefa. Pode ser absoluto (At)
+// ou relativo (Offset, ex.: "-1h") ao lembrete principal da tarefa ou, com
+// Anchor "due", ao prazo dela. FireAt guarda o horário já calculado, usado
+// pelo scheduler. Uma entrega que falha volta a ficar pendente em RetryAt,
+// só para os RetryChannels.
+type Reminder struct {
+	ID            string      `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	TaskID        string      `gorm:"type:uuid;not null;index" json:"task_id"`
+	Task          *Task       `gorm:"foreignKey:TaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	At            *time.Time  `json:"at,omitempty"`
+	Offset        string      `gorm:"column:fire_offset;type:varchar(32)" json:"offset,omitempty"`
+	Anchor        string      `gorm:"type:varchar(10)" json:"anchor,omitempty" enums:"reminder,due"`
+	Channel       string      `gorm:"type:varchar(20)" json:"channel,omitempty"`
+	FireAt        time.Time   `gorm:"not null;index" json:"fire_at"`
+	DeliveredAt   *time.Time  `json:"delivered_at,omitempty"`
+	Attempts      int         `gorm:"not null;default:0" json:"attempts,omitempty"`
+	RetryAt       *time.Time  `json:"retry_at,omitempty"`
+	RetryChannels ChannelList `gorm:"type:varchar(255)" json:"retry_channels,omitempty" swaggertype:"array,string"`
+	LastError     string      `gorm:"type:text" json:"last_error,omitempty"`
+	CreatedAt     time.Time   `json:"created_at"`
+	UpdatedAt     time.Time   `json:"updated_at"`
+}
+
+const (
+	AnchorReminder = "reminder"
+	AnchorDue      = "due"
+)
+
+func (r Reminder) IsRelative() bool {
+	return r.At == nil
+}
+
+// NextAttempt é quando o scheduler deve tentar a entrega: RetryAt depois de
+// uma falha, senão FireAt.
+func (r Reminder) NextAttempt() time.Time {
+	if r.RetryAt != nil {
+		return *r.RetryAt
+	}
+	return r.FireAt
+}
+
+// RelativeToDue diz se o Offset conta a partir do prazo da tarefa.
+func (r Reminder) RelativeToDue() bool {
+	return r.IsRelative() && r.Anchor == AnchorDue
+}
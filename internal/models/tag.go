@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// Tag é um rótulo de contexto (ex.: casa, homelab). O nome é guardado sem o
+// "#" e em minúsculas; Color é uma das cores de task.TagColors, usada na CLI.
+type Tag struct {
+	ID        string    `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Name      string    `gorm:"type:varchar(50);not null;uniqueIndex" json:"name" example:"homelab"`
+	Color     string    `gorm:"type:varchar(20)" json:"color,omitempty" example:"cyan"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
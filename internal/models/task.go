@@ -14,17 +14,86 @@ const (
 	PriorityHigh   Priority = "high"
 )
 
+// TaskStatus é a etapa do fluxo de trabalho de uma tarefa. done e cancelled
+// fecham a tarefa; dali ela só sai reaberta.
+type TaskStatus string
+
+const (
+	StatusTodo       TaskStatus = "todo"
+	StatusInProgress TaskStatus = "in_progress"
+	StatusBlocked    TaskStatus = "blocked"
+	StatusWaiting    TaskStatus = "waiting"
+	StatusDone       TaskStatus = "done"
+	StatusCancelled  TaskStatus = "cancelled"
+)
+
+func (s TaskStatus) Closed() bool {
+	return s == StatusDone || s == StatusCancelled
+}
+
+// ClosedStatuses são os status em que a tarefa não conta mais como aberta.
+var ClosedStatuses = []TaskStatus{StatusDone, StatusCancelled}
+
+// OpenStatuses são os demais status, com a tarefa ainda por fazer.
+var OpenStatuses = []TaskStatus{StatusTodo, StatusInProgress, StatusBlocked, StatusWaiting}
+
 type Task struct {
-	ID          string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
-	Title       string         `gorm:"not null" json:"title"`
-	Description string         `gorm:"type:text" json:"description"`
-	Priority    Priority       `gorm:"type:varchar(10);not null" json:"priority"`
-	ReminderAt  time.Time      `json:"reminder_at"`
-	Done        bool           `gorm:"default:false" json:"done"`
-	ParentID    *string        `gorm:"type:uuid;index" json:"parent_id,omitempty"`
-	Parent      *Task          `gorm:"foreignKey:ParentID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"parent,omitempty"`
-	Children    []Task         `gorm:"foreignKey:ParentID;references:ID" json:"children,omitempty"`
-	CreatedAt   time.Time      `json:"created_at"`
-	UpdatedAt   time.Time      `json:"updated_at"`
-	DeletedAt   gorm.DeletedAt `gorm:"index" json:"-"`
+	ID              string         `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	Title           string         `gorm:"not null" json:"title"`
+	Description     string         `gorm:"type:text" json:"description"`
+	Priority        Priority       `gorm:"type:varchar(10);not null" json:"priority"`
+	ReminderAt      time.Time      `gorm:"index" json:"reminder_at"`
+	DueAt           *time.Time     `gorm:"index" json:"due_at,omitempty"`
+	DueAllDay       bool           `gorm:"not null;default:false" json:"due_all_day,omitempty"`
+	EstimateMinutes int            `gorm:"not null;default:0" json:"estimate_minutes,omitempty"`
+	SnoozeCount     int            `gorm:"not null;default:0" json:"snooze_count"`
+	Channels        ChannelList    `gorm:"type:varchar(255)" json:"channels,omitempty" swaggertype:"array,string"`
+	Status          TaskStatus     `gorm:"type:varchar(16);not null;default:todo;index" json:"status" enums:"todo,in_progress,blocked,waiting,done,cancelled"`
+	Done            bool           `gorm:"default:false" json:"done"`
+	StartedAt       *time.Time     `json:"started_at,omitempty"`
+	CompletedAt     *time.Time     `json:"completed_at,omitempty"`
+	CancelledAt     *time.Time     `json:"cancelled_at,omitempty"`
+	Recurrence      string         `gorm:"type:varchar(255)" json:"recurrence,omitempty"`
+	SeriesID        *string        `gorm:"type:uuid;index" json:"series_id,omitempty"`
+	ParentID        *string        `gorm:"type:uuid;index" json:"parent_id,omitempty"`
+	Parent          *Task          `gorm:"foreignKey:ParentID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:SET NULL" json:"parent,omitempty"`
+	Children        []Task         `gorm:"foreignKey:ParentID;references:ID" json:"children,omitempty"`
+	ProjectID       *string        `gorm:"type:uuid;index" json:"project_id,omitempty"`
+	Reminders       []Reminder     `gorm:"foreignKey:TaskID;references:ID" json:"reminders,omitempty"`
+	Tags            []Tag          `gorm:"many2many:task_tags" json:"tags,omitempty"`
+	Progress        *Progress      `gorm:"-" json:"progress,omitempty"`
+	Blocked         bool           `gorm:"-" json:"blocked"`
+	BlockedBy       []string       `gorm:"-" json:"blocked_by,omitempty"`
+	Overdue         bool           `gorm:"-" json:"overdue"`
+	DueToday        bool           `gorm:"-" json:"due_today"`
+	DueSoon         bool           `gorm:"-" json:"due_soon"`
+	TrackedSeconds  int64          `gorm:"-" json:"tracked_seconds"`
+	Version         int64          `gorm:"not null;default:1" json:"version"`
+	CreatedAt       time.Time      `json:"created_at"`
+	UpdatedAt       time.Time      `json:"updated_at"`
+	DeletedAt       gorm.DeletedAt `gorm:"index" json:"-"`
+}
+
+// SyncStatus acerta Status e Done antes de gravar uma tarefa nova: sem status,
+// vale o Done; com status, Done passa a ser derivado dele.
+func (t *Task) SyncStatus() {
+	if t.Status == "" {
+		t.Status = StatusTodo
+		if t.Done {
+			t.Status = StatusDone
+		}
+	}
+	t.Done = t.Status == StatusDone
+}
+
+func (t *Task) BeforeCreate(tx *gorm.DB) error {
+	t.SyncStatus()
+	return nil
+}
+
+// Progress conta as subtarefas concluídas em todos os níveis abaixo da tarefa.
+// Não é gravado: o Service calcula ao devolver a tarefa.
+type Progress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
 }
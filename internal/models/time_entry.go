@@ -0,0 +1,32 @@
+package models
+
+import "time"
+
+// TimeEntry é um período de trabalho numa tarefa. Sem StoppedAt, o cronômetro
+// ainda está rodando; só um fica rodando por vez.
+type TimeEntry struct {
+	ID        string     `gorm:"type:uuid;default:gen_random_uuid();primaryKey" json:"id"`
+	TaskID    string     `gorm:"type:uuid;not null;index" json:"task_id"`
+	Task      *Task      `gorm:"foreignKey:TaskID;references:ID;constraint:OnUpdate:CASCADE,OnDelete:CASCADE" json:"-"`
+	StartedAt time.Time  `gorm:"not null;index" json:"started_at"`
+	StoppedAt *time.Time `gorm:"index" json:"stopped_at,omitempty"`
+	Note      string     `gorm:"type:text" json:"note,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func (e TimeEntry) Running() bool {
+	return e.StoppedAt == nil
+}
+
+// Duration é quanto tempo o registro cobre; rodando, conta até now.
+func (e TimeEntry) Duration(now time.Time) time.Duration {
+	end := now
+	if e.StoppedAt != nil {
+		end = *e.StoppedAt
+	}
+	if end.Before(e.StartedAt) {
+		return 0
+	}
+	return end.Sub(e.StartedAt)
+}